@@ -42,6 +42,8 @@ type Config struct {
 	Tags                      []string            `yaml:"Tags"`
 	Tests                     []Test              `yaml:"Tests"`
 	DedupPeriodMinutes        int                 `yaml:"DedupPeriodMinutes"`
+	Threshold                 int                 `yaml:"Threshold"`
+	ThresholdWindowMinutes    int                 `yaml:"ThresholdWindowMinutes"`
 	Reports                   map[string][]string `yaml:"Reports"`
 }
 
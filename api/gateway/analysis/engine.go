@@ -33,9 +33,19 @@ type Policy struct {
 
 // Resource is a subset of the resource fields needed for analysis.
 type Resource struct {
-	Attributes interface{} `json:"attributes"`
-	ID         string      `json:"id"`
-	Type       string      `json:"type"`
+	Attributes interface{}       `json:"attributes"`
+	ID         string            `json:"id"`
+	Related    []RelatedResource `json:"related,omitempty"` // only for policies which accept related resources
+	Type       string            `json:"type"`
+}
+
+// RelatedResource is a resource referencing or referenced by the resource being analyzed.
+type RelatedResource struct {
+	Attributes   interface{} `json:"attributes"`
+	Direction    string      `json:"direction"`    // "outgoing" if the analyzed resource references this one
+	ID           string      `json:"id"`           // resourceID
+	Relationship string      `json:"relationship"` // e.g. "securityGroup" or "role"
+	Type         string      `json:"type"`
 }
 
 // PolicyEngineOutput is the response format returned by the panther-policy-engine Lambda function.
@@ -105,6 +105,12 @@ type Rule struct {
 	// Required: true
 	Tests TestSuite `json:"tests"`
 
+	// threshold
+	Threshold Threshold `json:"threshold,omitempty"`
+
+	// threshold window minutes
+	ThresholdWindowMinutes ThresholdWindowMinutes `json:"thresholdWindowMinutes,omitempty"`
+
 	// version Id
 	// Required: true
 	VersionID VersionID `json:"versionId"`
@@ -182,6 +188,14 @@ func (m *Rule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateThreshold(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateThresholdWindowMinutes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateVersionID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -408,6 +422,38 @@ func (m *Rule) validateTests(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rule) validateThreshold(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Threshold) { // not required
+		return nil
+	}
+
+	if err := m.Threshold.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("threshold")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Rule) validateThresholdWindowMinutes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ThresholdWindowMinutes) { // not required
+		return nil
+	}
+
+	if err := m.ThresholdWindowMinutes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("thresholdWindowMinutes")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Rule) validateVersionID(formats strfmt.Registry) error {
 
 	if err := m.VersionID.Validate(formats); err != nil {
@@ -61,6 +61,9 @@ type UpdatePolicy struct {
 	// reference
 	Reference Reference `json:"reference,omitempty"`
 
+	// reports
+	Reports Reports `json:"reports,omitempty"`
+
 	// resource types
 	ResourceTypes TypeSet `json:"resourceTypes,omitempty"`
 
@@ -121,6 +124,10 @@ func (m *UpdatePolicy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateResourceTypes(formats); err != nil {
 		res = append(res, err)
 	}
@@ -271,6 +278,22 @@ func (m *UpdatePolicy) validateReference(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdatePolicy) validateReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reports) { // not required
+		return nil
+	}
+
+	if err := m.Reports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("reports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdatePolicy) validateResourceTypes(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ResourceTypes) { // not required
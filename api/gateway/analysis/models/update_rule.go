@@ -77,6 +77,12 @@ type UpdateRule struct {
 	// tests
 	Tests TestSuite `json:"tests,omitempty"`
 
+	// threshold
+	Threshold Threshold `json:"threshold,omitempty"`
+
+	// threshold window minutes
+	ThresholdWindowMinutes ThresholdWindowMinutes `json:"thresholdWindowMinutes,omitempty"`
+
 	// user Id
 	// Required: true
 	UserID UserID `json:"userId"`
@@ -138,6 +144,14 @@ func (m *UpdateRule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateThreshold(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateThresholdWindowMinutes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateUserID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -340,6 +354,38 @@ func (m *UpdateRule) validateTests(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateRule) validateThreshold(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Threshold) { // not required
+		return nil
+	}
+
+	if err := m.Threshold.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("threshold")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateRule) validateThresholdWindowMinutes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ThresholdWindowMinutes) { // not required
+		return nil
+	}
+
+	if err := m.ThresholdWindowMinutes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("thresholdWindowMinutes")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdateRule) validateUserID(formats strfmt.Registry) error {
 
 	if err := m.UserID.Validate(formats); err != nil {
@@ -53,6 +53,12 @@ type ClientService interface {
 
 	DescribeResource(params *DescribeResourceParams) (*DescribeResourceOK, error)
 
+	ExportFrameworkReport(params *ExportFrameworkReportParams) (*ExportFrameworkReportOK, error)
+
+	GetComplianceTrend(params *GetComplianceTrendParams) (*GetComplianceTrendOK, error)
+
+	GetFrameworkReport(params *GetFrameworkReportParams) (*GetFrameworkReportOK, error)
+
 	GetOrgOverview(params *GetOrgOverviewParams) (*GetOrgOverviewOK, error)
 
 	GetStatus(params *GetStatusParams) (*GetStatusOK, error)
@@ -200,6 +206,108 @@ func (a *Client) DescribeResource(params *DescribeResourceParams) (*DescribeReso
 	panic(msg)
 }
 
+/*
+  ExportFrameworkReport exports a compliance framework report as c s v or JSON to s3
+*/
+func (a *Client) ExportFrameworkReport(params *ExportFrameworkReportParams) (*ExportFrameworkReportOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExportFrameworkReportParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ExportFrameworkReport",
+		Method:             "POST",
+		PathPattern:        "/framework-report/export",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ExportFrameworkReportReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExportFrameworkReportOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ExportFrameworkReport: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetComplianceTrend gets daily compliance posture snapshots over a date range
+*/
+func (a *Client) GetComplianceTrend(params *GetComplianceTrendParams) (*GetComplianceTrendOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetComplianceTrendParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetComplianceTrend",
+		Method:             "GET",
+		PathPattern:        "/trend",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetComplianceTrendReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetComplianceTrendOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetComplianceTrend: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetFrameworkReport gets per control pass fail counts and failing resources for a compliance framework
+*/
+func (a *Client) GetFrameworkReport(params *GetFrameworkReportParams) (*GetFrameworkReportOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetFrameworkReportParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetFrameworkReport",
+		Method:             "GET",
+		PathPattern:        "/framework-report",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetFrameworkReportReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetFrameworkReportOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetFrameworkReport: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetOrgOverview gets account totals and top failing policies resources
 */
@@ -54,6 +54,10 @@ type ComplianceStatus struct {
 	// Required: true
 	PolicyID PolicyID `json:"policyId"`
 
+	// policy reports
+	// Required: true
+	PolicyReports PolicyReports `json:"policyReports"`
+
 	// policy severity
 	// Required: true
 	PolicySeverity PolicySeverity `json:"policySeverity"`
@@ -66,6 +70,9 @@ type ComplianceStatus struct {
 	// Required: true
 	ResourceType ResourceType `json:"resourceType"`
 
+	// resource version
+	ResourceVersion ResourceVersion `json:"resourceVersion,omitempty"`
+
 	// status
 	// Required: true
 	Status Status `json:"status"`
@@ -99,6 +106,10 @@ func (m *ComplianceStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePolicyReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePolicySeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -111,6 +122,10 @@ func (m *ComplianceStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateResourceVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStatus(formats); err != nil {
 		res = append(res, err)
 	}
@@ -189,6 +204,18 @@ func (m *ComplianceStatus) validatePolicyID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ComplianceStatus) validatePolicyReports(formats strfmt.Registry) error {
+
+	if err := m.PolicyReports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyReports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *ComplianceStatus) validatePolicySeverity(formats strfmt.Registry) error {
 
 	if err := m.PolicySeverity.Validate(formats); err != nil {
@@ -225,6 +252,22 @@ func (m *ComplianceStatus) validateResourceType(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ComplianceStatus) validateResourceVersion(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ResourceVersion) { // not required
+		return nil
+	}
+
+	if err := m.ResourceVersion.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourceVersion")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *ComplianceStatus) validateStatus(formats strfmt.Registry) error {
 
 	if err := m.Status.Validate(formats); err != nil {
@@ -45,6 +45,9 @@ type SetStatus struct {
 	// Required: true
 	PolicyID PolicyID `json:"policyId"`
 
+	// policy reports
+	PolicyReports PolicyReports `json:"policyReports,omitempty"`
+
 	// policy severity
 	// Required: true
 	PolicySeverity PolicySeverity `json:"policySeverity"`
@@ -57,6 +60,9 @@ type SetStatus struct {
 	// Required: true
 	ResourceType ResourceType `json:"resourceType"`
 
+	// resource version
+	ResourceVersion ResourceVersion `json:"resourceVersion,omitempty"`
+
 	// status
 	// Required: true
 	Status Status `json:"status"`
@@ -82,6 +88,10 @@ func (m *SetStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePolicyReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePolicySeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -94,6 +104,10 @@ func (m *SetStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateResourceVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStatus(formats); err != nil {
 		res = append(res, err)
 	}
@@ -148,6 +162,22 @@ func (m *SetStatus) validatePolicyID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SetStatus) validatePolicyReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyReports) { // not required
+		return nil
+	}
+
+	if err := m.PolicyReports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyReports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *SetStatus) validatePolicySeverity(formats strfmt.Registry) error {
 
 	if err := m.PolicySeverity.Validate(formats); err != nil {
@@ -184,6 +214,22 @@ func (m *SetStatus) validateResourceType(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SetStatus) validateResourceVersion(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ResourceVersion) { // not required
+		return nil
+	}
+
+	if err := m.ResourceVersion.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourceVersion")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *SetStatus) validateStatus(formats strfmt.Registry) error {
 
 	if err := m.Status.Validate(formats); err != nil {
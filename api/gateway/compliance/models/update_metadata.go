@@ -38,6 +38,9 @@ type UpdateMetadata struct {
 	// Required: true
 	PolicyID PolicyID `json:"policyId"`
 
+	// reports
+	Reports PolicyReports `json:"reports,omitempty"`
+
 	// severity
 	// Required: true
 	Severity PolicySeverity `json:"severity"`
@@ -54,6 +57,10 @@ func (m *UpdateMetadata) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -80,6 +87,22 @@ func (m *UpdateMetadata) validatePolicyID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateMetadata) validateReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reports) { // not required
+		return nil
+	}
+
+	if err := m.Reports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("reports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdateMetadata) validateSeverity(formats strfmt.Registry) error {
 
 	if err := m.Severity.Validate(formats); err != nil {
@@ -0,0 +1,56 @@
+package compliance
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// ReportsEqual returns true if the two compliance framework mappings contain the same controls
+// for the same frameworks, in any order.
+//
+// Both policies (analysis api) and their compliance entries (compliance api) map frameworks to controls.
+func ReportsEqual(first, second map[string][]string) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for framework, controls := range first {
+		otherControls, ok := second[framework]
+		if !ok || !sameControls(controls, otherControls) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if the two lists have the same unique controls in any order
+func sameControls(first, second []string) bool {
+	firstSet := make(map[string]bool, len(first))
+	for _, control := range first {
+		firstSet[control] = true
+	}
+
+	secondSet := make(map[string]bool, len(second))
+	for _, control := range second {
+		if !firstSet[control] {
+			return false
+		}
+		secondSet[control] = true
+	}
+
+	return len(firstSet) == len(secondSet)
+}
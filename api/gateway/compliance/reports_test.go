@@ -0,0 +1,40 @@
+package compliance
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	analysismodels "github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+func TestReportsEqual(t *testing.T) {
+	assert.True(t, ReportsEqual(nil, models.PolicyReports{}))
+	assert.True(t, ReportsEqual(
+		models.PolicyReports{"CIS": {"1.3", "1.4"}, "PCI": {"8.1.4"}},
+		models.PolicyReports{"PCI": {"8.1.4"}, "CIS": {"1.4", "1.3"}}))
+	assert.True(t, ReportsEqual(analysismodels.Reports{"CIS": {"1.3", "1.3"}}, analysismodels.Reports{"CIS": {"1.3"}}))
+	assert.False(t, ReportsEqual(models.PolicyReports{"CIS": {"1.3"}}, models.PolicyReports{"CIS": {"1.3", "1.4"}}))
+	assert.False(t, ReportsEqual(models.PolicyReports{"CIS": {"1.3", "1.3"}}, models.PolicyReports{"CIS": {"1.3", "1.4"}}))
+	assert.False(t, ReportsEqual(models.PolicyReports{"CIS": {"1.3"}}, models.PolicyReports{"PCI": {"1.3"}}))
+	assert.False(t, ReportsEqual(analysismodels.Reports{"CIS": {"1.3"}}, nil))
+}
@@ -0,0 +1,75 @@
+package compliance
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// NewStatusCount creates a new pass/fail counter with values initialized to 0
+func NewStatusCount() *models.StatusCount {
+	return &models.StatusCount{
+		Error: aws.Int64(0),
+		Fail:  aws.Int64(0),
+		Pass:  aws.Int64(0),
+	}
+}
+
+// UpdateStatusCount updates status counters based on the string status
+func UpdateStatusCount(count *models.StatusCount, status models.Status) {
+	switch status {
+	case models.StatusPASS:
+		*count.Pass++
+	case models.StatusFAIL:
+		*count.Fail++
+	default:
+		*count.Error++
+	}
+}
+
+// NewStatusCountBySeverity creates a new pass/fail counter keyed by severity with initial values
+func NewStatusCountBySeverity() *models.StatusCountBySeverity {
+	return &models.StatusCountBySeverity{
+		Info:     NewStatusCount(),
+		Low:      NewStatusCount(),
+		Medium:   NewStatusCount(),
+		High:     NewStatusCount(),
+		Critical: NewStatusCount(),
+	}
+}
+
+// UpdateStatusCountBySeverity updates StatusCountBySeverity totals with a pass/fail status of a given severity
+func UpdateStatusCountBySeverity(
+	count *models.StatusCountBySeverity, severity models.PolicySeverity, status models.Status) {
+
+	switch severity {
+	case models.PolicySeverityINFO:
+		UpdateStatusCount(count.Info, status)
+	case models.PolicySeverityLOW:
+		UpdateStatusCount(count.Low, status)
+	case models.PolicySeverityMEDIUM:
+		UpdateStatusCount(count.Medium, status)
+	case models.PolicySeverityHIGH:
+		UpdateStatusCount(count.High, status)
+	default:
+		UpdateStatusCount(count.Critical, status)
+	}
+}
@@ -49,12 +49,22 @@ type ClientService interface {
 
 	DeleteResources(params *DeleteResourcesParams) (*DeleteResourcesOK, error)
 
+	DiffResourceVersions(params *DiffResourceVersionsParams) (*DiffResourceVersionsOK, error)
+
 	GetOrgOverview(params *GetOrgOverviewParams) (*GetOrgOverviewOK, error)
 
 	GetResource(params *GetResourceParams) (*GetResourceOK, error)
 
+	ListNeighbors(params *ListNeighborsParams) (*ListNeighborsOK, error)
+
+	ListResourceVersions(params *ListResourceVersionsParams) (*ListResourceVersionsOK, error)
+
 	ListResources(params *ListResourcesParams) (*ListResourcesOK, error)
 
+	QueryPaths(params *QueryPathsParams) (*QueryPathsOK, error)
+
+	ReindexRelationships(params *ReindexRelationshipsParams) (*ReindexRelationshipsOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -126,6 +136,40 @@ func (a *Client) DeleteResources(params *DeleteResourcesParams) (*DeleteResource
 	panic(msg)
 }
 
+/*
+  DiffResourceVersions compares two versions of a resource
+*/
+func (a *Client) DiffResourceVersions(params *DiffResourceVersionsParams) (*DiffResourceVersionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDiffResourceVersionsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DiffResourceVersions",
+		Method:             "GET",
+		PathPattern:        "/diff",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &DiffResourceVersionsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DiffResourceVersionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for DiffResourceVersions: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetOrgOverview gets an overview of the resources in an organization
 */
@@ -194,6 +238,74 @@ func (a *Client) GetResource(params *GetResourceParams) (*GetResourceOK, error)
 	panic(msg)
 }
 
+/*
+  ListNeighbors lists the resources related to each of the given resources
+*/
+func (a *Client) ListNeighbors(params *ListNeighborsParams) (*ListNeighborsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListNeighborsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListNeighbors",
+		Method:             "POST",
+		PathPattern:        "/neighbors",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListNeighborsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListNeighborsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListNeighbors: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListResourceVersions lists the recorded versions of a resource newest first
+*/
+func (a *Client) ListResourceVersions(params *ListResourceVersionsParams) (*ListResourceVersionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListResourceVersionsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListResourceVersions",
+		Method:             "GET",
+		PathPattern:        "/versions",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListResourceVersionsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListResourceVersionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListResourceVersions: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   ListResources lists resources for a customer account
 */
@@ -228,6 +340,74 @@ func (a *Client) ListResources(params *ListResourcesParams) (*ListResourcesOK, e
 	panic(msg)
 }
 
+/*
+  QueryPaths finds chains of related resources
+*/
+func (a *Client) QueryPaths(params *QueryPathsParams) (*QueryPathsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewQueryPathsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "QueryPaths",
+		Method:             "POST",
+		PathPattern:        "/paths",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &QueryPathsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*QueryPathsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for QueryPaths: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ReindexRelationships indexes the relationships of a page of resources
+*/
+func (a *Client) ReindexRelationships(params *ReindexRelationshipsParams) (*ReindexRelationshipsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewReindexRelationshipsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ReindexRelationships",
+		Method:             "POST",
+		PathPattern:        "/reindex",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ReindexRelationshipsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ReindexRelationshipsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ReindexRelationships: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
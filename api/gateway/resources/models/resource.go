@@ -67,6 +67,9 @@ type Resource struct {
 	// type
 	// Required: true
 	Type ResourceType `json:"type"`
+
+	// version
+	Version Version `json:"version,omitempty"`
 }
 
 // Validate validates this resource
@@ -105,6 +108,10 @@ func (m *Resource) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -204,6 +211,22 @@ func (m *Resource) validateType(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Resource) validateVersion(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Version) { // not required
+		return nil
+	}
+
+	if err := m.Version.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("version")
+		}
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Resource) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -22,10 +22,55 @@ import "time"
 
 // LambdaInput is the request structure for the alerts-api Lambda function.
 type LambdaInput struct {
-	GetAlert   *GetAlertInput   `json:"getAlert"`
-	ListAlerts *ListAlertsInput `json:"listAlerts"`
+	GetAlert             *GetAlertInput             `json:"getAlert"`
+	ListAlerts           *ListAlertsInput           `json:"listAlerts"`
+	UpdateAlertStatus    *UpdateAlertStatusInput    `json:"updateAlertStatus"`
+	UpdateAlertAssignee  *UpdateAlertAssigneeInput  `json:"updateAlertAssignee"`
+	AddAlertComment      *AddAlertCommentInput      `json:"addAlertComment"`
+	ExportAlertEvents    *ExportAlertEventsInput    `json:"exportAlertEvents"`
+	GetAlertEventsExport *GetAlertEventsExportInput `json:"getAlertEventsExport"`
+
+	CreateAlertSuppression *CreateAlertSuppressionInput `json:"createAlertSuppression"`
+	ListAlertSuppressions  *ListAlertSuppressionsInput  `json:"listAlertSuppressions"`
+	DeleteAlertSuppression *DeleteAlertSuppressionInput `json:"deleteAlertSuppression"`
+
+	GetAlertStats *GetAlertStatsInput `json:"getAlertStats"`
 }
 
+// Alert triage statuses. Alerts without a status are OPEN.
+const (
+	AlertStatusOpen          = "OPEN"
+	AlertStatusTriaged       = "TRIAGED"
+	AlertStatusClosed        = "CLOSED"
+	AlertStatusFalsePositive = "FALSE_POSITIVE"
+)
+
+// Alert activity types, recorded for every triage change
+const (
+	AlertActivityStatus   = "STATUS"
+	AlertActivityAssignee = "ASSIGNEE"
+	AlertActivityComment  = "COMMENT"
+)
+
+// Alert types, rule alerts come from log analysis and policy alerts from cloud security
+const (
+	AlertTypeRule   = "RULE"
+	AlertTypePolicy = "POLICY"
+)
+
+// Alert statistics time bucket sizes
+const (
+	StatsBucketHour = "hour"
+	StatsBucketDay  = "day"
+)
+
+// Alert event export statuses
+const (
+	ExportStatusRunning   = "RUNNING"
+	ExportStatusSucceeded = "SUCCEEDED"
+	ExportStatusFailed    = "FAILED"
+)
+
 // GetAlertInput retrieves details for a single alert.
 //
 // The response will contain by definition all of the events associated with the alert.
@@ -51,23 +96,48 @@ type GetAlertOutput = Alert
 // If the "exclusiveStartKey" is not set, we return alerts starting from the most recent one. If it is set,
 // the output will return alerts starting from the "exclusiveStartKey" exclusive.
 //
+// The alerts can be filtered by "type" (RULE or POLICY), by triage "status" (any of the given statuses) and "assigneeId",
+// by "severity", "logTypes" and rule "tags" (any of the given values), by creation and update time ranges,
+// by a case-sensitive "titleContains" substring and by the number of events matched.
+//
+// They can be sorted by "creationTime" (the default), "updateTime", "severity" or "eventsMatched"
+// in "descending" (the default) or "ascending" order. Sorting by anything other than creation time only covers
+// alerts created in the 7 days before "creationTimeBefore" (or now).
 //
 // {
 //     "listAlerts": {
 //         "ruleId": "My.Rule",
-//         "pageSize": 25
+//         "pageSize": 25,
+//         "status": ["OPEN", "TRIAGED"],
+//         "severity": ["HIGH", "CRITICAL"],
+//         "creationTimeAfter": "2020-05-01T00:00:00Z",
+//         "sortBy": "severity"
 //     }
 // }
 type ListAlertsInput struct {
-	RuleID            *string `json:"ruleId,omitempty"`
-	PageSize          *int    `json:"pageSize,omitempty"  validate:"omitempty,min=1,max=50"`
-	ExclusiveStartKey *string `json:"exclusiveStartKey,omitempty"`
+	RuleID             *string    `json:"ruleId,omitempty"`
+	Type               *string    `json:"type,omitempty" validate:"omitempty,oneof=RULE POLICY"`
+	PageSize           *int       `json:"pageSize,omitempty"  validate:"omitempty,min=1,max=50"`
+	ExclusiveStartKey  *string    `json:"exclusiveStartKey,omitempty"`
+	Status             []*string  `json:"status,omitempty" validate:"omitempty,dive,oneof=OPEN TRIAGED CLOSED FALSE_POSITIVE"`
+	AssigneeID         *string    `json:"assigneeId,omitempty" validate:"omitempty,uuid4"`
+	Severity           []*string  `json:"severity,omitempty" validate:"omitempty,dive,oneof=INFO LOW MEDIUM HIGH CRITICAL"`
+	LogTypes           []*string  `json:"logTypes,omitempty" validate:"omitempty,dive,required"`
+	Tags               []*string  `json:"tags,omitempty" validate:"omitempty,dive,required"`
+	TitleContains      *string    `json:"titleContains,omitempty" validate:"omitempty,min=1"`
+	CreationTimeAfter  *time.Time `json:"creationTimeAfter,omitempty"`
+	CreationTimeBefore *time.Time `json:"creationTimeBefore,omitempty"`
+	UpdateTimeAfter    *time.Time `json:"updateTimeAfter,omitempty"`
+	UpdateTimeBefore   *time.Time `json:"updateTimeBefore,omitempty"`
+	EventCountMin      *int       `json:"eventCountMin,omitempty" validate:"omitempty,min=0"`
+	EventCountMax      *int       `json:"eventCountMax,omitempty" validate:"omitempty,min=0"`
+	SortBy             *string    `json:"sortBy,omitempty" validate:"omitempty,oneof=creationTime updateTime severity eventsMatched"`
+	SortDir            *string    `json:"sortDir,omitempty" validate:"omitempty,oneof=ascending descending"`
 }
 
 // ListAlertsOutput is the returned alert list.
 type ListAlertsOutput struct {
-	// Alerts is a list of alerts sorted by timestamp descending.
-	// Alerts with the same timestamp are returned in ascending order of alert ID.
+	// Alerts is a list of alerts in the requested order (by default, creation time descending).
 	Alerts []*AlertSummary `json:"alertSummaries"`
 	// LastEvaluatedKey contains the last evaluated alert Id.
 	// If it is populated it means there are more alerts available
@@ -76,8 +146,11 @@ type ListAlertsOutput struct {
 }
 
 // AlertSummary contains summary information for an alert
+//
+// The rule fields of policy alerts are the policy, which failed for a single resource.
 type AlertSummary struct {
 	AlertID         *string    `json:"alertId" validate:"required"`
+	Type            *string    `json:"type" validate:"required,oneof=RULE POLICY"`
 	RuleID          *string    `json:"ruleId" validate:"required"`
 	RuleDisplayName *string    `json:"ruleDisplayName,omitempty"`
 	RuleVersion     *string    `json:"ruleVersion" validate:"required"`
@@ -87,11 +160,208 @@ type AlertSummary struct {
 	EventsMatched   *int       `json:"eventsMatched" validate:"required"`
 	Severity        *string    `json:"severity" validate:"required"`
 	Title           *string    `json:"title" validate:"required"`
+	Status          *string    `json:"status" validate:"required"`
+	AssigneeID      *string    `json:"assigneeId,omitempty"`
+	LastUpdatedBy   *string    `json:"lastUpdatedBy,omitempty"`
+	LastUpdatedTime *time.Time `json:"lastUpdatedTime,omitempty"`
+	Suppressed      *bool      `json:"suppressed,omitempty"` // set if the alert was muted by a suppression
+	SuppressionID   *string    `json:"suppressionId,omitempty"`
+	Pending         *bool      `json:"pending,omitempty"` // set if the rule threshold was not reached yet
+	ResourceID      *string    `json:"resourceId,omitempty"` // the failing resource of policy alerts
+
+	// Tickets are the issues created for the alert by ticketing outputs
+	Tickets []*AlertTicket `json:"tickets" validate:"required"`
+}
+
+// AlertTicket is an issue created for the alert by a Jira or GitHub output.
+type AlertTicket struct {
+	OutputID  *string    `json:"outputId" validate:"required"`
+	System    *string    `json:"system" validate:"required,oneof=jira github"`
+	Key       *string    `json:"key" validate:"required"` // PANTHER-12 for Jira, org/repo#12 for GitHub
+	URL       *string    `json:"url" validate:"required"`
+	CreatedAt *time.Time `json:"createdAt" validate:"required"`
 }
 
 // Alert contains the details of an alert
 type Alert struct {
 	AlertSummary
-	Events                 []*string `json:"events" validate:"required"`
-	EventsLastEvaluatedKey *string   `json:"eventsLastEvaluatedKey,omitempty"`
+	Events                 []*string        `json:"events" validate:"required"`
+	EventsLastEvaluatedKey *string          `json:"eventsLastEvaluatedKey,omitempty"`
+	Activity               []*AlertActivity `json:"activity" validate:"required"`
+}
+
+// AlertActivity is a single triage change, in the order they were made. Only the 50 most recent are kept.
+type AlertActivity struct {
+	Type       *string    `json:"type" validate:"required,oneof=STATUS ASSIGNEE COMMENT"`
+	UserID     *string    `json:"userId" validate:"required"`
+	Time       *time.Time `json:"time" validate:"required"`
+	Status     *string    `json:"status,omitempty"`     // the new status, for STATUS changes
+	AssigneeID *string    `json:"assigneeId,omitempty"` // the new assignee (nil if unassigned), for ASSIGNEE changes
+	Comment    *string    `json:"comment,omitempty"`    // the comment text, for COMMENT activity
+}
+
+// UpdateAlertStatusInput sets the triage status of an alert.
+//
+// {
+//     "updateAlertStatus": {
+//         "alertId": "6c3a6b0f8b56f8f6b3ad4bd1d1b0cd30",
+//         "status": "TRIAGED",
+//         "userId": "6a4b3a36-79d4-4d33-a7e5-9a3b9e4dd3a8"
+//     }
+// }
+type UpdateAlertStatusInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Status  *string `json:"status" validate:"required,oneof=OPEN TRIAGED CLOSED FALSE_POSITIVE"`
+	UserID  *string `json:"userId" validate:"required,uuid4"`
+}
+
+// UpdateAlertAssigneeInput assigns an alert to a user, or unassigns it if the "assigneeId" is not set.
+type UpdateAlertAssigneeInput struct {
+	AlertID    *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	AssigneeID *string `json:"assigneeId,omitempty" validate:"omitempty,uuid4"`
+	UserID     *string `json:"userId" validate:"required,uuid4"`
+}
+
+// AddAlertCommentInput appends a comment to the alert activity.
+type AddAlertCommentInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Comment *string `json:"comment" validate:"required,min=1,max=4000"`
+	UserID  *string `json:"userId" validate:"required,uuid4"`
+}
+
+// UpdateAlertOutput is the alert summary after a triage change, with the full activity history.
+//
+// The output is nil if the alert does not exist.
+type UpdateAlertOutput struct {
+	AlertSummary
+	Activity []*AlertActivity `json:"activity" validate:"required"`
+}
+
+// ExportAlertEventsInput starts exporting every event of an alert to S3, one file per log type.
+//
+// The export runs in the background with Athena, poll "getAlertEventsExport" with the returned "exportId".
+// {
+//     "exportAlertEvents": {
+//         "alertId": "6a4b3a3679d44d33a7e59a3b9e4dd3a8",
+//         "format": "csv"
+//     }
+// }
+type ExportAlertEventsInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Format  *string `json:"format" validate:"required,oneof=csv json"`
+}
+
+// ExportAlertEventsOutput identifies a running export.
+//
+// The output is nil if the alert does not exist.
+type ExportAlertEventsOutput struct {
+	ExportID *string `json:"exportId" validate:"required"`
+}
+
+// GetAlertEventsExportInput retrieves the status of an export.
+type GetAlertEventsExportInput struct {
+	ExportID *string `json:"exportId" validate:"required"`
+}
+
+// GetAlertEventsExportOutput is the status of an export, with download links to the files once it succeeded.
+type GetAlertEventsExportOutput struct {
+	Status *string   `json:"status" validate:"required,oneof=RUNNING SUCCEEDED FAILED"`
+	Error  *string   `json:"error,omitempty"`
+	Files  []*string `json:"files"` // presigned S3 URLs
+}
+
+// CreateAlertSuppressionInput mutes new alerts until "expiresAt".
+//
+// The suppression matches alerts of the "ruleId", alerts with the "dedupString" and alerts where the
+// "field" ("title", "severity" or "logType") has the "value". Every criteria which is set must match.
+// A rule or dedup string is required, and matching a field requires the rule.
+// Suppressed alerts are stored (with "suppressed" set) but never delivered.
+// {
+//     "createAlertSuppression": {
+//         "ruleId": "AWS.CloudTrail.Created",
+//         "field": "severity",
+//         "value": "LOW",
+//         "expiresAt": "2020-06-01T00:00:00Z",
+//         "reason": "Noisy during the migration",
+//         "userId": "6a4b3a36-79d4-4d33-a7e5-9a3b9e4dd3a8"
+//     }
+// }
+type CreateAlertSuppressionInput struct {
+	RuleID      *string    `json:"ruleId,omitempty" validate:"required_without=DedupString,required_with=Field"`
+	DedupString *string    `json:"dedupString,omitempty" validate:"omitempty,min=1"`
+	Field       *string    `json:"field,omitempty" validate:"omitempty,oneof=title severity logType"`
+	Value       *string    `json:"value,omitempty" validate:"required_with=Field"`
+	ExpiresAt   *time.Time `json:"expiresAt" validate:"required"`
+	Reason      *string    `json:"reason" validate:"required,min=1,max=1000"`
+	UserID      *string    `json:"userId" validate:"required,uuid4"`
+}
+
+// ListAlertSuppressionsInput lists the suppressions which have not expired.
+type ListAlertSuppressionsInput struct{}
+
+// ListAlertSuppressionsOutput is the list of active suppressions.
+type ListAlertSuppressionsOutput = []*AlertSuppression
+
+// DeleteAlertSuppressionInput removes a suppression before it expires.
+type DeleteAlertSuppressionInput struct {
+	SuppressionID *string `json:"suppressionId" validate:"required,uuid4"`
+}
+
+// AlertSuppression mutes new alerts until it expires.
+type AlertSuppression struct {
+	SuppressionID *string    `json:"suppressionId" validate:"required"`
+	RuleID        *string    `json:"ruleId,omitempty"`
+	DedupString   *string    `json:"dedupString,omitempty"`
+	Field         *string    `json:"field,omitempty"`
+	Value         *string    `json:"value,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt" validate:"required"`
+	Reason        *string    `json:"reason" validate:"required"`
+	CreatedBy     *string    `json:"createdBy" validate:"required"`
+	CreatedAt     *time.Time `json:"createdAt" validate:"required"`
+}
+
+// GetAlertStatsInput counts the alerts created in a time range.
+//
+// Alerts are counted by severity, by log type, by rule (only the "topRules" rules with the most alerts)
+// and over time, by severity, in "hour" or "day" (the default) buckets.
+// {
+//     "getAlertStats": {
+//         "creationTimeAfter": "2020-05-01T00:00:00Z",
+//         "creationTimeBefore": "2020-05-08T00:00:00Z",
+//         "bucketSize": "day",
+//         "topRules": 10
+//     }
+// }
+type GetAlertStatsInput struct {
+	CreationTimeAfter  *time.Time `json:"creationTimeAfter" validate:"required"`
+	CreationTimeBefore *time.Time `json:"creationTimeBefore" validate:"required"`
+	BucketSize         *string    `json:"bucketSize,omitempty" validate:"omitempty,oneof=hour day"`
+	TopRules           *int       `json:"topRules,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// GetAlertStatsOutput has the alert counts for the requested time range, each list is sorted by count descending.
+type GetAlertStatsOutput struct {
+	TotalAlerts *int                `json:"totalAlerts" validate:"required"`
+	TotalEvents *int                `json:"totalEvents" validate:"required"`
+	BySeverity  []*AlertCount       `json:"bySeverity" validate:"required"`
+	ByLogType   []*AlertCount       `json:"byLogType" validate:"required"`
+	ByRule      []*AlertCount       `json:"byRule" validate:"required"`
+	OverTime    []*AlertStatsBucket `json:"overTime" validate:"required"`
+}
+
+// AlertCount is the number of alerts, and of events matched by them, for a single severity, log type or rule.
+//
+// Alerts with several log types are counted once for each of them.
+type AlertCount struct {
+	Key         *string `json:"key" validate:"required"`
+	AlertCount  *int    `json:"alertCount" validate:"required"`
+	EventCount  *int    `json:"eventCount" validate:"required"`
+	DisplayName *string `json:"displayName,omitempty"` // the rule display name, for rule counts
+}
+
+// AlertStatsBucket counts the alerts created in a time bucket, every bucket of the range is returned.
+type AlertStatsBucket struct {
+	Time       *time.Time    `json:"time" validate:"required"` // the start of the bucket
+	AlertCount *int          `json:"alertCount" validate:"required"`
+	BySeverity []*AlertCount `json:"bySeverity" validate:"required"`
 }
@@ -47,6 +47,7 @@ type AddOutputInput struct {
 	DisplayName        *string       `json:"displayName" validate:"required,min=1,excludesall='<>&\""`
 	OutputConfig       *OutputConfig `json:"outputConfig" validate:"required"`
 	DefaultForSeverity []*string     `json:"defaultForSeverity"`
+	Digest             *DigestConfig `json:"digest"`
 }
 
 // AddOutputOutput returns a randomly generated UUID for the output.
@@ -86,6 +87,7 @@ type UpdateOutputInput struct {
 	OutputID           *string       `json:"outputId" validate:"required,uuid4"`
 	OutputConfig       *OutputConfig `json:"outputConfig"`
 	DefaultForSeverity []*string     `json:"defaultForSeverity"`
+	Digest             *DigestConfig `json:"digest"`
 }
 
 // UpdateOutputOutput returns the new updated output
@@ -161,6 +163,20 @@ type AlertOutput struct {
 
 	// DefaultForSeverity defines the alert severities that will be forwarded through this output
 	DefaultForSeverity []*string `json:"defaultForSeverity"`
+
+	// Digest defines the alert severities that are grouped into periodic summary messages
+	Digest *DigestConfig `json:"digest,omitempty"`
+}
+
+// DigestConfig enables digest delivery for an output.
+//
+// Alerts with one of the given severities are held and then delivered as a single summary
+// message once WindowMins has passed since the oldest held alert or MaxAlerts are held.
+// CRITICAL alerts are always delivered immediately.
+type DigestConfig struct {
+	Severities []*string `json:"severities" validate:"omitempty,dive,oneof=INFO LOW MEDIUM HIGH"`
+	WindowMins int       `json:"windowMins" validate:"omitempty,min=1,max=1440"`
+	MaxAlerts  int       `json:"maxAlerts" validate:"omitempty,min=1,max=1000"`
 }
 
 // OutputConfig contains the configuration for the output
@@ -194,6 +210,15 @@ type OutputConfig struct {
 
 	// CustomWebhook contains the configuration for a Custom Webhook alert output
 	CustomWebhook *CustomWebhookConfig `json:"customWebhook,omitempty"`
+
+	// Email contains the configuration for an Email (SMTP) alert output
+	Email *EmailConfig `json:"email,omitempty"`
+
+	// Syslog contains the configuration for a Syslog (RFC5424) alert output
+	Syslog *SyslogConfig `json:"syslog,omitempty"`
+
+	// Splunk contains the configuration for a Splunk HTTP Event Collector alert output
+	Splunk *SplunkConfig `json:"splunk,omitempty"`
 }
 
 // SlackConfig defines options for each Slack output.
@@ -215,6 +240,8 @@ type PagerDutyConfig struct {
 type GithubConfig struct {
 	RepoName string `json:"repoName"`
 	Token    string `json:"token"`
+	// WebhookSecret enables syncing issue changes back to the alert with a repository webhook
+	WebhookSecret string `json:"webhookSecret"`
 }
 
 // JiraConfig defines options for each Jira output
@@ -225,6 +252,8 @@ type JiraConfig struct {
 	APIKey     string `json:"apiKey"`
 	AssigneeID string `json:"assigneeId"`
 	Type       string `json:"issueType"`
+	// WebhookSecret enables syncing issue changes back to the alert with a Jira webhook
+	WebhookSecret string `json:"webhookSecret"`
 }
 
 // OpsgenieConfig defines options for each Opsgenie output
@@ -248,9 +277,80 @@ type AsanaConfig struct {
 	ProjectGids         []string `json:"projectGids" validate:"omitempty,min=1,dive,required"`
 }
 
+// Supported authentication schemes for the CustomWebhook output
+const (
+	CustomWebhookAuthBearer = "bearer"
+	CustomWebhookAuthBasic  = "basic"
+)
+
 // CustomWebhookConfig defines options for each CustomWebhook output
 type CustomWebhookConfig struct {
 	WebhookURL string `json:"webhookURL" validate:"omitempty,url"`
+	// Headers are added to every request sent to the webhook
+	Headers []*CustomWebhookHeader `json:"headers,omitempty" validate:"omitempty,dive"`
+	// AuthType is one of bearer or basic, no Authorization header is sent if it is empty
+	AuthType     string `json:"authType" validate:"omitempty,oneof=bearer basic"`
+	AuthUserName string `json:"authUserName"`
+	// AuthSecret is the bearer token or the basic auth password
+	AuthSecret string `json:"authSecret"`
+	// SigningSecret enables HMAC-SHA256 request signatures, which receivers check with pkg/webhooksig
+	SigningSecret string `json:"signingSecret"`
+}
+
+// CustomWebhookHeader is a custom HTTP header sent to a CustomWebhook output
+type CustomWebhookHeader struct {
+	Key   string `json:"key" validate:"required,printascii,excludesall=:"`
+	Value string `json:"value" validate:"printascii"`
+}
+
+// Supported connection security modes for the Email output
+const (
+	EmailSecurityStartTLS = "starttls"
+	EmailSecurityTLS      = "tls"
+	EmailSecurityNone     = "none"
+)
+
+// EmailConfig defines options for each Email output
+type EmailConfig struct {
+	Host string `json:"host" validate:"omitempty,hostname|ip"`
+	// Port defaults to 587 for starttls, 465 for tls and 25 for none
+	Port int `json:"port,omitempty" validate:"omitempty,min=1,max=65535"`
+	// Security is one of starttls (default), tls or none
+	Security   string   `json:"security" validate:"omitempty,oneof=starttls tls none"`
+	UserName   string   `json:"userName"`
+	Password   string   `json:"password"`
+	From       string   `json:"from" validate:"omitempty,email"`
+	Recipients []string `json:"recipients" validate:"omitempty,min=1,dive,email"`
+}
+
+// Supported transports and payload formats for the Syslog output
+const (
+	SyslogProtocolTCP = "tcp"
+	SyslogProtocolTLS = "tls"
+
+	SyslogFormatRFC5424 = "rfc5424"
+	SyslogFormatCEF     = "cef"
+	SyslogFormatLEEF    = "leef"
+)
+
+// SyslogConfig defines options for each Syslog output
+type SyslogConfig struct {
+	Host string `json:"host" validate:"omitempty,hostname|ip"`
+	// Port defaults to 6514 for tls and 514 for tcp
+	Port int `json:"port,omitempty" validate:"omitempty,min=1,max=65535"`
+	// Protocol is one of tls (default) or tcp
+	Protocol string `json:"protocol" validate:"omitempty,oneof=tcp tls"`
+	// Format is the message payload: rfc5424 (JSON, default), cef or leef
+	Format string `json:"format" validate:"omitempty,oneof=rfc5424 cef leef"`
+}
+
+// SplunkConfig defines options for each Splunk HTTP Event Collector output
+type SplunkConfig struct {
+	// URL is the HEC event endpoint, e.g. https://splunk.example.com:8088/services/collector/event
+	URL        string `json:"url" validate:"omitempty,url"`
+	Token      string `json:"token"`
+	Index      string `json:"index"`
+	SourceType string `json:"sourceType"`
 }
 
 // DefaultOutputs is the structure holding the information about default outputs for severity
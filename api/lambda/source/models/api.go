@@ -33,8 +33,11 @@ type LambdaInput struct {
 
 	UpdateIntegrationLastScanEnd   *UpdateIntegrationLastScanEndInput   `json:"updateIntegrationLastScanEnd"`
 	UpdateIntegrationLastScanStart *UpdateIntegrationLastScanStartInput `json:"updateIntegrationLastScanStart"`
+	UpdateIntegrationResourceScans *UpdateIntegrationResourceScansInput `json:"updateIntegrationResourceScans"`
 
 	FullScan *FullScanInput `json:"fullScan"`
+
+	SyncOrgIntegrations *SyncOrgIntegrationsInput `json:"syncOrgIntegrations"`
 }
 
 //
@@ -44,7 +47,7 @@ type LambdaInput struct {
 // CheckIntegrationInput is used to check the health of a potential configuration.
 type CheckIntegrationInput struct {
 	AWSAccountID     *string `genericapi:"redact" json:"awsAccountId" validate:"required,len=12,numeric"`
-	IntegrationType  *string `json:"integrationType" validate:"required,oneof=aws-scan aws-s3"`
+	IntegrationType  *string `json:"integrationType" validate:"required,oneof=aws-scan aws-s3 aws-org"`
 	IntegrationLabel *string `json:"integrationLabel" validate:"required,integrationLabel"`
 
 	// Checks for cloudsec integrations
@@ -71,7 +74,7 @@ type PutIntegrationInput struct {
 type PutIntegrationSettings struct {
 	AWSAccountID       *string   `genericapi:"redact" json:"awsAccountId,omitempty" validate:"required,len=12,numeric"`
 	IntegrationLabel   *string   `json:"integrationLabel,omitempty" validate:"required,integrationLabel,excludesall='<>&\""`
-	IntegrationType    *string   `json:"integrationType" validate:"required,oneof=aws-scan aws-s3"`
+	IntegrationType    *string   `json:"integrationType" validate:"required,oneof=aws-scan aws-s3 aws-org"`
 	CWEEnabled         *bool     `json:"cweEnabled,omitempty"`
 	RemediationEnabled *bool     `json:"remediationEnabled,omitempty"`
 	ScanIntervalMins   *int      `json:"scanIntervalMins,omitempty" validate:"omitempty,oneof=60 180 360 720 1440"`
@@ -80,15 +83,21 @@ type PutIntegrationSettings struct {
 	S3Prefix           *string   `json:"s3Prefix,omitempty" validate:"omitempty,min=1"`
 	KmsKey             *string   `json:"kmsKey,omitempty" validate:"omitempty,kmsKeyArn"`
 	LogTypes           []*string `json:"logTypes,omitempty" validate:"omitempty,min=1"`
+
+	ResourceScanIntervals []*ResourceScanInterval `json:"resourceScanIntervals,omitempty" validate:"omitempty,dive,required"`
+	ScanRegions           []*string               `json:"scanRegions,omitempty" validate:"omitempty,dive,required,min=1"`
 }
 
 //
 // ListIntegrations: Used by the Scheduler to find integrations to scan
 //
 
-// ListIntegrationsInput allows filtering by the IntegrationType or Enabled fields
+// ListIntegrationsInput allows filtering by the IntegrationType or OrgUnitPath fields
+//
+// OrgUnitPath matches the integrations of accounts in the given organizational unit or any unit nested below it.
 type ListIntegrationsInput struct {
-	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3"`
+	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-org"`
+	OrgUnitPath     *string `json:"orgUnitPath" validate:"omitempty,min=1"`
 }
 
 // UpdateIntegrationSettingsInput is used to update integration settings.
@@ -102,6 +111,9 @@ type UpdateIntegrationSettingsInput struct {
 	S3Prefix           *string   `json:"s3Prefix,omitempty" validate:"omitempty,min=1"`
 	KmsKey             *string   `json:"kmsKey,omitempty" validate:"omitempty,kmsKeyArn"`
 	LogTypes           []*string `json:"logTypes,omitempty" validate:"omitempty,min=1"`
+
+	ResourceScanIntervals []*ResourceScanInterval `json:"resourceScanIntervals,omitempty" validate:"omitempty,dive,required"`
+	ScanRegions           []*string               `json:"scanRegions,omitempty" validate:"omitempty,dive,required,min=1"`
 }
 
 // DeleteIntegrationInput is used to delete a specific item from the database.
@@ -118,6 +130,15 @@ type FullScanInput struct {
 	Integrations []*SourceIntegrationMetadata
 }
 
+//
+// SyncOrgIntegrations: Used by the Scheduler to onboard the member accounts of organizations
+//
+
+// SyncOrgIntegrationsInput syncs the member account integrations of one aws-org integration, or all of them.
+type SyncOrgIntegrationsInput struct {
+	IntegrationID *string `json:"integrationId" validate:"omitempty,uuid4"`
+}
+
 //
 // GetIntegrationTemplate: Used by the frontend to provide templates for users
 //
@@ -125,7 +146,7 @@ type FullScanInput struct {
 // GetIntegrationTemplateInput allows specification of what resources should be enabled/disabled in the template
 type GetIntegrationTemplateInput struct {
 	AWSAccountID       *string `genericapi:"redact" json:"awsAccountId" validate:"required,len=12,numeric"`
-	IntegrationType    *string `json:"integrationType" validate:"oneof=aws-scan aws-s3"`
+	IntegrationType    *string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-org"`
 	IntegrationLabel   *string `json:"integrationLabel" validate:"required,integrationLabel"`
 	RemediationEnabled *bool   `json:"remediationEnabled,omitempty"`
 	CWEEnabled         *bool   `json:"cweEnabled,omitempty"`
@@ -153,3 +174,10 @@ type UpdateIntegrationLastScanEndInput struct {
 	LastScanErrorMessage *string    `json:"lastScanErrorMessage"`
 	ScanStatus           *string    `json:"scanStatus" validate:"required,oneof=ok error scanning"`
 }
+
+// UpdateIntegrationResourceScansInput is used by the scheduler to record which resource types it scanned.
+type UpdateIntegrationResourceScansInput struct {
+	IntegrationID *string    `json:"integrationId" validate:"required,uuid4"`
+	LastScanTime  *time.Time `json:"lastScanTime" validate:"required"`
+	ResourceTypes []*string  `json:"resourceTypes" validate:"required,min=1,dive,required"`
+}
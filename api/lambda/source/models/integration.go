@@ -38,6 +38,21 @@ type SourceIntegrationScanInformation struct {
 	LastScanEndTime      *time.Time `json:"lastScanEndTime"`
 	LastScanErrorMessage *string    `json:"lastScanErrorMessage"`
 	LastScanStartTime    *time.Time `json:"lastScanStartTime"`
+
+	// When each resource type was last scheduled for scanning
+	ResourceLastScans []*ResourceLastScan `json:"resourceLastScans,omitempty"`
+}
+
+// ResourceLastScan is the last time the scheduler requested a scan of one resource type.
+type ResourceLastScan struct {
+	ResourceType *string    `json:"resourceType"`
+	LastScanTime *time.Time `json:"lastScanTime"`
+}
+
+// ResourceScanInterval overrides the scan interval of the integration for one resource type.
+type ResourceScanInterval struct {
+	ResourceType *string `json:"resourceType" validate:"required"`
+	IntervalMins *int    `json:"intervalMins" validate:"required,oneof=60 180 360 720 1440"`
 }
 
 // SourceIntegrationMetadata is general settings and metadata for an integration.
@@ -57,6 +72,16 @@ type SourceIntegrationMetadata struct {
 	LogTypes           []*string  `json:"logTypes,omitempty"`
 	LogProcessingRole  *string    `json:"logProcessingRole,omitempty"`
 	StackName          *string    `json:"stackName,omitempty"`
+
+	// Resource types scanned more or less often than ScanIntervalMins, and the regions
+	// to scan (all of the enabled regions of the account if empty)
+	ResourceScanIntervals []*ResourceScanInterval `json:"resourceScanIntervals,omitempty"`
+	ScanRegions           []*string               `json:"scanRegions,omitempty"`
+
+	// Set on the aws-scan integrations of member accounts discovered through an aws-org integration
+	OrgIntegrationID *string `json:"orgIntegrationId,omitempty"`
+	OrgUnitPath      *string `json:"orgUnitPath,omitempty"`
+	Disabled         *bool   `json:"disabled,omitempty"`
 }
 
 type SourceIntegrationHealth struct {
@@ -68,6 +93,9 @@ type SourceIntegrationHealth struct {
 	CWERoleStatus         SourceIntegrationItemStatus `json:"cweRoleStatus"`
 	RemediationRoleStatus SourceIntegrationItemStatus `json:"remediationRoleStatus"`
 
+	// Checks for organization integrations
+	OrganizationStatus SourceIntegrationItemStatus `json:"organizationStatus"`
+
 	// Checks for log analysis integrations
 	ProcessingRoleStatus SourceIntegrationItemStatus `json:"processingRoleStatus"`
 	S3BucketStatus       SourceIntegrationItemStatus `json:"s3BucketStatus"`
@@ -79,6 +107,13 @@ type SourceIntegrationItemStatus struct {
 	ErrorMessage *string `json:"errorMessage"`
 }
 
+// SyncOrgIntegrationsOutput counts the member account integrations changed by an organization sync.
+type SyncOrgIntegrationsOutput struct {
+	Created  int `json:"created"`
+	Updated  int `json:"updated"`
+	Disabled int `json:"disabled"`
+}
+
 type SourceIntegrationTemplate struct {
 	Body      *string `json:"body"`
 	StackName *string `json:"stackName"`
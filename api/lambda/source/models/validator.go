@@ -27,7 +27,8 @@ import (
 )
 
 const (
-	integrationLabelMaxLength = 32
+	// IntegrationLabelMaxLength is the longest integration label allowed
+	IntegrationLabelMaxLength = 32
 )
 
 var (
@@ -48,7 +49,7 @@ func Validator() (*validator.Validate, error) {
 
 func validateIntegrationLabel(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
-	if len(strings.TrimSpace(value)) == 0 || len(value) > integrationLabelMaxLength {
+	if len(strings.TrimSpace(value)) == 0 || len(value) > IntegrationLabelMaxLength {
 		return false
 	}
 	return integrationLabelValidatorRegex.MatchString(value)
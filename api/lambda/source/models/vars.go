@@ -23,6 +23,8 @@ const (
 	IntegrationTypeAWSScan = "aws-scan"
 	// IntegrationTypeAWS3 is the integration type for importing data from customer S3 buckets.
 	IntegrationTypeAWS3 = "aws-s3"
+	// IntegrationTypeAWSOrg is the integration type for onboarding the member accounts of an AWS Organization.
+	IntegrationTypeAWSOrg = "aws-org"
 
 	// StatusError is the string set in the database when an error occurs in a scan.
 	StatusError = "error"
@@ -1,6 +1,8 @@
 package requeue
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"strconv"
 
@@ -88,3 +90,44 @@ func Requeue(sqsClient sqsiface.SQSAPI, region, fromQueueName, toQueueName strin
 		}
 	}
 }
+
+// Inspect writes the body of up to maxMessages messages in a queue to out, one per line, without deleting them.
+//
+// The messages become visible again in the queue after the visibility timeout.
+func Inspect(sqsClient sqsiface.SQSAPI, region, queueName string, maxMessages int, out io.Writer) error {
+	queueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: &queueName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cannot find queue %s in region %s", queueName, region)
+	}
+
+	totalMessages := 0
+	for totalMessages < maxMessages {
+		batchSize := maxMessages - totalMessages
+		if batchSize > messageBatchSize {
+			batchSize = messageBatchSize
+		}
+		resp, err := sqsClient.ReceiveMessage(&sqs.ReceiveMessageInput{
+			MaxNumberOfMessages: aws.Int64(int64(batchSize)),
+			VisibilityTimeout:   aws.Int64(visibilityTimeoutSeconds),
+			QueueUrl:            queueURL.QueueUrl,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failure receiving messages to inspect from %s", queueName)
+		}
+		if len(resp.Messages) == 0 {
+			break
+		}
+
+		for _, message := range resp.Messages {
+			if _, err = fmt.Fprintln(out, aws.StringValue(message.Body)); err != nil {
+				return errors.Wrap(err, "failure writing message")
+			}
+		}
+		totalMessages += len(resp.Messages)
+	}
+
+	log.Printf("Inspected %d message(s) in %s.", totalMessages, queueName)
+	return nil
+}
@@ -15,13 +15,16 @@ import (
 )
 
 const (
-	banner = "moves messages from one sqs queue to another"
+	banner = "moves messages from one sqs queue to another, or prints the messages in a queue with -inspect"
 )
 
 var (
 	REGION = flag.String("region", "", "The AWS region where the queues exists (optional, defaults to session env vars)")
 	FROMQ  = flag.String("from.q", "", "The name of the queue to copy from")
 	TOQ    = flag.String("to.q", "", "The name of the queue to copy to")
+
+	INSPECT     = flag.Bool("inspect", false, "Print the messages in -from.q to stdout without moving or deleting them")
+	MAXMESSAGES = flag.Int("max.messages", 100, "The maximum number of messages to print with -inspect")
 )
 
 func usage() {
@@ -50,7 +53,11 @@ func main() {
 
 	validateFlags()
 
-	err = requeue.Requeue(sqs.New(sess), *sess.Config.Region, *FROMQ, *TOQ)
+	if *INSPECT {
+		err = requeue.Inspect(sqs.New(sess), *sess.Config.Region, *FROMQ, *MAXMESSAGES, os.Stdout)
+	} else {
+		err = requeue.Requeue(sqs.New(sess), *sess.Config.Region, *FROMQ, *TOQ)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -70,6 +77,12 @@ func validateFlags() {
 		err = errors.New("-from.q not set")
 		return
 	}
+	if *INSPECT {
+		if *MAXMESSAGES <= 0 {
+			err = errors.New("-max.messages must be positive")
+		}
+		return
+	}
 	if *TOQ == "" {
 		err = errors.New("-to.q not set")
 		return
@@ -0,0 +1,58 @@
+package requeue
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSQSClient struct {
+	sqsiface.SQSAPI
+	mock.Mock
+}
+
+func (m *mockSQSClient) GetQueueUrl(input *sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.GetQueueUrlOutput), args.Error(1)
+}
+
+func (m *mockSQSClient) ReceiveMessage(input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.ReceiveMessageOutput), args.Error(1)
+}
+
+func TestInspect(t *testing.T) {
+	sqsClient := &mockSQSClient{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(
+		&sqs.GetQueueUrlOutput{QueueUrl: aws.String("dlq.url")}, nil)
+	sqsClient.On("ReceiveMessage", mock.Anything).Return(&sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{{Body: aws.String(`{"alertId":"a"}`)}, {Body: aws.String(`{"alertId":"b"}`)}},
+	}, nil).Once()
+	sqsClient.On("ReceiveMessage", mock.Anything).Return(&sqs.ReceiveMessageOutput{}, nil).Once()
+
+	var out bytes.Buffer
+	require.NoError(t, Inspect(sqsClient, "us-east-1", "panther-alerts-queue-dlq", 100, &out))
+	assert.Equal(t, "{\"alertId\":\"a\"}\n{\"alertId\":\"b\"}\n", out.String())
+	sqsClient.AssertExpectations(t)
+}
+
+func TestInspectMaxMessages(t *testing.T) {
+	sqsClient := &mockSQSClient{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(
+		&sqs.GetQueueUrlOutput{QueueUrl: aws.String("dlq.url")}, nil)
+	sqsClient.On("ReceiveMessage", mock.Anything).Return(&sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{{Body: aws.String("a")}},
+	}, nil).Once()
+
+	var out bytes.Buffer
+	require.NoError(t, Inspect(sqsClient, "us-east-1", "panther-alerts-queue-dlq", 1, &out))
+	assert.Equal(t, "a\n", out.String())
+	assert.Equal(t, int64(1), *sqsClient.Calls[1].Arguments.Get(0).(*sqs.ReceiveMessageInput).MaxNumberOfMessages)
+	sqsClient.AssertExpectations(t)
+}
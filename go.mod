@@ -4,7 +4,7 @@ go 1.14
 
 require (
 	github.com/aws/aws-lambda-go v1.17.0
-	github.com/aws/aws-sdk-go v1.31.8
+	github.com/aws/aws-sdk-go v1.32.7
 	github.com/cenkalti/backoff/v4 v4.0.2
 	github.com/go-openapi/errors v0.19.4
 	github.com/go-openapi/runtime v0.19.15
@@ -20,27 +20,61 @@ package forwarder
 
 import (
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
 
+// Policy alerts are stored in the same partition as rule alerts, so they are listed together
+const defaultTimePartition = "defaultPartition"
+
 var (
-	alertQueueURL                 = os.Getenv("ALERTING_QUEUE_URL")
-	awsSession                    = session.Must(session.NewSession())
-	sqsClient     sqsiface.SQSAPI = sqs.New(awsSession)
+	alertQueueURL                           = os.Getenv("ALERTING_QUEUE_URL")
+	alertsTable                             = os.Getenv("ALERTS_TABLE")
+	awsSession                              = session.Must(session.NewSession())
+	sqsClient     sqsiface.SQSAPI           = sqs.New(awsSession)
+	ddbClient     dynamodbiface.DynamoDBAPI = dynamodb.New(awsSession)
 )
 
-// Handle forwards an alert to the alert delivery SQS queue
+// alertItem is a policy alert stored in the alerts table, where it is listed and triaged with the rule alerts
+type alertItem struct {
+	ID              string    `dynamodbav:"id"`
+	Type            string    `dynamodbav:"type"`
+	TimePartition   string    `dynamodbav:"timePartition"`
+	RuleID          string    `dynamodbav:"ruleId"` // the policy ID, so alerts of a policy are listed with the rule index
+	RuleVersion     string    `dynamodbav:"ruleVersion"`
+	RuleDisplayName *string   `dynamodbav:"ruleDisplayName,omitempty"`
+	Title           string    `dynamodbav:"title"`
+	ResourceID      string    `dynamodbav:"resourceId"`
+	DedupString     string    `dynamodbav:"dedup"`
+	CreationTime    time.Time `dynamodbav:"creationTime"`
+	UpdateTime      time.Time `dynamodbav:"updateTime"`
+	Severity        string    `dynamodbav:"severity"`
+	EventCount      int       `dynamodbav:"eventCount"`
+	RuleTags        []string  `dynamodbav:"ruleTags,stringset,omitempty"`
+}
+
+// Handle stores a policy alert in the alerts table and forwards it to the alert delivery SQS queue
 func Handle(event *models.Alert) error {
 	zap.L().Info("received alert", zap.String("policyId", *event.PolicyID))
 
+	if err := storeAlert(event); err != nil {
+		return err
+	}
+
 	msgBody, err := jsoniter.Marshal(event)
 	if err != nil {
 		return err
@@ -58,3 +92,63 @@ func Handle(event *models.Alert) error {
 
 	return nil
 }
+
+// storeAlert adds the alert to the alerts table, unless it is already there
+//
+// Alerts are retried if their delivery fails, the condition keeps the triage state of a stored alert.
+func storeAlert(event *models.Alert) error {
+	if event.AlertID == nil || event.ResourceID == nil {
+		// Alerts queued before policy alerts were stored have no ID, they are only delivered
+		return nil
+	}
+
+	title := aws.StringValue(event.PolicyName)
+	if title == "" {
+		title = *event.PolicyID
+	}
+	alert := &alertItem{
+		ID:              *event.AlertID,
+		Type:            models.PolicyType,
+		TimePartition:   defaultTimePartition,
+		RuleID:          *event.PolicyID,
+		RuleVersion:     aws.StringValue(event.PolicyVersionID),
+		RuleDisplayName: event.PolicyName,
+		Title:           title,
+		ResourceID:      *event.ResourceID,
+		DedupString:     *event.ResourceID,
+		CreationTime:    event.CreatedAt.UTC(),
+		UpdateTime:      event.CreatedAt.UTC(),
+		Severity:        *event.Severity,
+		EventCount:      1,
+		RuleTags:        aws.StringValueSlice(event.Tags),
+	}
+	if alert.RuleDisplayName != nil && *alert.RuleDisplayName == "" {
+		alert.RuleDisplayName = nil
+	}
+
+	item, err := dynamodbattribute.MarshalMap(alert)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal alert")
+	}
+	condition, err := expression.NewBuilder().
+		WithCondition(expression.AttributeNotExists(expression.Name("id"))).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build condition expression")
+	}
+
+	_, err = ddbClient.PutItem(&dynamodb.PutItemInput{
+		TableName:                aws.String(alertsTable),
+		Item:                     item,
+		ConditionExpression:      condition.Condition(),
+		ExpressionAttributeNames: condition.Names(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			zap.L().Debug("alert already stored", zap.String("alertId", alert.ID))
+			return nil
+		}
+		return errors.Wrapf(err, "failed to store alert %s", alert.ID)
+	}
+	return nil
+}
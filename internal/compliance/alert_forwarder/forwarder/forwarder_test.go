@@ -21,15 +21,20 @@ package forwarder
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 type mockSqsClient struct {
@@ -44,6 +49,7 @@ func (m *mockSqsClient) SendMessage(input *sqs.SendMessageInput) (*sqs.SendMessa
 
 func init() {
 	alertQueueURL = "alertQueueURL"
+	alertsTable = "alertsTable"
 }
 
 func TestHandleAlert(t *testing.T) {
@@ -78,3 +84,67 @@ func TestHandleAlertSqsError(t *testing.T) {
 	require.Error(t, Handle(input))
 	mockSqsClient.AssertExpectations(t)
 }
+
+func policyAlert() *models.Alert {
+	return &models.Alert{
+		AlertID:         aws.String("6c3a6b0f8b56f8f6b3ad4bd1d1b0cd30"),
+		CreatedAt:       aws.Time(time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)),
+		PolicyID:        aws.String("AWS.S3.Encryption"),
+		PolicyName:      aws.String(""),
+		PolicyVersionID: aws.String("policyVersion"),
+		ResourceID:      aws.String("arn:aws:s3:::bucket"),
+		Severity:        aws.String("HIGH"),
+		Tags:            aws.StringSlice([]string{"S3"}),
+		Type:            aws.String(models.PolicyType),
+	}
+}
+
+func TestHandleStoresPolicyAlert(t *testing.T) {
+	mockSqsClient := &mockSqsClient{}
+	sqsClient = mockSqsClient
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	mockSqsClient.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+	require.NoError(t, Handle(policyAlert()))
+
+	input := ddbMock.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, "alertsTable", *input.TableName)
+	assert.Equal(t, "attribute_not_exists (#0)", *input.ConditionExpression)
+	assert.Equal(t, "6c3a6b0f8b56f8f6b3ad4bd1d1b0cd30", *input.Item["id"].S)
+	assert.Equal(t, "POLICY", *input.Item["type"].S)
+	assert.Equal(t, "AWS.S3.Encryption", *input.Item["ruleId"].S)
+	assert.Equal(t, "AWS.S3.Encryption", *input.Item["title"].S)
+	assert.Equal(t, "arn:aws:s3:::bucket", *input.Item["resourceId"].S)
+	assert.Equal(t, "defaultPartition", *input.Item["timePartition"].S)
+	assert.Equal(t, "2020-05-01T00:00:00Z", *input.Item["creationTime"].S)
+	assert.Nil(t, input.Item["ruleDisplayName"])
+	ddbMock.AssertExpectations(t)
+	mockSqsClient.AssertExpectations(t)
+}
+
+func TestHandlePolicyAlertAlreadyStored(t *testing.T) {
+	mockSqsClient := &mockSqsClient{}
+	sqsClient = mockSqsClient
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "exists", nil))
+	mockSqsClient.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+	require.NoError(t, Handle(policyAlert()))
+	ddbMock.AssertExpectations(t)
+	mockSqsClient.AssertExpectations(t)
+}
+
+func TestHandlePolicyAlertStoreError(t *testing.T) {
+	mockSqsClient := &mockSqsClient{}
+	sqsClient = mockSqsClient
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, errors.New("error"))
+	require.Error(t, Handle(policyAlert()))
+	mockSqsClient.AssertNotCalled(t, "SendMessage", mock.Anything)
+}
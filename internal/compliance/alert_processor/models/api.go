@@ -39,4 +39,8 @@ type ComplianceNotification struct {
 
 	//Timestamp indicates when the policy was actually evaluated
 	Timestamp *time.Time `json:"timestamp"`
+
+	//Status is PASS when a resource passes a policy it was failing, so its open alert is resolved.
+	//Notifications for failing resources have no status.
+	Status *string `json:"status,omitempty" validate:"omitempty,oneof=PASS"`
 }
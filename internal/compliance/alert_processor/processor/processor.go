@@ -19,7 +19,10 @@ package processor
  */
 
 import (
+	"crypto/md5" // nolint(gosec)
+	"encoding/hex"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -78,12 +81,16 @@ var (
 )
 
 //Handle method checks if a resource is compliant for a rule or not.
-// If the resource is compliant, it will do nothing
+// If the resource is compliant, it will resolve the open alerts of a policy it was failing
 // If the resource is not compliant, it will trigger an auto-remediation action
 // and an alert - if alerting is not suppressed
 func Handle(event *models.ComplianceNotification) error {
 	zap.L().Debug("received new event", zap.String("resourceId", *event.ResourceID))
 
+	if aws.StringValue(event.Status) == string(compliancemodels.StatusPASS) {
+		return resolveAlerts(event)
+	}
+
 	triggerActions, err := shouldTriggerActions(event)
 	if err != nil {
 		return err
@@ -110,6 +117,15 @@ func Handle(event *models.ComplianceNotification) error {
 
 // We should trigger actions on resource if the resource is failing for a policy
 func shouldTriggerActions(event *models.ComplianceNotification) (bool, error) {
+	status, err := getStatus(event)
+	if err != nil {
+		return false, err
+	}
+	return status == compliancemodels.StatusFAIL, nil
+}
+
+// getStatus returns the current compliance status of the policy/resource pair, empty if it has none
+func getStatus(event *models.ComplianceNotification) (compliancemodels.Status, error) {
 	zap.L().Debug("getting resource status",
 		zap.String("policyId", *event.PolicyID),
 		zap.String("resourceId", *event.ResourceID))
@@ -121,9 +137,9 @@ func shouldTriggerActions(event *models.ComplianceNotification) (bool, error) {
 		})
 	if err != nil {
 		if _, ok := err.(*complianceoperations.GetStatusNotFound); ok {
-			return false, nil
+			return "", nil
 		}
-		return false, err
+		return "", err
 	}
 
 	zap.L().Debug("got resource status",
@@ -131,7 +147,7 @@ func shouldTriggerActions(event *models.ComplianceNotification) (bool, error) {
 		zap.String("resourceId", *event.ResourceID),
 		zap.String("status", string(response.Payload.Status)))
 
-	return response.Payload.Status == compliancemodels.StatusFAIL, nil
+	return response.Payload.Status, nil
 }
 
 func triggerAlert(event *models.ComplianceNotification) (canRemediate bool, err error) {
@@ -230,11 +246,13 @@ func getAlertConfigPolicy(event *models.ComplianceNotification) (*alertmodel.Ale
 	}
 
 	return &alertmodel.Alert{
+			AlertID:           aws.String(generateAlertID(event)),
 			CreatedAt:         event.Timestamp,
 			PolicyDescription: aws.String(string(policy.Payload.Description)),
 			PolicyID:          event.PolicyID,
 			PolicyName:        aws.String(string(policy.Payload.DisplayName)),
 			PolicyVersionID:   event.PolicyVersionID,
+			ResourceID:        event.ResourceID,
 			Runbook:           aws.String(string(policy.Payload.Runbook)),
 			Severity:          aws.String(string(policy.Payload.Severity)),
 			Tags:              aws.StringSlice(policy.Payload.Tags),
@@ -243,3 +261,10 @@ func getAlertConfigPolicy(event *models.ComplianceNotification) (*alertmodel.Ale
 		policy.Payload.AutoRemediationID != "", // means we can remediate
 		nil
 }
+
+// generateAlertID identifies the alert of a policy failure for a resource, the same as the ID of rule alerts
+func generateAlertID(event *models.ComplianceNotification) string {
+	key := *event.PolicyID + ":" + *event.ResourceID + ":" + strconv.FormatInt(aws.TimeValue(event.Timestamp).Unix(), 10)
+	keyHash := md5.Sum([]byte(key)) // nolint(gosec)
+	return hex.EncodeToString(keyHash[:])
+}
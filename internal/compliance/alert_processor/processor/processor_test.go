@@ -36,6 +36,7 @@ import (
 	analysismodels "github.com/panther-labs/panther/api/gateway/analysis/models"
 	compliancemodels "github.com/panther-labs/panther/api/gateway/compliance/models"
 	"github.com/panther-labs/panther/internal/compliance/alert_processor/models"
+	alertmodel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
 
 type mockDdbClient struct {
@@ -48,6 +49,11 @@ func (m *mockDdbClient) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.U
 	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
 }
 
+func (m *mockDdbClient) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
 type mockRoundTripper struct {
 	http.RoundTripper
 	mock.Mock
@@ -142,6 +148,18 @@ func TestHandleEventWithAlertButNoAutoRemediationID(t *testing.T) {
 	httpRequest := mockRoundTripper.Calls[0].Arguments[0].(*http.Request)
 	assert.Equal(t, "policyId=test-policy&resourceId=test-resource", httpRequest.URL.RawQuery)
 
+	// The alert identifies the failing resource, so it can be stored with the rule alerts
+	updateInput := mockDdbClient.Calls[0].Arguments[0].(*dynamodb.UpdateItemInput)
+	var alertConfig alertmodel.Alert
+	for _, value := range updateInput.ExpressionAttributeValues {
+		if value.B != nil {
+			require.NoError(t, jsoniter.Unmarshal(value.B, &alertConfig))
+		}
+	}
+	assert.Equal(t, aws.String("test-resource"), alertConfig.ResourceID)
+	assert.Equal(t, aws.String(generateAlertID(input)), alertConfig.AlertID)
+	assert.Len(t, *alertConfig.AlertID, 32)
+
 	mockDdbClient.AssertExpectations(t)
 	mockRoundTripper.AssertExpectations(t)
 }
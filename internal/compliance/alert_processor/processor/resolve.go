@@ -0,0 +1,148 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	compliancemodels "github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/internal/compliance/alert_processor/models"
+	alertmodel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+var (
+	alertsTable                     = os.Getenv("ALERTS_TABLE")
+	alertsRuleIndex                 = os.Getenv("ALERTS_RULE_INDEX")
+	alertQueueURL                   = os.Getenv("ALERTING_QUEUE_URL")
+	sqsClient       sqsiface.SQSAPI = sqs.New(awsSession)
+)
+
+// openAlert is a policy alert in the alerts table which has not been closed by a user
+type openAlert struct {
+	ID              string    `dynamodbav:"id"`
+	RuleVersion     string    `dynamodbav:"ruleVersion"`
+	RuleDisplayName *string   `dynamodbav:"ruleDisplayName"`
+	Title           string    `dynamodbav:"title"`
+	CreationTime    time.Time `dynamodbav:"creationTime"`
+	Severity        string    `dynamodbav:"severity"`
+}
+
+// resolveAlerts sends a resolve action for the open alerts of a policy/resource pair which passes again.
+//
+// Outputs which track incidents (PagerDuty and Opsgenie) then resolve the incidents of those alerts.
+func resolveAlerts(event *models.ComplianceNotification) error {
+	// The resource may have failed again since it was evaluated
+	status, err := getStatus(event)
+	if err != nil {
+		return err
+	}
+	if status != compliancemodels.StatusPASS {
+		zap.L().Debug("resource no longer passes, not resolving alerts",
+			zap.String("policyId", *event.PolicyID),
+			zap.String("resourceId", *event.ResourceID))
+		return nil
+	}
+
+	alerts, err := getOpenAlerts(*event.PolicyID, *event.ResourceID)
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts {
+		resolve := &alertmodel.Alert{
+			AlertID:         aws.String(alert.ID),
+			Action:          aws.String(alertmodel.ResolveAction),
+			CreatedAt:       aws.Time(alert.CreationTime),
+			PolicyID:        event.PolicyID,
+			PolicyName:      alert.RuleDisplayName,
+			PolicyVersionID: aws.String(alert.RuleVersion),
+			ResourceID:      event.ResourceID,
+			Severity:        aws.String(alert.Severity),
+			Title:           aws.String(alert.Title),
+			Type:            aws.String(alertmodel.PolicyType),
+		}
+		body, err := jsoniter.MarshalToString(resolve)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal resolve action for alert %s", alert.ID)
+		}
+		_, err = sqsClient.SendMessage(&sqs.SendMessageInput{
+			QueueUrl:    aws.String(alertQueueURL),
+			MessageBody: aws.String(body),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to queue resolve action for alert %s", alert.ID)
+		}
+		zap.L().Info("resolving alert", zap.String("alertId", alert.ID), zap.String("resourceId", *event.ResourceID))
+	}
+	return nil
+}
+
+// getOpenAlerts finds the policy alerts of a resource which are not closed
+func getOpenAlerts(policyID, resourceID string) ([]*openAlert, error) {
+	keyCondition := expression.Key("ruleId").Equal(expression.Value(policyID))
+	filter := expression.Name("resourceId").Equal(expression.Value(resourceID)).
+		And(expression.Name("type").Equal(expression.Value(alertmodel.PolicyType))).
+		And(expression.Or(
+			expression.Name("status").AttributeNotExists(),
+			expression.Name("status").Equal(expression.Value("OPEN")),
+			expression.Name("status").Equal(expression.Value("TRIAGED")),
+		))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithFilter(filter).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build open alerts query")
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(alertsTable),
+		IndexName:                 aws.String(alertsRuleIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var result []*openAlert
+	for {
+		output, err := ddbClient.Query(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query open alerts of policy %s", policyID)
+		}
+		var page []*openAlert
+		if err = dynamodbattribute.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal open alerts")
+		}
+		result = append(result, page...)
+
+		if len(output.LastEvaluatedKey) == 0 {
+			return result, nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,150 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	compliancemodels "github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/internal/compliance/alert_processor/models"
+	alertmodel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var passNotification = &models.ComplianceNotification{
+	ResourceID:      aws.String("test-resource"),
+	PolicyID:        aws.String("test-policy"),
+	PolicyVersionID: aws.String("test-version"),
+	ShouldAlert:     aws.Bool(false),
+	Status:          aws.String("PASS"),
+}
+
+func TestHandlePassResolvesOpenAlerts(t *testing.T) {
+	mockDdbClient := &mockDdbClient{}
+	ddbClient = mockDdbClient
+	mockSqsClient := &testutils.SqsMock{}
+	sqsClient = mockSqsClient
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+	alertQueueURL = "alertQueueURL"
+	alertsRuleIndex = "alertsRuleIndex"
+
+	complianceResponse := &compliancemodels.ComplianceStatus{
+		PolicyID:   "test-policy",
+		ResourceID: "test-resource",
+		Status:     compliancemodels.StatusPASS,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(complianceResponse, http.StatusOK), nil).Once()
+
+	createdAt := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{{
+			"id":           {S: aws.String("alert-id")},
+			"ruleVersion":  {S: aws.String("test-version")},
+			"title":        {S: aws.String("test-policy")},
+			"creationTime": {S: aws.String("2020-05-01T12:00:00Z")},
+			"severity":     {S: aws.String("HIGH")},
+		}},
+	}, nil)
+	mockSqsClient.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+
+	require.NoError(t, Handle(passNotification))
+
+	query := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "alertsRuleIndex", *query.IndexName)
+	var queryValues []string
+	for _, value := range query.ExpressionAttributeValues {
+		queryValues = append(queryValues, aws.StringValue(value.S))
+	}
+	assert.Subset(t, queryValues, []string{"test-policy", "test-resource", "POLICY"})
+
+	input := mockSqsClient.Calls[0].Arguments.Get(0).(*sqs.SendMessageInput)
+	assert.Equal(t, "alertQueueURL", *input.QueueUrl)
+	var alert alertmodel.Alert
+	require.NoError(t, jsoniter.UnmarshalFromString(*input.MessageBody, &alert))
+	assert.Equal(t, alertmodel.Alert{
+		AlertID:         aws.String("alert-id"),
+		Action:          aws.String("resolve"),
+		CreatedAt:       aws.Time(createdAt),
+		PolicyID:        aws.String("test-policy"),
+		PolicyVersionID: aws.String("test-version"),
+		ResourceID:      aws.String("test-resource"),
+		Severity:        aws.String("HIGH"),
+		Title:           aws.String("test-policy"),
+		Type:            aws.String("POLICY"),
+	}, alert)
+
+	mockDdbClient.AssertExpectations(t)
+	mockSqsClient.AssertExpectations(t)
+	mockRoundTripper.AssertExpectations(t)
+}
+
+func TestHandlePassFailingAgain(t *testing.T) {
+	mockDdbClient := &mockDdbClient{}
+	ddbClient = mockDdbClient
+	mockSqsClient := &testutils.SqsMock{}
+	sqsClient = mockSqsClient
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+
+	// The resource failed again before the notification was processed
+	complianceResponse := &compliancemodels.ComplianceStatus{
+		PolicyID:   "test-policy",
+		ResourceID: "test-resource",
+		Status:     compliancemodels.StatusFAIL,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(complianceResponse, http.StatusOK), nil).Once()
+
+	require.NoError(t, Handle(passNotification))
+	mockDdbClient.AssertExpectations(t)
+	mockSqsClient.AssertExpectations(t)
+	mockRoundTripper.AssertExpectations(t)
+}
+
+func TestHandlePassNoOpenAlerts(t *testing.T) {
+	mockDdbClient := &mockDdbClient{}
+	ddbClient = mockDdbClient
+	mockSqsClient := &testutils.SqsMock{}
+	sqsClient = mockSqsClient
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+
+	complianceResponse := &compliancemodels.ComplianceStatus{
+		PolicyID:   "test-policy",
+		ResourceID: "test-resource",
+		Status:     compliancemodels.StatusPASS,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(complianceResponse, http.StatusOK), nil).Once()
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil)
+
+	require.NoError(t, Handle(passNotification))
+	mockDdbClient.AssertExpectations(t)
+	mockSqsClient.AssertExpectations(t)
+	mockRoundTripper.AssertExpectations(t)
+}
@@ -90,6 +90,11 @@ func refreshAccounts() error {
 	}
 
 	for _, integration := range output {
+		// Accounts which left their organization are no longer monitored
+		if aws.BoolValue(integration.Disabled) {
+			delete(accounts, *integration.AWSAccountID)
+			continue
+		}
 		accounts[*integration.AWSAccountID] = integration
 	}
 	accountsLastUpdated = time.Now()
@@ -250,3 +250,23 @@ func TestRefreshAccountsUseCache(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, accounts, 2)
 }
+
+func TestRefreshAccountsDropsDisabled(t *testing.T) {
+	disabled := *exampleIntegrations[0]
+	disabled.Disabled = aws.Bool(true)
+
+	mockLambda := &mockLambdaClient{}
+	mockLambda.
+		On("Invoke", getTestInvokeInput()).
+		Return(getTestInvokeOutput([]*models.SourceIntegration{&disabled}, 200), nil)
+	lambdaClient = mockLambda
+
+	// The account was enabled the last time the cache was refreshed
+	resetAccountCache()
+	accounts[*disabled.AWSAccountID] = exampleIntegrations[0]
+	accountsLastUpdated = time.Now().Add(time.Duration(-10) * time.Minute)
+
+	err := refreshAccounts()
+	require.NoError(t, err)
+	assert.Empty(t, accounts)
+}
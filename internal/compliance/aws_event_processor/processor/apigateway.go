@@ -0,0 +1,127 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	restAPIResourcePrefix = "/restapis/"
+	v2APIResourcePrefix   = "/apis/"
+)
+
+func classifyAPIGateway(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonapigateway.html
+	//
+	// REST APIs (v1) and HTTP/WebSocket APIs (v2) share an event source and many event names, they are
+	// told apart by whether the request identifies the API with a restApiId or an apiId.
+	var restAPIID, v2APIID string
+	switch metadata.eventName {
+	case "CreateRestApi", "ImportRestApi":
+		restAPIID = detail.Get("responseElements.id").Str
+	case "CreateApi", "ImportApi":
+		v2APIID = detail.Get("responseElements.apiId").Str
+	case "DeleteRestApi", "PutRestApi", "UpdateRestApi":
+		restAPIID = detail.Get("requestParameters.restApiId").Str
+	case "DeleteApi", "ReimportApi", "UpdateApi":
+		v2APIID = detail.Get("requestParameters.apiId").Str
+	case "CreateAuthorizer", "CreateDeployment", "CreateStage", "DeleteAuthorizer", "DeleteStage",
+		"UpdateAuthorizer", "UpdateStage":
+		restAPIID = detail.Get("requestParameters.restApiId").Str
+		v2APIID = detail.Get("requestParameters.apiId").Str
+	case "TagResource", "UntagResource":
+		resourceARN, err := arn.Parse(detail.Get("requestParameters.resourceArn").Str)
+		if err != nil {
+			zap.L().Error("apigateway: error parsing ARN", zap.String("eventName", metadata.eventName), zap.Error(err))
+			return nil
+		}
+		return apiGatewayChanges(resourceARN, metadata)
+	default:
+		zap.L().Info("apigateway: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if restAPIID == "" && v2APIID == "" {
+		zap.L().Error("apigateway: known event name, but still failed to parse API ID", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	resourceID := restAPIResourcePrefix + restAPIID
+	resourceType := schemas.APIGatewayRestAPISchema
+	deleteEvent := "DeleteRestApi"
+	if restAPIID == "" {
+		resourceID = v2APIResourcePrefix + v2APIID
+		resourceType = schemas.APIGatewayV2APISchema
+		deleteEvent = "DeleteApi"
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == deleteEvent,
+		EventName:    metadata.eventName,
+		ResourceID:   apiGatewayARN(metadata.region, resourceID),
+		ResourceType: resourceType,
+	}}
+}
+
+// apiGatewayChanges returns the API to rescan given the ARN of an API Gateway resource
+//
+// API Gateway ARNs are of the form arn:aws:apigateway:<region>::/restapis/<id>/stages/<stage>, the
+// resource path always starts with the API the resource belongs to.
+func apiGatewayChanges(resourceARN arn.ARN, metadata *CloudTrailMetadata) []*resourceChange {
+	var prefix, resourceType string
+	switch {
+	case strings.HasPrefix(resourceARN.Resource, restAPIResourcePrefix):
+		prefix, resourceType = restAPIResourcePrefix, schemas.APIGatewayRestAPISchema
+	case strings.HasPrefix(resourceARN.Resource, v2APIResourcePrefix):
+		prefix, resourceType = v2APIResourcePrefix, schemas.APIGatewayV2APISchema
+	default:
+		// Custom domain names, usage plans, API keys etc. are not tracked
+		return nil
+	}
+
+	apiID := strings.SplitN(strings.TrimPrefix(resourceARN.Resource, prefix), "/", 2)[0]
+	if apiID == "" {
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		EventName:    metadata.eventName,
+		ResourceID:   apiGatewayARN(resourceARN.Region, prefix+apiID),
+		ResourceType: resourceType,
+	}}
+}
+
+// apiGatewayARN builds the ARN of an API Gateway resource, which has no account ID
+func apiGatewayARN(region, resource string) string {
+	return arn.ARN{
+		Partition: "aws",
+		Service:   "apigateway",
+		Region:    region,
+		Resource:  resource,
+	}.String()
+}
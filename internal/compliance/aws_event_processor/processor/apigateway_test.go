@@ -0,0 +1,65 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyAPIGatewayRestAPI(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"restApiId": "abcdef1234", "stageName": "prod"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "UpdateStage"}
+
+	changes := classifyAPIGateway(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/abcdef1234", changes[0].ResourceID)
+	assert.Equal(t, schemas.APIGatewayRestAPISchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyAPIGatewayV2API(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"apiId": "qwerty5678"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "DeleteApi"}
+
+	changes := classifyAPIGateway(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/apis/qwerty5678", changes[0].ResourceID)
+	assert.Equal(t, schemas.APIGatewayV2APISchema, changes[0].ResourceType)
+	assert.True(t, changes[0].Delete)
+}
+
+func TestClassifyWAFRegionalAPIGatewayStage(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {
+		"resourceArn": "arn:aws:apigateway:us-west-2::/restapis/abcdef1234/stages/prod",
+		"webAclId": "1111-2222"
+	}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "AssociateWebACL"}
+
+	changes := classifyWAFRegional(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/abcdef1234", changes[0].ResourceID)
+	assert.Equal(t, schemas.APIGatewayRestAPISchema, changes[0].ResourceType)
+	assert.Equal(t, schemas.WafRegionalWebAclSchema, changes[1].ResourceType)
+}
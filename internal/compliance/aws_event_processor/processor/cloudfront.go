@@ -0,0 +1,68 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyCloudFront(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazoncloudfront.html
+	var distributionID string
+	switch metadata.eventName {
+	case "CreateDistribution", "CreateDistributionWithTags":
+		distributionID = detail.Get("responseElements.distribution.id").Str
+	case "DeleteDistribution", "UpdateDistribution":
+		distributionID = detail.Get("requestParameters.id").Str
+	case "TagResource", "UntagResource":
+		// Streaming distributions can be tagged as well, but we only track web distributions
+		resourceARN, err := arn.Parse(detail.Get("requestParameters.resource").Str)
+		if err != nil || !strings.HasPrefix(resourceARN.Resource, "distribution/") {
+			return nil
+		}
+		distributionID = strings.TrimPrefix(resourceARN.Resource, "distribution/")
+	default:
+		zap.L().Info("cloudfront: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if distributionID == "" {
+		zap.L().Error("cloudfront: known event name, but failed to parse distribution ID", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteDistribution",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "cloudfront",
+			AccountID: metadata.accountID,
+			Resource:  "distribution/" + distributionID,
+		}.String(),
+		ResourceType: schemas.CloudFrontDistributionSchema,
+	}}
+}
@@ -0,0 +1,74 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyECR(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticcontainerregistry.html
+	var repositoryARN string
+	switch metadata.eventName {
+	case "CreateRepository":
+		repositoryARN = detail.Get("responseElements.repository.repositoryArn").Str
+	case "DeleteLifecyclePolicy", "DeleteRepository", "DeleteRepositoryPolicy", "PutImageScanningConfiguration",
+		"PutImageTagMutability", "PutLifecyclePolicy", "SetRepositoryPolicy":
+		name := detail.Get("requestParameters.repositoryName").Str
+		if name == "" {
+			break
+		}
+		// The registry ID is optional, and defaults to the account making the request
+		registryID := detail.Get("requestParameters.registryId").Str
+		if registryID == "" {
+			registryID = metadata.accountID
+		}
+		repositoryARN = arn.ARN{
+			Partition: "aws",
+			Service:   "ecr",
+			Region:    metadata.region,
+			AccountID: registryID,
+			Resource:  "repository/" + name,
+		}.String()
+	case "TagResource", "UntagResource":
+		repositoryARN = detail.Get("requestParameters.resourceArn").Str
+	default:
+		zap.L().Info("ecr: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if !strings.HasPrefix(repositoryARN, "arn:") {
+		zap.L().Error("ecr: known event name, but failed to parse repository ARN", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteRepository",
+		EventName:    metadata.eventName,
+		ResourceID:   repositoryARN,
+		ResourceType: schemas.EcrRepositorySchema,
+	}}
+}
@@ -32,7 +32,10 @@ import (
 func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticcontainerservice.html
 	var clusterARN string
+	var changes []*resourceChange
 	switch metadata.eventName {
+	case "RegisterTaskDefinition", "DeregisterTaskDefinition":
+		return ecsTaskDefinitionChanges(detail.Get("responseElements.taskDefinition.taskDefinitionArn").Str, metadata)
 	case "CreateTaskSet", "DeleteCluster", "DeleteTaskSet", "UpdateServicePrimaryTaskSet", "UpdateTaskSet":
 		clusterARN = detail.Get("requestParameters.cluster").Str
 	case "CreateService", "DeleteAttributes", "DeleteService", "DeregisterContainerInstance", "PutAttributes",
@@ -68,15 +71,28 @@ func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		if strings.HasPrefix(parsed.Resource, "cluster") {
 			break
 		}
+		if strings.HasPrefix(parsed.Resource, "task-definition/") {
+			return ecsTaskDefinitionChanges(clusterARN, metadata)
+		}
 
 		// If it wasn't a cluster, we have to scan the whole region.
-		return []*resourceChange{{
+		changes = append(changes, &resourceChange{
 			AwsAccountID: metadata.accountID,
 			Delete:       false,
 			EventName:    metadata.eventName,
 			Region:       metadata.region,
 			ResourceType: schemas.EcsClusterSchema,
-		}}
+		})
+		if strings.HasPrefix(parsed.Resource, "service/") {
+			changes = append(changes, &resourceChange{
+				AwsAccountID: metadata.accountID,
+				Delete:       false,
+				EventName:    metadata.eventName,
+				ResourceID:   clusterARN,
+				ResourceType: schemas.EcsServiceSchema,
+			})
+		}
+		return changes
 	default:
 		zap.L().Info("ecs: encountered unknown event name", zap.String("eventName", metadata.eventName))
 		return nil
@@ -102,11 +118,55 @@ func classifyECS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 		}.String()
 	}
 
-	return []*resourceChange{{
+	changes = append(changes, &resourceChange{
 		AwsAccountID: metadata.accountID,
 		Delete:       metadata.eventName == "DeleteCluster",
 		EventName:    metadata.eventName,
 		ResourceID:   clusterARN,
 		ResourceType: schemas.EcsClusterSchema,
+	})
+
+	// Changes to a service also change the service itself, which is tracked as its own resource
+	switch metadata.eventName {
+	case "CreateService", "DeleteService", "UpdateService":
+		if serviceARN := detail.Get("responseElements.service.serviceArn").Str; serviceARN != "" {
+			changes = append(changes, &resourceChange{
+				AwsAccountID: metadata.accountID,
+				Delete:       metadata.eventName == "DeleteService",
+				EventName:    metadata.eventName,
+				ResourceID:   serviceARN,
+				ResourceType: schemas.EcsServiceSchema,
+			})
+		}
+	}
+
+	return changes
+}
+
+// ecsTaskDefinitionChanges summarizes a change to the task definition family of the given revision ARN.
+//
+// Deregistering a single revision does not delete the family, the snapshot poller will determine whether
+// any active revisions remain.
+func ecsTaskDefinitionChanges(revisionARN string, metadata *CloudTrailMetadata) []*resourceChange {
+	parsed, err := arn.Parse(revisionARN)
+	if err != nil {
+		zap.L().Error(
+			"ecs: unable to parse task definition ARN",
+			zap.String("eventName", metadata.eventName),
+			zap.String("resource ARN", revisionARN),
+			zap.Error(errors.WithStack(err)),
+		)
+		return nil
+	}
+	if idx := strings.LastIndex(parsed.Resource, ":"); idx > 0 {
+		parsed.Resource = parsed.Resource[:idx]
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       false,
+		EventName:    metadata.eventName,
+		ResourceID:   parsed.String(),
+		ResourceType: schemas.EcsTaskDefinitionSchema,
 	}}
 }
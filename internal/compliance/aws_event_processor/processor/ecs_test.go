@@ -0,0 +1,55 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyECSRegisterTaskDefinition(t *testing.T) {
+	detail := gjson.Parse(`{"responseElements": {"taskDefinition": {
+		"taskDefinitionArn": "arn:aws:ecs:us-west-2:123456789012:task-definition/example-task:3"}}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "RegisterTaskDefinition"}
+
+	changes := classifyECS(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:task-definition/example-task", changes[0].ResourceID)
+	assert.Equal(t, schemas.EcsTaskDefinitionSchema, changes[0].ResourceType)
+	assert.False(t, changes[0].Delete)
+}
+
+func TestClassifyECSDeleteService(t *testing.T) {
+	detail := gjson.Parse(`{"requestParameters": {"cluster": "example-cluster", "service": "example"}, "responseElements": {"service": {
+		"serviceArn": "arn:aws:ecs:us-west-2:123456789012:service/example-cluster/example"}}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "DeleteService"}
+
+	changes := classifyECS(detail, metadata)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:cluster/example-cluster", changes[0].ResourceID)
+	assert.False(t, changes[0].Delete)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:service/example-cluster/example", changes[1].ResourceID)
+	assert.Equal(t, schemas.EcsServiceSchema, changes[1].ResourceType)
+	assert.True(t, changes[1].Delete)
+}
@@ -0,0 +1,79 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyEKS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelastickubernetesservice.html
+	var clusterARN string
+	switch metadata.eventName {
+	case "CreateCluster":
+		clusterARN = detail.Get("responseElements.cluster.arn").Str
+	case "DeleteCluster", "UpdateClusterConfig", "UpdateClusterVersion":
+		clusterARN = eksClusterARN(detail.Get("requestParameters.name").Str, metadata)
+	case "AssociateEncryptionConfig", "AssociateIdentityProviderConfig", "DisassociateIdentityProviderConfig":
+		clusterARN = eksClusterARN(detail.Get("requestParameters.clusterName").Str, metadata)
+	case "TagResource", "UntagResource":
+		// Node groups and fargate profiles can be tagged as well, but we only track clusters
+		resourceARN, err := arn.Parse(detail.Get("requestParameters.resourceArn").Str)
+		if err != nil || !strings.HasPrefix(resourceARN.Resource, "cluster/") {
+			return nil
+		}
+		clusterARN = resourceARN.String()
+	default:
+		zap.L().Info("eks: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if clusterARN == "" {
+		zap.L().Error("eks: known event name, but failed to parse cluster ARN", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteCluster",
+		EventName:    metadata.eventName,
+		ResourceID:   clusterARN,
+		ResourceType: schemas.EksClusterSchema,
+	}}
+}
+
+// eksClusterARN constructs the ARN of a cluster from its name, which is all most EKS API calls reference
+func eksClusterARN(name string, metadata *CloudTrailMetadata) string {
+	if name == "" {
+		return ""
+	}
+	return arn.ARN{
+		Partition: "aws",
+		Service:   "eks",
+		Region:    metadata.region,
+		AccountID: metadata.accountID,
+		Resource:  "cluster/" + name,
+	}.String()
+}
@@ -0,0 +1,74 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// Classic and v2 load balancers share an event source, they are told apart by the API version
+const elbClassicAPIVersion = "2012-06-01"
+
+func classifyELB(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_elasticloadbalancing.html
+	var names []string
+	switch metadata.eventName {
+	case "ApplySecurityGroupsToLoadBalancer", "AttachLoadBalancerToSubnets", "ConfigureHealthCheck",
+		"CreateAppCookieStickinessPolicy", "CreateLBCookieStickinessPolicy", "CreateLoadBalancer",
+		"CreateLoadBalancerListeners", "CreateLoadBalancerPolicy", "DeleteLoadBalancer", "DeleteLoadBalancerListeners",
+		"DeleteLoadBalancerPolicy", "DeregisterInstancesFromLoadBalancer", "DetachLoadBalancerFromSubnets",
+		"DisableAvailabilityZonesForLoadBalancer", "EnableAvailabilityZonesForLoadBalancer", "ModifyLoadBalancerAttributes",
+		"RegisterInstancesWithLoadBalancer", "SetLoadBalancerListenerSSLCertificate",
+		"SetLoadBalancerPoliciesForBackendServer", "SetLoadBalancerPoliciesOfListener":
+		names = append(names, detail.Get("requestParameters.loadBalancerName").Str)
+	case "AddTags", "RemoveTags":
+		for _, name := range detail.Get("requestParameters.loadBalancerNames").Array() {
+			names = append(names, name.Str)
+		}
+	default:
+		zap.L().Info("elb: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	changes := make([]*resourceChange, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			zap.L().Error("elb: known event name, but failed to parse load balancer name", zap.String("eventName", metadata.eventName))
+			continue
+		}
+		changes = append(changes, &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delete:       metadata.eventName == "DeleteLoadBalancer",
+			EventName:    metadata.eventName,
+			ResourceID: arn.ARN{
+				Partition: "aws",
+				Service:   "elasticloadbalancing",
+				Region:    metadata.region,
+				AccountID: metadata.accountID,
+				Resource:  "loadbalancer/" + name,
+			}.String(),
+			ResourceType: schemas.ElbLoadBalancerSchema,
+		})
+	}
+	return changes
+}
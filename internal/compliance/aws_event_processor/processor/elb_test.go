@@ -0,0 +1,50 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestClassifyELBClassic(t *testing.T) {
+	detail := gjson.Parse(`{"apiVersion": "2012-06-01", "requestParameters": {"loadBalancerNames": ["example-classic"]}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "AddTags"}
+
+	changes := classifyELBV2(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/example-classic", changes[0].ResourceID)
+	assert.Equal(t, schemas.ElbLoadBalancerSchema, changes[0].ResourceType)
+}
+
+func TestClassifyELBV2NetworkLoadBalancer(t *testing.T) {
+	detail := gjson.Parse(`{"apiVersion": "2015-12-01", "requestParameters": {
+		"listenerArn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/net/example/1234567890abcdef/abcdef1234567890"}}`)
+	metadata := &CloudTrailMetadata{region: "us-west-2", accountID: "123456789012", eventName: "ModifyListener"}
+
+	changes := classifyELBV2(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/example/1234567890abcdef", changes[0].ResourceID)
+	assert.Equal(t, schemas.Elbv2NetworkLoadBalancerSchema, changes[0].ResourceType)
+}
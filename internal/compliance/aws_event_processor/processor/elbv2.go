@@ -28,7 +28,20 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+// elbv2Schema returns the resource type of a v2 load balancer, which is determined by its ARN:
+// arn:aws:elasticloadbalancing:region:account-id:loadbalancer/[app|net]/lb-name/lb-id
+func elbv2Schema(lbARN arn.ARN) string {
+	if strings.HasPrefix(lbARN.Resource, "loadbalancer/net/") {
+		return schemas.Elbv2NetworkLoadBalancerSchema
+	}
+	return schemas.Elbv2LoadBalancerSchema
+}
+
 func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	if detail.Get("apiVersion").Str == elbClassicAPIVersion {
+		return classifyELB(detail, metadata)
+	}
+
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_elasticloadbalancingv2.html
 	var parseErr error
 	lbARN := arn.ARN{
@@ -81,7 +94,7 @@ func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourc
 				Delete:       false,
 				EventName:    metadata.eventName,
 				ResourceID:   resourceARN.String(),
-				ResourceType: schemas.Elbv2LoadBalancerSchema,
+				ResourceType: elbv2Schema(resourceARN),
 			})
 		}
 		return changes
@@ -100,7 +113,7 @@ func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourc
 				Delete:       false,
 				EventName:    metadata.eventName,
 				ResourceID:   lbARN.String(),
-				ResourceType: schemas.Elbv2LoadBalancerSchema,
+				ResourceType: elbv2Schema(lbARN),
 			})
 		}
 		return changes
@@ -120,7 +133,7 @@ func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourc
 				Delete:       false,
 				EventName:    metadata.eventName,
 				ResourceID:   lbARN.String(),
-				ResourceType: schemas.Elbv2LoadBalancerSchema,
+				ResourceType: elbv2Schema(lbARN),
 			})
 		}
 		return changes
@@ -137,6 +150,6 @@ func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourc
 		Delete:       metadata.eventName == "DeleteLoadBalancer",
 		EventName:    metadata.eventName,
 		ResourceID:   lbARN.String(),
-		ResourceType: schemas.Elbv2LoadBalancerSchema,
+		ResourceType: elbv2Schema(lbARN),
 	}}
 }
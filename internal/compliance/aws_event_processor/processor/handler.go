@@ -252,13 +252,16 @@ func submitChanges(changes map[string]*resourceChange) error {
 			// ID = “abc-123”, region =“”:		Single resource scan
 			// ID = “abc-123”, region =“west”:	Undefined, treated as single resource scan
 			var resourceID *string
-			var region *string
+			var region, orgUnitPath *string
 			if change.ResourceID != "" {
 				resourceID = &change.ResourceID
 			}
 			if change.Region != "" {
 				region = &change.Region
 			}
+			if change.OrgUnitPath != "" {
+				orgUnitPath = &change.OrgUnitPath
+			}
 
 			if _, ok := requestsByDelay[change.Delay]; !ok {
 				requestsByDelay[change.Delay] = &poller.ScanMsg{}
@@ -270,6 +273,7 @@ func submitChanges(changes map[string]*resourceChange) error {
 			requestsByDelay[change.Delay].Entries = append(requestsByDelay[change.Delay].Entries, &poller.ScanEntry{
 				AWSAccountID:     &change.AwsAccountID,
 				IntegrationID:    &change.IntegrationID,
+				OrgUnitPath:      orgUnitPath,
 				Region:           region,
 				ResourceID:       resourceID,
 				ResourceType:     &change.ResourceType,
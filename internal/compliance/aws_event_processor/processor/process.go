@@ -34,6 +34,7 @@ type resourceChange struct {
 	EventName     string `json:"eventName"`     // CloudTrail event name (for logging only)
 	EventTime     string `json:"eventTime"`     // official CloudTrail RFC3339 timestamp
 	IntegrationID string `json:"integrationId"` // account integration ID
+	OrgUnitPath   string `json:"orgUnitPath"`   // organizational unit of the account, if onboarded through an organization
 	Region        string `json:"region"`        // Region (for resource type scans only)
 	ResourceID    string `json:"resourceId"`    // e.g. "arn:aws:s3:::my-bucket"
 	ResourceType  string `json:"resourceType"`  // e.g. "AWS.S3.Bucket"
@@ -46,12 +47,16 @@ type resourceChange struct {
 var (
 	classifiers = map[string]func(gjson.Result, *CloudTrailMetadata) []*resourceChange{
 		"acm.amazonaws.com":                  classifyACM,
+		"apigateway.amazonaws.com":           classifyAPIGateway,
 		"cloudformation.amazonaws.com":       classifyCloudFormation,
+		"cloudfront.amazonaws.com":           classifyCloudFront,
 		"cloudtrail.amazonaws.com":           classifyCloudTrail,
 		"config.amazonaws.com":               classifyConfig,
 		"dynamodb.amazonaws.com":             classifyDynamoDB,
 		"ec2.amazonaws.com":                  classifyEC2,
+		"ecr.amazonaws.com":                  classifyECR,
 		"ecs.amazonaws.com":                  classifyECS,
+		"eks.amazonaws.com":                  classifyEKS,
 		"elasticloadbalancing.amazonaws.com": classifyELBV2,
 		"guardduty.amazonaws.com":            classifyGuardDuty,
 		"iam.amazonaws.com":                  classifyIAM,
@@ -60,7 +65,12 @@ var (
 		"logs.amazonaws.com":                 classifyCloudWatchLogGroup,
 		"rds.amazonaws.com":                  classifyRDS,
 		"redshift.amazonaws.com":             classifyRedshift,
+		"route53.amazonaws.com":              classifyRoute53,
 		"s3.amazonaws.com":                   classifyS3,
+		"secretsmanager.amazonaws.com":       classifySecretsManager,
+		"sns.amazonaws.com":                  classifySNS,
+		"sqs.amazonaws.com":                  classifySQS,
+		"states.amazonaws.com":               classifySFN,
 		"waf.amazonaws.com":                  classifyWAF,
 		"waf-regional.amazonaws.com":         classifyWAFRegional,
 	}
@@ -92,7 +102,6 @@ var (
 		"PutDestination":       {},
 		"PutDestinationPolicy": {},
 		"PutLogEvents":         {},
-		"PutResourcePolicy":    {},
 		"StartQuery":           {},
 		"StopQuery":            {},
 		"TestMetricFilter":     {},
@@ -105,6 +114,12 @@ var (
 		"ForgotPassword":        {},
 		"UpdateUserAttributes":  {},
 
+		// cloudfront
+		"CreateCloudFrontOriginAccessIdentity": {}, // Origin access identities are tracked through the distributions using them
+		"CreateInvalidation":                   {},
+		"DeleteCloudFrontOriginAccessIdentity": {},
+		"UpdateCloudFrontOriginAccessIdentity": {},
+
 		// config
 		"BatchGetResourceConfig":          {},
 		"SelectResourceConfig":            {},
@@ -144,14 +159,31 @@ var (
 		"CreateInternetGateway":  {}, // Currently we don't have an EC2 InternetGateway resource,
 		"DeleteInternetGateway":  {}, // when we do we will need to handle these
 
+		// ecr
+		"BatchCheckLayerAvailability": {},
+		"BatchDeleteImage":            {},
+		"BatchGetImage":               {},
+		"CompleteLayerUpload":         {},
+		"InitiateLayerUpload":         {},
+		"PutImage":                    {},
+		"StartImageScan":              {},
+		"StartLifecyclePolicyPreview": {},
+		"UploadLayerPart":             {},
+
 		// ecs
 		"DeleteAccountSetting":     {},
-		"DeregisterTaskDefinition": {},
 		"PutAccountSetting":        {},
 		"PutAccountSettingDefault": {},
-		"RegisterTaskDefinition":   {},
 		"UpdateContainerAgent":     {},
 
+		// eks
+		"CreateFargateProfile":   {}, // Node groups and fargate profiles are not tracked as resources
+		"CreateNodegroup":        {},
+		"DeleteFargateProfile":   {},
+		"DeleteNodegroup":        {},
+		"UpdateNodegroupConfig":  {},
+		"UpdateNodegroupVersion": {},
+
 		// elbv2
 		"DeleteTargetGroup":           {},
 		"CreateTargetGroup":           {},
@@ -239,6 +271,11 @@ var (
 		"RevokeClusterSecurityGroupIngress": {},
 		"CreateClusterParameterGroup":       {},
 
+		// route53
+		"CreateHealthCheck": {},
+		"DeleteHealthCheck": {},
+		"UpdateHealthCheck": {},
+
 		// s3
 		"UploadPart":              {},
 		"CreateMultipartUpload":   {},
@@ -246,6 +283,33 @@ var (
 		"HeadBucket":              {},
 		"PutObject":               {},
 
+		// secretsmanager
+		"ValidateResourcePolicy": {},
+
+		// sfn
+		"CreateActivity":     {},
+		"DeleteActivity":     {},
+		"SendTaskFailure":    {},
+		"SendTaskHeartbeat":  {},
+		"SendTaskSuccess":    {},
+		"StartExecution":     {},
+		"StartSyncExecution": {},
+		"StopExecution":      {},
+
+		// sns
+		"CheckIfPhoneNumberIsOptedOut":     {},
+		"CreatePlatformApplication":        {},
+		"CreatePlatformEndpoint":           {},
+		"DeletePlatformApplication":        {},
+		"OptInPhoneNumber":                 {},
+		"Publish":                          {},
+		"SetEndpointAttributes":            {},
+		"SetPlatformApplicationAttributes": {},
+		"SetSMSAttributes":                 {},
+
+		// sqs
+		"PurgeQueue": {},
+
 		// waf, waf-regional
 		// TODO get suffixes
 		"DeletePermissionPolicy": {},
@@ -388,6 +452,9 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 	for _, change := range newChanges {
 		change.EventTime = eventTime
 		change.IntegrationID = *integration.IntegrationID
+		if integration.OrgUnitPath != nil {
+			change.OrgUnitPath = *integration.OrgUnitPath
+		}
 		zap.L().Info("resource scan required", zap.Any("changeDetail", change))
 		// Prevents the following from being de-duped mistakenly:
 		//
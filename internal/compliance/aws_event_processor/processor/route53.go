@@ -0,0 +1,79 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyRoute53(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonroute53.html
+	var hostedZoneID string
+	switch metadata.eventName {
+	case "CreateHostedZone":
+		hostedZoneID = detail.Get("responseElements.hostedZone.id").Str
+	case "DeleteHostedZone", "UpdateHostedZoneComment":
+		hostedZoneID = detail.Get("requestParameters.id").Str
+	case "AssociateVPCWithHostedZone", "ChangeResourceRecordSets", "CreateQueryLoggingConfig", "DisassociateVPCFromHostedZone":
+		hostedZoneID = detail.Get("requestParameters.hostedZoneId").Str
+	case "ChangeTagsForResource":
+		// Health checks can be tagged as well, but we only track hosted zones
+		if detail.Get("requestParameters.resourceType").Str != "hostedzone" {
+			return nil
+		}
+		hostedZoneID = detail.Get("requestParameters.resourceId").Str
+	case "DeleteQueryLoggingConfig":
+		// Only the ID of the query logging config is known, so we have to scan all hosted zones
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			EventName:    metadata.eventName,
+			Region:       schemas.GlobalRegion,
+			ResourceType: schemas.Route53HostedZoneSchema,
+		}}
+	default:
+		zap.L().Info("route53: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	// Hosted zone IDs are sometimes prefixed with "/hostedzone/"
+	hostedZoneID = strings.TrimPrefix(hostedZoneID, "/hostedzone/")
+	if hostedZoneID == "" {
+		zap.L().Error("route53: known event name, but failed to parse hosted zone ID", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	// arn:aws:route53:::hostedzone/id
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteHostedZone",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "route53",
+			Resource:  "hostedzone/" + hostedZoneID,
+		}.String(),
+		ResourceType: schemas.Route53HostedZoneSchema,
+	}}
+}
@@ -0,0 +1,63 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifySFN(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awsstepfunctions.html
+	var stateMachineARN string
+	switch metadata.eventName {
+	case "CreateStateMachine":
+		stateMachineARN = detail.Get("responseElements.stateMachineArn").Str
+	case "DeleteStateMachine", "UpdateStateMachine":
+		stateMachineARN = detail.Get("requestParameters.stateMachineArn").Str
+	case "TagResource", "UntagResource":
+		// Activities can be tagged as well, but we do not track them
+		stateMachineARN = detail.Get("requestParameters.resourceArn").Str
+		parsed, err := arn.Parse(stateMachineARN)
+		if err != nil || !strings.HasPrefix(parsed.Resource, "stateMachine:") {
+			return nil
+		}
+	default:
+		zap.L().Info("sfn: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if stateMachineARN == "" {
+		zap.L().Error("sfn: known event name, but still failed to parse stateMachineARN", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteStateMachine",
+		EventName:    metadata.eventName,
+		ResourceID:   stateMachineARN,
+		ResourceType: schemas.SfnStateMachineSchema,
+	}}
+}
@@ -83,6 +83,9 @@ func classifyWAFRegional(detail gjson.Result, metadata *CloudTrailMetadata) []*r
 				ResourceID:   resourceARN.String(),
 				ResourceType: schemas.Elbv2LoadBalancerSchema,
 			})
+		} else {
+			// The Web ACL association is part of the API Gateway stage configuration
+			changes = append(changes, apiGatewayChanges(resourceARN, metadata)...)
 		}
 		changes = append(changes, &resourceChange{
 			AwsAccountID: metadata.accountID,
@@ -116,6 +119,9 @@ func classifyWAFRegional(detail gjson.Result, metadata *CloudTrailMetadata) []*r
 				ResourceID:   resourceARN.String(),
 				ResourceType: schemas.Elbv2LoadBalancerSchema,
 			})
+		} else {
+			// The Web ACL association is part of the API Gateway stage configuration
+			changes = append(changes, apiGatewayChanges(resourceARN, metadata)...)
 		}
 		changes = append(changes, &resourceChange{
 			AwsAccountID: metadata.accountID,
@@ -20,7 +20,8 @@ package handlers
 
 // Default values for unspecified parameters - values should match those in api.yml
 const (
-	defaultTopFailing = 10
-	defaultPage       = 1
-	defaultPageSize   = 25
+	defaultTopFailing       = 10
+	defaultPage             = 1
+	defaultPageSize         = 25
+	defaultFailingResources = 100
 )
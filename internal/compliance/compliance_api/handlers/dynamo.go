@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	"github.com/panther-labs/panther/api/gateway/compliance/models"
 )
 
@@ -128,13 +129,13 @@ func scanGroupByID(
 			policy, ok := policies[item.PolicyID]
 			if !ok {
 				policy = &models.PolicySummary{
-					Count:    NewStatusCount(),
+					Count:    compliance.NewStatusCount(),
 					ID:       item.PolicyID,
 					Severity: item.PolicySeverity,
 				}
 				policies[item.PolicyID] = policy
 			}
-			updateStatusCount(policy.Count, item.Status)
+			compliance.UpdateStatusCount(policy.Count, item.Status)
 		}
 
 		// Update resources
@@ -142,13 +143,13 @@ func scanGroupByID(
 			resource, ok := resources[item.ResourceID]
 			if !ok {
 				resource = &models.ResourceSummary{
-					Count: NewStatusCountBySeverity(),
+					Count: compliance.NewStatusCountBySeverity(),
 					ID:    item.ResourceID,
 					Type:  item.ResourceType,
 				}
 				resources[item.ResourceID] = resource
 			}
-			updateStatusCountBySeverity(resource.Count, item.PolicySeverity, item.Status)
+			compliance.UpdateStatusCountBySeverity(resource.Count, item.PolicySeverity, item.Status)
 		}
 
 		return nil
@@ -20,7 +20,9 @@ package handlers
 
 type envConfig struct {
 	ComplianceTable string `required:"true" split_words:"true"`
+	HistoryTable    string `required:"true" split_words:"true"`
 	IndexName       string `required:"true" split_words:"true"`
+	ReportsBucket   string `required:"true" split_words:"true"`
 }
 
 // Env is the parsed environment variables
@@ -0,0 +1,154 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+var s3Client s3iface.S3API = s3.New(awsSession)
+
+// Header row for CSV exports - each subsequent row describes a single control
+var csvHeader = []string{
+	"framework", "version", "section", "control", "title", "status",
+	"pass", "fail", "error", "policies", "failingResources",
+}
+
+// ExportFrameworkReport builds a full framework report and uploads it to the reports bucket.
+func ExportFrameworkReport(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseExportFrameworkReport(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	fw, err := parseFramework(string(input.Framework))
+	if err != nil {
+		return badRequest(err)
+	}
+
+	// Exported reports include every failing resource
+	report, err := frameworkReport(fw, input.Section, -1)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	var body []byte
+	var contentType string
+	if input.Format == models.ReportFormatCSV {
+		body, err = reportToCSV(report)
+		contentType = "text/csv"
+	} else {
+		body, err = jsoniter.Marshal(report)
+		contentType = "application/json"
+	}
+	if err != nil {
+		zap.L().Error("failed to encode framework report",
+			zap.String("format", string(input.Format)), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	key := reportKey(report, input.Format)
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:        bytes.NewReader(body),
+		Bucket:      &Env.ReportsBucket,
+		ContentType: &contentType,
+		Key:         &key,
+	})
+	if err != nil {
+		zap.L().Error("s3Client.PutObject failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(
+		&models.ExportedReport{Bucket: &Env.ReportsBucket, Key: &key}, http.StatusOK)
+}
+
+func parseExportFrameworkReport(request *events.APIGatewayProxyRequest) (*models.ExportFrameworkReport, error) {
+	var result models.ExportFrameworkReport
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, result.Validate(nil)
+}
+
+// S3 key for an exported report, e.g. "CIS/section-1/2020-04-01T00:00:00Z.csv"
+func reportKey(report *models.FrameworkReport, format models.ReportFormat) string {
+	section := "all"
+	if report.Section != "" {
+		section = "section-" + report.Section
+	}
+	name := time.Time(*report.GeneratedAt).Format(time.RFC3339) + "." + strings.ToLower(string(format))
+	return path.Join(string(report.Framework), section, name)
+}
+
+func reportToCSV(report *models.FrameworkReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	for _, control := range report.Controls {
+		policies := make([]string, len(control.Policies))
+		for i, policyID := range control.Policies {
+			policies[i] = string(policyID)
+		}
+		resources := make([]string, len(control.FailingResources))
+		for i, resource := range control.FailingResources {
+			resources[i] = string(resource.ID)
+		}
+
+		row := []string{
+			string(report.Framework),
+			aws.StringValue(report.Version),
+			aws.StringValue(control.Section),
+			aws.StringValue(control.ID),
+			control.Title,
+			string(control.Status),
+			strconv.FormatInt(*control.Count.Pass, 10),
+			strconv.FormatInt(*control.Count.Fail, 10),
+			strconv.FormatInt(*control.Count.Error, 10),
+			strings.Join(policies, ";"),
+			strings.Join(resources, ";"),
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
@@ -0,0 +1,192 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// A compliance framework (e.g. CIS AWS Foundations) and its controls.
+//
+// Policies are mapped to controls with their "reports" field, keyed by the framework ID:
+//
+//	Reports: {"CIS": ["1.3", "1.4"], "PCI": ["8.1.4"]}
+type framework struct {
+	ID       models.Framework
+	Name     string
+	Version  string
+	Controls []control
+}
+
+type control struct {
+	ID    string
+	Title string
+}
+
+// Supported compliance frameworks, keyed by ID
+var frameworks = map[models.Framework]*framework{
+	cisAWS.ID: cisAWS,
+	pciDSS.ID: pciDSS,
+	soc2.ID:   soc2,
+}
+
+var cisAWS = &framework{
+	ID:      "CIS",
+	Name:    "CIS Amazon Web Services Foundations Benchmark",
+	Version: "1.2.0",
+	Controls: []control{
+		// 1 Identity and Access Management
+		{ID: "1.1", Title: `Avoid the use of the "root" account`},
+		{ID: "1.2", Title: "Ensure multi-factor authentication (MFA) is enabled for all IAM users that have a console password"},
+		{ID: "1.3", Title: "Ensure credentials unused for 90 days or greater are disabled"},
+		{ID: "1.4", Title: "Ensure access keys are rotated every 90 days or less"},
+		{ID: "1.5", Title: "Ensure IAM password policy requires at least one uppercase letter"},
+		{ID: "1.6", Title: "Ensure IAM password policy requires at least one lowercase letter"},
+		{ID: "1.7", Title: "Ensure IAM password policy requires at least one symbol"},
+		{ID: "1.8", Title: "Ensure IAM password policy requires at least one number"},
+		{ID: "1.9", Title: "Ensure IAM password policy requires minimum length of 14 or greater"},
+		{ID: "1.10", Title: "Ensure IAM password policy prevents password reuse"},
+		{ID: "1.11", Title: "Ensure IAM password policy expires passwords within 90 days or less"},
+		{ID: "1.12", Title: `Ensure no "root" account access key exists`},
+		{ID: "1.13", Title: `Ensure MFA is enabled for the "root" account`},
+		{ID: "1.14", Title: `Ensure hardware MFA is enabled for the "root" account`},
+		{ID: "1.15", Title: "Ensure security questions are registered in the AWS account"},
+		{ID: "1.16", Title: "Ensure IAM policies are attached only to groups or roles"},
+		{ID: "1.17", Title: "Maintain current contact details"},
+		{ID: "1.18", Title: "Ensure security contact information is registered"},
+		{ID: "1.19", Title: "Ensure IAM instance roles are used for AWS resource access from instances"},
+		{ID: "1.20", Title: "Ensure a support role has been created to manage incidents with AWS Support"},
+		{ID: "1.21", Title: "Do not setup access keys during initial user setup for all IAM users that have a console password"},
+		{ID: "1.22", Title: `Ensure IAM policies that allow full "*:*" administrative privileges are not created`},
+
+		// 2 Logging
+		{ID: "2.1", Title: "Ensure CloudTrail is enabled in all regions"},
+		{ID: "2.2", Title: "Ensure CloudTrail log file validation is enabled"},
+		{ID: "2.3", Title: "Ensure the S3 bucket used to store CloudTrail logs is not publicly accessible"},
+		{ID: "2.4", Title: "Ensure CloudTrail trails are integrated with CloudWatch Logs"},
+		{ID: "2.5", Title: "Ensure AWS Config is enabled in all regions"},
+		{ID: "2.6", Title: "Ensure S3 bucket access logging is enabled on the CloudTrail S3 bucket"},
+		{ID: "2.7", Title: "Ensure CloudTrail logs are encrypted at rest using KMS CMKs"},
+		{ID: "2.8", Title: "Ensure rotation for customer created CMKs is enabled"},
+		{ID: "2.9", Title: "Ensure VPC flow logging is enabled in all VPCs"},
+
+		// 3 Monitoring
+		{ID: "3.1", Title: "Ensure a log metric filter and alarm exist for unauthorized API calls"},
+		{ID: "3.2", Title: "Ensure a log metric filter and alarm exist for Management Console sign-in without MFA"},
+		{ID: "3.3", Title: `Ensure a log metric filter and alarm exist for usage of "root" account`},
+		{ID: "3.4", Title: "Ensure a log metric filter and alarm exist for IAM policy changes"},
+		{ID: "3.5", Title: "Ensure a log metric filter and alarm exist for CloudTrail configuration changes"},
+		{ID: "3.6", Title: "Ensure a log metric filter and alarm exist for AWS Management Console authentication failures"},
+		{ID: "3.7", Title: "Ensure a log metric filter and alarm exist for disabling or scheduled deletion of customer created CMKs"},
+		{ID: "3.8", Title: "Ensure a log metric filter and alarm exist for S3 bucket policy changes"},
+		{ID: "3.9", Title: "Ensure a log metric filter and alarm exist for AWS Config configuration changes"},
+		{ID: "3.10", Title: "Ensure a log metric filter and alarm exist for security group changes"},
+		{ID: "3.11", Title: "Ensure a log metric filter and alarm exist for changes to Network Access Control Lists (NACL)"},
+		{ID: "3.12", Title: "Ensure a log metric filter and alarm exist for changes to network gateways"},
+		{ID: "3.13", Title: "Ensure a log metric filter and alarm exist for route table changes"},
+		{ID: "3.14", Title: "Ensure a log metric filter and alarm exist for VPC changes"},
+
+		// 4 Networking
+		{ID: "4.1", Title: "Ensure no security groups allow ingress from 0.0.0.0/0 to port 22"},
+		{ID: "4.2", Title: "Ensure no security groups allow ingress from 0.0.0.0/0 to port 3389"},
+		{ID: "4.3", Title: "Ensure the default security group of every VPC restricts all traffic"},
+		{ID: "4.4", Title: `Ensure routing tables for VPC peering are "least access"`},
+	},
+}
+
+var pciDSS = &framework{
+	ID:      "PCI",
+	Name:    "PCI DSS",
+	Version: "3.2.1",
+	Controls: []control{
+		{ID: "1.2.1", Title: "Restrict inbound and outbound traffic to that which is necessary for the cardholder data environment"},
+		{ID: "1.3.1", Title: "Implement a DMZ to limit inbound traffic to system components that provide authorized public services"},
+		{ID: "1.3.2", Title: "Limit inbound Internet traffic to IP addresses within the DMZ"},
+		{ID: "1.3.4", Title: "Do not allow unauthorized outbound traffic from the cardholder data environment to the Internet"},
+		{ID: "2.1", Title: "Always change vendor-supplied defaults and remove or disable unnecessary default accounts"},
+		{ID: "2.2", Title: "Develop configuration standards for all system components"},
+		{ID: "3.4", Title: "Render PAN unreadable anywhere it is stored"},
+		{ID: "3.5", Title: "Document and implement procedures to protect keys used to secure stored cardholder data"},
+		{ID: "3.6.4", Title: "Cryptographic key changes for keys that have reached the end of their cryptoperiod"},
+		{ID: "4.1", Title: "Use strong cryptography and security protocols to safeguard sensitive cardholder data during transmission"},
+		{ID: "6.2", Title: "Ensure that all system components and software are protected from known vulnerabilities"},
+		{ID: "7.1", Title: "Limit access to system components and cardholder data to only those individuals whose job requires such access"},
+		{ID: "7.2", Title: "Establish an access control system that restricts access based on a user's need to know"},
+		{ID: "8.1.4", Title: "Remove/disable inactive user accounts within 90 days"},
+		{ID: "8.1.5", Title: "Manage IDs used by third parties to access, support, or maintain system components"},
+		{ID: "8.2.3", Title: "Passwords must require a minimum length of at least seven characters with both numeric and alphabetic characters"},
+		{ID: "8.2.4", Title: "Change user passwords at least once every 90 days"},
+		{ID: "8.2.5", Title: "Do not allow a new password that is the same as any of the last four passwords"},
+		{ID: "8.3.1", Title: "Incorporate multi-factor authentication for all non-console administrative access into the CDE"},
+		{ID: "10.1", Title: "Implement audit trails to link all access to system components to each individual user"},
+		{ID: "10.2", Title: "Implement automated audit trails for all system components"},
+		{ID: "10.5", Title: "Secure audit trails so they cannot be altered"},
+		{ID: "10.5.2", Title: "Protect audit trail files from unauthorized modifications"},
+		{ID: "10.7", Title: "Retain audit trail history for at least one year"},
+		{ID: "11.4", Title: "Use intrusion-detection and/or intrusion-prevention techniques to detect and/or prevent intrusions"},
+		{ID: "11.5", Title: "Deploy a change-detection mechanism to alert personnel to unauthorized modification of critical files"},
+	},
+}
+
+var soc2 = &framework{
+	ID:      "SOC2",
+	Name:    "SOC 2 Trust Services Criteria",
+	Version: "2017",
+	Controls: []control{
+		{ID: "CC6.1", Title: "Logical access security software, infrastructure, and architectures protect information assets"},
+		{ID: "CC6.2", Title: "New internal and external users are registered and authorized before system credentials are issued"},
+		{ID: "CC6.3", Title: "Access to data, software, and other protected information assets is authorized based on roles"},
+		{ID: "CC6.6", Title: "Logical access security measures protect against threats from sources outside system boundaries"},
+		{ID: "CC6.7", Title: "Transmission, movement, and removal of information is restricted to authorized users and processes"},
+		{ID: "CC6.8", Title: "Controls prevent or detect and act upon the introduction of unauthorized or malicious software"},
+		{ID: "CC7.1", Title: "Detection and monitoring procedures identify configuration changes and new vulnerabilities"},
+		{ID: "CC7.2", Title: "System components are monitored for anomalies indicative of malicious acts, disasters, and errors"},
+		{ID: "CC7.3", Title: "Security events are evaluated to determine whether they resulted in a failure to meet objectives"},
+		{ID: "CC8.1", Title: "Changes to infrastructure, data, software, and procedures are authorized, tested, and approved"},
+		{ID: "A1.2", Title: "Environmental protections, data backup processes, and recovery infrastructure are maintained"},
+		{ID: "C1.1", Title: "Confidential information is identified and maintained to meet confidentiality objectives"},
+	},
+}
+
+// The section of a control is the first component of its ID ("1.3" => "1", "CC6.1" => "CC6")
+func controlSection(controlID string) string {
+	return strings.SplitN(controlID, ".", 2)[0]
+}
+
+// Returns true if the left control ID sorts before the right one ("1.2" < "1.10" < "2.1").
+func controlLess(left, right string) bool {
+	leftParts, rightParts := strings.Split(left, "."), strings.Split(right, ".")
+	for i := 0; i < len(leftParts) && i < len(rightParts); i++ {
+		if leftParts[i] == rightParts[i] {
+			continue
+		}
+
+		leftNum, leftErr := strconv.Atoi(leftParts[i])
+		rightNum, rightErr := strconv.Atoi(rightParts[i])
+		if leftErr == nil && rightErr == nil {
+			return leftNum < rightNum
+		}
+		return leftParts[i] < rightParts[i]
+	}
+
+	return len(leftParts) < len(rightParts)
+}
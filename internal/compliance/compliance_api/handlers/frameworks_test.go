@@ -0,0 +1,113 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+func TestControlSection(t *testing.T) {
+	assert.Equal(t, "1", controlSection("1.3"))
+	assert.Equal(t, "10", controlSection("10.5.2"))
+	assert.Equal(t, "CC6", controlSection("CC6.1"))
+	assert.Equal(t, "custom", controlSection("custom"))
+}
+
+func TestControlLess(t *testing.T) {
+	assert.True(t, controlLess("1.2", "1.10"))
+	assert.True(t, controlLess("1.10", "2.1"))
+	assert.True(t, controlLess("8.1", "8.1.4"))
+	assert.True(t, controlLess("CC6.1", "CC7.1"))
+	assert.False(t, controlLess("1.10", "1.2"))
+	assert.False(t, controlLess("1.2", "1.2"))
+}
+
+func TestBuildFrameworkReport(t *testing.T) {
+	controls := make(map[string]*controlState)
+	entries := []*models.ComplianceStatus{
+		{PolicyID: "AWS.IAM.MFA", ResourceID: "user-a", ResourceType: "AWS.IAM.User", Status: models.StatusPASS},
+		{PolicyID: "AWS.IAM.MFA", ResourceID: "user-b", ResourceType: "AWS.IAM.User", Status: models.StatusFAIL},
+		{PolicyID: "AWS.IAM.Console", ResourceID: "user-b", ResourceType: "AWS.IAM.User", Status: models.StatusERROR},
+	}
+	for _, entry := range entries {
+		state, ok := controls["1.2"]
+		if !ok {
+			state = &controlState{
+				policies:  make(map[models.PolicyID]struct{}),
+				resources: make(map[models.ResourceID]*models.FailingResource),
+			}
+			controls["1.2"] = state
+		}
+		state.add(entry)
+	}
+	controls["1.99"] = &controlState{
+		policies:  map[models.PolicyID]struct{}{"Custom": {}},
+		resources: map[models.ResourceID]*models.FailingResource{"user-c": {ID: "user-c", Status: models.StatusPASS}},
+	}
+
+	report := buildFrameworkReport(cisAWS, "1", controls, 10)
+	require.Len(t, report.Controls, 23) // 22 defined controls + 1 unknown
+	assert.Equal(t, "CIS Amazon Web Services Foundations Benchmark", *report.Name)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(1), Fail: aws.Int64(0), Pass: aws.Int64(1)}, report.Count)
+
+	notEvaluated := report.Controls[0]
+	assert.Equal(t, "1.1", *notEvaluated.ID)
+	assert.Equal(t, models.ControlStatusNOTEVALUATED, notEvaluated.Status)
+	assert.Empty(t, notEvaluated.Policies)
+
+	mfa := report.Controls[1]
+	assert.Equal(t, "1.2", *mfa.ID)
+	assert.Equal(t, models.ControlStatusERROR, mfa.Status)
+	assert.Equal(t, []models.PolicyID{"AWS.IAM.Console", "AWS.IAM.MFA"}, mfa.Policies)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(1), Fail: aws.Int64(0), Pass: aws.Int64(1)}, mfa.Count)
+	assert.Equal(t, []*models.FailingResource{{
+		ID:       "user-b",
+		Policies: []models.PolicyID{"AWS.IAM.Console", "AWS.IAM.MFA"},
+		Status:   models.StatusERROR,
+		Type:     "AWS.IAM.User",
+	}}, mfa.FailingResources)
+
+	unknown := report.Controls[22]
+	assert.Equal(t, "1.99", *unknown.ID)
+	assert.Equal(t, models.ControlStatusPASS, unknown.Status)
+	assert.Empty(t, unknown.Title)
+
+	// Failing resources are truncated to the limit
+	report = buildFrameworkReport(cisAWS, "1", controls, 0)
+	assert.Empty(t, report.Controls[1].FailingResources)
+	assert.Equal(t, int64(1), *report.Controls[1].Count.Error)
+}
+
+func TestReportToCSV(t *testing.T) {
+	report := buildFrameworkReport(soc2, "CC8", nil, -1)
+	result, err := reportToCSV(report)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"framework,version,section,control,title,status,pass,fail,error,policies,failingResources\n"+
+			"SOC2,2017,CC8,CC8.1,"+
+			"\"Changes to infrastructure, data, software, and procedures are authorized, tested, and approved\","+
+			"NOT_EVALUATED,0,0,0,,\n",
+		string(result))
+}
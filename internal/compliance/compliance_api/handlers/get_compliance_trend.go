@@ -0,0 +1,158 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	dayLayout = "2006-01-02"
+
+	// Upper bound on the number of daily snapshots returned in a single response
+	maxTrendDays = 366
+)
+
+type getComplianceTrendParams struct {
+	Days                []string
+	IncludeIntegrations bool
+	IncludePolicies     bool
+}
+
+// GetComplianceTrend returns the daily compliance posture snapshots recorded over a date range.
+func GetComplianceTrend(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseGetComplianceTrend(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	input, err := buildGetComplianceTrendInput(params)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	response, err := dynamodbbatch.BatchGetItem(dynamoClient, input)
+	if err != nil {
+		zap.L().Error("dynamodbbatch.BatchGetItem failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := models.ComplianceTrend{Days: make([]*models.DailyPosture, 0, len(params.Days))}
+	if err := dynamodbattribute.UnmarshalListOfMaps(response.Responses[Env.HistoryTable], &result.Days); err != nil {
+		zap.L().Error("failed to unmarshal daily posture", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	// BatchGetItem does not preserve the order of the requested keys
+	sort.Slice(result.Days, func(i, j int) bool {
+		return aws.StringValue(result.Days[i].Day) < aws.StringValue(result.Days[j].Day)
+	})
+	return gatewayapi.MarshalResponse(&result, http.StatusOK)
+}
+
+func parseGetComplianceTrend(request *events.APIGatewayProxyRequest) (*getComplianceTrendParams, error) {
+	start, err := time.Parse(dayLayout, request.QueryStringParameters["startDate"])
+	if err != nil {
+		return nil, errors.New("invalid startDate: " + err.Error())
+	}
+	end, err := time.Parse(dayLayout, request.QueryStringParameters["endDate"])
+	if err != nil {
+		return nil, errors.New("invalid endDate: " + err.Error())
+	}
+
+	if end.Before(start) {
+		return nil, errors.New("endDate must not be before startDate")
+	}
+	// Both days are included in the range
+	if int(end.Sub(start)/(24*time.Hour))+1 > maxTrendDays {
+		return nil, errors.New("date range can not exceed " + strconv.Itoa(maxTrendDays) + " days")
+	}
+
+	var result getComplianceTrendParams
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		result.Days = append(result.Days, day.Format(dayLayout))
+	}
+
+	if raw := request.QueryStringParameters["includeIntegrations"]; raw != "" {
+		if result.IncludeIntegrations, err = strconv.ParseBool(raw); err != nil {
+			return nil, errors.New("invalid includeIntegrations: " + err.Error())
+		}
+	}
+	if raw := request.QueryStringParameters["includePolicies"]; raw != "" {
+		if result.IncludePolicies, err = strconv.ParseBool(raw); err != nil {
+			return nil, errors.New("invalid includePolicies: " + err.Error())
+		}
+	}
+
+	return &result, nil
+}
+
+func buildGetComplianceTrendInput(params *getComplianceTrendParams) (*dynamodb.BatchGetItemInput, error) {
+	keys := make([]map[string]*dynamodb.AttributeValue, len(params.Days))
+	for i, day := range params.Days {
+		keys[i] = map[string]*dynamodb.AttributeValue{"day": {S: aws.String(day)}}
+	}
+
+	// The per-integration and per-policy breakdowns grow with the number of accounts and policies,
+	// so they are only read when requested to keep a year of snapshots within the response size limit
+	projection := expression.NamesList(
+		expression.Name("byResourceType"),
+		expression.Name("bySeverity"),
+		expression.Name("day"),
+		expression.Name("recordedAt"),
+		expression.Name("resources"),
+	)
+	if params.IncludeIntegrations {
+		projection = projection.AddNames(expression.Name("byIntegration"))
+	}
+	if params.IncludePolicies {
+		projection = projection.AddNames(expression.Name("byPolicy"))
+	}
+
+	expr, err := expression.NewBuilder().WithProjection(projection).Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]*dynamodb.KeysAndAttributes{
+			Env.HistoryTable: {
+				ExpressionAttributeNames: expr.Names(),
+				Keys:                     keys,
+				ProjectionExpression:     expr.Projection(),
+			},
+		},
+	}, nil
+}
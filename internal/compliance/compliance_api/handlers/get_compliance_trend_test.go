@@ -0,0 +1,186 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func trendRequest(query map[string]string) *events.APIGatewayProxyRequest {
+	return &events.APIGatewayProxyRequest{QueryStringParameters: query}
+}
+
+func TestParseGetComplianceTrend(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    map[string]string
+		expected *getComplianceTrendParams
+		err      string
+	}{
+		{
+			name:     "single day",
+			query:    map[string]string{"startDate": "2020-04-01", "endDate": "2020-04-01"},
+			expected: &getComplianceTrendParams{Days: []string{"2020-04-01"}},
+		},
+		{
+			name: "breakdowns",
+			query: map[string]string{
+				"startDate":           "2020-02-28",
+				"endDate":             "2020-03-01",
+				"includeIntegrations": "true",
+				"includePolicies":     "true",
+			},
+			expected: &getComplianceTrendParams{
+				Days:                []string{"2020-02-28", "2020-02-29", "2020-03-01"},
+				IncludeIntegrations: true,
+				IncludePolicies:     true,
+			},
+		},
+		{
+			name:  "invalid startDate",
+			query: map[string]string{"startDate": "2020-4-1", "endDate": "2020-04-01"},
+			err:   "invalid startDate",
+		},
+		{
+			name:  "missing endDate",
+			query: map[string]string{"startDate": "2020-04-01"},
+			err:   "invalid endDate",
+		},
+		{
+			name:  "reversed range",
+			query: map[string]string{"startDate": "2020-04-02", "endDate": "2020-04-01"},
+			err:   "endDate must not be before startDate",
+		},
+		{
+			name:  "too many days",
+			query: map[string]string{"startDate": "2020-01-01", "endDate": "2021-01-01"},
+			err:   "date range can not exceed 366 days",
+		},
+		{
+			name:  "invalid includeIntegrations",
+			query: map[string]string{"startDate": "2020-04-01", "endDate": "2020-04-01", "includeIntegrations": "yes"},
+			err:   "invalid includeIntegrations",
+		},
+		{
+			name:  "invalid includePolicies",
+			query: map[string]string{"startDate": "2020-04-01", "endDate": "2020-04-01", "includePolicies": "yes"},
+			err:   "invalid includePolicies",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := parseGetComplianceTrend(trendRequest(test.query))
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+
+	// 2020 is a leap year, so the whole year is the longest range allowed
+	result, err := parseGetComplianceTrend(trendRequest(map[string]string{"startDate": "2020-01-01", "endDate": "2020-12-31"}))
+	require.NoError(t, err)
+	assert.Len(t, result.Days, maxTrendDays)
+}
+
+func TestGetComplianceTrendInvalidRange(t *testing.T) {
+	result := GetComplianceTrend(trendRequest(map[string]string{"startDate": "2020-04-02", "endDate": "2020-04-01"}))
+	assert.Equal(t, http.StatusBadRequest, result.StatusCode)
+}
+
+func TestGetComplianceTrend(t *testing.T) {
+	Env.HistoryTable = "history"
+
+	tests := []struct {
+		name       string
+		query      map[string]string
+		attributes []string
+	}{
+		{
+			name:       "default",
+			query:      map[string]string{},
+			attributes: []string{"byResourceType", "bySeverity", "day", "recordedAt", "resources"},
+		},
+		{
+			name:       "integrations",
+			query:      map[string]string{"includeIntegrations": "true"},
+			attributes: []string{"byResourceType", "bySeverity", "day", "recordedAt", "resources", "byIntegration"},
+		},
+		{
+			name:       "policies",
+			query:      map[string]string{"includePolicies": "true"},
+			attributes: []string{"byResourceType", "bySeverity", "day", "recordedAt", "resources", "byPolicy"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := &testutils.DynamoDBMock{}
+			dynamoClient = mockClient
+
+			// BatchGetItem returns the days in any order
+			mockClient.On("BatchGetItemPages", mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]*dynamodb.AttributeValue{
+					"history": {
+						{"day": {S: aws.String("2020-04-03")}},
+						{"day": {S: aws.String("2020-04-01")}},
+						{"day": {S: aws.String("2020-04-02")}},
+					},
+				},
+			}, nil)
+
+			test.query["startDate"] = "2020-04-01"
+			test.query["endDate"] = "2020-04-03"
+			result := GetComplianceTrend(trendRequest(test.query))
+			require.Equal(t, http.StatusOK, result.StatusCode)
+
+			var trend models.ComplianceTrend
+			require.NoError(t, jsoniter.UnmarshalFromString(result.Body, &trend))
+			require.Len(t, trend.Days, 3)
+			for i, day := range []string{"2020-04-01", "2020-04-02", "2020-04-03"} {
+				assert.Equal(t, day, *trend.Days[i].Day)
+			}
+
+			input := mockClient.Calls[0].Arguments.Get(0).(*dynamodb.BatchGetItemInput)
+			var attributes []string
+			for _, name := range input.RequestItems["history"].ExpressionAttributeNames {
+				attributes = append(attributes, *name)
+			}
+			assert.ElementsMatch(t, test.attributes, attributes)
+			assert.Len(t, input.RequestItems["history"].Keys, 3)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/go-openapi/strfmt"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance"
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+type getFrameworkReportParams struct {
+	Framework             *framework
+	Section               string
+	LimitFailingResources int
+}
+
+// Pass/fail state of every resource evaluated by the policies mapped to a single control
+type controlState struct {
+	policies  map[models.PolicyID]struct{}
+	resources map[models.ResourceID]*models.FailingResource
+}
+
+// GetFrameworkReport returns per-control pass/fail counts and failing resources for a compliance framework.
+func GetFrameworkReport(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseGetFrameworkReport(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	report, err := frameworkReport(params.Framework, params.Section, params.LimitFailingResources)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(report, http.StatusOK)
+}
+
+func parseGetFrameworkReport(request *events.APIGatewayProxyRequest) (*getFrameworkReportParams, error) {
+	fw, err := parseFramework(request.QueryStringParameters["framework"])
+	if err != nil {
+		return nil, err
+	}
+
+	result := getFrameworkReportParams{
+		Framework:             fw,
+		Section:               request.QueryStringParameters["section"],
+		LimitFailingResources: defaultFailingResources,
+	}
+
+	rawLimit := request.QueryStringParameters["limitFailingResources"]
+	if rawLimit != "" {
+		result.LimitFailingResources, err = strconv.Atoi(rawLimit)
+		if err != nil {
+			return nil, errors.New("invalid limitFailingResources: " + err.Error())
+		}
+		if result.LimitFailingResources < 0 {
+			return nil, errors.New("invalid limitFailingResources: must be non-negative")
+		}
+	}
+
+	return &result, nil
+}
+
+// Look up a supported framework by its ID
+func parseFramework(id string) (*framework, error) {
+	if id == "" {
+		return nil, errors.New("framework is required")
+	}
+
+	fw, ok := frameworks[models.Framework(id)]
+	if !ok {
+		return nil, errors.New("unknown framework: " + id)
+	}
+	return fw, nil
+}
+
+// Build the report for a framework (or one of its sections) from the compliance table.
+//
+// A negative limitFailing will include every failing resource.
+func frameworkReport(fw *framework, section string, limitFailing int) (*models.FrameworkReport, error) {
+	input, err := buildFrameworkReportScan(fw)
+	if err != nil {
+		return nil, err
+	}
+
+	controls := make(map[string]*controlState)
+	err = scanPages(input, func(item *models.ComplianceStatus) error {
+		for _, controlID := range item.PolicyReports[string(fw.ID)] {
+			if section != "" && controlSection(controlID) != section {
+				continue
+			}
+
+			state, ok := controls[controlID]
+			if !ok {
+				state = &controlState{
+					policies:  make(map[models.PolicyID]struct{}),
+					resources: make(map[models.ResourceID]*models.FailingResource),
+				}
+				controls[controlID] = state
+			}
+			state.add(item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildFrameworkReport(fw, section, controls, limitFailing), nil
+}
+
+func buildFrameworkReportScan(fw *framework) (*dynamodb.ScanInput, error) {
+	filter := expression.Equal(expression.Name("suppressed"), expression.Value(false)).
+		And(expression.AttributeExists(expression.Name("policyReports." + string(fw.ID))))
+	projection := expression.NamesList(
+		expression.Name("policyId"),
+		expression.Name("policyReports"),
+		expression.Name("resourceId"),
+		expression.Name("resourceType"),
+		expression.Name("status"),
+	)
+
+	expr, err := expression.NewBuilder().
+		WithFilter(filter).
+		WithProjection(projection).
+		Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 &Env.ComplianceTable,
+	}, nil
+}
+
+// Record the status of a single policy/resource pair mapped to this control
+func (s *controlState) add(item *models.ComplianceStatus) {
+	s.policies[item.PolicyID] = struct{}{}
+
+	resource, ok := s.resources[item.ResourceID]
+	if !ok {
+		resource = &models.FailingResource{
+			ID:       item.ResourceID,
+			Policies: make([]models.PolicyID, 0),
+			Status:   models.StatusPASS,
+			Type:     item.ResourceType,
+		}
+		s.resources[item.ResourceID] = resource
+	}
+
+	if item.Status == models.StatusPASS {
+		return
+	}
+
+	resource.Policies = append(resource.Policies, item.PolicyID)
+	if resource.Status != models.StatusERROR {
+		// ERROR takes precedence over FAIL
+		resource.Status = item.Status
+	}
+}
+
+func buildFrameworkReport(
+	fw *framework, section string, controls map[string]*controlState, limitFailing int) *models.FrameworkReport {
+
+	// Every defined control is reported, as well as any unknown controls referenced by policies
+	controlIDs := make([]string, 0, len(fw.Controls))
+	titles := make(map[string]string, len(fw.Controls))
+	for _, c := range fw.Controls {
+		if section != "" && controlSection(c.ID) != section {
+			continue
+		}
+		controlIDs = append(controlIDs, c.ID)
+		titles[c.ID] = c.Title
+	}
+	var unknownIDs []string
+	for controlID := range controls {
+		if _, ok := titles[controlID]; !ok {
+			unknownIDs = append(unknownIDs, controlID)
+		}
+	}
+	sort.Slice(unknownIDs, func(i, j int) bool { return controlLess(unknownIDs[i], unknownIDs[j]) })
+	controlIDs = append(controlIDs, unknownIDs...)
+
+	result := &models.FrameworkReport{
+		Controls:    make([]*models.ControlReport, 0, len(controlIDs)),
+		Count:       compliance.NewStatusCount(),
+		Framework:   fw.ID,
+		GeneratedAt: (*strfmt.DateTime)(aws.Time(time.Now().UTC())),
+		Name:        aws.String(fw.Name),
+		Section:     section,
+		Version:     aws.String(fw.Version),
+	}
+
+	for _, controlID := range controlIDs {
+		report := &models.ControlReport{
+			Count:            compliance.NewStatusCount(),
+			FailingResources: make([]*models.FailingResource, 0),
+			ID:               aws.String(controlID),
+			Policies:         make([]models.PolicyID, 0),
+			Section:          aws.String(controlSection(controlID)),
+			Status:           models.ControlStatusNOTEVALUATED,
+			Title:            titles[controlID],
+		}
+		result.Controls = append(result.Controls, report)
+
+		state, ok := controls[controlID]
+		if !ok {
+			continue
+		}
+
+		for policyID := range state.policies {
+			report.Policies = append(report.Policies, policyID)
+		}
+		sort.Slice(report.Policies, func(i, j int) bool { return report.Policies[i] < report.Policies[j] })
+
+		for _, resource := range state.resources {
+			compliance.UpdateStatusCount(report.Count, resource.Status)
+			if resource.Status != models.StatusPASS {
+				sort.Slice(resource.Policies, func(i, j int) bool { return resource.Policies[i] < resource.Policies[j] })
+				report.FailingResources = append(report.FailingResources, resource)
+			}
+		}
+
+		// Sort failing resources with errors first, then by ID
+		sort.Slice(report.FailingResources, func(i, j int) bool {
+			left, right := report.FailingResources[i], report.FailingResources[j]
+			if left.Status != right.Status {
+				return left.Status == models.StatusERROR
+			}
+			return left.ID < right.ID
+		})
+		if limitFailing >= 0 && len(report.FailingResources) > limitFailing {
+			report.FailingResources = report.FailingResources[:limitFailing]
+		}
+
+		status := countToStatus(report.Count)
+		report.Status = models.ControlStatus(status)
+		compliance.UpdateStatusCount(result.Count, status)
+	}
+
+	return result
+}
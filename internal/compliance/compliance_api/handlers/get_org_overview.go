@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	"github.com/panther-labs/panther/api/gateway/compliance/models"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
@@ -93,11 +94,11 @@ func buildGetOrgOverviewQuery() (*dynamodb.ScanInput, error) {
 
 func buildOverview(policies policyMap, resources resourceMap, limitTopFailing int) *models.OrgSummary {
 	// Count policies by severity and record failed policies
-	appliedPolicies := NewStatusCountBySeverity()
+	appliedPolicies := compliance.NewStatusCountBySeverity()
 	failedPolicies := make([]*models.PolicySummary, 0)
 	for _, policy := range policies {
 		status := countToStatus(policy.Count)
-		updateStatusCountBySeverity(appliedPolicies, policy.Severity, status)
+		compliance.UpdateStatusCountBySeverity(appliedPolicies, policy.Severity, status)
 		if status != models.StatusPASS {
 			failedPolicies = append(failedPolicies, policy)
 		}
@@ -115,12 +116,12 @@ func buildOverview(policies policyMap, resources resourceMap, limitTopFailing in
 	for _, resource := range resources {
 		count, ok := resourcesByType[resource.Type]
 		if !ok {
-			count = NewStatusCount()
+			count = compliance.NewStatusCount()
 			resourcesByType[resource.Type] = count
 		}
 
 		status := countBySeverityToStatus(resource.Count)
-		updateStatusCount(count, status)
+		compliance.UpdateStatusCount(count, status)
 		if status != models.StatusPASS {
 			failedResources = append(failedResources, resource)
 		}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	"github.com/panther-labs/panther/api/gateway/compliance/models"
 )
 
@@ -91,8 +92,8 @@ func policyResourceDetail(
 		},
 		Status: models.StatusPASS,
 		Totals: &models.ActiveSuppressCount{
-			Active:     NewStatusCount(),
-			Suppressed: NewStatusCount(),
+			Active:     compliance.NewStatusCount(),
+			Suppressed: compliance.NewStatusCount(),
 		},
 	}
 
@@ -54,16 +54,18 @@ func SetStatus(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyRe
 	writeRequests := make([]*dynamodb.WriteRequest, len(input.Entries))
 	for i, entry := range input.Entries {
 		status := &models.ComplianceStatus{
-			ErrorMessage:   entry.ErrorMessage,
-			ExpiresAt:      models.ExpiresAt(expiresAt),
-			IntegrationID:  entry.IntegrationID,
-			LastUpdated:    models.LastUpdated(now),
-			PolicyID:       entry.PolicyID,
-			PolicySeverity: entry.PolicySeverity,
-			ResourceID:     entry.ResourceID,
-			ResourceType:   entry.ResourceType,
-			Status:         entry.Status,
-			Suppressed:     entry.Suppressed,
+			ErrorMessage:    entry.ErrorMessage,
+			ExpiresAt:       models.ExpiresAt(expiresAt),
+			IntegrationID:   entry.IntegrationID,
+			LastUpdated:     models.LastUpdated(now),
+			PolicyID:        entry.PolicyID,
+			PolicyReports:   entry.PolicyReports,
+			PolicySeverity:  entry.PolicySeverity,
+			ResourceID:      entry.ResourceID,
+			ResourceType:    entry.ResourceType,
+			ResourceVersion: entry.ResourceVersion,
+			Status:          entry.Status,
+			Suppressed:      entry.Suppressed,
 		}
 
 		marshalled, err := dynamodbattribute.MarshalMap(status)
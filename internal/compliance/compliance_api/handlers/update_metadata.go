@@ -29,11 +29,12 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	"github.com/panther-labs/panther/api/gateway/compliance/models"
 	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
 )
 
-// UpdateMetadata updates status entries for a given policy with a new severity / suppression set.
+// UpdateMetadata updates status entries for a given policy with a new severity / suppression set / reports.
 func UpdateMetadata(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
 	input, err := parseUpdateMetadata(request)
 	if err != nil {
@@ -89,7 +90,10 @@ func itemsToUpdate(input *models.UpdateMetadata) ([]*dynamodb.WriteRequest, *eve
 		}
 
 		// This status entry has changed - we need to rewrite it
-		if bool(item.Suppressed) != ignored || item.PolicySeverity != input.Severity {
+		if bool(item.Suppressed) != ignored || item.PolicySeverity != input.Severity ||
+			!compliance.ReportsEqual(item.PolicyReports, input.Reports) {
+
+			item.PolicyReports = input.Reports
 			item.PolicySeverity = input.Severity
 			item.Suppressed = models.Suppressed(ignored)
 
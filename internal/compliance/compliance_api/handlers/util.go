@@ -45,27 +45,6 @@ func badRequest(err error) *events.APIGatewayProxyResponse {
 	return gatewayapi.MarshalResponse(errModel, http.StatusBadRequest)
 }
 
-// NewStatusCount creates a new pass/fail counter with values initialized to 0
-func NewStatusCount() *models.StatusCount {
-	return &models.StatusCount{
-		Error: aws.Int64(0),
-		Fail:  aws.Int64(0),
-		Pass:  aws.Int64(0),
-	}
-}
-
-// Update status counters based on the string status
-func updateStatusCount(count *models.StatusCount, status models.Status) {
-	switch status {
-	case models.StatusPASS:
-		*count.Pass++
-	case models.StatusFAIL:
-		*count.Fail++
-	default:
-		*count.Error++
-	}
-}
-
 // Convert pass/fail counts to a compliance status string
 func countToStatus(count *models.StatusCount) models.Status {
 	if *count.Error > 0 {
@@ -77,35 +56,6 @@ func countToStatus(count *models.StatusCount) models.Status {
 	return models.StatusPASS
 }
 
-// NewStatusCountBySeverity creates a new pass/fail counter keyed by severity with initial values
-func NewStatusCountBySeverity() *models.StatusCountBySeverity {
-	return &models.StatusCountBySeverity{
-		Info:     NewStatusCount(),
-		Low:      NewStatusCount(),
-		Medium:   NewStatusCount(),
-		High:     NewStatusCount(),
-		Critical: NewStatusCount(),
-	}
-}
-
-// Update StatsCountBySeverity totals with a pass/fail status of a given severity
-func updateStatusCountBySeverity(
-	count *models.StatusCountBySeverity, severity models.PolicySeverity, status models.Status) {
-
-	switch severity {
-	case models.PolicySeverityINFO:
-		updateStatusCount(count.Info, status)
-	case models.PolicySeverityLOW:
-		updateStatusCount(count.Low, status)
-	case models.PolicySeverityMEDIUM:
-		updateStatusCount(count.Medium, status)
-	case models.PolicySeverityHIGH:
-		updateStatusCount(count.High, status)
-	default:
-		updateStatusCount(count.Critical, status)
-	}
-}
-
 // Convert pass/fail counts by severity to a compliance status string
 func countBySeverityToStatus(count *models.StatusCountBySeverity) models.Status {
 	if *count.Low.Error > 0 || *count.Info.Error > 0 || *count.Medium.Error > 0 ||
@@ -31,10 +31,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	"github.com/panther-labs/panther/api/gateway/compliance/client"
 	"github.com/panther-labs/panther/api/gateway/compliance/client/operations"
 	"github.com/panther-labs/panther/api/gateway/compliance/models"
-	"github.com/panther-labs/panther/internal/compliance/compliance_api/handlers"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
@@ -607,7 +607,7 @@ func getOrgOverviewEmpty(t *testing.T) {
 	require.NoError(t, err)
 
 	expected := &models.OrgSummary{
-		AppliedPolicies:     handlers.NewStatusCountBySeverity(),
+		AppliedPolicies:     compliance.NewStatusCountBySeverity(),
 		ScannedResources:    &models.ScannedResources{ByType: []*models.ResourceOfType{}},
 		TopFailingPolicies:  []*models.PolicySummary{},
 		TopFailingResources: []*models.ResourceSummary{},
@@ -639,8 +639,8 @@ func getOrgOverview(t *testing.T) {
 				Fail:  aws.Int64(1),
 				Pass:  aws.Int64(0),
 			},
-			Low:  handlers.NewStatusCount(),
-			Info: handlers.NewStatusCount(),
+			Low:  compliance.NewStatusCount(),
+			Info: compliance.NewStatusCount(),
 		},
 		ScannedResources: &models.ScannedResources{
 			ByType: []*models.ResourceOfType{
@@ -695,24 +695,24 @@ func getOrgOverview(t *testing.T) {
 						Fail:  aws.Int64(0),
 						Pass:  aws.Int64(0),
 					},
-					Medium: handlers.NewStatusCount(),
-					Low:    handlers.NewStatusCount(),
-					Info:   handlers.NewStatusCount(),
+					Medium: compliance.NewStatusCount(),
+					Low:    compliance.NewStatusCount(),
+					Info:   compliance.NewStatusCount(),
 				},
 				ID:   "arn:aws:s3:::my-bucket",
 				Type: "AWS.S3.Bucket",
 			},
 			{
 				Count: &models.StatusCountBySeverity{
-					Critical: handlers.NewStatusCount(),
-					High:     handlers.NewStatusCount(),
+					Critical: compliance.NewStatusCount(),
+					High:     compliance.NewStatusCount(),
 					Medium: &models.StatusCount{
 						Error: aws.Int64(0),
 						Fail:  aws.Int64(1),
 						Pass:  aws.Int64(0),
 					},
-					Low:  handlers.NewStatusCount(),
-					Info: handlers.NewStatusCount(),
+					Low:  compliance.NewStatusCount(),
+					Info: compliance.NewStatusCount(),
 				},
 				ID:   "arn:aws:s3:::my-other-bucket",
 				Type: "AWS.S3.Bucket",
@@ -30,12 +30,15 @@ var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"GET /describe-org":      handlers.DescribeOrg,
 	"GET /describe-policy":   handlers.DescribePolicy,
 	"GET /describe-resource": handlers.DescribeResource,
+	"GET /framework-report":  handlers.GetFrameworkReport,
 	"GET /org-overview":      handlers.GetOrgOverview,
 	"GET /status":            handlers.GetStatus,
+	"GET /trend":             handlers.GetComplianceTrend,
 
-	"POST /delete": handlers.DeleteStatus,
-	"POST /status": handlers.SetStatus,
-	"POST /update": handlers.UpdateMetadata,
+	"POST /delete":                  handlers.DeleteStatus,
+	"POST /framework-report/export": handlers.ExportFrameworkReport,
+	"POST /status":                  handlers.SetStatus,
+	"POST /update":                  handlers.UpdateMetadata,
 }
 
 func main() {
@@ -0,0 +1,243 @@
+package history
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/go-openapi/strfmt"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance"
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+const dayLayout = "2006-01-02"
+
+type envConfig struct {
+	ComplianceTable string `required:"true" split_words:"true"`
+	HistoryTable    string `required:"true" split_words:"true"`
+}
+
+var (
+	// Env is the parsed environment variables
+	Env envConfig
+
+	awsSession                             = session.Must(session.NewSession())
+	dynamoClient dynamodbiface.DynamoDBAPI = dynamodb.New(awsSession)
+)
+
+// Worst status of a single resource across every policy which evaluated it
+type resourceState struct {
+	integrationID models.IntegrationID
+	resourceType  models.ResourceType
+	status        models.Status
+}
+
+// RecordDailyPosture aggregates the current compliance table and saves it as the snapshot for the given day.
+//
+// Running more than once in the same (UTC) day replaces that day's snapshot.
+func RecordDailyPosture(now time.Time) error {
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	posture, err := aggregatePosture(now.UTC())
+	if err != nil {
+		return err
+	}
+
+	item, err := dynamodbattribute.MarshalMap(posture)
+	if err != nil {
+		zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+		return err
+	}
+
+	if _, err = dynamoClient.PutItem(&dynamodb.PutItemInput{Item: item, TableName: &Env.HistoryTable}); err != nil {
+		zap.L().Error("dynamoClient.PutItem failed", zap.Error(err))
+		return err
+	}
+
+	zap.L().Info("recorded daily compliance posture",
+		zap.String("day", *posture.Day),
+		zap.Int("policies", len(posture.ByPolicy)),
+		zap.Int64("resources", *posture.Resources.Pass+*posture.Resources.Fail+*posture.Resources.Error))
+	return nil
+}
+
+// Scan the compliance table and summarize every non-suppressed policy/resource pair.
+func aggregatePosture(now time.Time) (*models.DailyPosture, error) {
+	input, err := buildComplianceScan()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator := newPostureAggregator()
+	var innerErr error
+	err = dynamoClient.ScanPages(input, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		var entries []*models.ComplianceStatus
+		if innerErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &entries); innerErr != nil {
+			zap.L().Error("failed to unmarshal compliance status", zap.Error(innerErr))
+			return false // stop paging
+		}
+		for _, entry := range entries {
+			aggregator.add(entry)
+		}
+		return true
+	})
+	if innerErr != nil {
+		return nil, innerErr
+	}
+	if err != nil {
+		zap.L().Error("dynamoClient.ScanPages failed", zap.Error(err))
+		return nil, err
+	}
+
+	return aggregator.posture(now), nil
+}
+
+func buildComplianceScan() (*dynamodb.ScanInput, error) {
+	filter := expression.Equal(expression.Name("suppressed"), expression.Value(false))
+	projection := expression.NamesList(
+		expression.Name("integrationId"),
+		expression.Name("policyId"),
+		expression.Name("policySeverity"),
+		expression.Name("resourceId"),
+		expression.Name("resourceType"),
+		expression.Name("status"),
+	)
+
+	expr, err := expression.NewBuilder().
+		WithFilter(filter).
+		WithProjection(projection).
+		Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 &Env.ComplianceTable,
+	}, nil
+}
+
+type postureAggregator struct {
+	bySeverity *models.StatusCountBySeverity
+	policies   map[models.PolicyID]*models.PolicySummary
+	resources  map[models.ResourceID]*resourceState
+}
+
+func newPostureAggregator() *postureAggregator {
+	return &postureAggregator{
+		bySeverity: compliance.NewStatusCountBySeverity(),
+		policies:   make(map[models.PolicyID]*models.PolicySummary, 200),
+		resources:  make(map[models.ResourceID]*resourceState, 1000),
+	}
+}
+
+// Add a single policy/resource pair to the running totals
+func (a *postureAggregator) add(entry *models.ComplianceStatus) {
+	compliance.UpdateStatusCountBySeverity(a.bySeverity, entry.PolicySeverity, entry.Status)
+
+	policy, ok := a.policies[entry.PolicyID]
+	if !ok {
+		policy = &models.PolicySummary{Count: compliance.NewStatusCount(), ID: entry.PolicyID, Severity: entry.PolicySeverity}
+		a.policies[entry.PolicyID] = policy
+	}
+	compliance.UpdateStatusCount(policy.Count, entry.Status)
+
+	resource, ok := a.resources[entry.ResourceID]
+	if !ok {
+		resource = &resourceState{
+			integrationID: entry.IntegrationID,
+			resourceType:  entry.ResourceType,
+			status:        models.StatusPASS,
+		}
+		a.resources[entry.ResourceID] = resource
+	}
+	if entry.Status == models.StatusERROR || (entry.Status == models.StatusFAIL && resource.status == models.StatusPASS) {
+		resource.status = entry.Status
+	}
+}
+
+// Build the final snapshot, with every breakdown sorted for stable output
+func (a *postureAggregator) posture(now time.Time) *models.DailyPosture {
+	result := &models.DailyPosture{
+		ByIntegration:  make([]*models.IntegrationSummary, 0),
+		ByPolicy:       make([]*models.PolicySummary, 0, len(a.policies)),
+		ByResourceType: make([]*models.ResourceOfType, 0),
+		BySeverity:     a.bySeverity,
+		Day:            aws.String(now.Format(dayLayout)),
+		RecordedAt:     (*strfmt.DateTime)(&now),
+		Resources:      compliance.NewStatusCount(),
+	}
+
+	for _, policy := range a.policies {
+		result.ByPolicy = append(result.ByPolicy, policy)
+	}
+	sort.Slice(result.ByPolicy, func(i, j int) bool { return result.ByPolicy[i].ID < result.ByPolicy[j].ID })
+
+	byIntegration := make(map[models.IntegrationID]*models.StatusCount)
+	byType := make(map[models.ResourceType]*models.StatusCount)
+	for _, resource := range a.resources {
+		compliance.UpdateStatusCount(result.Resources, resource.status)
+
+		count, ok := byIntegration[resource.integrationID]
+		if !ok {
+			count = compliance.NewStatusCount()
+			byIntegration[resource.integrationID] = count
+		}
+		compliance.UpdateStatusCount(count, resource.status)
+
+		count, ok = byType[resource.resourceType]
+		if !ok {
+			count = compliance.NewStatusCount()
+			byType[resource.resourceType] = count
+		}
+		compliance.UpdateStatusCount(count, resource.status)
+	}
+
+	for integrationID, count := range byIntegration {
+		result.ByIntegration = append(result.ByIntegration,
+			&models.IntegrationSummary{Count: count, IntegrationID: integrationID})
+	}
+	sort.Slice(result.ByIntegration, func(i, j int) bool {
+		return result.ByIntegration[i].IntegrationID < result.ByIntegration[j].IntegrationID
+	})
+
+	for resourceType, count := range byType {
+		result.ByResourceType = append(result.ByResourceType, &models.ResourceOfType{Count: count, Type: resourceType})
+	}
+	sort.Slice(result.ByResourceType, func(i, j int) bool {
+		return result.ByResourceType[i].Type < result.ByResourceType[j].Type
+	})
+
+	return result
+}
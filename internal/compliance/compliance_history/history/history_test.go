@@ -0,0 +1,67 @@
+package history
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+func TestPostureAggregator(t *testing.T) {
+	aggregator := newPostureAggregator()
+	entries := []*models.ComplianceStatus{
+		{IntegrationID: "a", PolicyID: "p1", PolicySeverity: models.PolicySeverityHIGH,
+			ResourceID: "r1", ResourceType: "AWS.S3.Bucket", Status: models.StatusPASS},
+		{IntegrationID: "a", PolicyID: "p2", PolicySeverity: models.PolicySeverityLOW,
+			ResourceID: "r1", ResourceType: "AWS.S3.Bucket", Status: models.StatusFAIL},
+		{IntegrationID: "a", PolicyID: "p1", PolicySeverity: models.PolicySeverityHIGH,
+			ResourceID: "r2", ResourceType: "AWS.S3.Bucket", Status: models.StatusPASS},
+		{IntegrationID: "b", PolicyID: "p3", PolicySeverity: models.PolicySeverityCRITICAL,
+			ResourceID: "r3", ResourceType: "AWS.IAM.Role", Status: models.StatusERROR},
+	}
+	for _, entry := range entries {
+		aggregator.add(entry)
+	}
+
+	now := time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)
+	result := aggregator.posture(now)
+
+	assert.Equal(t, "2020-03-04", *result.Day)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(1), Fail: aws.Int64(1), Pass: aws.Int64(1)}, result.Resources)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(0), Pass: aws.Int64(2)}, result.BySeverity.High)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(1), Fail: aws.Int64(0), Pass: aws.Int64(0)}, result.BySeverity.Critical)
+
+	require.Len(t, result.ByPolicy, 3)
+	assert.Equal(t, models.PolicyID("p1"), result.ByPolicy[0].ID)
+	assert.Equal(t, int64(2), *result.ByPolicy[0].Count.Pass)
+
+	require.Len(t, result.ByIntegration, 2)
+	assert.Equal(t, models.IntegrationID("a"), result.ByIntegration[0].IntegrationID)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(1), Pass: aws.Int64(1)}, result.ByIntegration[0].Count)
+
+	require.Len(t, result.ByResourceType, 2)
+	assert.Equal(t, models.ResourceType("AWS.IAM.Role"), result.ByResourceType[0].Type)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(1), Pass: aws.Int64(1)}, result.ByResourceType[1].Count)
+}
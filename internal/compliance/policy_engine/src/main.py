@@ -35,7 +35,7 @@ def lambda_handler(lambda_event: Dict[str, Any], unused_context: Any) -> Dict[st
             ###### Compliance Evaluation ######
             'policies': [
                 {
-                    'body': 'def policy(resource): ...',
+                    'body': 'def policy(resource): ...',  # or 'def policy(resource, related): ...'
                     'id': 'BucketEncryptionEnabled',
                     'resourceTypes': ['AWS.S3.Bucket']  # can be empty for all resource types
                 }
@@ -44,6 +44,15 @@ def lambda_handler(lambda_event: Dict[str, Any], unused_context: Any) -> Dict[st
                 {
                     'attributes': { ... resource attributes ... },
                     'id': 'arn:aws:s3:::my-bucket',
+                    'related': [  # only included if a policy accepts related resources
+                        {
+                            'attributes': { ... related resource attributes ... },
+                            'direction': 'outgoing',  # the bucket references the related resource
+                            'id': 'arn:aws:kms:us-west-2:123456789012:key/...',
+                            'relationship': 'kmsKey',
+                            'type': 'AWS.KMS.Key'
+                        }
+                    ],
                     'type': 'AWS.S3.Bucket'
                 }
             ]
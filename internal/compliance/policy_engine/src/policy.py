@@ -15,8 +15,9 @@
 # along with this program.  If not, see <https://www.gnu.org/licenses/>.
 """Classes to represent a Panther policy and a collection of policies."""
 import collections
+import inspect
 from importlib import util as import_util
-from typing import Any, Dict, List, Union
+from typing import Any, Dict, List, Optional, Union
 
 
 class Policy:
@@ -41,19 +42,37 @@ class Policy:
         self.policy_id = policy_id
 
         self._import_error = None
+        self._accepts_related = False
         try:
             self._module = self.import_module(policy_id, path)
+            self._accepts_related = self.accepts_related(self._module)
         except Exception as err:  # pylint: disable=broad-except
             self._import_error = err
 
-    def run(self, resource_attributes: Dict[str, Any]) -> Union[bool, Exception]:
-        """Analyze a resource with this policy and return True, False, or an error."""
+    @staticmethod
+    def accepts_related(module: Any) -> bool:
+        """True if the policy function takes a second argument for the related resources."""
+        try:
+            return len(inspect.signature(module.policy).parameters) >= 2
+        except (AttributeError, TypeError, ValueError):
+            return False
+
+    def run(self, resource_attributes: Dict[str, Any], related: Optional[List[Dict[str, Any]]] = None) -> Union[bool, Exception]:
+        """Analyze a resource with this policy and return True, False, or an error.
+
+        Args:
+            resource_attributes: Attributes of the resource being analyzed
+            related: Resources referencing or referenced by the analyzed resource, for policies which accept them
+        """
         if self._import_error:
             return self._import_error
 
         try:
             # Python source should have a method called "policy"
-            matched = self._module.policy(resource_attributes)
+            if self._accepts_related:
+                matched = self._module.policy(resource_attributes, related or [])
+            else:
+                matched = self._module.policy(resource_attributes)
         except Exception as err:  # pylint: disable=broad-except
             return err
 
@@ -103,7 +122,7 @@ class PolicySet:
         passed: List[str] = []
 
         for policy in self._policies_by_type[resource['type']] + self._global_policies:
-            result = policy.run(resource['attributes'])
+            result = policy.run(resource['attributes'], resource.get('related'))
             if isinstance(result, Exception):
                 errored.append({'id': policy.policy_id, 'message': '{}: {}'.format(type(result).__name__, result)})
             elif result is False:
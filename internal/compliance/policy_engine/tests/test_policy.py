@@ -40,6 +40,16 @@ class TestPolicy(unittest.TestCase):
         policy = Policy('test-id', path)
         self.assertFalse(policy.run({'hello': 'world'}))
 
+    def test_run_related(self) -> None:
+        """Policies with a second argument are given the related resources."""
+        path = os.path.join(tempfile.gettempdir(), 'panther-related.py')
+        with open(path, 'w') as policy_file:
+            policy_file.write('def policy(resource, related): return not any(r["type"] == "AWS.IAM.Role" for r in related)')
+        policy = Policy('test-id', path)
+        self.assertTrue(policy.run({'hello': 'world'}))
+        self.assertTrue(policy.run({'hello': 'world'}, [{'type': 'AWS.EC2.SecurityGroup'}]))
+        self.assertFalse(policy.run({'hello': 'world'}, [{'type': 'AWS.IAM.Role'}]))
+
     def test_run_import_error(self) -> None:
         """A policy which failed to import will raise errors for every resource."""
         path = os.path.join(tempfile.gettempdir(), 'panther-invalid.py')
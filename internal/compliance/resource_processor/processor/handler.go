@@ -46,7 +46,16 @@ import (
 	"github.com/panther-labs/panther/pkg/oplog"
 )
 
-const defaultDelaySeconds = 30
+const (
+	defaultDelaySeconds   = 30
+	maxCompliancePageSize = 1000
+
+	// Related resources make the policy engine input much larger, so resources are evaluated in smaller batches
+	relatedBatchSize = 50
+)
+
+// Policies accepting a second argument are given the resources related to the one they analyze
+var relatedPolicyRegex = regexp.MustCompile(`def\s+policy\s*\(\s*\w+\s*,\s*\w+`)
 
 // Map policy/resource ID to the instance of the object
 type policyMap map[string]*analysismodels.EnabledPolicy
@@ -141,6 +150,7 @@ func (r *batchResults) analyzeUpdatedPolicy(policy *analysismodels.Policy) error
 		string(policy.ID): &analysismodels.EnabledPolicy{
 			Body:          policy.Body,
 			ID:            policy.ID,
+			Reports:       policy.Reports,
 			ResourceTypes: policy.ResourceTypes,
 			Severity:      policy.Severity,
 			Suppressions:  policy.Suppressions,
@@ -188,7 +198,7 @@ func (r *batchResults) analyze(resources resourceMap, policies policyMap) error
 	}
 
 	var analysis *enginemodels.PolicyEngineOutput
-	analysis, err = evaluatePolicies(policies, resources)
+	analysis, err = evaluateInBatches(policies, resources)
 	if err != nil {
 		return err
 	}
@@ -247,30 +257,143 @@ func (r *batchResults) analyze(resources resourceMap, policies policyMap) error
 				// We only need to send an alert to the user if the status is newly FAILing
 				ShouldAlert: aws.Bool(status != compliancemodels.StatusFAIL),
 			}
-			var sqsMessageBody string
-			if sqsMessageBody, err = jsoniter.MarshalToString(complianceNotification); err != nil {
-				zap.L().Error("failed to marshal complianceNotification body", zap.Error(err))
+			if err = r.notify(complianceNotification); err != nil {
 				return err
 			}
+		}
 
-			r.Alerts = append(r.Alerts, &sqs.SendMessageBatchRequestEntry{
-				DelaySeconds: aws.Int64(defaultDelaySeconds),
-				Id:           aws.String(strconv.Itoa(len(r.Alerts))),
-				MessageBody:  aws.String(sqsMessageBody),
-			})
+		if len(result.Passed) == 0 {
+			continue
 		}
 
+		// Resources which pass a policy they were failing resolve the open alert for the pair
+		var failing map[string]bool
+		if failing, err = failingPolicies(result.ID); err != nil {
+			return err
+		}
 		for _, policyID := range result.Passed {
-			entry := buildStatus(policies[policyID], resources[result.ID], compliancemodels.StatusPASS)
+			policy, resource := policies[policyID], resources[result.ID]
+			entry := buildStatus(policy, resource, compliancemodels.StatusPASS)
 			r.StatusEntries = append(r.StatusEntries, entry)
+
+			if !failing[policyID] {
+				continue
+			}
+			zap.L().Info("resource passes a policy it was failing",
+				zap.String("policyId", policyID),
+				zap.String("resourceId", result.ID),
+			)
+			err = r.notify(&alertmodels.ComplianceNotification{
+				ResourceID:      aws.String(string(resource.ID)),
+				PolicyID:        aws.String(string(policy.ID)),
+				PolicyVersionID: aws.String(string(policy.VersionID)),
+				Timestamp:       aws.Time(time.Now()),
+				ShouldAlert:     aws.Bool(false),
+				Status:          aws.String(string(compliancemodels.StatusPASS)),
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// Queue a notification for the alert processor
+func (r *batchResults) notify(complianceNotification *alertmodels.ComplianceNotification) error {
+	sqsMessageBody, err := jsoniter.MarshalToString(complianceNotification)
+	if err != nil {
+		zap.L().Error("failed to marshal complianceNotification body", zap.Error(err))
+		return err
+	}
+
+	r.Alerts = append(r.Alerts, &sqs.SendMessageBatchRequestEntry{
+		DelaySeconds: aws.Int64(defaultDelaySeconds),
+		Id:           aws.String(strconv.Itoa(len(r.Alerts))),
+		MessageBody:  aws.String(sqsMessageBody),
+	})
+	return nil
+}
+
+// Find the policies an unsuppressed resource is currently failing, according to compliance-api
+func failingPolicies(resourceID string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	var totalPages int64 = 1
+	for page := int64(1); page <= totalPages; page++ {
+		response, err := complianceClient.Operations.DescribeResource(&complianceops.DescribeResourceParams{
+			ResourceID: resourceID,
+			Status:     aws.String(string(compliancemodels.StatusFAIL)),
+			Suppressed: aws.Bool(false),
+			Page:       aws.Int64(page),
+			PageSize:   aws.Int64(maxCompliancePageSize),
+			HTTPClient: httpClient,
+		})
+		if err != nil {
+			zap.L().Error("failed to fetch failing policies", zap.String("resourceId", resourceID), zap.Error(err))
+			return nil, err
+		}
+
+		for _, item := range response.Payload.Items {
+			result[string(item.PolicyID)] = true
+		}
+		totalPages = aws.Int64Value(response.Payload.Paging.TotalPages)
+	}
+	return result, nil
+}
+
+// Evaluate policies in batches small enough to include related resources, if any policy uses them.
+func evaluateInBatches(policies policyMap, resources resourceMap) (*enginemodels.PolicyEngineOutput, error) {
+	if !usesRelated(policies) {
+		return evaluatePolicies(policies, resources, nil)
+	}
+
+	resourceIDs := make([]string, 0, len(resources))
+	for resourceID := range resources {
+		resourceIDs = append(resourceIDs, resourceID)
+	}
+
+	result := &enginemodels.PolicyEngineOutput{}
+	for start := 0; start < len(resourceIDs); start += relatedBatchSize {
+		end := start + relatedBatchSize
+		if end > len(resourceIDs) {
+			end = len(resourceIDs)
+		}
+
+		batch := make(resourceMap, end-start)
+		for _, resourceID := range resourceIDs[start:end] {
+			batch[resourceID] = resources[resourceID]
+		}
+
+		related, err := getRelated(resourceIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		analysis, err := evaluatePolicies(policies, batch, related)
+		if err != nil {
+			return nil, err
+		}
+		result.Resources = append(result.Resources, analysis.Resources...)
+	}
+	return result, nil
+}
+
+func usesRelated(policies policyMap) bool {
+	for _, policy := range policies {
+		if relatedPolicyRegex.MatchString(string(policy.Body)) {
+			return true
+		}
+	}
+	return false
+}
+
 // Invoke the policy engine.
-func evaluatePolicies(policies policyMap, resources resourceMap) (*enginemodels.PolicyEngineOutput, error) {
+func evaluatePolicies(
+	policies policyMap,
+	resources resourceMap,
+	related map[string][]enginemodels.RelatedResource,
+) (*enginemodels.PolicyEngineOutput, error) {
+
 	input := enginemodels.PolicyEngineInput{
 		Policies:  make([]enginemodels.Policy, 0, len(policies)),
 		Resources: make([]enginemodels.Resource, 0, len(resources)),
@@ -286,6 +409,7 @@ func evaluatePolicies(policies policyMap, resources resourceMap) (*enginemodels.
 		input.Resources = append(input.Resources, enginemodels.Resource{
 			Attributes: resource.Attributes,
 			ID:         string(resource.ID),
+			Related:    related[string(resource.ID)],
 			Type:       string(resource.Type),
 		})
 	}
@@ -334,12 +458,16 @@ func buildStatus(
 
 	return &compliancemodels.SetStatus{
 		PolicyID:       compliancemodels.PolicyID(policy.ID),
+		PolicyReports:  compliancemodels.PolicyReports(policy.Reports),
 		PolicySeverity: compliancemodels.PolicySeverity(policy.Severity),
 		ResourceID:     compliancemodels.ResourceID(resource.ID),
 		ResourceType:   compliancemodels.ResourceType(resource.Type),
 		Suppressed:     compliancemodels.Suppressed(isSuppressed(string(resource.ID), policy)),
 		IntegrationID:  compliancemodels.IntegrationID(resource.IntegrationID),
 
+		// Links the status to the version of the resource which was evaluated
+		ResourceVersion: compliancemodels.ResourceVersion(resource.Version),
+
 		Status: status,
 	}
 }
@@ -102,3 +102,13 @@ func TestIsSuppressed(t *testing.T) {
 		Suppressions: []string{"not", "this", "one", "but", "here:", "*.us-west-2/*"},
 	}))
 }
+
+func TestUsesRelated(t *testing.T) {
+	assert.False(t, usesRelated(policyMap{
+		"a": &analysismodels.EnabledPolicy{Body: "def policy(resource):\n    return True"},
+	}))
+	assert.True(t, usesRelated(policyMap{
+		"a": &analysismodels.EnabledPolicy{Body: "def policy(resource):\n    return True"},
+		"b": &analysismodels.EnabledPolicy{Body: "def policy( resource, related ):\n    return not related"},
+	}))
+}
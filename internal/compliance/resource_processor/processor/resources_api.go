@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"go.uber.org/zap"
 
+	enginemodels "github.com/panther-labs/panther/api/gateway/analysis"
 	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
 	resourcemodels "github.com/panther-labs/panther/api/gateway/resources/models"
 )
@@ -44,7 +45,7 @@ func getResources(resourceTypes []string, pageno int64) (resourceMap, int64, err
 
 	page, err := resourceClient.Operations.ListResources(&operations.ListResourcesParams{
 		Deleted:    aws.Bool(false),
-		Fields:     []string{"attributes", "id", "integrationId", "integrationType", "type"},
+		Fields:     []string{"attributes", "id", "integrationId", "integrationType", "type", "version"},
 		Page:       &pageno,
 		PageSize:   aws.Int64(resourcePageSize),
 		Types:      resourceTypes,
@@ -77,3 +78,45 @@ func getResource(resourceID string) (*resourcemodels.Resource, error) {
 
 	return resource.Payload, nil
 }
+
+// Get the resources related to each of the given resources, keyed by resource ID
+//
+// Related resources which are not tracked by Panther themselves (e.g. instance profiles) are left out.
+func getRelated(resourceIDs []string) (map[string][]enginemodels.RelatedResource, error) {
+	zap.L().Debug("listing related resources from resources-api", zap.Int("resourceCount", len(resourceIDs)))
+
+	query := &resourcemodels.NeighborsQuery{
+		Direction:         resourcemodels.DirectionBoth,
+		IncludeAttributes: true,
+		ResourceIds:       make([]resourcemodels.ResourceID, len(resourceIDs)),
+	}
+	for i, resourceID := range resourceIDs {
+		query.ResourceIds[i] = resourcemodels.ResourceID(resourceID)
+	}
+
+	response, err := resourceClient.Operations.ListNeighbors(&operations.ListNeighborsParams{
+		Body:       query,
+		HTTPClient: httpClient,
+	})
+	if err != nil {
+		zap.L().Error("failed to list related resources", zap.Error(err))
+		return nil, err
+	}
+
+	result := make(map[string][]enginemodels.RelatedResource, len(resourceIDs))
+	for _, resource := range response.Payload.Resources {
+		for _, neighbor := range resource.Neighbors {
+			if neighbor.Type == "" {
+				continue
+			}
+			result[string(resource.ID)] = append(result[string(resource.ID)], enginemodels.RelatedResource{
+				Attributes:   neighbor.Attributes,
+				Direction:    string(neighbor.Direction),
+				ID:           string(neighbor.ID),
+				Relationship: string(neighbor.Relationship),
+				Type:         string(neighbor.Type),
+			})
+		}
+	}
+	return result, nil
+}
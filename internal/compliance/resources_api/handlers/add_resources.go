@@ -44,6 +44,8 @@ const (
 	// Expire resources after three days (slightly longer than compliance-api timeout of two days) automatically
 	// if we miss the delete API call
 	deleteMissWindow = 3 * 24 * 60 * 60
+	// Prior versions of resource attributes are kept for a year as evidence for audits and incident response
+	versionLifetime = 365 * 24 * time.Hour
 )
 
 // AddResources batch writes a group of resources to the Dynamo table.
@@ -54,10 +56,9 @@ func AddResources(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 	}
 
 	now := models.LastModified(time.Now())
-	writeRequests := make([]*dynamodb.WriteRequest, len(input.Resources))
-	sqsEntries := make([]*sqs.SendMessageBatchRequestEntry, len(input.Resources))
+	items := make([]*resourceItem, len(input.Resources))
 	for i, r := range input.Resources {
-		item := resourceItem{
+		items[i] = &resourceItem{
 			Attributes:      r.Attributes,
 			Deleted:         false,
 			ID:              r.ID,
@@ -68,7 +69,22 @@ func AddResources(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 			LowerID:         strings.ToLower(string(r.ID)),
 			ExpiresAt:       time.Now().Unix() + deleteMissWindow,
 		}
+	}
+
+	// Versions and relationships are recorded before the resources which reference them are written,
+	// so a failure is retried the next time the resources are added.
+	if err := recordVersions(items, time.Time(now)); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	// Every resource is indexed, not only the changed ones: resources added before relationships were
+	// indexed, or deleted and added back unchanged, would otherwise never be indexed.
+	if err := indexRelationships(items); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
 
+	writeRequests := make([]*dynamodb.WriteRequest, len(items))
+	sqsEntries := make([]*sqs.SendMessageBatchRequestEntry, len(items))
+	for i, item := range items {
 		marshalled, err := dynamodbattribute.MarshalMap(item)
 		if err != nil {
 			zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
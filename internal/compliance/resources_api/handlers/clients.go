@@ -44,10 +44,12 @@ var (
 )
 
 type envConfig struct {
-	ComplianceAPIHost string `required:"true" split_words:"true"`
-	ComplianceAPIPath string `required:"true" split_words:"true"`
-	ResourcesQueueURL string `required:"true" split_words:"true"`
-	ResourcesTable    string `required:"true" split_words:"true"`
+	ComplianceAPIHost          string `required:"true" split_words:"true"`
+	ComplianceAPIPath          string `required:"true" split_words:"true"`
+	ResourcesQueueURL          string `required:"true" split_words:"true"`
+	ResourcesTable             string `required:"true" split_words:"true"`
+	ResourceRelationshipsTable string `required:"true" split_words:"true"`
+	ResourceVersionsTable      string `required:"true" split_words:"true"`
 }
 
 // Setup parses the environment and builds the AWS and http clients.
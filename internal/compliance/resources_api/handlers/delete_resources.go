@@ -43,6 +43,7 @@ func DeleteResources(request *events.APIGatewayProxyRequest) *events.APIGatewayP
 	}
 
 	deletes := make([]*compliance.DeleteStatus, len(input.Resources))
+	resourceIDs := make([]models.ResourceID, len(input.Resources))
 	update := expression.
 		Set(expression.Name("deleted"), expression.Value(true)).
 		Set(expression.Name("expiresAt"), expression.Value(time.Now().Unix()+deleteWindowSecs))
@@ -50,6 +51,7 @@ func DeleteResources(request *events.APIGatewayProxyRequest) *events.APIGatewayP
 		deletes[i] = &compliance.DeleteStatus{
 			Resource: &compliance.DeleteResource{ID: compliance.ResourceID(entry.ID)},
 		}
+		resourceIDs[i] = entry.ID
 
 		// Dynamo does not support batch update, so these are sequential
 		response := doUpdate(update, entry.ID)
@@ -64,6 +66,11 @@ func DeleteResources(request *events.APIGatewayProxyRequest) *events.APIGatewayP
 		}
 	}
 
+	// Deleted resources no longer reference anything
+	if err := removeRelationships(resourceIDs); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	// Delete affected compliance states
 	zap.L().Info("deleting compliance status entries", zap.Int("itemCount", len(deletes)))
 	_, err = complianceClient.Operations.DeleteStatus(&complianceops.DeleteStatusParams{
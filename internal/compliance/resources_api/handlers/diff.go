@@ -0,0 +1,111 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+// Escape an object key for use in a JSON pointer (RFC 6901)
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// jsonPatch returns the JSON patch (RFC 6902) which transforms one version of resource attributes into another.
+//
+// Object keys are compared in sorted order so the patch is deterministic. An attribute set to null is treated
+// the same as a missing attribute, which is how the snapshot pollers report unset fields.
+func jsonPatch(from, to interface{}) []*models.PatchOperation {
+	patch := make([]*models.PatchOperation, 0)
+	diffValues("", from, to, &patch)
+	return patch
+}
+
+func diffValues(path string, from, to interface{}, patch *[]*models.PatchOperation) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, ok := to.(map[string]interface{}); ok {
+			diffObjects(path, fromValue, toValue, patch)
+			return
+		}
+	case []interface{}:
+		if toValue, ok := to.([]interface{}); ok {
+			diffArrays(path, fromValue, toValue, patch)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*patch = append(*patch, patchOperation(models.PatchOpReplace, path, to))
+	}
+}
+
+func diffObjects(path string, from, to map[string]interface{}, patch *[]*models.PatchOperation) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path + "/" + pointerEscaper.Replace(key)
+		fromValue, toValue := from[key], to[key]
+		switch {
+		case fromValue == nil && toValue == nil:
+			continue
+		case fromValue == nil:
+			*patch = append(*patch, patchOperation(models.PatchOpAdd, keyPath, toValue))
+		case toValue == nil:
+			*patch = append(*patch, patchOperation(models.PatchOpRemove, keyPath, nil))
+		default:
+			diffValues(keyPath, fromValue, toValue, patch)
+		}
+	}
+}
+
+// Array elements are compared by position, elements are added or removed at the end of the array.
+func diffArrays(path string, from, to []interface{}, patch *[]*models.PatchOperation) {
+	common := intMin(len(from), len(to))
+	for i := 0; i < common; i++ {
+		diffValues(path+"/"+strconv.Itoa(i), from[i], to[i], patch)
+	}
+
+	for i := common; i < len(to); i++ {
+		*patch = append(*patch, patchOperation(models.PatchOpAdd, path+"/"+strconv.Itoa(i), to[i]))
+	}
+
+	// Remove from the back so the indices of the remaining elements don't shift
+	for i := len(from) - 1; i >= common; i-- {
+		*patch = append(*patch, patchOperation(models.PatchOpRemove, path+"/"+strconv.Itoa(i), nil))
+	}
+}
+
+func patchOperation(op models.PatchOp, path string, value interface{}) *models.PatchOperation {
+	return &models.PatchOperation{Op: op, Path: aws.String(path), Value: value}
+}
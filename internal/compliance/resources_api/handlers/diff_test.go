@@ -0,0 +1,102 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+func TestJSONPatchEqual(t *testing.T) {
+	attributes := map[string]interface{}{
+		"Name":   "my-bucket",
+		"Tags":   map[string]interface{}{"Owner": "security"},
+		"Grants": []interface{}{map[string]interface{}{"Permission": "READ"}},
+	}
+	assert.Equal(t, []*models.PatchOperation{}, jsonPatch(attributes, attributes))
+}
+
+func TestJSONPatchObject(t *testing.T) {
+	from := map[string]interface{}{
+		"Encryption": nil,
+		"Name":       "my-bucket",
+		"Policy":     "old",
+		"Tags":       map[string]interface{}{"Owner": "security", "Stage": "prod"},
+		"Versioning": "Enabled",
+	}
+	to := map[string]interface{}{
+		"Encryption": map[string]interface{}{"Algorithm": "AES256"},
+		"Name":       "my-bucket",
+		"Policy":     "new",
+		"Tags":       map[string]interface{}{"Owner": "platform"},
+		"Versioning": nil,
+	}
+
+	expected := []*models.PatchOperation{
+		{Op: models.PatchOpAdd, Path: aws.String("/Encryption"), Value: map[string]interface{}{"Algorithm": "AES256"}},
+		{Op: models.PatchOpReplace, Path: aws.String("/Policy"), Value: "new"},
+		{Op: models.PatchOpReplace, Path: aws.String("/Tags/Owner"), Value: "platform"},
+		{Op: models.PatchOpRemove, Path: aws.String("/Tags/Stage")},
+		{Op: models.PatchOpRemove, Path: aws.String("/Versioning")},
+	}
+	assert.Equal(t, expected, jsonPatch(from, to))
+}
+
+func TestJSONPatchArray(t *testing.T) {
+	from := map[string]interface{}{
+		"Ports":   []interface{}{float64(22), float64(80), float64(443)},
+		"Subnets": []interface{}{"subnet-1"},
+	}
+	to := map[string]interface{}{
+		"Ports":   []interface{}{float64(80)},
+		"Subnets": []interface{}{"subnet-1", "subnet-2"},
+	}
+
+	expected := []*models.PatchOperation{
+		{Op: models.PatchOpReplace, Path: aws.String("/Ports/0"), Value: float64(80)},
+		{Op: models.PatchOpRemove, Path: aws.String("/Ports/2")},
+		{Op: models.PatchOpRemove, Path: aws.String("/Ports/1")},
+		{Op: models.PatchOpAdd, Path: aws.String("/Subnets/1"), Value: "subnet-2"},
+	}
+	assert.Equal(t, expected, jsonPatch(from, to))
+}
+
+func TestJSONPatchTypeChange(t *testing.T) {
+	from := map[string]interface{}{"Policy": map[string]interface{}{"Version": "2012-10-17"}}
+	to := map[string]interface{}{"Policy": "2012-10-17"}
+
+	expected := []*models.PatchOperation{
+		{Op: models.PatchOpReplace, Path: aws.String("/Policy"), Value: "2012-10-17"},
+	}
+	assert.Equal(t, expected, jsonPatch(from, to))
+}
+
+func TestJSONPatchEscapedKeys(t *testing.T) {
+	from := map[string]interface{}{"Tags": map[string]interface{}{"aws:cloudformation/stack~name": "old"}}
+	to := map[string]interface{}{"Tags": map[string]interface{}{"aws:cloudformation/stack~name": "new"}}
+
+	expected := []*models.PatchOperation{
+		{Op: models.PatchOpReplace, Path: aws.String("/Tags/aws:cloudformation~1stack~0name"), Value: "new"},
+	}
+	assert.Equal(t, expected, jsonPatch(from, to))
+}
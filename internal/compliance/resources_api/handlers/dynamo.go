@@ -30,6 +30,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
 )
 
 // The resource struct stored in Dynamo has some different fields compared to the external models.Resource
@@ -41,10 +42,12 @@ type resourceItem struct {
 	IntegrationType models.IntegrationType `json:"integrationType"`
 	LastModified    models.LastModified    `json:"lastModified"`
 	Type            models.ResourceType    `json:"type"`
+	Version         models.Version         `json:"version,omitempty"`
 
-	// Internal fields: TTL and more efficient filtering
-	ExpiresAt int64  `json:"expiresAt,omitempty"`
-	LowerID   string `json:"lowerId"` // lowercase ID for efficient ID substring filtering
+	// Internal fields: TTL, more efficient filtering and change detection
+	AttributesHash string `json:"attributesHash,omitempty"` // detects when a new version has to be recorded
+	ExpiresAt      int64  `json:"expiresAt,omitempty"`
+	LowerID        string `json:"lowerId"` // lowercase ID for efficient ID substring filtering
 }
 
 // Convert dynamo item to external models.Resource
@@ -58,9 +61,43 @@ func (r *resourceItem) Resource(status models.ComplianceStatus) *models.Resource
 		IntegrationType:  r.IntegrationType,
 		LastModified:     r.LastModified,
 		Type:             r.Type,
+		Version:          r.Version,
 	}
 }
 
+// A version of the resource attributes, stored in the versions table whenever the attributes change
+type versionItem struct {
+	Attributes      models.Attributes      `json:"attributes"`
+	ID              models.ResourceID      `json:"id"`
+	IntegrationID   models.IntegrationID   `json:"integrationId"`
+	IntegrationType models.IntegrationType `json:"integrationType"`
+	Type            models.ResourceType    `json:"type"`
+	Version         models.Version         `json:"version"`
+
+	// Internal fields: TTL
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// Convert dynamo item to external models.ResourceVersion
+func (v *versionItem) ResourceVersion() *models.ResourceVersion {
+	return &models.ResourceVersion{
+		Attributes:      v.Attributes,
+		ID:              v.ID,
+		IntegrationID:   v.IntegrationID,
+		IntegrationType: v.IntegrationType,
+		Type:            v.Type,
+		Version:         v.Version,
+	}
+}
+
+// A reference from one resource to another, stored in the relationships table
+type relationshipItem struct {
+	ID           models.ResourceID   `json:"id"`
+	Edge         string              `json:"edge"` // "<relationship>#<relatedId>", IDs can be referenced in several ways
+	RelatedID    models.ResourceID   `json:"relatedId"`
+	Relationship models.Relationship `json:"relationship"`
+}
+
 // Build the table key in the format Dynamo expects
 func tableKey(resourceID models.ResourceID) map[string]*dynamodb.AttributeValue {
 	return map[string]*dynamodb.AttributeValue{
@@ -68,6 +105,22 @@ func tableKey(resourceID models.ResourceID) map[string]*dynamodb.AttributeValue
 	}
 }
 
+// Build the versions table key in the format Dynamo expects
+func versionKey(resourceID models.ResourceID, version models.Version) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"id":      {S: aws.String(string(resourceID))},
+		"version": {S: aws.String(string(version))},
+	}
+}
+
+// Build the relationships table key in the format Dynamo expects
+func relationshipKey(resourceID models.ResourceID, edge string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"id":   {S: aws.String(string(resourceID))},
+		"edge": {S: aws.String(edge)},
+	}
+}
+
 // Build a condition expression if the resource must exist in the table
 func existsCondition(resourceID models.ResourceID) expression.ConditionBuilder {
 	return expression.Name("id").Equal(expression.Value(resourceID))
@@ -141,3 +194,58 @@ func scanPages(input *dynamodb.ScanInput, handler func(*resourceItem) error) err
 
 	return nil
 }
+
+// Look up many resources by ID, optionally retrieving only some of their fields
+//
+// Resources which do not exist are left out of the result.
+func batchGetResources(
+	resourceIDs []models.ResourceID, fields ...string) (map[models.ResourceID]*resourceItem, error) {
+
+	// BatchGetItem rejects duplicate keys
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(resourceIDs))
+	seen := make(map[models.ResourceID]bool, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		if !seen[resourceID] {
+			seen[resourceID] = true
+			keys = append(keys, tableKey(resourceID))
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	request := &dynamodb.KeysAndAttributes{Keys: keys}
+	if len(fields) > 0 {
+		projection := expression.NamesList(expression.Name(fields[0]))
+		for _, field := range fields[1:] {
+			projection = projection.AddNames(expression.Name(field))
+		}
+		expr, err := expression.NewBuilder().WithProjection(projection).Build()
+		if err != nil {
+			zap.L().Error("expr.Build failed", zap.Error(err))
+			return nil, err
+		}
+		request.ExpressionAttributeNames = expr.Names()
+		request.ProjectionExpression = expr.Projection()
+	}
+
+	response, err := dynamodbbatch.BatchGetItem(dynamoClient, &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]*dynamodb.KeysAndAttributes{env.ResourcesTable: request},
+	})
+	if err != nil {
+		zap.L().Error("dynamodbbatch.BatchGetItem failed", zap.Error(err))
+		return nil, err
+	}
+
+	var stored []*resourceItem
+	if err := dynamodbattribute.UnmarshalListOfMaps(response.Responses[env.ResourcesTable], &stored); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+		return nil, err
+	}
+
+	result := make(map[models.ResourceID]*resourceItem, len(stored))
+	for _, item := range stored {
+		result[item.ID] = item
+	}
+	return result, nil
+}
@@ -34,7 +34,7 @@ import (
 
 // GetResource retrieves a single resource from the Dynamo table.
 func GetResource(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
-	resourceID, err := parseGetResource(request)
+	resourceID, err := parseResourceID(request)
 	if err != nil {
 		return badRequest(err)
 	}
@@ -68,7 +68,7 @@ func GetResource(request *events.APIGatewayProxyRequest) *events.APIGatewayProxy
 }
 
 // API gateway doesn't do advanced validation of query parameters, but we can do it here.
-func parseGetResource(request *events.APIGatewayProxyRequest) (resourceID models.ResourceID, err error) {
+func parseResourceID(request *events.APIGatewayProxyRequest) (resourceID models.ResourceID, err error) {
 	escaped, err := url.QueryUnescape(request.QueryStringParameters["resourceId"])
 	if err != nil {
 		err = errors.New("invalid resourceId: " + err.Error())
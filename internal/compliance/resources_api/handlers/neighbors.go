@@ -0,0 +1,187 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-lambda-go/events"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// ListNeighbors lists the resources related to each of the given resources.
+func ListNeighbors(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseListNeighbors(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	edges := make(map[models.ResourceID][]*edge, len(input.ResourceIds))
+	for _, resourceID := range input.ResourceIds {
+		if edges[resourceID], err = relatedEdges(resourceID, input.Relationship, input.Direction); err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	resources, err := lookupNeighbors(edges, input.IncludeAttributes)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	// Some resources are only linked through an identifier which is not tracked as a resource itself:
+	// an instance and its role both reference the same instance profile.
+	viaEdges, err := resolveVia(edges, resources)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	viaResources, err := lookupNeighbors(viaEdges, input.IncludeAttributes)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	for resourceID, related := range viaEdges {
+		edges[resourceID] = append(edges[resourceID], related...)
+	}
+	for resourceID, resource := range viaResources {
+		resources[resourceID] = resource
+	}
+
+	result := &models.NeighborsList{Resources: make([]*models.ResourceNeighbors, len(input.ResourceIds))}
+	for i, resourceID := range input.ResourceIds {
+		result.Resources[i] = &models.ResourceNeighbors{
+			ID:        resourceID,
+			Neighbors: buildNeighbors(resourceID, edges[resourceID], resources, input.IncludeAttributes),
+		}
+	}
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+func parseListNeighbors(request *events.APIGatewayProxyRequest) (*models.NeighborsQuery, error) {
+	var result models.NeighborsQuery
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, result.Validate(nil)
+}
+
+// Convert the relationships of a resource to the neighbors listed for it
+func buildNeighbors(
+	resourceID models.ResourceID,
+	edges []*edge,
+	resources map[models.ResourceID]*resourceItem,
+	includeAttributes bool,
+) []*models.Neighbor {
+
+	var resourceType models.ResourceType
+	if resource := resources[resourceID]; resource != nil {
+		resourceType = resource.Type
+	}
+
+	neighbors := make([]*models.Neighbor, 0, len(edges))
+	for _, e := range edges {
+		resource := resources[e.id]
+		if e.via != "" && (resource == nil || resource.Type == resourceType) {
+			// Resources of the same type are not related just because they share e.g. a role
+			continue
+		}
+
+		neighbor := &models.Neighbor{
+			Direction:    e.direction,
+			ID:           e.id,
+			Relationship: e.relationship,
+			Via:          e.via,
+		}
+		if resource != nil {
+			if resource.Deleted {
+				continue
+			}
+			neighbor.Type = resource.Type
+			if includeAttributes {
+				neighbor.Attributes = resource.Attributes
+			}
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].Relationship != neighbors[j].Relationship {
+			return neighbors[i].Relationship < neighbors[j].Relationship
+		}
+		return neighbors[i].ID < neighbors[j].ID
+	})
+	return neighbors
+}
+
+// Look up the given resources and the resources on the other end of their relationships
+func lookupNeighbors(
+	edges map[models.ResourceID][]*edge, includeAttributes bool) (map[models.ResourceID]*resourceItem, error) {
+
+	var resourceIDs []models.ResourceID
+	for resourceID, related := range edges {
+		resourceIDs = append(resourceIDs, resourceID)
+		for _, e := range related {
+			resourceIDs = append(resourceIDs, e.id)
+		}
+	}
+
+	fields := []string{"deleted", "id", "type"}
+	if includeAttributes {
+		fields = append(fields, "attributes")
+	}
+	return batchGetResources(resourceIDs, fields...)
+}
+
+// Find the resources which reference the same untracked identifiers as the given resources
+func resolveVia(
+	edges map[models.ResourceID][]*edge,
+	resources map[models.ResourceID]*resourceItem,
+) (map[models.ResourceID][]*edge, error) {
+
+	result := make(map[models.ResourceID][]*edge, len(edges))
+	for resourceID, related := range edges {
+		for _, e := range related {
+			if e.direction != models.DirectionOutgoing || !viaRelationships[e.relationship] || resources[e.id] != nil {
+				continue
+			}
+
+			linked, err := queryRelationships(e.id, e.relationship, models.DirectionIncoming)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range linked {
+				if item.ID == resourceID {
+					continue
+				}
+				result[resourceID] = append(result[resourceID], &edge{
+					direction:    e.direction,
+					id:           item.ID,
+					relationship: e.relationship,
+					via:          models.Via(e.id),
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+func TestBuildNeighbors(t *testing.T) {
+	resources := map[models.ResourceID]*resourceItem{
+		exampleInstance: {ID: exampleInstance, Type: "AWS.EC2.Instance"},
+		"arn:aws:ec2:us-west-2:123456789012:instance/i-2": {
+			ID: "arn:aws:ec2:us-west-2:123456789012:instance/i-2", Type: "AWS.EC2.Instance"},
+		"arn:aws:ec2:us-west-2:123456789012:volume/vol-1": {
+			ID: "arn:aws:ec2:us-west-2:123456789012:volume/vol-1", Type: "AWS.EC2.Volume", Deleted: true},
+		exampleRole: {ID: exampleRole, Type: "AWS.IAM.Role", Attributes: map[string]interface{}{"RoleName": "web"}},
+	}
+	edges := []*edge{
+		{direction: models.DirectionOutgoing, id: exampleVpc, relationship: relVpc},
+		{direction: models.DirectionIncoming, id: "arn:aws:ec2:us-west-2:123456789012:volume/vol-1", relationship: relInstance},
+		{direction: models.DirectionOutgoing, id: exampleRole, relationship: relInstanceProfile, via: exampleProfile},
+		// Instances are not related just because they share an instance profile
+		{direction: models.DirectionOutgoing, id: "arn:aws:ec2:us-west-2:123456789012:instance/i-2",
+			relationship: relInstanceProfile, via: exampleProfile},
+		// Only resources which are tracked themselves are linked through an identifier
+		{direction: models.DirectionOutgoing, id: "arn:aws:iam::123456789012:role/untracked",
+			relationship: relInstanceProfile, via: exampleProfile},
+	}
+
+	result := buildNeighbors(exampleInstance, edges, resources, true)
+	require.Len(t, result, 2)
+	assert.Equal(t, &models.Neighbor{
+		Attributes:   map[string]interface{}{"RoleName": "web"},
+		Direction:    models.DirectionOutgoing,
+		ID:           exampleRole,
+		Relationship: relInstanceProfile,
+		Type:         "AWS.IAM.Role",
+		Via:          exampleProfile,
+	}, result[0])
+	// The VPC is not tracked as a resource, it is listed without a type
+	assert.Equal(t, &models.Neighbor{
+		Direction:    models.DirectionOutgoing,
+		ID:           exampleVpc,
+		Relationship: relVpc,
+	}, result[1])
+}
+
+func TestResolveVia(t *testing.T) {
+	client := newRelationshipsClient([]*relationshipItem{
+		exampleRelationship(exampleInstance, relInstanceProfile, exampleProfile),
+		exampleRelationship(exampleRole, relInstanceProfile, exampleProfile),
+		exampleRelationship(exampleRole, relManagedPolicy, "arn:aws:iam::aws:policy/ReadOnlyAccess"),
+	})
+	edges := map[models.ResourceID][]*edge{
+		exampleInstance: {
+			{direction: models.DirectionOutgoing, id: exampleProfile, relationship: relInstanceProfile},
+			{direction: models.DirectionOutgoing, id: exampleVpc, relationship: relVpc},
+		},
+	}
+
+	result, err := resolveVia(edges, map[models.ResourceID]*resourceItem{})
+	require.NoError(t, err)
+	assert.Equal(t, map[models.ResourceID][]*edge{
+		exampleInstance: {
+			{direction: models.DirectionOutgoing, id: exampleRole, relationship: relInstanceProfile, via: exampleProfile},
+		},
+	}, result)
+	assert.Equal(t, 1, client.queries)
+
+	// Identifiers which are tracked as resources are related directly
+	client.queries = 0
+	result, err = resolveVia(edges, map[models.ResourceID]*resourceItem{
+		exampleProfile: {ID: exampleProfile, Type: "AWS.IAM.InstanceProfile"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Equal(t, 0, client.queries)
+}
@@ -0,0 +1,285 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	defaultPathLimit = 100
+
+	// Paths fan out quickly, this bounds the work done for a single query
+	maxPartialPaths = 10000
+)
+
+var errTooManyPaths = fmt.Errorf("query matches more than %d partial paths, add filters to narrow it down", maxPartialPaths)
+
+// A chain of related resources
+type resourcePath []models.ResourceID
+
+// QueryPaths finds chains of related resources.
+func QueryPaths(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseQueryPaths(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	paths, err := startPaths(input.Start)
+	for _, step := range input.Steps {
+		if err != nil || len(paths) == 0 {
+			break
+		}
+		paths, err = extendPaths(paths, step)
+	}
+	if err == errTooManyPaths {
+		return badRequest(err)
+	}
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return pathLess(paths[i], paths[j])
+	})
+
+	limit := int(input.Limit)
+	if limit == 0 {
+		limit = defaultPathLimit
+	}
+	result := &models.PathList{Truncated: aws.Bool(len(paths) > limit)}
+	result.Paths = make([]*models.ResourcePath, 0, intMin(len(paths), limit))
+	for _, path := range paths[:intMin(len(paths), limit)] {
+		result.Paths = append(result.Paths, &models.ResourcePath{ResourceIds: path})
+	}
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+func parseQueryPaths(request *events.APIGatewayProxyRequest) (*models.PathQuery, error) {
+	var result models.PathQuery
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	// Swagger can't express these constraints
+	if len(result.Start.Types) == 0 {
+		return nil, errors.New("start.types cannot be empty")
+	}
+	filters := result.Start.Attributes
+	for _, step := range result.Steps {
+		if step.Node != nil {
+			filters = append(filters, step.Node.Attributes...)
+		}
+	}
+	for _, filter := range filters {
+		if filter.Op != models.FilterOpExists && filter.Value == "" {
+			return nil, fmt.Errorf("attribute filter %s requires a value", *filter.Attribute)
+		}
+	}
+
+	return &result, nil
+}
+
+// Find the resources the paths start from
+func startPaths(start *models.NodeFilter) ([]resourcePath, error) {
+	fields := []string{"id"}
+	if len(start.Attributes) > 0 {
+		fields = append(fields, "attributes")
+	}
+	types := make([]string, len(start.Types))
+	for i, resourceType := range start.Types {
+		types[i] = string(resourceType)
+	}
+
+	scanInput, err := buildListScan(&operations.ListResourcesParams{
+		Deleted: aws.Bool(false),
+		Fields:  fields,
+		Types:   types,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []resourcePath
+	err = scanPages(scanInput, func(item *resourceItem) error {
+		if !matchesAttributes(item.Attributes, start.Attributes) {
+			return nil
+		}
+		if len(result) == maxPartialPaths {
+			return errTooManyPaths
+		}
+		result = append(result, resourcePath{item.ID})
+		return nil
+	})
+	return result, err
+}
+
+// Extend each path by one step, dropping the paths which can't be extended
+func extendPaths(paths []resourcePath, step *models.PathStep) ([]resourcePath, error) {
+	edges := make(map[models.ResourceID][]*edge, len(paths))
+	var candidates []models.ResourceID
+	for _, path := range paths {
+		last := path[len(path)-1]
+		if _, ok := edges[last]; ok {
+			continue
+		}
+
+		related, err := relatedEdges(last, step.Relationship, step.Direction)
+		if err != nil {
+			return nil, err
+		}
+		edges[last] = related
+		for _, e := range related {
+			candidates = append(candidates, e.id)
+		}
+	}
+
+	fields := []string{"deleted", "id", "type"}
+	if step.Node != nil && len(step.Node.Attributes) > 0 {
+		fields = append(fields, "attributes")
+	}
+	resources, err := batchGetResources(candidates, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []resourcePath
+	for _, path := range paths {
+		for _, e := range edges[path[len(path)-1]] {
+			if path.contains(e.id) || !matchesNode(resources[e.id], step.Node) {
+				continue
+			}
+			if len(result) == maxPartialPaths {
+				return nil, errTooManyPaths
+			}
+
+			extended := make(resourcePath, len(path), len(path)+1)
+			copy(extended, path)
+			result = append(result, append(extended, e.id))
+		}
+	}
+	return result, nil
+}
+
+func (p resourcePath) contains(resourceID models.ResourceID) bool {
+	for _, id := range p {
+		if id == resourceID {
+			return true
+		}
+	}
+	return false
+}
+
+func pathLess(left, right resourcePath) bool {
+	for i := 0; i < len(left) && i < len(right); i++ {
+		if left[i] != right[i] {
+			return left[i] < right[i]
+		}
+	}
+	return len(left) < len(right)
+}
+
+// Check whether a resource at some step of a path satisfies the filter for that step
+//
+// Identifiers which are not tracked as resources (e.g. instance profiles) only match steps without a filter.
+func matchesNode(resource *resourceItem, filter *models.NodeFilter) bool {
+	if resource == nil {
+		return filter == nil || (len(filter.Types) == 0 && len(filter.Attributes) == 0)
+	}
+	if resource.Deleted {
+		return false
+	}
+	if filter == nil {
+		return true
+	}
+
+	if len(filter.Types) > 0 {
+		matched := false
+		for _, resourceType := range filter.Types {
+			if resourceType == resource.Type {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return matchesAttributes(resource.Attributes, filter.Attributes)
+}
+
+// Check whether resource attributes satisfy all of the filters
+func matchesAttributes(attributes models.Attributes, filters []*models.AttributeFilter) bool {
+	for _, filter := range filters {
+		if !matchesAttribute(attributes, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check whether resource attributes satisfy a single filter, lists match if any of their elements match
+func matchesAttribute(attributes models.Attributes, filter *models.AttributeFilter) bool {
+	values := attributeValues(attributes, strings.Split(*filter.Attribute, "."))
+	if filter.Op == models.FilterOpExists {
+		return len(values) > 0
+	}
+
+	for _, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			// Compare anything else by its JSON encoding, e.g. true, 443 or {"Key":"Value"}
+			body, err := json.Marshal(value)
+			if err != nil {
+				continue
+			}
+			text = string(body)
+		}
+
+		switch filter.Op {
+		case models.FilterOpEquals:
+			if text == filter.Value {
+				return true
+			}
+		case models.FilterOpContains:
+			if strings.Contains(text, filter.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
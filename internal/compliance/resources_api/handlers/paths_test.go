@@ -0,0 +1,130 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+var exampleAttributes = map[string]interface{}{
+	"PolicyDocument":  `{"Statement":[{"Action":"iam:*","Effect":"Allow"}]}`,
+	"PublicIpAddress": "1.2.3.4",
+	"Monitoring":      map[string]interface{}{"State": "disabled"},
+	"EbsOptimized":    false,
+	"Tags":            []interface{}{map[string]interface{}{"Key": "env", "Value": "prod"}},
+}
+
+func TestMatchesAttribute(t *testing.T) {
+	matches := func(attribute string, op models.FilterOp, value string) bool {
+		return matchesAttribute(exampleAttributes, &models.AttributeFilter{
+			Attribute: aws.String(attribute), Op: op, Value: value})
+	}
+
+	assert.True(t, matches("PublicIpAddress", models.FilterOpExists, ""))
+	assert.False(t, matches("PrivateIpAddress", models.FilterOpExists, ""))
+
+	assert.True(t, matches("Monitoring.State", models.FilterOpEquals, "disabled"))
+	assert.False(t, matches("Monitoring.State", models.FilterOpEquals, "disable"))
+	assert.True(t, matches("EbsOptimized", models.FilterOpEquals, "false"))
+	assert.True(t, matches("Tags.Value", models.FilterOpEquals, "prod"))
+
+	assert.True(t, matches("PolicyDocument", models.FilterOpContains, "iam:*"))
+	assert.True(t, matches("Monitoring", models.FilterOpContains, `"State":"disabled"`))
+	assert.False(t, matches("Tags.Key", models.FilterOpContains, "prod"))
+}
+
+func TestMatchesNode(t *testing.T) {
+	resource := &resourceItem{ID: "arn:aws:iam::123456789012:role/web", Type: "AWS.IAM.Role", Attributes: exampleAttributes}
+	typeFilter := &models.NodeFilter{Types: []models.ResourceType{"AWS.IAM.Role"}}
+	attributeFilter := &models.NodeFilter{Attributes: []*models.AttributeFilter{
+		{Attribute: aws.String("PublicIpAddress"), Op: models.FilterOpEquals, Value: "1.2.3.4"},
+	}}
+
+	assert.True(t, matchesNode(resource, nil))
+	assert.True(t, matchesNode(resource, typeFilter))
+	assert.True(t, matchesNode(resource, attributeFilter))
+	assert.False(t, matchesNode(resource, &models.NodeFilter{Types: []models.ResourceType{"AWS.IAM.User"}}))
+
+	// Untracked identifiers only match steps without a filter
+	assert.True(t, matchesNode(nil, nil))
+	assert.True(t, matchesNode(nil, &models.NodeFilter{}))
+	assert.False(t, matchesNode(nil, typeFilter))
+
+	resource.Deleted = true
+	assert.False(t, matchesNode(resource, nil))
+}
+
+func TestPathLess(t *testing.T) {
+	assert.True(t, pathLess(resourcePath{"a", "b"}, resourcePath{"a", "c"}))
+	assert.True(t, pathLess(resourcePath{"a"}, resourcePath{"a", "b"}))
+	assert.False(t, pathLess(resourcePath{"b"}, resourcePath{"a", "b"}))
+}
+
+func TestExtendPaths(t *testing.T) {
+	client := newRelationshipsClient(
+		[]*relationshipItem{
+			exampleRelationship(exampleInstance, relInstanceProfile, exampleProfile),
+			exampleRelationship(exampleInstance, relVpc, exampleVpc),
+			exampleRelationship(exampleRole, relInstanceProfile, exampleProfile),
+		},
+		&resourceItem{ID: exampleRole, Type: "AWS.IAM.Role"},
+	)
+	paths := []resourcePath{{exampleInstance, exampleProfile}, {exampleRole, exampleProfile}}
+
+	// Paths don't visit a resource twice, and both paths share the lookup of the profile
+	result, err := extendPaths(paths, &models.PathStep{Direction: models.DirectionIncoming})
+	require.NoError(t, err)
+	assert.Equal(t, []resourcePath{
+		{exampleInstance, exampleProfile, exampleRole},
+		{exampleRole, exampleProfile, exampleInstance},
+	}, result)
+	assert.Equal(t, 1, client.queries)
+
+	// Untracked resources don't match a filter
+	result, err = extendPaths(paths, &models.PathStep{
+		Direction: models.DirectionIncoming,
+		Node:      &models.NodeFilter{Types: []models.ResourceType{"AWS.IAM.Role"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []resourcePath{{exampleInstance, exampleProfile, exampleRole}}, result)
+}
+
+func TestExtendPathsTooManyPaths(t *testing.T) {
+	newRelationshipsClient([]*relationshipItem{exampleRelationship(exampleRole, relInstanceProfile, exampleProfile)})
+
+	paths := make([]resourcePath, maxPartialPaths)
+	for i := range paths {
+		paths[i] = resourcePath{models.ResourceID(fmt.Sprintf("instance-%d", i)), exampleRole}
+	}
+	step := &models.PathStep{Direction: models.DirectionOutgoing}
+
+	result, err := extendPaths(paths, step)
+	require.NoError(t, err)
+	assert.Len(t, result, maxPartialPaths)
+
+	_, err = extendPaths(append(paths, resourcePath{"instance", exampleRole}), step)
+	assert.Equal(t, errTooManyPaths, err)
+}
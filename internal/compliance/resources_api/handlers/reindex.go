@@ -0,0 +1,81 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// Resources scanned per reindex request, small enough to finish well within the API Gateway timeout
+const reindexPageSize = 100
+
+// ReindexRelationships indexes the relationships of a page of resources.
+//
+// This backfills the resources which were stored before their relationships were indexed,
+// the caller passes the lastEvaluatedKey back until there are no more pages.
+func ReindexRelationships(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	exclusiveStartKey, err := url.QueryUnescape(request.QueryStringParameters["exclusiveStartKey"])
+	if err != nil {
+		return badRequest(errors.New("invalid exclusiveStartKey: " + err.Error()))
+	}
+
+	scanInput, err := buildListScan(&operations.ListResourcesParams{
+		Deleted: aws.Bool(false),
+		Fields:  []string{"attributes", "id", "type"},
+	})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	scanInput.Limit = aws.Int64(reindexPageSize)
+	if exclusiveStartKey != "" {
+		scanInput.ExclusiveStartKey = tableKey(models.ResourceID(exclusiveStartKey))
+	}
+
+	output, err := dynamoClient.Scan(scanInput)
+	if err != nil {
+		zap.L().Error("dynamoClient.Scan failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	var items []*resourceItem
+	if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &items); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if err := indexRelationships(items); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := &models.ReindexResult{ResourceCount: aws.Int64(int64(len(items)))}
+	if lastKey := output.LastEvaluatedKey["id"]; lastKey != nil {
+		result.LastEvaluatedKey = models.ResourceID(aws.StringValue(lastKey.S))
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+func TestReindexRelationships(t *testing.T) {
+	client := newRelationshipsClient(nil)
+	role, err := dynamodbattribute.MarshalMap(&resourceItem{
+		ID:         exampleRole,
+		Type:       "AWS.IAM.Role",
+		Attributes: map[string]interface{}{"InstanceProfileArns": []interface{}{exampleProfile}},
+	})
+	require.NoError(t, err)
+	client.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{role},
+		LastEvaluatedKey: tableKey(exampleRole),
+	}, nil).Once()
+
+	response := ReindexRelationships(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"exclusiveStartKey": exampleInstance},
+	})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var result models.ReindexResult
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Equal(t, models.ReindexResult{ResourceCount: aws.Int64(1), LastEvaluatedKey: exampleRole}, result)
+
+	scanInput := client.Calls[0].Arguments.Get(0).(*dynamodb.ScanInput)
+	assert.Equal(t, tableKey(exampleInstance), scanInput.ExclusiveStartKey)
+	_, put := client.writtenEdges(t)
+	assert.Equal(t, []string{exampleRole + " instanceProfile#" + exampleProfile}, put)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,442 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+// Incoming relationships are looked up by the ID of the referenced resource
+const relatedIDIndex = "relatedId-index"
+
+// Relationship names, from the point of view of the referencing resource
+const (
+	relBucket           models.Relationship = "bucket"
+	relCluster          models.Relationship = "cluster"
+	relExecutionRole    models.Relationship = "executionRole"
+	relGroup            models.Relationship = "group"
+	relInstance         models.Relationship = "instance"
+	relInstanceProfile  models.Relationship = "instanceProfile"
+	relKmsKey           models.Relationship = "kmsKey"
+	relLogsRole         models.Relationship = "cloudWatchLogsRole"
+	relManagedPolicy    models.Relationship = "managedPolicy"
+	relRole             models.Relationship = "role"
+	relSecurityGroup    models.Relationship = "securityGroup"
+	relSnsTopic         models.Relationship = "snsTopic"
+	relSubnet           models.Relationship = "subnet"
+	relTaskDefinition   models.Relationship = "taskDefinition"
+	relTaskRole         models.Relationship = "taskRole"
+	relVolume           models.Relationship = "volume"
+	relVpc              models.Relationship = "vpc"
+	ec2SecurityGroupARN                     = "security-group"
+)
+
+// Identifiers which are not polled as resources themselves, but link the resources referencing them.
+// Subnets are not included: resources are not related just because they share a subnet.
+var viaRelationships = map[models.Relationship]bool{
+	relInstanceProfile: true,
+}
+
+// Each resource type references other resources in its own attributes
+var referenceExtractors = map[models.ResourceType]func(*references){
+	awsmodels.CloudTrailSchema: func(r *references) {
+		r.arns(relLogsRole, "CloudWatchLogsRoleArn")
+		r.arns(relKmsKey, "KmsKeyId")
+		r.arns(relSnsTopic, "SnsTopicARN")
+		for _, bucket := range r.values("S3BucketName") {
+			r.add(relBucket, arn.ARN{Partition: r.partition, Service: "s3", Resource: bucket}.String())
+		}
+	},
+	awsmodels.Ec2InstanceSchema: func(r *references) {
+		r.arns(relInstanceProfile, "IamInstanceProfile.Arn")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "SecurityGroups.GroupId")
+		r.ec2(relSubnet, "subnet", "SubnetId")
+		r.ec2(relVolume, "volume", "BlockDeviceMappings.Ebs.VolumeId")
+		r.ec2(relVpc, "vpc", "VpcId")
+	},
+	awsmodels.Ec2NetworkAclSchema: func(r *references) {
+		r.ec2(relVpc, "vpc", "VpcId")
+	},
+	awsmodels.Ec2SecurityGroupSchema: func(r *references) {
+		r.ec2(relVpc, "vpc", "VpcId")
+	},
+	awsmodels.Ec2VolumeSchema: func(r *references) {
+		r.ec2(relInstance, "instance", "Attachments.InstanceId")
+		r.arns(relKmsKey, "KmsKeyId")
+	},
+	awsmodels.EcsServiceSchema: func(r *references) {
+		r.arns(relCluster, "ClusterArn")
+		r.arns(relRole, "RoleArn")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "NetworkConfiguration.AwsvpcConfiguration.SecurityGroups")
+		r.ec2(relSubnet, "subnet", "NetworkConfiguration.AwsvpcConfiguration.Subnets")
+		// Services run a revision of a task definition, task definitions are tracked by family
+		for _, revision := range r.values("TaskDefinition") {
+			if idx := strings.LastIndex(revision, ":"); idx > 0 && strings.HasPrefix(revision, "arn:") {
+				r.add(relTaskDefinition, revision[:idx])
+			}
+		}
+	},
+	awsmodels.EcsTaskDefinitionSchema: func(r *references) {
+		r.arns(relExecutionRole, "ExecutionRoleArn")
+		r.arns(relTaskRole, "TaskRoleArn")
+	},
+	awsmodels.EksClusterSchema: func(r *references) {
+		r.arns(relRole, "RoleArn")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "ResourcesVpcConfig.SecurityGroupIds")
+		r.ec2(relSubnet, "subnet", "ResourcesVpcConfig.SubnetIds")
+		r.ec2(relVpc, "vpc", "ResourcesVpcConfig.VpcId")
+	},
+	awsmodels.ElbLoadBalancerSchema: func(r *references) {
+		r.ec2(relInstance, "instance", "Instances.InstanceId")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "SecurityGroups")
+		r.ec2(relSubnet, "subnet", "Subnets")
+		r.ec2(relVpc, "vpc", "VPCId")
+	},
+	awsmodels.Elbv2LoadBalancerSchema: func(r *references) {
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "SecurityGroups")
+		r.ec2(relSubnet, "subnet", "AvailabilityZones.SubnetId")
+		r.ec2(relVpc, "vpc", "VpcId")
+	},
+	awsmodels.Elbv2NetworkLoadBalancerSchema: func(r *references) {
+		r.ec2(relSubnet, "subnet", "AvailabilityZones.SubnetId")
+		r.ec2(relVpc, "vpc", "VpcId")
+	},
+	awsmodels.IAMGroupSchema: func(r *references) {
+		r.arns(relManagedPolicy, "ManagedPolicyARNs")
+	},
+	awsmodels.IAMRoleSchema: func(r *references) {
+		r.arns(relInstanceProfile, "InstanceProfileArns")
+		r.arns(relManagedPolicy, "ManagedPolicyARNs")
+	},
+	awsmodels.IAMUserSchema: func(r *references) {
+		r.arns(relGroup, "Groups.Arn")
+		r.arns(relManagedPolicy, "ManagedPolicyARNs")
+	},
+	awsmodels.LambdaFunctionSchema: func(r *references) {
+		r.arns(relKmsKey, "KMSKeyArn")
+		r.arns(relRole, "Role")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "VpcConfig.SecurityGroupIds")
+		r.ec2(relSubnet, "subnet", "VpcConfig.SubnetIds")
+		r.ec2(relVpc, "vpc", "VpcConfig.VpcId")
+	},
+	awsmodels.RDSInstanceSchema: func(r *references) {
+		r.arns(relKmsKey, "KmsKeyId")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "VpcSecurityGroups.VpcSecurityGroupId")
+		r.ec2(relSubnet, "subnet", "DBSubnetGroup.Subnets.SubnetIdentifier")
+		r.ec2(relVpc, "vpc", "DBSubnetGroup.VpcId")
+	},
+	awsmodels.RedshiftClusterSchema: func(r *references) {
+		r.arns(relKmsKey, "KmsKeyId")
+		r.ec2(relSecurityGroup, ec2SecurityGroupARN, "VpcSecurityGroups.VpcSecurityGroupId")
+		r.ec2(relVpc, "vpc", "VpcId")
+	},
+}
+
+// Collects the references from one resource to others
+//
+// Related resources referenced by a plain ID (e.g. a security group ID) are assumed to live in the
+// same partition, account and region as the referencing resource.
+type references struct {
+	accountID  string
+	attributes models.Attributes
+	id         models.ResourceID
+	partition  string
+	region     string
+	result     map[string]*relationshipItem
+}
+
+// Reference a related resource, which is usually identified by its ARN
+func (r *references) add(relationship models.Relationship, relatedID string) {
+	if relatedID == "" || models.ResourceID(relatedID) == r.id {
+		return
+	}
+
+	edge := string(relationship) + "#" + relatedID
+	r.result[edge] = &relationshipItem{
+		ID:           r.id,
+		Edge:         edge,
+		RelatedID:    models.ResourceID(relatedID),
+		Relationship: relationship,
+	}
+}
+
+// Find the string values of an attribute
+func (r *references) values(attribute string) []string {
+	var result []string
+	for _, value := range attributeValues(r.attributes, strings.Split(attribute, ".")) {
+		if s, ok := value.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Reference the ARNs in an attribute
+func (r *references) arns(relationship models.Relationship, attribute string) {
+	for _, value := range r.values(attribute) {
+		// Some attributes can hold either an ARN or a plain ID or alias, only ARNs are unambiguous
+		if strings.HasPrefix(value, "arn:") {
+			r.add(relationship, value)
+		}
+	}
+}
+
+// Reference the EC2 IDs in an attribute by the ARNs the EC2 pollers identify them with
+//
+// Format: arn:aws:ec2:region:account-id:resourceType/id
+func (r *references) ec2(relationship models.Relationship, resourceType string, attribute string) {
+	for _, value := range r.values(attribute) {
+		r.add(relationship, arn.ARN{
+			Partition: r.partition,
+			Service:   "ec2",
+			Region:    r.region,
+			AccountID: r.accountID,
+			Resource:  resourceType + "/" + value,
+		}.String())
+	}
+}
+
+// Find the values at an attribute path, descending into every element of the lists along the way
+func attributeValues(value interface{}, path []string) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		var result []interface{}
+		for _, element := range list {
+			result = append(result, attributeValues(element, path)...)
+		}
+		return result
+	}
+
+	if len(path) == 0 {
+		if value == nil {
+			return nil
+		}
+		return []interface{}{value}
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return attributeValues(object[path[0]], path[1:])
+}
+
+// Find the references from a resource to others, keyed by edge
+func extractRelationships(item *resourceItem) map[string]*relationshipItem {
+	r := &references{
+		attributes: item.Attributes,
+		id:         item.ID,
+		partition:  "aws",
+		result:     make(map[string]*relationshipItem),
+	}
+
+	extract, ok := referenceExtractors[item.Type]
+	if !ok {
+		return r.result
+	}
+
+	if parsed, err := arn.Parse(string(item.ID)); err == nil {
+		r.partition = parsed.Partition
+	}
+	if values := r.values("AccountId"); len(values) > 0 {
+		r.accountID = values[0]
+	}
+	if values := r.values("Region"); len(values) > 0 {
+		r.region = values[0]
+	}
+
+	extract(r)
+	return r.result
+}
+
+// Index the references of resources, replacing what was indexed for them before.
+//
+// Only the differences are written, so a resource whose references did not change costs a single query.
+func indexRelationships(items []*resourceItem) error {
+	var writeRequests []*dynamodb.WriteRequest
+	for _, item := range items {
+		indexed, err := queryRelationships(item.ID, "", models.DirectionOutgoing)
+		if err != nil {
+			return err
+		}
+
+		current := extractRelationships(item)
+		for _, relationship := range indexed {
+			if current[relationship.Edge] != nil {
+				delete(current, relationship.Edge) // already indexed
+				continue
+			}
+			writeRequests = append(writeRequests, &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: relationshipKey(relationship.ID, relationship.Edge)},
+			})
+		}
+
+		for _, relationship := range current {
+			marshalled, err := dynamodbattribute.MarshalMap(relationship)
+			if err != nil {
+				zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+				return err
+			}
+			writeRequests = append(writeRequests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: marshalled}})
+		}
+	}
+
+	return writeRelationships(writeRequests)
+}
+
+// Remove the references of deleted resources.
+//
+// References to deleted resources are kept, they are filtered out when they are looked up.
+func removeRelationships(resourceIDs []models.ResourceID) error {
+	var writeRequests []*dynamodb.WriteRequest
+	for _, resourceID := range resourceIDs {
+		indexed, err := queryRelationships(resourceID, "", models.DirectionOutgoing)
+		if err != nil {
+			return err
+		}
+
+		for _, relationship := range indexed {
+			writeRequests = append(writeRequests, &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: relationshipKey(relationship.ID, relationship.Edge)},
+			})
+		}
+	}
+
+	return writeRelationships(writeRequests)
+}
+
+func writeRelationships(writeRequests []*dynamodb.WriteRequest) error {
+	if len(writeRequests) == 0 {
+		return nil
+	}
+
+	zap.L().Info("updating resource relationships", zap.Int("itemCount", len(writeRequests)))
+	dynamoInput := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{env.ResourceRelationshipsTable: writeRequests},
+	}
+	if err := dynamodbbatch.BatchWriteItem(dynamoClient, maxBackoff, dynamoInput); err != nil {
+		zap.L().Error("dynamodbbatch.BatchWriteItem failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Query the outgoing or incoming relationships of a resource, optionally of a single kind
+func queryRelationships(
+	resourceID models.ResourceID,
+	relationship models.Relationship,
+	direction models.Direction,
+) ([]*relationshipItem, error) {
+
+	builder := expression.NewBuilder()
+	input := &dynamodb.QueryInput{TableName: &env.ResourceRelationshipsTable}
+	if direction == models.DirectionIncoming {
+		input.IndexName = aws.String(relatedIDIndex)
+		builder = builder.WithKeyCondition(expression.Key("relatedId").Equal(expression.Value(resourceID)))
+		if relationship != "" {
+			builder = builder.WithFilter(expression.Name("relationship").Equal(expression.Value(relationship)))
+		}
+	} else {
+		keyCondition := expression.Key("id").Equal(expression.Value(resourceID))
+		if relationship != "" {
+			keyCondition = keyCondition.And(expression.Key("edge").BeginsWith(string(relationship) + "#"))
+		}
+		builder = builder.WithKeyCondition(keyCondition)
+	}
+
+	expr, err := builder.Build()
+	if err != nil {
+		zap.L().Error("expr.Build failed", zap.Error(err))
+		return nil, err
+	}
+	input.ExpressionAttributeNames = expr.Names()
+	input.ExpressionAttributeValues = expr.Values()
+	input.FilterExpression = expr.Filter()
+	input.KeyConditionExpression = expr.KeyCondition()
+
+	var result []*relationshipItem
+	var unmarshalErr error
+	err = dynamoClient.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*relationshipItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false // stop paginating
+		}
+		result = append(result, items...)
+		return true
+	})
+
+	if unmarshalErr != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(unmarshalErr))
+		return nil, unmarshalErr
+	}
+	if err != nil {
+		zap.L().Error("dynamoClient.QueryPages failed", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// A relationship as seen from one of the resources involved
+type edge struct {
+	direction    models.Direction
+	id           models.ResourceID // the resource on the other end
+	relationship models.Relationship
+	via          models.Via
+}
+
+// Find the relationships of a resource in the given direction, both directions if none is given
+func relatedEdges(
+	resourceID models.ResourceID,
+	relationship models.Relationship,
+	direction models.Direction,
+) ([]*edge, error) {
+
+	var result []*edge
+	if direction != models.DirectionIncoming {
+		items, err := queryRelationships(resourceID, relationship, models.DirectionOutgoing)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			result = append(result, &edge{direction: models.DirectionOutgoing, id: item.RelatedID, relationship: item.Relationship})
+		}
+	}
+
+	if direction != models.DirectionOutgoing {
+		items, err := queryRelationships(resourceID, relationship, models.DirectionIncoming)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			result = append(result, &edge{direction: models.DirectionIncoming, id: item.ID, relationship: item.Relationship})
+		}
+	}
+
+	return result, nil
+}
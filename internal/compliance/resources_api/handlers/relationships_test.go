@@ -0,0 +1,316 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	equalsCondition     = regexp.MustCompile(`(#\d+) = (:\d+)`)
+	beginsWithCondition = regexp.MustCompile(`begins_with \((#\d+), (:\d+)\)`)
+)
+
+// Serves relationship queries and resource lookups from memory and records the relationship writes
+type relationshipsClient struct {
+	testutils.DynamoDBMock
+	relationships []*relationshipItem
+	resources     []*resourceItem
+	writes        []*dynamodb.WriteRequest
+	queries       int
+}
+
+func newRelationshipsClient(relationships []*relationshipItem, resources ...*resourceItem) *relationshipsClient {
+	client := &relationshipsClient{relationships: relationships, resources: resources}
+	dynamoClient = client
+	return client
+}
+
+func (c *relationshipsClient) QueryPages(
+	input *dynamodb.QueryInput, handler func(*dynamodb.QueryOutput, bool) bool) error {
+
+	c.queries++
+	conditions := aws.StringValue(input.KeyConditionExpression) + " AND " + aws.StringValue(input.FilterExpression)
+	value := func(name, placeholder string) (string, string) {
+		return aws.StringValue(input.ExpressionAttributeNames[name]), aws.StringValue(input.ExpressionAttributeValues[placeholder].S)
+	}
+
+	var page dynamodb.QueryOutput
+	for _, item := range c.relationships {
+		fields := map[string]string{
+			"edge":         item.Edge,
+			"id":           string(item.ID),
+			"relatedId":    string(item.RelatedID),
+			"relationship": string(item.Relationship),
+		}
+		matches := true
+		for _, match := range equalsCondition.FindAllStringSubmatch(conditions, -1) {
+			field, expected := value(match[1], match[2])
+			matches = matches && fields[field] == expected
+		}
+		for _, match := range beginsWithCondition.FindAllStringSubmatch(conditions, -1) {
+			field, prefix := value(match[1], match[2])
+			matches = matches && strings.HasPrefix(fields[field], prefix)
+		}
+		if matches {
+			marshalled, err := dynamodbattribute.MarshalMap(item)
+			if err != nil {
+				return err
+			}
+			page.Items = append(page.Items, marshalled)
+		}
+	}
+
+	handler(&page, true)
+	return nil
+}
+
+func (c *relationshipsClient) BatchGetItemPages(
+	input *dynamodb.BatchGetItemInput, handler func(*dynamodb.BatchGetItemOutput, bool) bool) error {
+
+	var items []map[string]*dynamodb.AttributeValue
+	for _, key := range input.RequestItems[env.ResourcesTable].Keys {
+		for _, resource := range c.resources {
+			if string(resource.ID) == aws.StringValue(key["id"].S) {
+				marshalled, err := dynamodbattribute.MarshalMap(resource)
+				if err != nil {
+					return err
+				}
+				items = append(items, marshalled)
+			}
+		}
+	}
+
+	handler(&dynamodb.BatchGetItemOutput{
+		Responses: map[string][]map[string]*dynamodb.AttributeValue{env.ResourcesTable: items},
+	}, true)
+	return nil
+}
+
+func (c *relationshipsClient) BatchWriteItem(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
+	c.writes = append(c.writes, input.RequestItems[env.ResourceRelationshipsTable]...)
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+// The edges deleted and the edges put by the recorded writes
+func (c *relationshipsClient) writtenEdges(t *testing.T) (deleted []string, put []string) {
+	for _, request := range c.writes {
+		if request.DeleteRequest != nil {
+			deleted = append(deleted, aws.StringValue(request.DeleteRequest.Key["id"].S)+" "+
+				aws.StringValue(request.DeleteRequest.Key["edge"].S))
+			continue
+		}
+		var item relationshipItem
+		require.NoError(t, dynamodbattribute.UnmarshalMap(request.PutRequest.Item, &item))
+		put = append(put, string(item.ID)+" "+item.Edge)
+	}
+	return deleted, put
+}
+
+func TestAttributeValues(t *testing.T) {
+	attributes := map[string]interface{}{
+		"SecurityGroups": []interface{}{
+			map[string]interface{}{"GroupId": "sg-1"},
+			map[string]interface{}{"GroupId": "sg-2"},
+			map[string]interface{}{"GroupName": "no-id"},
+		},
+		"VpcId": "vpc-1",
+		"Empty": nil,
+	}
+
+	assert.Equal(t, []interface{}{"sg-1", "sg-2"}, attributeValues(attributes, []string{"SecurityGroups", "GroupId"}))
+	assert.Equal(t, []interface{}{"vpc-1"}, attributeValues(attributes, []string{"VpcId"}))
+	assert.Nil(t, attributeValues(attributes, []string{"Empty"}))
+	assert.Nil(t, attributeValues(attributes, []string{"VpcId", "Nested"}))
+	assert.Nil(t, attributeValues(attributes, []string{"Missing"}))
+}
+
+func TestExtractRelationshipsEc2Instance(t *testing.T) {
+	item := &resourceItem{
+		ID:   "arn:aws:ec2:us-west-2:123456789012:instance/i-1",
+		Type: "AWS.EC2.Instance",
+		Attributes: map[string]interface{}{
+			"AccountId": "123456789012",
+			"BlockDeviceMappings": []interface{}{
+				map[string]interface{}{"Ebs": map[string]interface{}{"VolumeId": "vol-1"}},
+			},
+			"IamInstanceProfile": map[string]interface{}{"Arn": "arn:aws:iam::123456789012:instance-profile/web"},
+			"Region":             "us-west-2",
+			"SecurityGroups":     []interface{}{map[string]interface{}{"GroupId": "sg-1"}},
+			"SubnetId":           "subnet-1",
+			"VpcId":              "vpc-1",
+		},
+	}
+
+	result := extractRelationships(item)
+	expected := map[string]models.Relationship{
+		"instanceProfile#arn:aws:iam::123456789012:instance-profile/web":       "instanceProfile",
+		"securityGroup#arn:aws:ec2:us-west-2:123456789012:security-group/sg-1": "securityGroup",
+		"subnet#arn:aws:ec2:us-west-2:123456789012:subnet/subnet-1":            "subnet",
+		"volume#arn:aws:ec2:us-west-2:123456789012:volume/vol-1":               "volume",
+		"vpc#arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1":                     "vpc",
+	}
+	assert.Len(t, result, len(expected))
+	for edge, relationship := range expected {
+		if assert.Contains(t, result, edge) {
+			assert.Equal(t, relationship, result[edge].Relationship)
+			assert.Equal(t, item.ID, result[edge].ID)
+		}
+	}
+	assert.Equal(t, models.ResourceID("arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1"),
+		result["vpc#arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1"].RelatedID)
+}
+
+func TestExtractRelationshipsIgnoresNonArns(t *testing.T) {
+	item := &resourceItem{
+		ID:   "arn:aws:lambda:us-west-2:123456789012:function:example",
+		Type: "AWS.Lambda.Function",
+		Attributes: map[string]interface{}{
+			"AccountId": "123456789012",
+			"KMSKeyArn": "alias/aws/lambda",
+			"Region":    "us-west-2",
+			"Role":      "arn:aws:iam::123456789012:role/example",
+		},
+	}
+
+	result := extractRelationships(item)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, "role#arn:aws:iam::123456789012:role/example")
+}
+
+func TestExtractRelationshipsEcsServiceTaskDefinitionFamily(t *testing.T) {
+	item := &resourceItem{
+		ID:   "arn:aws:ecs:us-west-2:123456789012:service/example-cluster/example",
+		Type: "AWS.ECS.Service",
+		Attributes: map[string]interface{}{
+			"AccountId":      "123456789012",
+			"ClusterArn":     "arn:aws:ecs:us-west-2:123456789012:cluster/example-cluster",
+			"Region":         "us-west-2",
+			"TaskDefinition": "arn:aws:ecs:us-west-2:123456789012:task-definition/example:3",
+		},
+	}
+
+	result := extractRelationships(item)
+	assert.Len(t, result, 2)
+	assert.Contains(t, result, "cluster#arn:aws:ecs:us-west-2:123456789012:cluster/example-cluster")
+	assert.Contains(t, result, "taskDefinition#arn:aws:ecs:us-west-2:123456789012:task-definition/example")
+}
+
+func TestExtractRelationshipsUnknownType(t *testing.T) {
+	item := &resourceItem{
+		ID:         "arn:aws:s3:::example-bucket",
+		Type:       "AWS.S3.Bucket",
+		Attributes: map[string]interface{}{"Name": "example-bucket"},
+	}
+
+	assert.Empty(t, extractRelationships(item))
+}
+
+const (
+	exampleInstance = "arn:aws:ec2:us-west-2:123456789012:instance/i-1"
+	exampleProfile  = "arn:aws:iam::123456789012:instance-profile/web"
+	exampleRole     = "arn:aws:iam::123456789012:role/web"
+	exampleVpc      = "arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1"
+)
+
+func exampleRelationship(id models.ResourceID, relationship models.Relationship, relatedID models.ResourceID) *relationshipItem {
+	return &relationshipItem{
+		ID:           id,
+		Edge:         string(relationship) + "#" + string(relatedID),
+		RelatedID:    relatedID,
+		Relationship: relationship,
+	}
+}
+
+func TestIndexRelationships(t *testing.T) {
+	client := newRelationshipsClient([]*relationshipItem{
+		exampleRelationship(exampleInstance, relVpc, exampleVpc),
+		exampleRelationship(exampleInstance, relSubnet, "arn:aws:ec2:us-west-2:123456789012:subnet/subnet-old"),
+		// Incoming relationships of the indexed resource are not replaced
+		exampleRelationship("arn:aws:ec2:us-west-2:123456789012:volume/vol-1", relInstance, exampleInstance),
+	})
+
+	err := indexRelationships([]*resourceItem{
+		{
+			ID:   exampleInstance,
+			Type: "AWS.EC2.Instance",
+			Attributes: map[string]interface{}{
+				"AccountId": "123456789012",
+				"Region":    "us-west-2",
+				"SubnetId":  "subnet-new",
+				"VpcId":     "vpc-1",
+			},
+		},
+		{
+			ID:         exampleRole,
+			Type:       "AWS.IAM.Role",
+			Attributes: map[string]interface{}{"InstanceProfileArns": []interface{}{exampleProfile}},
+		},
+	})
+	require.NoError(t, err)
+
+	deleted, put := client.writtenEdges(t)
+	assert.Equal(t, []string{exampleInstance + " subnet#arn:aws:ec2:us-west-2:123456789012:subnet/subnet-old"}, deleted)
+	assert.ElementsMatch(t, []string{
+		exampleInstance + " subnet#arn:aws:ec2:us-west-2:123456789012:subnet/subnet-new",
+		exampleRole + " instanceProfile#" + exampleProfile,
+	}, put)
+}
+
+func TestIndexRelationshipsUnchanged(t *testing.T) {
+	client := newRelationshipsClient([]*relationshipItem{exampleRelationship(exampleInstance, relVpc, exampleVpc)})
+
+	err := indexRelationships([]*resourceItem{{
+		ID:         exampleInstance,
+		Type:       "AWS.EC2.Instance",
+		Attributes: map[string]interface{}{"AccountId": "123456789012", "Region": "us-west-2", "VpcId": "vpc-1"},
+	}})
+	require.NoError(t, err)
+	assert.Empty(t, client.writes)
+	assert.Equal(t, 1, client.queries)
+}
+
+func TestRemoveRelationships(t *testing.T) {
+	client := newRelationshipsClient([]*relationshipItem{
+		exampleRelationship(exampleInstance, relVpc, exampleVpc),
+		exampleRelationship(exampleInstance, relInstanceProfile, exampleProfile),
+		exampleRelationship("arn:aws:ec2:us-west-2:123456789012:volume/vol-1", relInstance, exampleInstance),
+		exampleRelationship(exampleRole, relInstanceProfile, exampleProfile),
+	})
+
+	require.NoError(t, removeRelationships([]models.ResourceID{exampleInstance}))
+
+	deleted, put := client.writtenEdges(t)
+	assert.ElementsMatch(t, []string{
+		exampleInstance + " vpc#" + exampleVpc,
+		exampleInstance + " instanceProfile#" + exampleProfile,
+	}, deleted)
+	assert.Empty(t, put)
+}
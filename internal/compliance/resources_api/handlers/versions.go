@@ -0,0 +1,316 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	// Versions are identified by the time they were first recorded, in a fixed-width format so they sort in order
+	versionFormat = "2006-01-02T15:04:05.000000000Z"
+
+	defaultVersionPageSize = 25
+	maxVersionPageSize     = 100
+)
+
+// ListResourceVersions returns the recorded versions of a resource, newest first.
+func ListResourceVersions(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	resourceID, pageSize, exclusiveStartKey, err := parseListResourceVersions(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	keyCondition := expression.Key("id").Equal(expression.Value(resourceID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		zap.L().Error("expr.Build failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	input := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ScanIndexForward:          aws.Bool(false), // newest first
+		TableName:                 &env.ResourceVersionsTable,
+	}
+	if exclusiveStartKey != "" {
+		input.ExclusiveStartKey = versionKey(resourceID, exclusiveStartKey)
+	}
+
+	result := &models.ResourceVersionList{Versions: make([]*models.ResourceVersion, 0, pageSize)}
+	for {
+		// Large versions may not all fit in a single page
+		input.Limit = aws.Int64(pageSize - int64(len(result.Versions)))
+		output, err := dynamoClient.Query(input)
+		if err != nil {
+			zap.L().Error("dynamoClient.Query failed", zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+
+		var items []*versionItem
+		if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &items); err != nil {
+			zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		for _, item := range items {
+			result.Versions = append(result.Versions, item.ResourceVersion())
+		}
+
+		if len(output.LastEvaluatedKey) == 0 {
+			break
+		}
+		if int64(len(result.Versions)) >= pageSize {
+			// More versions may follow, the next page starts after the last version returned
+			result.LastEvaluatedKey = result.Versions[len(result.Versions)-1].Version
+			break
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+func parseListResourceVersions(request *events.APIGatewayProxyRequest) (models.ResourceID, int64, models.Version, error) {
+	resourceID, err := parseResourceID(request)
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	pageSize := int64(defaultVersionPageSize)
+	if rawPageSize := request.QueryStringParameters["pageSize"]; rawPageSize != "" {
+		pageSize, err = strconv.ParseInt(rawPageSize, 10, 64)
+		if err != nil {
+			return "", 0, "", errors.New("invalid pageSize: " + err.Error())
+		}
+		if pageSize < 1 || pageSize > maxVersionPageSize {
+			return "", 0, "", errors.New("invalid pageSize: must be between 1 and " + strconv.Itoa(maxVersionPageSize))
+		}
+	}
+
+	exclusiveStartKey, err := parseVersion(request, "exclusiveStartKey")
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	return resourceID, pageSize, exclusiveStartKey, nil
+}
+
+// DiffResourceVersions compares two versions of a resource as a JSON patch.
+func DiffResourceVersions(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	resourceID, fromVersion, toVersion, err := parseDiffResourceVersions(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if toVersion == "" {
+		// Compare to the current version of the resource
+		response, err := dynamoClient.GetItem(&dynamodb.GetItemInput{
+			Key:       tableKey(resourceID),
+			TableName: &env.ResourcesTable,
+		})
+		if err != nil {
+			zap.L().Error("dynamoClient.GetItem failed", zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+
+		var item resourceItem
+		if err := dynamodbattribute.UnmarshalMap(response.Item, &item); err != nil {
+			zap.L().Error("dynamodbattribute.UnmarshalMap failed", zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if item.Version == "" {
+			zap.L().Debug("resource has no recorded version", zap.String("resourceID", string(resourceID)))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+		}
+		toVersion = item.Version
+	}
+
+	from, err := getVersion(resourceID, fromVersion)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	to, err := getVersion(resourceID, toVersion)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if from == nil || to == nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+
+	return gatewayapi.MarshalResponse(&models.ResourceVersionDiff{
+		FromVersion: fromVersion,
+		ID:          resourceID,
+		Patch:       jsonPatch(from.Attributes, to.Attributes),
+		ToVersion:   toVersion,
+	}, http.StatusOK)
+}
+
+func parseDiffResourceVersions(
+	request *events.APIGatewayProxyRequest) (resourceID models.ResourceID, from, to models.Version, err error) {
+
+	if resourceID, err = parseResourceID(request); err != nil {
+		return
+	}
+	if from, err = parseVersion(request, "fromVersion"); err != nil {
+		return
+	}
+	if from == "" {
+		err = errors.New("invalid fromVersion: fromVersion in query is required")
+		return
+	}
+	to, err = parseVersion(request, "toVersion")
+	return
+}
+
+func parseVersion(request *events.APIGatewayProxyRequest, name string) (models.Version, error) {
+	escaped, err := url.QueryUnescape(request.QueryStringParameters[name])
+	if err != nil {
+		return "", errors.New("invalid " + name + ": " + err.Error())
+	}
+	if escaped == "" {
+		return "", nil
+	}
+
+	version := models.Version(escaped)
+	if err = version.Validate(nil); err != nil {
+		return "", errors.New("invalid " + name + ": " + err.Error())
+	}
+	return version, nil
+}
+
+// Get a single version of a resource, returns nil if it does not exist
+func getVersion(resourceID models.ResourceID, version models.Version) (*versionItem, error) {
+	response, err := dynamoClient.GetItem(&dynamodb.GetItemInput{
+		Key:       versionKey(resourceID, version),
+		TableName: &env.ResourceVersionsTable,
+	})
+	if err != nil {
+		zap.L().Error("dynamoClient.GetItem failed", zap.Error(err))
+		return nil, err
+	}
+
+	if len(response.Item) == 0 {
+		zap.L().Debug("could not find resource version",
+			zap.String("resourceID", string(resourceID)), zap.String("version", string(version)))
+		return nil, nil
+	}
+
+	var item versionItem
+	if err := dynamodbattribute.UnmarshalMap(response.Item, &item); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalMap failed", zap.Error(err))
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Assign a version to each resource, recording a new version for the resources whose attributes changed.
+func recordVersions(items []*resourceItem, now time.Time) error {
+	current, err := currentVersions(items)
+	if err != nil {
+		return err
+	}
+
+	version := models.Version(now.UTC().Format(versionFormat))
+	expiresAt := now.Add(versionLifetime).Unix()
+	var writeRequests []*dynamodb.WriteRequest
+	for _, item := range items {
+		if item.AttributesHash, err = attributesHash(item.Attributes); err != nil {
+			zap.L().Error("failed to hash resource attributes", zap.Error(err))
+			return err
+		}
+
+		if previous := current[item.ID]; previous != nil && previous.Version != "" &&
+			previous.AttributesHash == item.AttributesHash {
+
+			// Nothing changed since the current version was recorded
+			item.Version = previous.Version
+			continue
+		}
+
+		item.Version = version
+		marshalled, err := dynamodbattribute.MarshalMap(&versionItem{
+			Attributes:      item.Attributes,
+			ID:              item.ID,
+			IntegrationID:   item.IntegrationID,
+			IntegrationType: item.IntegrationType,
+			Type:            item.Type,
+			Version:         version,
+			ExpiresAt:       expiresAt,
+		})
+		if err != nil {
+			zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+			return err
+		}
+		writeRequests = append(writeRequests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: marshalled}})
+	}
+
+	if len(writeRequests) == 0 {
+		return nil
+	}
+
+	zap.L().Info("recording new resource versions", zap.Int("versionCount", len(writeRequests)))
+	dynamoInput := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{env.ResourceVersionsTable: writeRequests},
+	}
+	if err := dynamodbbatch.BatchWriteItem(dynamoClient, maxBackoff, dynamoInput); err != nil {
+		zap.L().Error("dynamodbbatch.BatchWriteItem failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Look up the stored version of each resource, so we know which resources have changed
+func currentVersions(items []*resourceItem) (map[models.ResourceID]*resourceItem, error) {
+	resourceIDs := make([]models.ResourceID, len(items))
+	for i, item := range items {
+		resourceIDs[i] = item.ID
+	}
+	return batchGetResources(resourceIDs, "attributesHash", "id", "version")
+}
+
+// Hash resource attributes to cheaply detect when they change
+func attributesHash(attributes models.Attributes) (string, error) {
+	// encoding/json sorts map keys, so equal attributes always have the same encoding
+	body, err := json.Marshal(attributes)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,121 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestAttributesHash(t *testing.T) {
+	first, err := attributesHash(map[string]interface{}{"Name": "my-bucket", "Region": "us-west-2"})
+	assert.NoError(t, err)
+	second, err := attributesHash(map[string]interface{}{"Region": "us-west-2", "Name": "my-bucket"})
+	assert.NoError(t, err)
+	changed, err := attributesHash(map[string]interface{}{"Name": "my-bucket", "Region": "us-east-1"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, changed)
+}
+
+func versionQueryItem(version string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"id":      {S: aws.String("arn:aws:s3:::my-bucket")},
+		"type":    {S: aws.String("AWS.S3.Bucket")},
+		"version": {S: aws.String(version)},
+	}
+}
+
+func listVersionsRequest(parameters map[string]string) *events.APIGatewayProxyRequest {
+	parameters["resourceId"] = "arn:aws:s3:::my-bucket"
+	return &events.APIGatewayProxyRequest{QueryStringParameters: parameters}
+}
+
+func TestListResourceVersionsNextPage(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = mockClient
+	mockClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			versionQueryItem("2020-01-02T00:00:00.000000000Z"),
+			versionQueryItem("2020-01-01T00:00:00.000000000Z"),
+		},
+		LastEvaluatedKey: versionQueryItem("2020-01-01T00:00:00.000000000Z"),
+	}, nil).Once()
+
+	response := ListResourceVersions(listVersionsRequest(map[string]string{"pageSize": "2"}))
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var result models.ResourceVersionList
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Len(t, result.Versions, 2)
+	assert.Equal(t, models.Version("2020-01-01T00:00:00.000000000Z"), result.LastEvaluatedKey)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListResourceVersionsExclusiveStartKey(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = mockClient
+	var inputs []dynamodb.QueryInput
+	mockClient.On("Query", mock.Anything).Run(func(args mock.Arguments) {
+		inputs = append(inputs, *args.Get(0).(*dynamodb.QueryInput))
+	}).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{versionQueryItem("2019-12-31T00:00:00.000000000Z")},
+		LastEvaluatedKey: versionQueryItem("2019-12-31T00:00:00.000000000Z"),
+	}, nil).Once()
+	mockClient.On("Query", mock.Anything).Run(func(args mock.Arguments) {
+		inputs = append(inputs, *args.Get(0).(*dynamodb.QueryInput))
+	}).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{versionQueryItem("2019-12-30T00:00:00.000000000Z")},
+	}, nil).Once()
+
+	response := ListResourceVersions(listVersionsRequest(map[string]string{
+		"exclusiveStartKey": "2020-01-01T00:00:00.000000000Z",
+		"pageSize":          "5",
+	}))
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var result models.ResourceVersionList
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Len(t, result.Versions, 2)
+	assert.Empty(t, result.LastEvaluatedKey)
+
+	// The first query starts after the given version, the second continues where Dynamo stopped
+	require.Len(t, inputs, 2)
+	assert.Equal(t, "2020-01-01T00:00:00.000000000Z", *inputs[0].ExclusiveStartKey["version"].S)
+	assert.Equal(t, int64(5), *inputs[0].Limit)
+	assert.Equal(t, "2019-12-31T00:00:00.000000000Z", *inputs[1].ExclusiveStartKey["version"].S)
+	assert.Equal(t, int64(4), *inputs[1].Limit)
+}
+
+func TestListResourceVersionsInvalidExclusiveStartKey(t *testing.T) {
+	response := ListResourceVersions(listVersionsRequest(map[string]string{"exclusiveStartKey": "%zz"}))
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
@@ -97,6 +97,8 @@ func TestIntegrationAPI(t *testing.T) {
 
 	// Reset Dynamo tables and build API client
 	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-resources"))
+	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-resource-versions"))
+	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-resource-relationships"))
 	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-compliance"))
 	require.NotEmpty(t, endpoint)
 	apiClient = client.NewHTTPClientWithConfig(nil, client.DefaultTransportConfig().
@@ -118,6 +120,12 @@ func TestIntegrationAPI(t *testing.T) {
 		return
 	}
 
+	t.Run("ResourceVersions", func(t *testing.T) {
+		t.Run("ListVersions", listVersions)
+		t.Run("DiffVersions", diffVersions)
+		t.Run("DiffNotFound", diffNotFound)
+	})
+
 	t.Run("OrgOverview", func(t *testing.T) {
 		t.Run("OrgOverview", orgOverview)
 	})
@@ -256,10 +264,114 @@ func getSuccess(t *testing.T) {
 	require.NotNil(t, result)
 
 	require.NoError(t, result.Payload.Validate(nil))
+	require.NotEmpty(t, result.Payload.Version)
 	bucket.LastModified = result.Payload.LastModified
+	bucket.Version = result.Payload.Version
 	require.Equal(t, bucket, result.Payload)
 }
 
+func listVersions(t *testing.T) {
+	result, err := apiClient.Operations.ListResourceVersions(
+		&operations.ListResourceVersionsParams{
+			ResourceID: string(bucket.ID),
+			HTTPClient: httpClient,
+		})
+	require.NoError(t, err)
+	require.NoError(t, result.Payload.Validate(nil))
+
+	expected := &models.ResourceVersionList{
+		Versions: []*models.ResourceVersion{
+			{
+				Attributes:      bucket.Attributes,
+				ID:              bucket.ID,
+				IntegrationID:   bucket.IntegrationID,
+				IntegrationType: bucket.IntegrationType,
+				Type:            bucket.Type,
+				Version:         bucket.Version,
+			},
+		},
+	}
+	assert.Equal(t, expected, result.Payload)
+}
+
+func diffVersions(t *testing.T) {
+	// Adding the same attributes again does not record a new version
+	addBucket(t, bucket.Attributes)
+	versions, err := apiClient.Operations.ListResourceVersions(
+		&operations.ListResourceVersionsParams{
+			ResourceID: string(bucket.ID),
+			HTTPClient: httpClient,
+		})
+	require.NoError(t, err)
+	require.Len(t, versions.Payload.Versions, 1)
+
+	// Changing the attributes does
+	firstVersion := bucket.Version
+	bucket.Attributes = map[string]interface{}{"Panther": "Labs", "Versioning": "Enabled"}
+	addBucket(t, bucket.Attributes)
+	versions, err = apiClient.Operations.ListResourceVersions(
+		&operations.ListResourceVersionsParams{
+			ResourceID: string(bucket.ID),
+			HTTPClient: httpClient,
+		})
+	require.NoError(t, err)
+	require.Len(t, versions.Payload.Versions, 2)
+	assert.Equal(t, firstVersion, versions.Payload.Versions[1].Version)
+	bucket.Version = versions.Payload.Versions[0].Version
+
+	// The diff defaults to the current version
+	result, err := apiClient.Operations.DiffResourceVersions(
+		&operations.DiffResourceVersionsParams{
+			FromVersion: string(firstVersion),
+			ResourceID:  string(bucket.ID),
+			HTTPClient:  httpClient,
+		})
+	require.NoError(t, err)
+	require.NoError(t, result.Payload.Validate(nil))
+
+	expected := &models.ResourceVersionDiff{
+		FromVersion: firstVersion,
+		ID:          bucket.ID,
+		Patch: []*models.PatchOperation{
+			{Op: models.PatchOpAdd, Path: aws.String("/Versioning"), Value: "Enabled"},
+		},
+		ToVersion: bucket.Version,
+	}
+	assert.Equal(t, expected, result.Payload)
+}
+
+func diffNotFound(t *testing.T) {
+	result, err := apiClient.Operations.DiffResourceVersions(
+		&operations.DiffResourceVersionsParams{
+			FromVersion: "2019-01-01T00:00:00.000000000Z",
+			ResourceID:  string(bucket.ID),
+			HTTPClient:  httpClient,
+		})
+	assert.Nil(t, result)
+	require.Error(t, err)
+	require.IsType(t, &operations.DiffResourceVersionsNotFound{}, err)
+}
+
+func addBucket(t *testing.T, attributes interface{}) {
+	result, err := apiClient.Operations.AddResources(
+		&operations.AddResourcesParams{
+			Body: &models.AddResources{
+				Resources: []*models.AddResourceEntry{
+					{
+						Attributes:      attributes,
+						ID:              bucket.ID,
+						IntegrationID:   bucket.IntegrationID,
+						IntegrationType: bucket.IntegrationType,
+						Type:            bucket.Type,
+					},
+				},
+			},
+			HTTPClient: httpClient,
+		})
+	require.NoError(t, err)
+	require.Equal(t, &operations.AddResourcesCreated{}, result)
+}
+
 func listAll(t *testing.T) {
 	result, err := apiClient.Operations.ListResources(
 		&operations.ListResourcesParams{
@@ -27,10 +27,15 @@ import (
 
 var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"POST /delete":      handlers.DeleteResources,
+	"GET /diff":         handlers.DiffResourceVersions,
 	"GET /list":         handlers.ListResources,
+	"POST /neighbors":   handlers.ListNeighbors,
 	"GET /org-overview": handlers.OrgOverview,
+	"POST /paths":       handlers.QueryPaths,
 	"GET /resource":     handlers.GetResource,
+	"POST /reindex":     handlers.ReindexRelationships,
 	"POST /resource":    handlers.AddResources,
+	"GET /versions":     handlers.ListResourceVersions,
 }
 
 func main() {
@@ -0,0 +1,47 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "github.com/aws/aws-sdk-go/service/elbv2"
+
+const (
+	Elbv2NetworkLoadBalancerSchema = "AWS.ELBV2.NetworkLoadBalancer"
+)
+
+// Elbv2NetworkLoadBalancer contains all information about a network load balancer
+type Elbv2NetworkLoadBalancer struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from elbv2.LoadBalancer
+	AvailabilityZones     []*elbv2.AvailabilityZone
+	CanonicalHostedZoneId *string
+	DNSName               *string
+	IpAddressType         *string
+	Scheme                *string
+	State                 *elbv2.LoadBalancerState
+	Type                  *string
+	VpcId                 *string
+
+	// Additional fields
+	Attributes  map[string]*string
+	Listeners   []*elbv2.Listener
+	SSLPolicies map[string]*elbv2.SslPolicy
+}
@@ -39,6 +39,8 @@ type IAMRole struct {
 	PermissionsBoundary      *iam.AttachedPermissionsBoundary
 
 	// Additional fields
-	InlinePolicies     map[string]*string
-	ManagedPolicyNames []*string
+	InlinePolicies      map[string]*string
+	InstanceProfileArns []*string
+	ManagedPolicyARNs   []*string
+	ManagedPolicyNames  []*string
 }
@@ -46,6 +46,7 @@ type IAMUser struct {
 	CredentialReport   *IAMCredentialReport
 	Groups             []*iam.Group
 	InlinePolicies     map[string]*string
+	ManagedPolicyARNs  []*string
 	ManagedPolicyNames []*string
 	VirtualMFA         *VirtualMFADevice
 }
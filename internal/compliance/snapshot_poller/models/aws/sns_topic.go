@@ -0,0 +1,46 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "github.com/aws/aws-sdk-go/service/sns"
+
+const (
+	SnsTopicSchema = "AWS.SNS.Topic"
+)
+
+// SnsTopic contains all the information about an SNS topic
+type SnsTopic struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields parsed from the topic attributes
+	DeliveryPolicy          *string
+	DisplayName             *string
+	EffectiveDeliveryPolicy *string
+	KmsMasterKeyId          *string
+	Owner                   *string
+	Policy                  *string
+	SubscriptionsConfirmed  *int64
+	SubscriptionsDeleted    *int64
+	SubscriptionsPending    *int64
+
+	// Additional fields
+	Subscriptions []*sns.Subscription
+}
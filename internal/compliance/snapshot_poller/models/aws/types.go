@@ -54,6 +54,9 @@ type GenericAWSResource struct {
 	AccountID *string `json:"AccountId"` // The ID of the AWS Account the resource resides in
 	Region    *string `json:"Region"`    // The region the resource exists in, value of GLOBAL_REGION if global
 
+	// The organizational unit path of the account, only set for accounts onboarded through an organization
+	OrgUnitPath *string `json:"OrgUnitPath,omitempty"`
+
 	// Fields that can generally be populated while building the snapshot
 	ARN  *string            `json:"Arn,omitempty"`  // The Amazon Resource Name (ARN)
 	ID   *string            `json:"Id,omitempty"`   // The AWS resource identifier
@@ -61,12 +64,20 @@ type GenericAWSResource struct {
 	Tags map[string]*string // A standardized format for key/value resource tags
 }
 
+// SetOrgUnitPath records the organizational unit path of the account the resource resides in.
+func (r *GenericAWSResource) SetOrgUnitPath(orgUnitPath *string) {
+	r.OrgUnitPath = orgUnitPath
+}
+
 // ResourcePollerInput contains the metadata to request AWS resource info.
 type ResourcePollerInput struct {
 	AuthSource          *string
 	AuthSourceParsedARN arn.ARN
+	ContinuationMarker  *string
 	IntegrationID       *string
+	OrgUnitPath         *string
 	Regions             []*string
+	RequeueAttempts     int
 	Timestamp           *strfmt.DateTime
 }
 
@@ -34,4 +34,12 @@ type ScanEntry struct {
 	ResourceID       *string `json:"resourceId"`
 	ResourceType     *string `json:"resourceType"`
 	ScanAllResources *bool   `json:"scanAllResources"`
+	// The organizational unit of the account, if it was onboarded through an organization
+	OrgUnitPath *string `json:"orgUnitPath,omitempty"`
+	// Where a resource type scan resumes after it was cut short by throttling
+	ContinuationMarker *string `json:"continuationMarker,omitempty"`
+	// How many times in a row the scan was requeued without making progress
+	RequeueAttempts int `json:"requeueAttempts,omitempty"`
+	// The regions an account wide scan of a global resource type is limited to, every enabled region if empty
+	ScanRegions []*string `json:"scanRegions,omitempty"`
 }
@@ -0,0 +1,249 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const restAPIResourcePrefix = "/restapis/"
+
+// Set as variables to be overridden in testing
+var APIGatewayClientFunc = setupAPIGatewayClient
+
+func setupAPIGatewayClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigateway.New(sess, cfg)
+}
+
+func getAPIGatewayClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayiface.APIGatewayAPI, error) {
+
+	client, err := getClient(pollerResourceInput, APIGatewayClientFunc, "apigateway", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(apigatewayiface.APIGatewayAPI), nil
+}
+
+// restAPIARN builds the ARN of a REST API, API Gateway ARNs do not include the account ID
+//
+// Format: arn:aws:apigateway:region::/restapis/id
+func restAPIARN(region string, restAPIID *string) *string {
+	return aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "apigateway",
+		Region:    region,
+		Resource:  restAPIResourcePrefix + aws.StringValue(restAPIID),
+	}.String())
+}
+
+// PollAPIGatewayRestAPI polls a single API Gateway REST API resource
+func PollAPIGatewayRestAPI(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getAPIGatewayClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	restAPI, err := getRestAPI(client, aws.String(strings.TrimPrefix(resourceARN.Resource, restAPIResourcePrefix)))
+	if err != nil || restAPI == nil {
+		return nil, err
+	}
+
+	snapshot := buildAPIGatewayRestAPISnapshot(client, restAPI, resourceARN.Region)
+	if snapshot == nil {
+		return nil, nil
+	}
+	// The account ID is not part of API Gateway ARNs
+	snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listRestAPIs returns all REST APIs in the account
+func listRestAPIs(apigatewaySvc apigatewayiface.APIGatewayAPI) (restAPIs []*apigateway.RestApi) {
+	err := apigatewaySvc.GetRestApisPages(&apigateway.GetRestApisInput{},
+		func(page *apigateway.GetRestApisOutput, lastPage bool) bool {
+			restAPIs = append(restAPIs, page.Items...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("APIGateway.GetRestApisPages", err)
+	}
+	return
+}
+
+// getRestAPI returns a single REST API
+func getRestAPI(apigatewaySvc apigatewayiface.APIGatewayAPI, restAPIID *string) (*apigateway.RestApi, error) {
+	out, err := apigatewaySvc.GetRestApi(&apigateway.GetRestApiInput{RestApiId: restAPIID})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == apigateway.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *restAPIID),
+				zap.String("resourceType", awsmodels.APIGatewayRestAPISchema))
+			return nil, nil
+		}
+		utils.LogAWSError("APIGateway.GetRestApi", err)
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// getRestAPIAuthorizers returns all the authorizers of a REST API
+func getRestAPIAuthorizers(
+	apigatewaySvc apigatewayiface.APIGatewayAPI, restAPIID *string) ([]*apigateway.Authorizer, error) {
+
+	// GetAuthorizers does not have a version with builtin paging
+	var authorizers []*apigateway.Authorizer
+	input := &apigateway.GetAuthorizersInput{RestApiId: restAPIID}
+	for {
+		out, err := apigatewaySvc.GetAuthorizers(input)
+		if err != nil {
+			utils.LogAWSError("APIGateway.GetAuthorizers", err)
+			return nil, err
+		}
+		authorizers = append(authorizers, out.Items...)
+		if out.Position == nil {
+			return authorizers, nil
+		}
+		input.Position = out.Position
+	}
+}
+
+// getRestAPIStages returns all the stages of a REST API, which hold the logging and WAF configuration
+func getRestAPIStages(apigatewaySvc apigatewayiface.APIGatewayAPI, restAPIID *string) ([]*apigateway.Stage, error) {
+	out, err := apigatewaySvc.GetStages(&apigateway.GetStagesInput{RestApiId: restAPIID})
+	if err != nil {
+		utils.LogAWSError("APIGateway.GetStages", err)
+		return nil, err
+	}
+
+	return out.Item, nil
+}
+
+// buildAPIGatewayRestAPISnapshot makes all the calls to build up a snapshot of a given REST API
+func buildAPIGatewayRestAPISnapshot(
+	apigatewaySvc apigatewayiface.APIGatewayAPI, restAPI *apigateway.RestApi, region string) *awsmodels.APIGatewayRestAPI {
+
+	if restAPI == nil {
+		return nil
+	}
+
+	resourceARN := restAPIARN(region, restAPI.Id)
+	snapshot := &awsmodels.APIGatewayRestAPI{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   resourceARN,
+			ResourceType: aws.String(awsmodels.APIGatewayRestAPISchema),
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(restAPI.CreatedDate)),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  resourceARN,
+			ID:   restAPI.Id,
+			Name: restAPI.Name,
+			Tags: restAPI.Tags,
+		},
+		ApiKeySource:           restAPI.ApiKeySource,
+		BinaryMediaTypes:       restAPI.BinaryMediaTypes,
+		Description:            restAPI.Description,
+		EndpointConfiguration:  restAPI.EndpointConfiguration,
+		MinimumCompressionSize: restAPI.MinimumCompressionSize,
+		Policy:                 restAPI.Policy,
+		Version:                restAPI.Version,
+		Warnings:               restAPI.Warnings,
+	}
+
+	var err error
+	if snapshot.Authorizers, err = getRestAPIAuthorizers(apigatewaySvc, restAPI.Id); err != nil {
+		return nil
+	}
+	if snapshot.Stages, err = getRestAPIStages(apigatewaySvc, restAPI.Id); err != nil {
+		return nil
+	}
+
+	return snapshot
+}
+
+// PollAPIGatewayRestAPIs gathers information on each API Gateway REST API for an AWS account.
+func PollAPIGatewayRestAPIs(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting API Gateway REST API resource poller")
+	restAPISnapshots := make(map[string]*awsmodels.APIGatewayRestAPI)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "apigateway") {
+		apigatewaySvc, err := getAPIGatewayClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all REST APIs
+		restAPIs := listRestAPIs(apigatewaySvc)
+		if len(restAPIs) == 0 {
+			zap.L().Debug("no API Gateway REST APIs found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, restAPI := range restAPIs {
+			restAPISnapshot := buildAPIGatewayRestAPISnapshot(apigatewaySvc, restAPI, *regionID)
+			if restAPISnapshot == nil {
+				continue
+			}
+			restAPISnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			restAPISnapshot.Region = regionID
+
+			if _, ok := restAPISnapshots[*restAPISnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing API Gateway REST API snapshot",
+					zap.String("resourceId", *restAPISnapshot.ARN),
+				)
+			}
+			restAPISnapshots[*restAPISnapshot.ARN] = restAPISnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(restAPISnapshots))
+	for resourceID, restAPISnapshot := range restAPISnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      restAPISnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.APIGatewayRestAPISchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,132 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestAPIGatewayRestAPIList(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvc([]string{"GetRestApisPages"})
+
+	out := listRestAPIs(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestAPIGatewayRestAPIListError(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvcError([]string{"GetRestApisPages"})
+
+	out := listRestAPIs(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayRestAPIGet(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvc([]string{"GetRestApi"})
+
+	out, err := getRestAPI(mockSvc, awstest.ExampleRestAPIID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestAPIGatewayRestAPIGetDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockAPIGateway{}
+	mockSvc.On("GetRestApi", mock.Anything).
+		Return(
+			&apigateway.RestApi{},
+			awserr.New(apigateway.ErrCodeNotFoundException, "rest api does not exist", nil),
+		)
+
+	out, err := getRestAPI(mockSvc, awstest.ExampleRestAPIID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayRestAPIGetError(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvcError([]string{"GetRestApi"})
+
+	out, err := getRestAPI(mockSvc, awstest.ExampleRestAPIID)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayRestAPIBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvcAll()
+
+	restAPISnapshot := buildAPIGatewayRestAPISnapshot(mockSvc, awstest.ExampleRestAPI, "us-west-2")
+
+	require.NotNil(t, restAPISnapshot)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/abcdef1234", *restAPISnapshot.ARN)
+	assert.Len(t, restAPISnapshot.Authorizers, 1)
+	assert.Len(t, restAPISnapshot.Stages, 1)
+	assert.Equal(t, "Value1", *restAPISnapshot.Tags["Key1"])
+}
+
+func TestAPIGatewayRestAPIBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvcAllError()
+
+	restAPISnapshot := buildAPIGatewayRestAPISnapshot(mockSvc, awstest.ExampleRestAPI, "us-west-2")
+
+	assert.Nil(t, restAPISnapshot)
+}
+
+func TestAPIGatewayRestAPIPoller(t *testing.T) {
+	awstest.MockAPIGatewayForSetup = awstest.BuildMockAPIGatewaySvcAll()
+
+	APIGatewayClientFunc = awstest.SetupMockAPIGateway
+
+	resources, err := PollAPIGatewayRestAPIs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Contains(t, string(resources[0].ID), "::/restapis/abcdef1234")
+}
+
+func TestAPIGatewayRestAPIPollerError(t *testing.T) {
+	awstest.MockAPIGatewayForSetup = awstest.BuildMockAPIGatewaySvcAllError()
+
+	APIGatewayClientFunc = awstest.SetupMockAPIGateway
+
+	resources, err := PollAPIGatewayRestAPIs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
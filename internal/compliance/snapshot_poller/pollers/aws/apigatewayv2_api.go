@@ -0,0 +1,280 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const v2APIResourcePrefix = "/apis/"
+
+// Set as variables to be overridden in testing
+var APIGatewayV2ClientFunc = setupAPIGatewayV2Client
+
+func setupAPIGatewayV2Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigatewayv2.New(sess, cfg)
+}
+
+func getAPIGatewayV2Client(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayv2iface.ApiGatewayV2API, error) {
+
+	client, err := getClient(pollerResourceInput, APIGatewayV2ClientFunc, "apigatewayv2", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(apigatewayv2iface.ApiGatewayV2API), nil
+}
+
+// v2APIARN builds the ARN of an HTTP or WebSocket API, API Gateway ARNs do not include the account ID
+//
+// Format: arn:aws:apigateway:region::/apis/id
+func v2APIARN(region string, apiID *string) *string {
+	return aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "apigateway",
+		Region:    region,
+		Resource:  v2APIResourcePrefix + aws.StringValue(apiID),
+	}.String())
+}
+
+// PollAPIGatewayV2API polls a single API Gateway HTTP or WebSocket API resource
+func PollAPIGatewayV2API(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getAPIGatewayV2Client(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := getV2API(client, aws.String(strings.TrimPrefix(resourceARN.Resource, v2APIResourcePrefix)))
+	if err != nil || api == nil {
+		return nil, err
+	}
+
+	snapshot := buildAPIGatewayV2APISnapshot(client, api, resourceARN.Region)
+	if snapshot == nil {
+		return nil, nil
+	}
+	// The account ID is not part of API Gateway ARNs
+	snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listV2APIs returns all HTTP and WebSocket APIs in the account
+func listV2APIs(apigatewaySvc apigatewayv2iface.ApiGatewayV2API) []*apigatewayv2.Api {
+	// GetApis does not have a version with builtin paging
+	var apis []*apigatewayv2.Api
+	input := &apigatewayv2.GetApisInput{}
+	for {
+		out, err := apigatewaySvc.GetApis(input)
+		if err != nil {
+			utils.LogAWSError("ApiGatewayV2.GetApis", err)
+			return apis
+		}
+		apis = append(apis, out.Items...)
+		if out.NextToken == nil {
+			return apis
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// getV2API returns a single HTTP or WebSocket API
+func getV2API(apigatewaySvc apigatewayv2iface.ApiGatewayV2API, apiID *string) (*apigatewayv2.Api, error) {
+	out, err := apigatewaySvc.GetApi(&apigatewayv2.GetApiInput{ApiId: apiID})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == apigatewayv2.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *apiID),
+				zap.String("resourceType", awsmodels.APIGatewayV2APISchema))
+			return nil, nil
+		}
+		utils.LogAWSError("ApiGatewayV2.GetApi", err)
+		return nil, err
+	}
+
+	// GetApi returns the same fields as an item of GetApis
+	return &apigatewayv2.Api{
+		ApiEndpoint:               out.ApiEndpoint,
+		ApiId:                     out.ApiId,
+		ApiKeySelectionExpression: out.ApiKeySelectionExpression,
+		CorsConfiguration:         out.CorsConfiguration,
+		CreatedDate:               out.CreatedDate,
+		Description:               out.Description,
+		DisableSchemaValidation:   out.DisableSchemaValidation,
+		ImportInfo:                out.ImportInfo,
+		Name:                      out.Name,
+		ProtocolType:              out.ProtocolType,
+		RouteSelectionExpression:  out.RouteSelectionExpression,
+		Tags:                      out.Tags,
+		Version:                   out.Version,
+		Warnings:                  out.Warnings,
+	}, nil
+}
+
+// getV2APIAuthorizers returns all the authorizers of an HTTP or WebSocket API
+func getV2APIAuthorizers(
+	apigatewaySvc apigatewayv2iface.ApiGatewayV2API, apiID *string) ([]*apigatewayv2.Authorizer, error) {
+
+	var authorizers []*apigatewayv2.Authorizer
+	input := &apigatewayv2.GetAuthorizersInput{ApiId: apiID}
+	for {
+		out, err := apigatewaySvc.GetAuthorizers(input)
+		if err != nil {
+			utils.LogAWSError("ApiGatewayV2.GetAuthorizers", err)
+			return nil, err
+		}
+		authorizers = append(authorizers, out.Items...)
+		if out.NextToken == nil {
+			return authorizers, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// getV2APIStages returns all the stages of an HTTP or WebSocket API, which hold the logging configuration
+func getV2APIStages(apigatewaySvc apigatewayv2iface.ApiGatewayV2API, apiID *string) ([]*apigatewayv2.Stage, error) {
+	var stages []*apigatewayv2.Stage
+	input := &apigatewayv2.GetStagesInput{ApiId: apiID}
+	for {
+		out, err := apigatewaySvc.GetStages(input)
+		if err != nil {
+			utils.LogAWSError("ApiGatewayV2.GetStages", err)
+			return nil, err
+		}
+		stages = append(stages, out.Items...)
+		if out.NextToken == nil {
+			return stages, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// buildAPIGatewayV2APISnapshot makes all the calls to build up a snapshot of a given HTTP or WebSocket API
+func buildAPIGatewayV2APISnapshot(
+	apigatewaySvc apigatewayv2iface.ApiGatewayV2API, api *apigatewayv2.Api, region string) *awsmodels.APIGatewayV2API {
+
+	if api == nil {
+		return nil
+	}
+
+	resourceARN := v2APIARN(region, api.ApiId)
+	snapshot := &awsmodels.APIGatewayV2API{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   resourceARN,
+			ResourceType: aws.String(awsmodels.APIGatewayV2APISchema),
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(api.CreatedDate)),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  resourceARN,
+			ID:   api.ApiId,
+			Name: api.Name,
+			Tags: api.Tags,
+		},
+		ApiEndpoint:               api.ApiEndpoint,
+		ApiKeySelectionExpression: api.ApiKeySelectionExpression,
+		CorsConfiguration:         api.CorsConfiguration,
+		Description:               api.Description,
+		DisableSchemaValidation:   api.DisableSchemaValidation,
+		ImportInfo:                api.ImportInfo,
+		ProtocolType:              api.ProtocolType,
+		RouteSelectionExpression:  api.RouteSelectionExpression,
+		Version:                   api.Version,
+		Warnings:                  api.Warnings,
+	}
+
+	var err error
+	if snapshot.Authorizers, err = getV2APIAuthorizers(apigatewaySvc, api.ApiId); err != nil {
+		return nil
+	}
+	if snapshot.Stages, err = getV2APIStages(apigatewaySvc, api.ApiId); err != nil {
+		return nil
+	}
+
+	return snapshot
+}
+
+// PollAPIGatewayV2APIs gathers information on each API Gateway HTTP and WebSocket API for an AWS account.
+func PollAPIGatewayV2APIs(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting API Gateway V2 API resource poller")
+	apiSnapshots := make(map[string]*awsmodels.APIGatewayV2API)
+
+	// HTTP and WebSocket APIs share the endpoints of REST APIs
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "apigateway") {
+		apigatewaySvc, err := getAPIGatewayV2Client(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all APIs
+		apis := listV2APIs(apigatewaySvc)
+		if len(apis) == 0 {
+			zap.L().Debug("no API Gateway V2 APIs found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, api := range apis {
+			apiSnapshot := buildAPIGatewayV2APISnapshot(apigatewaySvc, api, *regionID)
+			if apiSnapshot == nil {
+				continue
+			}
+			apiSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			apiSnapshot.Region = regionID
+
+			if _, ok := apiSnapshots[*apiSnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing API Gateway V2 API snapshot",
+					zap.String("resourceId", *apiSnapshot.ARN),
+				)
+			}
+			apiSnapshots[*apiSnapshot.ARN] = apiSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(apiSnapshots))
+	for resourceID, apiSnapshot := range apiSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      apiSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.APIGatewayV2APISchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,133 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestAPIGatewayV2APIList(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewayV2Svc([]string{"GetApis"})
+
+	out := listV2APIs(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestAPIGatewayV2APIListError(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewayV2SvcError([]string{"GetApis"})
+
+	out := listV2APIs(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayV2APIGet(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewayV2Svc([]string{"GetApi"})
+
+	out, err := getV2API(mockSvc, awstest.ExampleV2APIID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	assert.Equal(t, awstest.ExampleV2APIID, out.ApiId)
+}
+
+func TestAPIGatewayV2APIGetDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockAPIGatewayV2{}
+	mockSvc.On("GetApi", mock.Anything).
+		Return(
+			&apigatewayv2.GetApiOutput{},
+			awserr.New(apigatewayv2.ErrCodeNotFoundException, "api does not exist", nil),
+		)
+
+	out, err := getV2API(mockSvc, awstest.ExampleV2APIID)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayV2APIGetError(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewayV2SvcError([]string{"GetApi"})
+
+	out, err := getV2API(mockSvc, awstest.ExampleV2APIID)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayV2APIBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewayV2SvcAll()
+
+	apiSnapshot := buildAPIGatewayV2APISnapshot(mockSvc, awstest.ExampleGetApisOutput.Items[0], "us-west-2")
+
+	require.NotNil(t, apiSnapshot)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/apis/qwerty5678", *apiSnapshot.ARN)
+	assert.Len(t, apiSnapshot.Authorizers, 1)
+	assert.Len(t, apiSnapshot.Stages, 1)
+	assert.Equal(t, "Value1", *apiSnapshot.Tags["Key1"])
+}
+
+func TestAPIGatewayV2APIBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewayV2SvcAllError()
+
+	apiSnapshot := buildAPIGatewayV2APISnapshot(mockSvc, awstest.ExampleGetApisOutput.Items[0], "us-west-2")
+
+	assert.Nil(t, apiSnapshot)
+}
+
+func TestAPIGatewayV2APIPoller(t *testing.T) {
+	awstest.MockAPIGatewayV2ForSetup = awstest.BuildMockAPIGatewayV2SvcAll()
+
+	APIGatewayV2ClientFunc = awstest.SetupMockAPIGatewayV2
+
+	resources, err := PollAPIGatewayV2APIs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Contains(t, string(resources[0].ID), "::/apis/qwerty5678")
+}
+
+func TestAPIGatewayV2APIPollerError(t *testing.T) {
+	awstest.MockAPIGatewayV2ForSetup = awstest.BuildMockAPIGatewayV2SvcAllError()
+
+	APIGatewayV2ClientFunc = awstest.SetupMockAPIGatewayV2
+
+	resources, err := PollAPIGatewayV2APIs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
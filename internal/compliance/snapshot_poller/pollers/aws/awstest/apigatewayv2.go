@@ -0,0 +1,209 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example API Gateway V2 API return values
+var (
+	ExampleV2APIID = aws.String("qwerty5678")
+
+	ExampleGetApisOutput = &apigatewayv2.GetApisOutput{
+		Items: []*apigatewayv2.Api{
+			{
+				ApiEndpoint:  aws.String("https://qwerty5678.execute-api.us-west-2.amazonaws.com"),
+				ApiId:        ExampleV2APIID,
+				CreatedDate:  ExampleDate,
+				Name:         aws.String("example-http-api"),
+				ProtocolType: aws.String(apigatewayv2.ProtocolTypeHttp),
+				Tags: map[string]*string{
+					"Key1": aws.String("Value1"),
+				},
+			},
+		},
+	}
+
+	ExampleGetApiOutput = &apigatewayv2.GetApiOutput{
+		ApiEndpoint:  aws.String("https://qwerty5678.execute-api.us-west-2.amazonaws.com"),
+		ApiId:        ExampleV2APIID,
+		CreatedDate:  ExampleDate,
+		Name:         aws.String("example-http-api"),
+		ProtocolType: aws.String(apigatewayv2.ProtocolTypeHttp),
+		Tags: map[string]*string{
+			"Key1": aws.String("Value1"),
+		},
+	}
+
+	ExampleV2GetAuthorizersOutput = &apigatewayv2.GetAuthorizersOutput{
+		Items: []*apigatewayv2.Authorizer{
+			{
+				AuthorizerId:   aws.String("auth34"),
+				AuthorizerType: aws.String(apigatewayv2.AuthorizerTypeJwt),
+				Name:           aws.String("example-jwt-authorizer"),
+			},
+		},
+	}
+
+	ExampleV2GetStagesOutput = &apigatewayv2.GetStagesOutput{
+		Items: []*apigatewayv2.Stage{
+			{
+				AccessLogSettings: &apigatewayv2.AccessLogSettings{
+					DestinationArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:example-access-logs"),
+					Format:         aws.String("$context.requestId"),
+				},
+				AutoDeploy:  aws.Bool(true),
+				CreatedDate: ExampleDate,
+				StageName:   aws.String("$default"),
+			},
+		},
+	}
+
+	svcAPIGatewayV2SetupCalls = map[string]func(*MockAPIGatewayV2){
+		"GetApis": func(svc *MockAPIGatewayV2) {
+			svc.On("GetApis", mock.Anything).
+				Return(ExampleGetApisOutput, nil)
+		},
+		"GetApi": func(svc *MockAPIGatewayV2) {
+			svc.On("GetApi", mock.Anything).
+				Return(ExampleGetApiOutput, nil)
+		},
+		"GetAuthorizers": func(svc *MockAPIGatewayV2) {
+			svc.On("GetAuthorizers", mock.Anything).
+				Return(ExampleV2GetAuthorizersOutput, nil)
+		},
+		"GetStages": func(svc *MockAPIGatewayV2) {
+			svc.On("GetStages", mock.Anything).
+				Return(ExampleV2GetStagesOutput, nil)
+		},
+	}
+
+	svcAPIGatewayV2SetupCallsError = map[string]func(*MockAPIGatewayV2){
+		"GetApis": func(svc *MockAPIGatewayV2) {
+			svc.On("GetApis", mock.Anything).
+				Return(&apigatewayv2.GetApisOutput{},
+					errors.New("ApiGatewayV2.GetApis error"),
+				)
+		},
+		"GetApi": func(svc *MockAPIGatewayV2) {
+			svc.On("GetApi", mock.Anything).
+				Return(&apigatewayv2.GetApiOutput{},
+					errors.New("ApiGatewayV2.GetApi error"),
+				)
+		},
+		"GetAuthorizers": func(svc *MockAPIGatewayV2) {
+			svc.On("GetAuthorizers", mock.Anything).
+				Return(&apigatewayv2.GetAuthorizersOutput{},
+					errors.New("ApiGatewayV2.GetAuthorizers error"),
+				)
+		},
+		"GetStages": func(svc *MockAPIGatewayV2) {
+			svc.On("GetStages", mock.Anything).
+				Return(&apigatewayv2.GetStagesOutput{},
+					errors.New("ApiGatewayV2.GetStages error"),
+				)
+		},
+	}
+
+	MockAPIGatewayV2ForSetup = &MockAPIGatewayV2{}
+)
+
+// API Gateway V2 mock
+
+// SetupMockAPIGatewayV2 is used to override the API Gateway V2 Client initializer
+func SetupMockAPIGatewayV2(_ *session.Session, _ *aws.Config) interface{} {
+	return MockAPIGatewayV2ForSetup
+}
+
+// MockAPIGatewayV2 is a mock API Gateway V2 client
+type MockAPIGatewayV2 struct {
+	apigatewayv2iface.ApiGatewayV2API
+	mock.Mock
+}
+
+// BuildMockAPIGatewayV2Svc builds and returns a MockAPIGatewayV2 struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockAPIGatewayV2Svc(funcs []string) (mockSvc *MockAPIGatewayV2) {
+	mockSvc = &MockAPIGatewayV2{}
+	for _, f := range funcs {
+		svcAPIGatewayV2SetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockAPIGatewayV2SvcError builds and returns a MockAPIGatewayV2 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockAPIGatewayV2SvcError(funcs []string) (mockSvc *MockAPIGatewayV2) {
+	mockSvc = &MockAPIGatewayV2{}
+	for _, f := range funcs {
+		svcAPIGatewayV2SetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockAPIGatewayV2SvcAll builds and returns a MockAPIGatewayV2 struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockAPIGatewayV2SvcAll() (mockSvc *MockAPIGatewayV2) {
+	mockSvc = &MockAPIGatewayV2{}
+	for _, f := range svcAPIGatewayV2SetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockAPIGatewayV2SvcAllError builds and returns a MockAPIGatewayV2 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockAPIGatewayV2SvcAllError() (mockSvc *MockAPIGatewayV2) {
+	mockSvc = &MockAPIGatewayV2{}
+	for _, f := range svcAPIGatewayV2SetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockAPIGatewayV2) GetApis(in *apigatewayv2.GetApisInput) (*apigatewayv2.GetApisOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetApisOutput), args.Error(1)
+}
+
+func (m *MockAPIGatewayV2) GetApi(in *apigatewayv2.GetApiInput) (*apigatewayv2.GetApiOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetApiOutput), args.Error(1)
+}
+
+func (m *MockAPIGatewayV2) GetAuthorizers(in *apigatewayv2.GetAuthorizersInput) (*apigatewayv2.GetAuthorizersOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetAuthorizersOutput), args.Error(1)
+}
+
+func (m *MockAPIGatewayV2) GetStages(in *apigatewayv2.GetStagesInput) (*apigatewayv2.GetStagesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetStagesOutput), args.Error(1)
+}
@@ -157,6 +157,47 @@ var (
 		},
 	}
 
+	ExampleListTaskDefinitionFamilies = &ecs.ListTaskDefinitionFamiliesOutput{
+		Families: []*string{
+			aws.String("example-task"),
+		},
+	}
+
+	ExampleEcsDescribeTaskDefinitionOutput = &ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecs.TaskDefinition{
+			Compatibilities: []*string{aws.String("EC2"), aws.String("FARGATE")},
+			ContainerDefinitions: []*ecs.ContainerDefinition{
+				{
+					Environment: []*ecs.KeyValuePair{
+						{
+							Name:  aws.String("LOG_LEVEL"),
+							Value: aws.String("info"),
+						},
+					},
+					Essential:  aws.Bool(true),
+					Image:      aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/example:latest"),
+					Name:       aws.String("example"),
+					Privileged: aws.Bool(false),
+				},
+			},
+			Cpu:                     aws.String("256"),
+			ExecutionRoleArn:        aws.String("arn:aws:iam::123456789012:role/example-task-execution-role"),
+			Family:                  aws.String("example-task"),
+			Memory:                  aws.String("512"),
+			NetworkMode:             aws.String("awsvpc"),
+			RequiresCompatibilities: []*string{aws.String("FARGATE")},
+			Revision:                aws.Int64(3),
+			Status:                  aws.String("ACTIVE"),
+			TaskDefinitionArn:       aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/example-task:3"),
+		},
+		Tags: []*ecs.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
 	svcEcsSetupCalls = map[string]func(*MockEcs){
 		"ListClustersPages": func(svc *MockEcs) {
 			svc.On("ListClustersPages", mock.Anything).
@@ -182,6 +223,14 @@ var (
 			svc.On("DescribeServices", mock.Anything).
 				Return(ExampleEcsDescribeServicesOutput, nil)
 		},
+		"ListTaskDefinitionFamiliesPages": func(svc *MockEcs) {
+			svc.On("ListTaskDefinitionFamiliesPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeTaskDefinition": func(svc *MockEcs) {
+			svc.On("DescribeTaskDefinition", mock.Anything).
+				Return(ExampleEcsDescribeTaskDefinitionOutput, nil)
+		},
 	}
 
 	svcEcsSetupCallsError = map[string]func(*MockEcs){
@@ -215,6 +264,16 @@ var (
 					errors.New("ECS.DescribeServices error"),
 				)
 		},
+		"ListTaskDefinitionFamiliesPages": func(svc *MockEcs) {
+			svc.On("ListTaskDefinitionFamiliesPages", mock.Anything).
+				Return(errors.New("ECS.ListTaskDefinitionFamiliesPages error"))
+		},
+		"DescribeTaskDefinition": func(svc *MockEcs) {
+			svc.On("DescribeTaskDefinition", mock.Anything).
+				Return(&ecs.DescribeTaskDefinitionOutput{},
+					errors.New("ECS.DescribeTaskDefinition error"),
+				)
+		},
 	}
 
 	MockEcsForSetup = &MockEcs{}
@@ -330,3 +389,21 @@ func (m *MockEcs) DescribeTasks(in *ecs.DescribeTasksInput) (*ecs.DescribeTasksO
 	args := m.Called(in)
 	return args.Get(0).(*ecs.DescribeTasksOutput), args.Error(1)
 }
+
+func (m *MockEcs) ListTaskDefinitionFamiliesPages(
+	in *ecs.ListTaskDefinitionFamiliesInput,
+	paginationFunction func(*ecs.ListTaskDefinitionFamiliesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListTaskDefinitionFamilies, true)
+	return args.Error(0)
+}
+
+func (m *MockEcs) DescribeTaskDefinition(in *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*ecs.DescribeTaskDefinitionOutput), args.Error(1)
+}
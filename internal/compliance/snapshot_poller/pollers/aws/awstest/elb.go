@@ -0,0 +1,264 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example ELB API return values
+var (
+	ExampleElbLoadBalancerArn = aws.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/example-classic")
+
+	ExampleElbLoadBalancer = &elb.LoadBalancerDescription{
+		AvailabilityZones:         []*string{aws.String("us-west-2a")},
+		CanonicalHostedZoneName:   aws.String("example-classic-123456789.us-west-2.elb.amazonaws.com"),
+		CanonicalHostedZoneNameID: aws.String("Z1H1FL5HABSF5"),
+		CreatedTime:               ExampleDate,
+		DNSName:                   aws.String("example-classic-123456789.us-west-2.elb.amazonaws.com"),
+		ListenerDescriptions: []*elb.ListenerDescription{
+			{
+				Listener: &elb.Listener{
+					InstancePort:     aws.Int64(80),
+					InstanceProtocol: aws.String("HTTP"),
+					LoadBalancerPort: aws.Int64(443),
+					Protocol:         aws.String("HTTPS"),
+					SSLCertificateId: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/abc123"),
+				},
+				PolicyNames: []*string{aws.String("ELBSecurityPolicy-2016-08")},
+			},
+		},
+		LoadBalancerName: aws.String("example-classic"),
+		Scheme:           aws.String("internet-facing"),
+		SecurityGroups:   []*string{aws.String("sg-1234asdf")},
+		Subnets:          []*string{aws.String("subnet-1234eee")},
+		VPCId:            aws.String("vpc-aaaa66666"),
+	}
+
+	ExampleElbDescribeLoadBalancersOutput = &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{ExampleElbLoadBalancer},
+	}
+
+	ExampleElbDescribeLoadBalancerAttributesOutput = &elb.DescribeLoadBalancerAttributesOutput{
+		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			AccessLog: &elb.AccessLog{
+				Enabled:      aws.Bool(true),
+				S3BucketName: aws.String("example-access-logs"),
+			},
+			ConnectionDraining: &elb.ConnectionDraining{
+				Enabled: aws.Bool(true),
+				Timeout: aws.Int64(300),
+			},
+			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
+				Enabled: aws.Bool(true),
+			},
+		},
+	}
+
+	ExampleElbDescribeLoadBalancerPoliciesOutput = &elb.DescribeLoadBalancerPoliciesOutput{
+		PolicyDescriptions: []*elb.PolicyDescription{
+			{
+				PolicyName:     aws.String("ELBSecurityPolicy-2016-08"),
+				PolicyTypeName: aws.String("SSLNegotiationPolicyType"),
+				PolicyAttributeDescriptions: []*elb.PolicyAttributeDescription{
+					{
+						AttributeName:  aws.String("Reference-Security-Policy"),
+						AttributeValue: aws.String("ELBSecurityPolicy-2016-08"),
+					},
+					{
+						AttributeName:  aws.String("Protocol-TLSv1"),
+						AttributeValue: aws.String("true"),
+					},
+				},
+			},
+		},
+	}
+
+	ExampleElbDescribeTagsOutput = &elb.DescribeTagsOutput{
+		TagDescriptions: []*elb.TagDescription{
+			{
+				LoadBalancerName: aws.String("example-classic"),
+				Tags: []*elb.Tag{
+					{
+						Key:   aws.String("Key1"),
+						Value: aws.String("Value1"),
+					},
+				},
+			},
+		},
+	}
+
+	svcElbSetupCalls = map[string]func(*MockElb){
+		"DescribeLoadBalancersPages": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancersPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeLoadBalancers": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancers", mock.Anything).
+				Return(ExampleElbDescribeLoadBalancersOutput, nil)
+		},
+		"DescribeLoadBalancerAttributes": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerAttributes", mock.Anything).
+				Return(ExampleElbDescribeLoadBalancerAttributesOutput, nil)
+		},
+		"DescribeLoadBalancerPolicies": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerPolicies", mock.Anything).
+				Return(ExampleElbDescribeLoadBalancerPoliciesOutput, nil)
+		},
+		"DescribeTags": func(svc *MockElb) {
+			svc.On("DescribeTags", mock.Anything).
+				Return(ExampleElbDescribeTagsOutput, nil)
+		},
+	}
+
+	svcElbSetupCallsError = map[string]func(*MockElb){
+		"DescribeLoadBalancersPages": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancersPages", mock.Anything).
+				Return(errors.New("ELB.DescribeLoadBalancersPages error"))
+		},
+		"DescribeLoadBalancers": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancers", mock.Anything).
+				Return(&elb.DescribeLoadBalancersOutput{},
+					errors.New("ELB.DescribeLoadBalancers error"),
+				)
+		},
+		"DescribeLoadBalancerAttributes": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerAttributes", mock.Anything).
+				Return(&elb.DescribeLoadBalancerAttributesOutput{},
+					errors.New("ELB.DescribeLoadBalancerAttributes error"),
+				)
+		},
+		"DescribeLoadBalancerPolicies": func(svc *MockElb) {
+			svc.On("DescribeLoadBalancerPolicies", mock.Anything).
+				Return(&elb.DescribeLoadBalancerPoliciesOutput{},
+					errors.New("ELB.DescribeLoadBalancerPolicies error"),
+				)
+		},
+		"DescribeTags": func(svc *MockElb) {
+			svc.On("DescribeTags", mock.Anything).
+				Return(&elb.DescribeTagsOutput{},
+					errors.New("ELB.DescribeTags error"),
+				)
+		},
+	}
+
+	MockElbForSetup = &MockElb{}
+)
+
+// ELB mock
+
+// SetupMockElb is used to override the ELB Client initializer
+func SetupMockElb(_ *session.Session, _ *aws.Config) interface{} {
+	return MockElbForSetup
+}
+
+// MockElb is a mock ELB client
+type MockElb struct {
+	elbiface.ELBAPI
+	mock.Mock
+}
+
+// BuildMockElbSvc builds and returns a MockElb struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElbSvc(funcs []string) (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range funcs {
+		svcElbSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElbSvcError builds and returns a MockElb struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElbSvcError(funcs []string) (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range funcs {
+		svcElbSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElbSvcAll builds and returns a MockElb struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElbSvcAll() (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range svcElbSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockElbSvcAllError builds and returns a MockElb struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElbSvcAllError() (mockSvc *MockElb) {
+	mockSvc = &MockElb{}
+	for _, f := range svcElbSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockElb) DescribeLoadBalancersPages(
+	in *elb.DescribeLoadBalancersInput,
+	paginationFunction func(*elb.DescribeLoadBalancersOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleElbDescribeLoadBalancersOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockElb) DescribeLoadBalancers(in *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeLoadBalancersOutput), args.Error(1)
+}
+
+func (m *MockElb) DescribeLoadBalancerAttributes(
+	in *elb.DescribeLoadBalancerAttributesInput,
+) (*elb.DescribeLoadBalancerAttributesOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeLoadBalancerAttributesOutput), args.Error(1)
+}
+
+func (m *MockElb) DescribeLoadBalancerPolicies(
+	in *elb.DescribeLoadBalancerPoliciesInput,
+) (*elb.DescribeLoadBalancerPoliciesOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeLoadBalancerPoliciesOutput), args.Error(1)
+}
+
+func (m *MockElb) DescribeTags(in *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elb.DescribeTagsOutput), args.Error(1)
+}
@@ -59,6 +59,43 @@ var (
 				},
 				IpAddressType: aws.String("ipv4"),
 			},
+			{
+				LoadBalancerArn:       aws.String("arn:aws:elasticloadbalancing:us-west-2:111111111111:loadbalancer/net/panther-test/bbbbbbbbbbbbb"),
+				DNSName:               aws.String("panther-test-bbbbbbbbbbbbb.elb.us-west-2.amazonaws.com"),
+				CanonicalHostedZoneId: aws.String("BBBBB123"),
+				CreatedTime:           ExampleDate,
+				LoadBalancerName:      aws.String("panther-test"),
+				Scheme:                aws.String("internet-facing"),
+				VpcId:                 aws.String("vpc-aaaa66666"),
+				State: &elbv2.LoadBalancerState{
+					Code: aws.String("active"),
+				},
+				Type: aws.String("network"),
+				AvailabilityZones: []*elbv2.AvailabilityZone{
+					{
+						ZoneName: aws.String("us-west-2c"),
+						SubnetId: aws.String("subnet-1234eee"),
+					},
+				},
+				IpAddressType: aws.String("ipv4"),
+			},
+		},
+	}
+
+	ExampleDescribeLoadBalancerAttributes = &elbv2.DescribeLoadBalancerAttributesOutput{
+		Attributes: []*elbv2.LoadBalancerAttribute{
+			{
+				Key:   aws.String("access_logs.s3.enabled"),
+				Value: aws.String("true"),
+			},
+			{
+				Key:   aws.String("access_logs.s3.bucket"),
+				Value: aws.String("panther-test-access-logs"),
+			},
+			{
+				Key:   aws.String("deletion_protection.enabled"),
+				Value: aws.String("false"),
+			},
 		},
 	}
 
@@ -133,6 +170,10 @@ var (
 			svc.On("DescribeSSLPolicies", mock.Anything).
 				Return(ExampleDescribeSSLPolicies, nil)
 		},
+		"DescribeLoadBalancerAttributes": func(svc *MockElbv2) {
+			svc.On("DescribeLoadBalancerAttributes", mock.Anything).
+				Return(ExampleDescribeLoadBalancerAttributes, nil)
+		},
 	}
 
 	svcElbv2SetupCallsError = map[string]func(*MockElbv2){
@@ -156,6 +197,12 @@ var (
 					errors.New("ELBV2.DescribeSSLPolicies error"),
 				)
 		},
+		"DescribeLoadBalancerAttributes": func(svc *MockElbv2) {
+			svc.On("DescribeLoadBalancerAttributes", mock.Anything).
+				Return(&elbv2.DescribeLoadBalancerAttributesOutput{},
+					errors.New("ELBV2.DescribeLoadBalancerAttributes error"),
+				)
+		},
 	}
 
 	MockElbv2ForSetup = &MockElbv2{}
@@ -258,3 +305,11 @@ func (m *MockElbv2) DescribeSSLPolicies(in *elbv2.DescribeSSLPoliciesInput) (*el
 	args := m.Called(in)
 	return args.Get(0).(*elbv2.DescribeSSLPoliciesOutput), args.Error(1)
 }
+
+func (m *MockElbv2) DescribeLoadBalancerAttributes(
+	in *elbv2.DescribeLoadBalancerAttributesInput,
+) (*elbv2.DescribeLoadBalancerAttributesOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*elbv2.DescribeLoadBalancerAttributesOutput), args.Error(1)
+}
@@ -232,9 +232,11 @@ var (
 	ExampleListAttachedUserPolicies = &iam.ListAttachedUserPoliciesOutput{
 		AttachedPolicies: []*iam.AttachedPolicy{
 			{
+				PolicyArn:  aws.String("arn:aws:iam::123456789012:policy/ForceMFA"),
 				PolicyName: aws.String("ForceMFA"),
 			},
 			{
+				PolicyArn:  aws.String("arn:aws:iam::123456789012:policy/IAMAdministrator"),
 				PolicyName: aws.String("IAMAdministrator"),
 			},
 		},
@@ -385,6 +387,15 @@ var (
 		},
 	}
 
+	ExampleListInstanceProfilesForRoleOutput = &iam.ListInstanceProfilesForRoleOutput{
+		InstanceProfiles: []*iam.InstanceProfile{
+			{
+				Arn:                 aws.String("arn:aws:iam::123456789012:instance-profile/ExampleProfile"),
+				InstanceProfileName: aws.String("ExampleProfile"),
+			},
+		},
+	}
+
 	ExampleGetRolePolicy = &iam.GetRolePolicyOutput{
 		RoleName:       aws.String("ExampleRole"),
 		PolicyName:     aws.String("PolicyName"),
@@ -444,6 +455,10 @@ var (
 			svc.On("ListRolePoliciesPages", mock.Anything).
 				Return(nil)
 		},
+		"ListInstanceProfilesForRolePages": func(svc *MockIAM) {
+			svc.On("ListInstanceProfilesForRolePages", mock.Anything).
+				Return(nil)
+		},
 		"GetRolePolicy": func(svc *MockIAM) {
 			svc.On("GetRolePolicy", mock.Anything).
 				Return(ExampleGetRolePolicy, nil)
@@ -549,6 +564,10 @@ var (
 			svc.On("ListRolePoliciesPages", mock.Anything).
 				Return(errors.New("IAM.ListRolePoliciesPages error"))
 		},
+		"ListInstanceProfilesForRolePages": func(svc *MockIAM) {
+			svc.On("ListInstanceProfilesForRolePages", mock.Anything).
+				Return(errors.New("IAM.ListInstanceProfilesForRolePages error"))
+		},
 		"GetRolePolicy": func(svc *MockIAM) {
 			svc.On("GetRolePolicy", mock.Anything).
 				Return(&iam.GetRolePolicyOutput{},
@@ -916,6 +935,19 @@ func (m *MockIAM) ListAttachedRolePoliciesPages(
 	return args.Error(0)
 }
 
+func (m *MockIAM) ListInstanceProfilesForRolePages(
+	in *iam.ListInstanceProfilesForRoleInput,
+	paginationFunction func(*iam.ListInstanceProfilesForRoleOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListInstanceProfilesForRoleOutput, true)
+	return args.Error(0)
+}
+
 func (m *MockIAM) ListGroupsForUserPages(
 	in *iam.ListGroupsForUserInput,
 	paginationFunction func(*iam.ListGroupsForUserOutput, bool) bool,
@@ -0,0 +1,255 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example Route 53 API return values
+var (
+	ExampleRoute53HostedZoneArn = aws.String("arn:aws:route53:::hostedzone/Z1D633PJN98FT9")
+
+	ExampleRoute53HostedZone = &route53.HostedZone{
+		CallerReference: aws.String("2017-03-01T11:22:14Z"),
+		Config: &route53.HostedZoneConfig{
+			Comment:     aws.String("example zone"),
+			PrivateZone: aws.Bool(false),
+		},
+		Id:                     aws.String("/hostedzone/Z1D633PJN98FT9"),
+		Name:                   aws.String("example.com."),
+		ResourceRecordSetCount: aws.Int64(3),
+	}
+
+	ExampleRoute53ListHostedZonesOutput = &route53.ListHostedZonesOutput{
+		HostedZones: []*route53.HostedZone{ExampleRoute53HostedZone},
+	}
+
+	ExampleRoute53GetHostedZoneOutput = &route53.GetHostedZoneOutput{
+		HostedZone: ExampleRoute53HostedZone,
+	}
+
+	ExampleRoute53ListResourceRecordSetsOutput = &route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{
+			{
+				Name: aws.String("example.com."),
+				ResourceRecords: []*route53.ResourceRecord{
+					{Value: aws.String("ns-2048.awsdns-64.com")},
+				},
+				TTL:  aws.Int64(172800),
+				Type: aws.String("NS"),
+			},
+			{
+				Name: aws.String("www.example.com."),
+				ResourceRecords: []*route53.ResourceRecord{
+					{Value: aws.String("example-bucket.s3-website-us-west-2.amazonaws.com")},
+				},
+				TTL:  aws.Int64(300),
+				Type: aws.String("CNAME"),
+			},
+		},
+	}
+
+	ExampleRoute53ListQueryLoggingConfigsOutput = &route53.ListQueryLoggingConfigsOutput{
+		QueryLoggingConfigs: []*route53.QueryLoggingConfig{
+			{
+				CloudWatchLogsLogGroupArn: aws.String("arn:aws:logs:us-east-1:123456789012:log-group:/aws/route53/example.com"),
+				HostedZoneId:              aws.String("Z1D633PJN98FT9"),
+				Id:                        aws.String("87654321-dcba-1234-abcd-1a2b3c4d5e6f"),
+			},
+		},
+	}
+
+	ExampleRoute53ListTagsForResourceOutput = &route53.ListTagsForResourceOutput{
+		ResourceTagSet: &route53.ResourceTagSet{
+			ResourceId:   aws.String("Z1D633PJN98FT9"),
+			ResourceType: aws.String("hostedzone"),
+			Tags: []*route53.Tag{
+				{
+					Key:   aws.String("Key1"),
+					Value: aws.String("Value1"),
+				},
+			},
+		},
+	}
+
+	svcRoute53SetupCalls = map[string]func(*MockRoute53){
+		"ListHostedZonesPages": func(svc *MockRoute53) {
+			svc.On("ListHostedZonesPages", mock.Anything).
+				Return(nil)
+		},
+		"GetHostedZone": func(svc *MockRoute53) {
+			svc.On("GetHostedZone", mock.Anything).
+				Return(ExampleRoute53GetHostedZoneOutput, nil)
+		},
+		"ListResourceRecordSetsPages": func(svc *MockRoute53) {
+			svc.On("ListResourceRecordSetsPages", mock.Anything).
+				Return(nil)
+		},
+		"ListQueryLoggingConfigsPages": func(svc *MockRoute53) {
+			svc.On("ListQueryLoggingConfigsPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTagsForResource": func(svc *MockRoute53) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleRoute53ListTagsForResourceOutput, nil)
+		},
+	}
+
+	svcRoute53SetupCallsError = map[string]func(*MockRoute53){
+		"ListHostedZonesPages": func(svc *MockRoute53) {
+			svc.On("ListHostedZonesPages", mock.Anything).
+				Return(errors.New("Route53.ListHostedZonesPages error"))
+		},
+		"GetHostedZone": func(svc *MockRoute53) {
+			svc.On("GetHostedZone", mock.Anything).
+				Return(&route53.GetHostedZoneOutput{},
+					errors.New("Route53.GetHostedZone error"),
+				)
+		},
+		"ListResourceRecordSetsPages": func(svc *MockRoute53) {
+			svc.On("ListResourceRecordSetsPages", mock.Anything).
+				Return(errors.New("Route53.ListResourceRecordSetsPages error"))
+		},
+		"ListQueryLoggingConfigsPages": func(svc *MockRoute53) {
+			svc.On("ListQueryLoggingConfigsPages", mock.Anything).
+				Return(errors.New("Route53.ListQueryLoggingConfigsPages error"))
+		},
+		"ListTagsForResource": func(svc *MockRoute53) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&route53.ListTagsForResourceOutput{},
+					errors.New("Route53.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockRoute53ForSetup = &MockRoute53{}
+)
+
+// Route 53 mock
+
+// SetupMockRoute53 is used to override the Route 53 Client initializer
+func SetupMockRoute53(_ *session.Session, _ *aws.Config) interface{} {
+	return MockRoute53ForSetup
+}
+
+// MockRoute53 is a mock Route 53 client
+type MockRoute53 struct {
+	route53iface.Route53API
+	mock.Mock
+}
+
+// BuildMockRoute53Svc builds and returns a MockRoute53 struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockRoute53Svc(funcs []string) (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range funcs {
+		svcRoute53SetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockRoute53SvcError builds and returns a MockRoute53 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockRoute53SvcError(funcs []string) (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range funcs {
+		svcRoute53SetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockRoute53SvcAll builds and returns a MockRoute53 struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockRoute53SvcAll() (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range svcRoute53SetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockRoute53SvcAllError builds and returns a MockRoute53 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockRoute53SvcAllError() (mockSvc *MockRoute53) {
+	mockSvc = &MockRoute53{}
+	for _, f := range svcRoute53SetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockRoute53) ListHostedZonesPages(
+	in *route53.ListHostedZonesInput,
+	paginationFunction func(*route53.ListHostedZonesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleRoute53ListHostedZonesOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRoute53) GetHostedZone(in *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*route53.GetHostedZoneOutput), args.Error(1)
+}
+
+func (m *MockRoute53) ListResourceRecordSetsPages(
+	in *route53.ListResourceRecordSetsInput,
+	paginationFunction func(*route53.ListResourceRecordSetsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleRoute53ListResourceRecordSetsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRoute53) ListQueryLoggingConfigsPages(
+	in *route53.ListQueryLoggingConfigsInput,
+	paginationFunction func(*route53.ListQueryLoggingConfigsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleRoute53ListQueryLoggingConfigsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockRoute53) ListTagsForResource(in *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*route53.ListTagsForResourceOutput), args.Error(1)
+}
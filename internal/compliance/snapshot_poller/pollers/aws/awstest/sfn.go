@@ -0,0 +1,184 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example Step Functions API return values
+var (
+	ExampleStateMachineArn = aws.String("arn:aws:states:us-west-2:123456789012:stateMachine:example-state-machine")
+
+	ExampleListStateMachinesOutput = &sfn.ListStateMachinesOutput{
+		StateMachines: []*sfn.StateMachineListItem{
+			{
+				CreationDate:    ExampleDate,
+				Name:            aws.String("example-state-machine"),
+				StateMachineArn: ExampleStateMachineArn,
+				Type:            aws.String(sfn.StateMachineTypeStandard),
+			},
+		},
+	}
+
+	ExampleDescribeStateMachineOutput = &sfn.DescribeStateMachineOutput{
+		CreationDate: ExampleDate,
+		Definition:   aws.String("{\"StartAt\":\"Done\",\"States\":{\"Done\":{\"Type\":\"Succeed\"}}}"),
+		LoggingConfiguration: &sfn.LoggingConfiguration{
+			IncludeExecutionData: aws.Bool(false),
+			Level:                aws.String(sfn.LogLevelOff),
+		},
+		Name:            aws.String("example-state-machine"),
+		RoleArn:         aws.String("arn:aws:iam::123456789012:role/example-state-machine-role"),
+		StateMachineArn: ExampleStateMachineArn,
+		Status:          aws.String(sfn.StateMachineStatusActive),
+		Type:            aws.String(sfn.StateMachineTypeStandard),
+	}
+
+	ExampleSfnListTagsForResourceOutput = &sfn.ListTagsForResourceOutput{
+		Tags: []*sfn.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
+	svcSfnSetupCalls = map[string]func(*MockSfn){
+		"ListStateMachinesPages": func(svc *MockSfn) {
+			svc.On("ListStateMachinesPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeStateMachine": func(svc *MockSfn) {
+			svc.On("DescribeStateMachine", mock.Anything).
+				Return(ExampleDescribeStateMachineOutput, nil)
+		},
+		"ListTagsForResource": func(svc *MockSfn) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleSfnListTagsForResourceOutput, nil)
+		},
+	}
+
+	svcSfnSetupCallsError = map[string]func(*MockSfn){
+		"ListStateMachinesPages": func(svc *MockSfn) {
+			svc.On("ListStateMachinesPages", mock.Anything).
+				Return(errors.New("SFN.ListStateMachinesPages error"))
+		},
+		"DescribeStateMachine": func(svc *MockSfn) {
+			svc.On("DescribeStateMachine", mock.Anything).
+				Return(&sfn.DescribeStateMachineOutput{},
+					errors.New("SFN.DescribeStateMachine error"),
+				)
+		},
+		"ListTagsForResource": func(svc *MockSfn) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&sfn.ListTagsForResourceOutput{},
+					errors.New("SFN.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockSfnForSetup = &MockSfn{}
+)
+
+// Step Functions mock
+
+// SetupMockSfn is used to override the Step Functions Client initializer
+func SetupMockSfn(_ *session.Session, _ *aws.Config) interface{} {
+	return MockSfnForSetup
+}
+
+// MockSfn is a mock Step Functions client
+type MockSfn struct {
+	sfniface.SFNAPI
+	mock.Mock
+}
+
+// BuildMockSfnSvc builds and returns a MockSfn struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSfnSvc(funcs []string) (mockSvc *MockSfn) {
+	mockSvc = &MockSfn{}
+	for _, f := range funcs {
+		svcSfnSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSfnSvcError builds and returns a MockSfn struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSfnSvcError(funcs []string) (mockSvc *MockSfn) {
+	mockSvc = &MockSfn{}
+	for _, f := range funcs {
+		svcSfnSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSfnSvcAll builds and returns a MockSfn struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSfnSvcAll() (mockSvc *MockSfn) {
+	mockSvc = &MockSfn{}
+	for _, f := range svcSfnSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockSfnSvcAllError builds and returns a MockSfn struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSfnSvcAllError() (mockSvc *MockSfn) {
+	mockSvc = &MockSfn{}
+	for _, f := range svcSfnSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockSfn) ListStateMachinesPages(
+	in *sfn.ListStateMachinesInput,
+	paginationFunction func(*sfn.ListStateMachinesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListStateMachinesOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockSfn) DescribeStateMachine(in *sfn.DescribeStateMachineInput) (*sfn.DescribeStateMachineOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*sfn.DescribeStateMachineOutput), args.Error(1)
+}
+
+func (m *MockSfn) ListTagsForResource(in *sfn.ListTagsForResourceInput) (*sfn.ListTagsForResourceOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*sfn.ListTagsForResourceOutput), args.Error(1)
+}
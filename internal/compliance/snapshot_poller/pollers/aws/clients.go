@@ -29,12 +29,13 @@ import (
 	"go.uber.org/zap"
 
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/throttle"
 )
 
 const (
 	// The amount of time credentials are valid
 	assumeRoleDuration = time.Hour
-	// retries on default session
+	// retries on default session, throttled requests are retried more often
 	maxRetries = 6
 
 	// error message for failure
@@ -42,7 +43,8 @@ const (
 )
 
 var (
-	snapshotPollerSession = session.Must(session.NewSession(&aws.Config{MaxRetries: aws.Int(maxRetries)}))
+	// Clients share the rate limits of their service and region, and retry throttled requests with backoff
+	snapshotPollerSession = throttle.Apply(session.Must(session.NewSession(&aws.Config{MaxRetries: aws.Int(maxRetries)})))
 
 	// assumeRoleFunc is the function to return valid AWS credentials.
 	assumeRoleFunc         = assumeRole
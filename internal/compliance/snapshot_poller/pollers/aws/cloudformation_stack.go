@@ -321,6 +321,7 @@ func PollCloudFormationStacks(pollerInput *awsmodels.ResourcePollerInput) ([]*ap
 				scanRequest.Entries = append(scanRequest.Entries, &pollermodels.ScanEntry{
 					AWSAccountID:     &pollerInput.AuthSourceParsedARN.AccountID,
 					IntegrationID:    pollerInput.IntegrationID,
+					OrgUnitPath:      pollerInput.OrgUnitPath,
 					ResourceID:       stackId,
 					ResourceType:     aws.String(awsmodels.CloudFormationStackSchema),
 					ScanAllResources: aws.Bool(false),
@@ -0,0 +1,219 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var CloudFrontClientFunc = setupCloudFrontClient
+
+func setupCloudFrontClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return cloudfront.New(sess, cfg)
+}
+
+func getCloudFrontClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (cloudfrontiface.CloudFrontAPI, error) {
+	client, err := getClient(pollerResourceInput, CloudFrontClientFunc, "cloudfront", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(cloudfrontiface.CloudFrontAPI), nil
+}
+
+// PollCloudFrontDistribution polls a single CloudFront distribution resource
+func PollCloudFrontDistribution(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getCloudFrontClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	distributionID := strings.TrimPrefix(resourceARN.Resource, "distribution/")
+	snapshot, err := buildCloudFrontDistributionSnapshot(client, aws.String(distributionID))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+	return snapshot, nil
+}
+
+// listDistributions returns the IDs of all the CloudFront distributions in the account
+func listDistributions(cloudFrontSvc cloudfrontiface.CloudFrontAPI) (distributions []*string, err error) {
+	err = cloudFrontSvc.ListDistributionsPages(&cloudfront.ListDistributionsInput{},
+		func(page *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+			if page.DistributionList == nil {
+				return true
+			}
+			for _, distribution := range page.DistributionList.Items {
+				distributions = append(distributions, distribution.Id)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "CloudFront.ListDistributionsPages")
+	}
+	return
+}
+
+// getDistribution returns the full configuration of a CloudFront distribution
+func getDistribution(cloudFrontSvc cloudfrontiface.CloudFrontAPI, id *string) (*cloudfront.Distribution, error) {
+	out, err := cloudFrontSvc.GetDistribution(&cloudfront.GetDistributionInput{Id: id})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudfront.ErrCodeNoSuchDistribution {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *id),
+				zap.String("resourceType", awsmodels.CloudFrontDistributionSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "CloudFront.GetDistribution: %s", aws.StringValue(id))
+	}
+	return out.Distribution, nil
+}
+
+// listCloudFrontTags returns the tags of a CloudFront distribution
+func listCloudFrontTags(cloudFrontSvc cloudfrontiface.CloudFrontAPI, distributionARN *string) ([]*cloudfront.Tag, error) {
+	out, err := cloudFrontSvc.ListTagsForResource(&cloudfront.ListTagsForResourceInput{Resource: distributionARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "CloudFront.ListTagsForResource: %s", aws.StringValue(distributionARN))
+	}
+	if out.Tags == nil {
+		return nil, nil
+	}
+	return out.Tags.Items, nil
+}
+
+// buildCloudFrontDistributionSnapshot makes all the calls to build up a snapshot of a given CloudFront distribution
+func buildCloudFrontDistributionSnapshot(
+	cloudFrontSvc cloudfrontiface.CloudFrontAPI, id *string) (*awsmodels.CloudFrontDistribution, error) {
+
+	distribution, err := getDistribution(cloudFrontSvc, id)
+	if err != nil || distribution == nil {
+		return nil, err
+	}
+
+	snapshot := &awsmodels.CloudFrontDistribution{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   distribution.ARN,
+			ResourceType: aws.String(awsmodels.CloudFrontDistributionSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN: distribution.ARN,
+			ID:  distribution.Id,
+		},
+		DomainName:                    distribution.DomainName,
+		InProgressInvalidationBatches: distribution.InProgressInvalidationBatches,
+		Status:                        distribution.Status,
+	}
+
+	if config := distribution.DistributionConfig; config != nil {
+		if config.Aliases != nil {
+			snapshot.Aliases = config.Aliases.Items
+		}
+		if config.CacheBehaviors != nil {
+			snapshot.CacheBehaviors = config.CacheBehaviors.Items
+		}
+		if config.OriginGroups != nil {
+			snapshot.OriginGroups = config.OriginGroups.Items
+		}
+		if config.Origins != nil {
+			snapshot.Origins = config.Origins.Items
+		}
+		snapshot.Comment = config.Comment
+		snapshot.DefaultCacheBehavior = config.DefaultCacheBehavior
+		snapshot.DefaultRootObject = config.DefaultRootObject
+		snapshot.Enabled = config.Enabled
+		snapshot.HttpVersion = config.HttpVersion
+		snapshot.IsIPV6Enabled = config.IsIPV6Enabled
+		snapshot.Logging = config.Logging
+		snapshot.PriceClass = config.PriceClass
+		snapshot.Restrictions = config.Restrictions
+		snapshot.ViewerCertificate = config.ViewerCertificate
+		snapshot.WebACLId = config.WebACLId
+	}
+
+	tags, err := listCloudFrontTags(cloudFrontSvc, distribution.ARN)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollCloudFrontDistributions gathers information on each CloudFront distribution for an AWS account.
+func PollCloudFrontDistributions(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting CloudFront Distribution resource poller")
+
+	// CloudFront is a global service
+	cloudFrontSvc, err := getCloudFrontClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	distributions, err := listDistributions(cloudFrontSvc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "PollCloudFrontDistributions(%#v)", *pollerInput)
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(distributions))
+	for _, id := range distributions {
+		snapshot, err := buildCloudFrontDistributionSnapshot(cloudFrontSvc, id)
+		if err != nil {
+			utils.LogAWSError("CloudFront.BuildSnapshot", err)
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              apimodels.ResourceID(*snapshot.ARN),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.CloudFrontDistributionSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,122 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestCloudFrontDistributionList(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvc([]string{"ListDistributionsPages"})
+
+	out, err := listDistributions(mockSvc)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestCloudFrontDistributionListError(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcError([]string{"ListDistributionsPages"})
+
+	out, err := listDistributions(mockSvc)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestCloudFrontDistributionGetDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockCloudFront{}
+	mockSvc.On("GetDistribution", mock.Anything).
+		Return(
+			&cloudfront.GetDistributionOutput{},
+			awserr.New(cloudfront.ErrCodeNoSuchDistribution, "The specified distribution does not exist.", nil),
+		)
+
+	out, err := getDistribution(mockSvc, aws.String("EDFDVBD6EXAMPLE"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestCloudFrontDistributionBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcAll()
+
+	distributionSnapshot, err := buildCloudFrontDistributionSnapshot(mockSvc, aws.String("EDFDVBD6EXAMPLE"))
+
+	require.NoError(t, err)
+	require.NotNil(t, distributionSnapshot)
+	assert.Equal(t, awstest.ExampleCloudFrontDistributionArn, distributionSnapshot.ARN)
+	assert.Equal(t, "TLSv1.2_2018", *distributionSnapshot.ViewerCertificate.MinimumProtocolVersion)
+	assert.NotNil(t, distributionSnapshot.Origins[0].S3OriginConfig.OriginAccessIdentity)
+	assert.NotNil(t, distributionSnapshot.WebACLId)
+	assert.True(t, *distributionSnapshot.Logging.Enabled)
+	assert.Equal(t, "Value1", *distributionSnapshot.Tags["Key1"])
+}
+
+func TestCloudFrontDistributionBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcAllError()
+
+	distributionSnapshot, err := buildCloudFrontDistributionSnapshot(mockSvc, aws.String("EDFDVBD6EXAMPLE"))
+
+	require.Error(t, err)
+	assert.Nil(t, distributionSnapshot)
+}
+
+func TestCloudFrontDistributionPoller(t *testing.T) {
+	awstest.MockCloudFrontForSetup = awstest.BuildMockCloudFrontSvcAll()
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resources, err := PollCloudFrontDistributions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleCloudFrontDistributionArn, string(resources[0].ID))
+	assert.Equal(t, awsmodels.GlobalRegion, *resources[0].Attributes.(*awsmodels.CloudFrontDistribution).Region)
+}
+
+func TestCloudFrontDistributionPollerError(t *testing.T) {
+	awstest.MockCloudFrontForSetup = awstest.BuildMockCloudFrontSvcAllError()
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resources, err := PollCloudFrontDistributions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Empty(t, resources)
+}
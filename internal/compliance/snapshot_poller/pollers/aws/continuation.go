@@ -0,0 +1,140 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/throttle"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Give a throttled API a minute to recover before resuming the scan
+const throttledRequeueDelaySeconds = 60
+
+// How many times in a row a scan is requeued without making progress before it is given up
+const maxThrottledRequeues = 10
+
+var (
+	// Set as variables to be overridden in testing
+	throttledRequestsFunc = throttle.ThrottledRequests
+	requeueFunc           = utils.Requeue
+)
+
+// resumableScan builds the resources of a resource type in order of their names, starting at the
+// continuation marker of the scan.
+//
+// If listing or building a resource is cut short because its requests were still throttled after
+// retrying, the resources built so far are returned and the rest of the scan is requeued, starting
+// from the incomplete resource. This way large accounts are scanned in several passes instead of
+// silently dropping the resources which could not be described.
+func resumableScan(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceType string,
+	list func() []*string,
+	build func(name *string) (*apimodels.AddResourceEntry, error),
+) ([]*apimodels.AddResourceEntry, error) {
+
+	throttled := throttledRequestsFunc()
+	names := list()
+	if throttledRequestsFunc() != throttled {
+		zap.L().Warn("listing resources throttled, requeueing scan", zap.String("resourceType", resourceType))
+		requeueRemaining(pollerInput, resourceType, pollerInput.ContinuationMarker, pollerInput.RequeueAttempts+1)
+		return nil, nil
+	}
+	if len(names) == 0 {
+		zap.L().Debug("no resources found", zap.String("resourceType", resourceType))
+		return nil, nil
+	}
+
+	sort.Slice(names, func(i, j int) bool { return aws.StringValue(names[i]) < aws.StringValue(names[j]) })
+
+	var resources []*apimodels.AddResourceEntry
+	for _, name := range names {
+		if pollerInput.ContinuationMarker != nil && *name < *pollerInput.ContinuationMarker {
+			continue
+		}
+
+		throttled = throttledRequestsFunc()
+		resource, err := build(name)
+		if err != nil {
+			return nil, err
+		}
+		if throttledRequestsFunc() != throttled {
+			zap.L().Warn("building resource throttled, requeueing remaining resources",
+				zap.String("resourceType", resourceType),
+				zap.String("continuationMarker", *name),
+				zap.Int("numResources", len(resources)))
+			// Only count the passes which did not build any resources, so a slow scan keeps going
+			attempts := 1
+			if len(resources) == 0 {
+				attempts = pollerInput.RequeueAttempts + 1
+			}
+			requeueRemaining(pollerInput, resourceType, name, attempts)
+			return resources, nil
+		}
+		if resource != nil {
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// requeueRemaining queues a scan of a resource type which resumes at the given continuation marker.
+//
+// Resumable resource types are global, so the scan is not split by region, it is limited to the
+// same regions as the scan it resumes. A scan which stays throttled for maxThrottledRequeues passes
+// in a row is given up, so it is not requeued forever.
+func requeueRemaining(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceType string,
+	continuationMarker *string,
+	attempts int,
+) {
+
+	if attempts > maxThrottledRequeues {
+		zap.L().Error("scan still throttled after too many requeues, giving up",
+			zap.String("resourceType", resourceType),
+			zap.String("integrationId", aws.StringValue(pollerInput.IntegrationID)),
+			zap.String("continuationMarker", aws.StringValue(continuationMarker)),
+			zap.Int("requeueAttempts", attempts-1))
+		return
+	}
+
+	requeueFunc(pollermodels.ScanMsg{
+		Entries: []*pollermodels.ScanEntry{
+			{
+				AWSAccountID:       aws.String(pollerInput.AuthSourceParsedARN.AccountID),
+				ContinuationMarker: continuationMarker,
+				IntegrationID:      pollerInput.IntegrationID,
+				OrgUnitPath:        pollerInput.OrgUnitPath,
+				RequeueAttempts:    attempts,
+				ResourceType:       aws.String(resourceType),
+				ScanRegions:        pollerInput.Regions,
+			},
+		},
+	}, throttledRequeueDelaySeconds)
+}
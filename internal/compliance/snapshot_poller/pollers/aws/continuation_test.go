@@ -0,0 +1,174 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+// resumableScanTest fakes the throttled request counter, which goes up whenever a name in throttledNames
+// is listed or built, and records the requeued scans.
+type resumableScanTest struct {
+	throttled      int64
+	throttledNames map[string]bool
+	requeued       []*pollermodels.ScanEntry
+	built          []string
+}
+
+func newResumableScanTest(throttledNames ...string) *resumableScanTest {
+	test := &resumableScanTest{throttledNames: make(map[string]bool)}
+	for _, name := range throttledNames {
+		test.throttledNames[name] = true
+	}
+	throttledRequestsFunc = func() int64 { return test.throttled }
+	requeueFunc = func(scanRequest pollermodels.ScanMsg, delay int64) {
+		test.requeued = append(test.requeued, scanRequest.Entries...)
+	}
+	return test
+}
+
+func (test *resumableScanTest) list(names ...string) func() []*string {
+	return func() []*string {
+		if test.throttledNames["list"] {
+			test.throttled++
+		}
+		return aws.StringSlice(names)
+	}
+}
+
+func (test *resumableScanTest) build(name *string) (*apimodels.AddResourceEntry, error) {
+	test.built = append(test.built, *name)
+	if test.throttledNames[*name] {
+		test.throttled++
+	}
+	return &apimodels.AddResourceEntry{ID: apimodels.ResourceID(*name)}, nil
+}
+
+func resumableScanInput(continuationMarker *string) *awsmodels.ResourcePollerInput {
+	return &awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		ContinuationMarker:  continuationMarker,
+		IntegrationID:       awstest.ExampleIntegrationID,
+	}
+}
+
+func TestResumableScan(t *testing.T) {
+	test := newResumableScanTest()
+
+	resources, err := resumableScan(resumableScanInput(nil), awsmodels.IAMRoleSchema,
+		test.list("c", "a", "b"), test.build)
+	require.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Equal(t, []string{"a", "b", "c"}, test.built)
+	assert.Empty(t, test.requeued)
+}
+
+func TestResumableScanContinuationMarker(t *testing.T) {
+	test := newResumableScanTest()
+
+	resources, err := resumableScan(resumableScanInput(aws.String("b")), awsmodels.IAMRoleSchema,
+		test.list("c", "a", "b"), test.build)
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, []string{"b", "c"}, test.built)
+	assert.Empty(t, test.requeued)
+}
+
+func TestResumableScanBuildThrottled(t *testing.T) {
+	test := newResumableScanTest("c")
+	pollerInput := resumableScanInput(nil)
+	pollerInput.Regions = awstest.ExampleRegions
+
+	resources, err := resumableScan(pollerInput, awsmodels.IAMRoleSchema,
+		test.list("a", "b", "c", "d"), test.build)
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(resources[0].ID))
+	assert.Equal(t, "b", string(resources[1].ID))
+	assert.Len(t, resources, 2)
+	assert.Equal(t, []string{"a", "b", "c"}, test.built)
+
+	require.Len(t, test.requeued, 1)
+	assert.Equal(t, &pollermodels.ScanEntry{
+		AWSAccountID:       aws.String(awstest.ExampleAuthSourceParsedARN.AccountID),
+		ContinuationMarker: aws.String("c"),
+		IntegrationID:      awstest.ExampleIntegrationID,
+		RequeueAttempts:    1,
+		ResourceType:       aws.String(awsmodels.IAMRoleSchema),
+		ScanRegions:        awstest.ExampleRegions,
+	}, test.requeued[0])
+}
+
+func TestResumableScanListThrottled(t *testing.T) {
+	test := newResumableScanTest("list")
+
+	resources, err := resumableScan(resumableScanInput(aws.String("b")), awsmodels.IAMRoleSchema,
+		test.list("a", "b"), test.build)
+	require.NoError(t, err)
+	assert.Nil(t, resources)
+	assert.Empty(t, test.built)
+
+	require.Len(t, test.requeued, 1)
+	assert.Equal(t, aws.String("b"), test.requeued[0].ContinuationMarker)
+}
+
+func TestResumableScanListThrottledRequeueAttempts(t *testing.T) {
+	test := newResumableScanTest("list")
+	pollerInput := resumableScanInput(aws.String("b"))
+	pollerInput.RequeueAttempts = maxThrottledRequeues - 1
+
+	_, err := resumableScan(pollerInput, awsmodels.IAMRoleSchema, test.list("a", "b"), test.build)
+	require.NoError(t, err)
+	require.Len(t, test.requeued, 1)
+	assert.Equal(t, maxThrottledRequeues, test.requeued[0].RequeueAttempts)
+
+	// The scan is given up once it was requeued too many times in a row
+	pollerInput.RequeueAttempts = maxThrottledRequeues
+	resources, err := resumableScan(pollerInput, awsmodels.IAMRoleSchema, test.list("a", "b"), test.build)
+	require.NoError(t, err)
+	assert.Nil(t, resources)
+	assert.Len(t, test.requeued, 1)
+}
+
+func TestResumableScanBuildThrottledRequeueAttempts(t *testing.T) {
+	test := newResumableScanTest("a", "c")
+	pollerInput := resumableScanInput(nil)
+	pollerInput.RequeueAttempts = maxThrottledRequeues
+
+	// Building a resource before being throttled resets the attempts
+	_, err := resumableScan(pollerInput, awsmodels.IAMRoleSchema, test.list("b", "c"), test.build)
+	require.NoError(t, err)
+	require.Len(t, test.requeued, 1)
+	assert.Equal(t, 1, test.requeued[0].RequeueAttempts)
+
+	// Without any progress the scan is given up
+	resources, err := resumableScan(pollerInput, awsmodels.IAMRoleSchema, test.list("a", "b"), test.build)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	assert.Len(t, test.requeued, 1)
+}
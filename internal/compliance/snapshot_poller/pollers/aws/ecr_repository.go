@@ -0,0 +1,240 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var EcrClientFunc = setupEcrClient
+
+func setupEcrClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return ecr.New(sess, cfg)
+}
+
+func getEcrClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (ecriface.ECRAPI, error) {
+	client, err := getClient(pollerResourceInput, EcrClientFunc, "ecr", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(ecriface.ECRAPI), nil
+}
+
+// PollECRRepository polls a single ECR repository resource
+func PollECRRepository(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcrClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form "repository/<name>", where the name may itself contain slashes
+	repository, err := describeRepository(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "repository/")))
+	if err != nil || repository == nil {
+		return nil, err
+	}
+
+	snapshot := buildEcrRepositorySnapshot(client, repository)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+
+	return snapshot, nil
+}
+
+// listRepositories returns all ECR repositories in the account
+func listRepositories(ecrSvc ecriface.ECRAPI) (repositories []*ecr.Repository) {
+	err := ecrSvc.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{},
+		func(page *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+			repositories = append(repositories, page.Repositories...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("ECR.DescribeRepositoriesPages", err)
+	}
+	return
+}
+
+// describeRepository returns a single ECR repository
+func describeRepository(ecrSvc ecriface.ECRAPI, name *string) (*ecr.Repository, error) {
+	out, err := ecrSvc.DescribeRepositories(&ecr.DescribeRepositoriesInput{RepositoryNames: []*string{name}})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecr.ErrCodeRepositoryNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *name),
+				zap.String("resourceType", awsmodels.EcrRepositorySchema))
+			return nil, nil
+		}
+		utils.LogAWSError("ECR.DescribeRepositories", err)
+		return nil, err
+	}
+
+	if len(out.Repositories) == 0 {
+		return nil, nil
+	}
+	return out.Repositories[0], nil
+}
+
+// getRepositoryPolicy returns the repository policy of an ECR repository, if one is set
+func getRepositoryPolicy(ecrSvc ecriface.ECRAPI, name *string) (*string, error) {
+	out, err := ecrSvc.GetRepositoryPolicy(&ecr.GetRepositoryPolicyInput{RepositoryName: name})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecr.ErrCodeRepositoryPolicyNotFoundException {
+			return nil, nil
+		}
+		utils.LogAWSError("ECR.GetRepositoryPolicy", err)
+		return nil, err
+	}
+
+	return out.PolicyText, nil
+}
+
+// getLifecyclePolicy returns the lifecycle policy of an ECR repository, if one is set
+func getLifecyclePolicy(ecrSvc ecriface.ECRAPI, name *string) (*string, error) {
+	out, err := ecrSvc.GetLifecyclePolicy(&ecr.GetLifecyclePolicyInput{RepositoryName: name})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecr.ErrCodeLifecyclePolicyNotFoundException {
+			return nil, nil
+		}
+		utils.LogAWSError("ECR.GetLifecyclePolicy", err)
+		return nil, err
+	}
+
+	return out.LifecyclePolicyText, nil
+}
+
+// listEcrTags returns the tags of an ECR repository
+func listEcrTags(ecrSvc ecriface.ECRAPI, repositoryARN *string) ([]*ecr.Tag, error) {
+	out, err := ecrSvc.ListTagsForResource(&ecr.ListTagsForResourceInput{ResourceArn: repositoryARN})
+	if err != nil {
+		utils.LogAWSError("ECR.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return out.Tags, nil
+}
+
+// buildEcrRepositorySnapshot makes all the calls to build up a snapshot of a given ECR repository
+func buildEcrRepositorySnapshot(ecrSvc ecriface.ECRAPI, repository *ecr.Repository) *awsmodels.EcrRepository {
+	if repository == nil {
+		return nil
+	}
+
+	snapshot := &awsmodels.EcrRepository{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   repository.RepositoryArn,
+			ResourceType: aws.String(awsmodels.EcrRepositorySchema),
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(repository.CreatedAt)),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  repository.RepositoryArn,
+			Name: repository.RepositoryName,
+		},
+		ImageScanningConfiguration: repository.ImageScanningConfiguration,
+		ImageTagMutability:         repository.ImageTagMutability,
+		RegistryId:                 repository.RegistryId,
+		RepositoryUri:              repository.RepositoryUri,
+	}
+
+	var err error
+	if snapshot.Policy, err = getRepositoryPolicy(ecrSvc, repository.RepositoryName); err != nil {
+		return nil
+	}
+	if snapshot.LifecyclePolicy, err = getLifecyclePolicy(ecrSvc, repository.RepositoryName); err != nil {
+		return nil
+	}
+
+	tags, err := listEcrTags(ecrSvc, repository.RepositoryArn)
+	if err == nil {
+		snapshot.Tags = utils.ParseTagSlice(tags)
+	}
+
+	return snapshot
+}
+
+// PollEcrRepositories gathers information on each ECR Repository for an AWS account.
+func PollEcrRepositories(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ECR Repository resource poller")
+	ecrRepositorySnapshots := make(map[string]*awsmodels.EcrRepository)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "api.ecr") {
+		ecrSvc, err := getEcrClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all repositories
+		repositories := listRepositories(ecrSvc)
+		if len(repositories) == 0 {
+			zap.L().Debug("no ECR repositories found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, repository := range repositories {
+			ecrRepositorySnapshot := buildEcrRepositorySnapshot(ecrSvc, repository)
+			if ecrRepositorySnapshot == nil {
+				continue
+			}
+			ecrRepositorySnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			ecrRepositorySnapshot.Region = regionID
+
+			if _, ok := ecrRepositorySnapshots[*ecrRepositorySnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing ECR Repository snapshot",
+					zap.String("resourceId", *ecrRepositorySnapshot.ARN),
+				)
+			}
+			ecrRepositorySnapshots[*ecrRepositorySnapshot.ARN] = ecrRepositorySnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(ecrRepositorySnapshots))
+	for resourceID, ecrSnapshot := range ecrRepositorySnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      ecrSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.EcrRepositorySchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,147 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcrRepositoryList(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"DescribeRepositoriesPages"})
+
+	out := listRepositories(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"DescribeRepositoriesPages"})
+
+	out := listRepositories(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"DescribeRepositories"})
+
+	out, err := describeRepository(mockSvc, aws.String("example/repository"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryDescribeDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockEcr{}
+	mockSvc.On("DescribeRepositories", mock.Anything).
+		Return(
+			&ecr.DescribeRepositoriesOutput{},
+			awserr.New(ecr.ErrCodeRepositoryNotFoundException, "repository does not exist", nil),
+		)
+
+	out, err := describeRepository(mockSvc, aws.String("example/repository"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"DescribeRepositories"})
+
+	out, err := describeRepository(mockSvc, aws.String("example/repository"))
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryPolicyNotFound(t *testing.T) {
+	mockSvc := &awstest.MockEcr{}
+	mockSvc.On("GetRepositoryPolicy", mock.Anything).
+		Return(
+			&ecr.GetRepositoryPolicyOutput{},
+			awserr.New(ecr.ErrCodeRepositoryPolicyNotFoundException, "no policy", nil),
+		)
+
+	out, err := getRepositoryPolicy(mockSvc, aws.String("example/repository"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAll()
+
+	repositorySnapshot := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+
+	require.NotNil(t, repositorySnapshot)
+	assert.Equal(t, awstest.ExampleEcrRepositoryArn, repositorySnapshot.ARN)
+	assert.True(t, *repositorySnapshot.ImageScanningConfiguration.ScanOnPush)
+	assert.NotEmpty(t, repositorySnapshot.Policy)
+	assert.NotEmpty(t, repositorySnapshot.LifecyclePolicy)
+	assert.Equal(t, "Value1", *repositorySnapshot.Tags["Key1"])
+}
+
+func TestEcrRepositoryBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAllError()
+
+	repositorySnapshot := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+
+	assert.Nil(t, repositorySnapshot)
+}
+
+func TestEcrRepositoryPoller(t *testing.T) {
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAll()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, *awstest.ExampleEcrRepositoryArn, string(resources[0].ID))
+}
+
+func TestEcrRepositoryPollerError(t *testing.T) {
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAllError()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
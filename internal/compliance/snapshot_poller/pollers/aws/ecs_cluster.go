@@ -188,15 +188,8 @@ func getClusterTasks(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodels.E
 // getClusterServices enumerates and then describes all active services of a cluster
 func getClusterServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodels.EcsService, error) {
 	// Enumerate services
-	var serviceArns []*string
-	err := ecsSvc.ListServicesPages(&ecs.ListServicesInput{Cluster: clusterArn},
-		func(page *ecs.ListServicesOutput, lastPage bool) bool {
-			serviceArns = append(serviceArns, page.ServiceArns...)
-			return true
-		})
-
+	serviceArns, err := listServices(ecsSvc, clusterArn)
 	if err != nil {
-		utils.LogAWSError("ECS.ListServicesPages", err)
 		return nil, err
 	}
 
@@ -206,24 +199,13 @@ func getClusterServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) ([]*awsmodel
 	}
 
 	// Describe services
-	//
-	// Oddly, the DescribeServices API call does not have a version with builtin paging like the list
-	// API call does. If we run into issues here we may need to implement paging ourselves.
-	rawServices, err := ecsSvc.DescribeServices(&ecs.DescribeServicesInput{
-		Cluster: clusterArn,
-		// This only accepts one argument, which is the string TAGS
-		// Indicates that we want to included the task tags
-		Include:  []*string{aws.String("TAGS")},
-		Services: serviceArns,
-	})
-
+	rawServices, err := describeServices(ecsSvc, clusterArn, serviceArns)
 	if err != nil {
-		utils.LogAWSError("ECS.DescribeServices", err)
 		return nil, err
 	}
 
-	services := make([]*awsmodels.EcsService, 0, len(rawServices.Services))
-	for _, service := range rawServices.Services {
+	services := make([]*awsmodels.EcsService, 0, len(rawServices))
+	for _, service := range rawServices {
 		services = append(services, &awsmodels.EcsService{
 			GenericAWSResource: awsmodels.GenericAWSResource{
 				ARN:  service.ServiceArn,
@@ -0,0 +1,223 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// DescribeServices accepts at most 10 services per call
+const ecsDescribeServicesMax = 10
+
+// PollECSService polls a single ECS service resource
+func PollECSService(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcsClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Service ARNs are of the form "service/<cluster>/<service>", services created before the long ARN
+	// format was introduced have ARNs of the form "service/<service>" and we have to look for their cluster.
+	var clusters []*string
+	if resourceParts := strings.Split(resourceARN.Resource, "/"); len(resourceParts) == 3 {
+		clusters = []*string{aws.String(resourceParts[1])}
+	} else {
+		clusters = listClusters(client)
+	}
+
+	for _, cluster := range clusters {
+		services, err := describeServices(client, cluster, []*string{scanRequest.ResourceID})
+		if err != nil {
+			return nil, err
+		}
+		if len(services) == 0 {
+			continue
+		}
+
+		snapshot := buildEcsServiceSnapshot(services[0])
+		snapshot.Region = aws.String(resourceARN.Region)
+		snapshot.AccountID = aws.String(resourceARN.AccountID)
+		return snapshot, nil
+	}
+
+	zap.L().Warn("tried to scan non-existent resource",
+		zap.String("resource", *scanRequest.ResourceID),
+		zap.String("resourceType", awsmodels.EcsServiceSchema))
+	return nil, nil
+}
+
+// listServices returns the ARNs of all services in a cluster
+func listServices(ecsSvc ecsiface.ECSAPI, clusterArn *string) (services []*string, err error) {
+	err = ecsSvc.ListServicesPages(&ecs.ListServicesInput{Cluster: clusterArn},
+		func(page *ecs.ListServicesOutput, lastPage bool) bool {
+			services = append(services, page.ServiceArns...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("ECS.ListServicesPages", err)
+		return nil, err
+	}
+	return services, nil
+}
+
+// describeServices describes the given services of a cluster, in batches of the most DescribeServices accepts
+//
+// Services which do not exist or are inactive are left out of the result.
+func describeServices(ecsSvc ecsiface.ECSAPI, clusterArn *string, serviceArns []*string) ([]*ecs.Service, error) {
+	var services []*ecs.Service
+	for start := 0; start < len(serviceArns); start += ecsDescribeServicesMax {
+		end := start + ecsDescribeServicesMax
+		if end > len(serviceArns) {
+			end = len(serviceArns)
+		}
+
+		out, err := ecsSvc.DescribeServices(&ecs.DescribeServicesInput{
+			Cluster: clusterArn,
+			// This only accepts one argument, which is the string TAGS
+			// Indicates that we want to included the service tags
+			Include:  []*string{aws.String("TAGS")},
+			Services: serviceArns[start:end],
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecs.ErrCodeClusterNotFoundException {
+				return nil, nil
+			}
+			utils.LogAWSError("ECS.DescribeServices", err)
+			return nil, err
+		}
+
+		for _, service := range out.Services {
+			if aws.StringValue(service.Status) != "INACTIVE" {
+				services = append(services, service)
+			}
+		}
+	}
+
+	return services, nil
+}
+
+// buildEcsServiceSnapshot converts an ECS service into a snapshot
+func buildEcsServiceSnapshot(service *ecs.Service) *awsmodels.EcsServiceResource {
+	return &awsmodels.EcsServiceResource{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   service.ServiceArn,
+			ResourceType: aws.String(awsmodels.EcsServiceSchema),
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(service.CreatedAt)),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  service.ServiceArn,
+			Name: service.ServiceName,
+			Tags: utils.ParseTagSlice(service.Tags),
+		},
+		CapacityProviderStrategy:      service.CapacityProviderStrategy,
+		ClusterArn:                    service.ClusterArn,
+		CreatedBy:                     service.CreatedBy,
+		DeploymentConfiguration:       service.DeploymentConfiguration,
+		DeploymentController:          service.DeploymentController,
+		Deployments:                   service.Deployments,
+		DesiredCount:                  service.DesiredCount,
+		EnableECSManagedTags:          service.EnableECSManagedTags,
+		HealthCheckGracePeriodSeconds: service.HealthCheckGracePeriodSeconds,
+		LaunchType:                    service.LaunchType,
+		LoadBalancers:                 service.LoadBalancers,
+		NetworkConfiguration:          service.NetworkConfiguration,
+		PendingCount:                  service.PendingCount,
+		PlacementConstraints:          service.PlacementConstraints,
+		PlacementStrategy:             service.PlacementStrategy,
+		PlatformVersion:               service.PlatformVersion,
+		PropagateTags:                 service.PropagateTags,
+		RoleArn:                       service.RoleArn,
+		RunningCount:                  service.RunningCount,
+		SchedulingStrategy:            service.SchedulingStrategy,
+		ServiceRegistries:             service.ServiceRegistries,
+		Status:                        service.Status,
+		TaskDefinition:                service.TaskDefinition,
+		TaskSets:                      service.TaskSets,
+	}
+}
+
+// PollEcsServices gathers information on each ECS Service for an AWS account.
+func PollEcsServices(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ECS Service resource poller")
+	ecsServiceSnapshots := make(map[string]*awsmodels.EcsServiceResource)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "ecs") {
+		ecsSvc, err := getEcsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Services are listed per cluster
+		for _, clusterArn := range listClusters(ecsSvc) {
+			serviceArns, err := listServices(ecsSvc, clusterArn)
+			if err != nil || len(serviceArns) == 0 {
+				continue
+			}
+
+			services, err := describeServices(ecsSvc, clusterArn, serviceArns)
+			if err != nil {
+				continue
+			}
+
+			for _, service := range services {
+				ecsServiceSnapshot := buildEcsServiceSnapshot(service)
+				ecsServiceSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+				ecsServiceSnapshot.Region = regionID
+
+				if _, ok := ecsServiceSnapshots[*ecsServiceSnapshot.ARN]; ok {
+					zap.L().Info(
+						"overwriting existing ECS Service snapshot",
+						zap.String("resourceId", *ecsServiceSnapshot.ARN),
+					)
+				}
+				ecsServiceSnapshots[*ecsServiceSnapshot.ARN] = ecsServiceSnapshot
+			}
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(ecsServiceSnapshots))
+	for resourceID, ecsSnapshot := range ecsServiceSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      ecsSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.EcsServiceSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,131 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcsServiceDescribeBatches(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"DescribeServices"})
+
+	serviceArns := make([]*string, 25)
+	for i := range serviceArns {
+		serviceArns[i] = awstest.ExampleServiceArn
+	}
+
+	out, err := describeServices(mockSvc, awstest.ExampleClusterArn, serviceArns)
+	require.NoError(t, err)
+	assert.Len(t, out, 3)
+	mockSvc.AssertNumberOfCalls(t, "DescribeServices", 3)
+}
+
+func TestEcsServiceDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcError([]string{"DescribeServices"})
+
+	out, err := describeServices(mockSvc, awstest.ExampleClusterArn, []*string{awstest.ExampleServiceArn})
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcsServiceDescribeInactive(t *testing.T) {
+	mockSvc := &awstest.MockEcs{}
+	mockSvc.On("DescribeServices", mock.Anything).
+		Return(
+			&ecs.DescribeServicesOutput{
+				Services: []*ecs.Service{{ServiceArn: awstest.ExampleServiceArn, Status: aws.String("INACTIVE")}},
+			},
+			nil,
+		)
+
+	out, err := describeServices(mockSvc, awstest.ExampleClusterArn, []*string{awstest.ExampleServiceArn})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestEcsServicePollSingle(t *testing.T) {
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvc([]string{"DescribeServices"})
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resourceID := "arn:aws:ecs:us-west-2:123456789012:service/example-cluster/example"
+	resourceARN, err := arn.Parse(resourceID)
+	require.NoError(t, err)
+
+	snapshot, err := PollECSService(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: &resourceID})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:cluster/example-cluster",
+		*snapshot.(*awsmodels.EcsServiceResource).ClusterArn)
+}
+
+func TestEcsServicePoller(t *testing.T) {
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAll()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, err := PollEcsServices(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:service/example", string(resources[0].ID))
+}
+
+func TestEcsServicePollerError(t *testing.T) {
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAllError()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, err := PollEcsServices(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
@@ -0,0 +1,202 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Every registration of a task definition creates a new immutable revision, so rather than tracking each revision
+// as its own resource we track the latest active revision of each task definition family. The resource ID is the
+// ARN of the family, which is the ARN of a revision without the ":<revision>" suffix.
+const taskDefinitionResourcePrefix = "task-definition/"
+
+// PollECSTaskDefinition polls a single ECS task definition family resource
+func PollECSTaskDefinition(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcsClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strip the revision, if one was provided
+	family := strings.TrimPrefix(resourceARN.Resource, taskDefinitionResourcePrefix)
+	if idx := strings.Index(family, ":"); idx > 0 {
+		family = family[:idx]
+	}
+
+	snapshot := buildEcsTaskDefinitionSnapshot(client, aws.String(family))
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+
+	return snapshot, nil
+}
+
+// listTaskDefinitionFamilies returns the names of all task definition families with an active revision
+func listTaskDefinitionFamilies(ecsSvc ecsiface.ECSAPI) (families []*string) {
+	err := ecsSvc.ListTaskDefinitionFamiliesPages(
+		&ecs.ListTaskDefinitionFamiliesInput{Status: aws.String(ecs.TaskDefinitionFamilyStatusActive)},
+		func(page *ecs.ListTaskDefinitionFamiliesOutput, lastPage bool) bool {
+			families = append(families, page.Families...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("ECS.ListTaskDefinitionFamiliesPages", err)
+	}
+	return
+}
+
+// describeTaskDefinition returns the latest active revision of a task definition family
+func describeTaskDefinition(ecsSvc ecsiface.ECSAPI, family *string) (*ecs.DescribeTaskDefinitionOutput, error) {
+	out, err := ecsSvc.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		Include:        []*string{aws.String(ecs.TaskDefinitionFieldTags)},
+		TaskDefinition: family,
+	})
+	if err != nil {
+		// ECS reports a family without any active revision as a client error
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecs.ErrCodeClientException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *family),
+				zap.String("resourceType", awsmodels.EcsTaskDefinitionSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("ECS.DescribeTaskDefinition", err)
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// buildEcsTaskDefinitionSnapshot returns a complete snapshot of the latest revision of a task definition family
+func buildEcsTaskDefinitionSnapshot(ecsSvc ecsiface.ECSAPI, family *string) *awsmodels.EcsTaskDefinition {
+	if family == nil {
+		return nil
+	}
+
+	out, err := describeTaskDefinition(ecsSvc, family)
+	if err != nil || out == nil || out.TaskDefinition == nil {
+		return nil
+	}
+	details := out.TaskDefinition
+
+	// Drop the revision from the ARN to identify the family
+	familyARN := details.TaskDefinitionArn
+	if idx := strings.LastIndex(*familyARN, ":"); idx > 0 {
+		familyARN = aws.String((*familyARN)[:idx])
+	}
+
+	return &awsmodels.EcsTaskDefinition{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   familyARN,
+			ResourceType: aws.String(awsmodels.EcsTaskDefinitionSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  details.TaskDefinitionArn,
+			Name: details.Family,
+			Tags: utils.ParseTagSlice(out.Tags),
+		},
+		Compatibilities:         details.Compatibilities,
+		ContainerDefinitions:    details.ContainerDefinitions,
+		Cpu:                     details.Cpu,
+		ExecutionRoleArn:        details.ExecutionRoleArn,
+		Family:                  details.Family,
+		InferenceAccelerators:   details.InferenceAccelerators,
+		IpcMode:                 details.IpcMode,
+		Memory:                  details.Memory,
+		NetworkMode:             details.NetworkMode,
+		PidMode:                 details.PidMode,
+		PlacementConstraints:    details.PlacementConstraints,
+		ProxyConfiguration:      details.ProxyConfiguration,
+		RequiresAttributes:      details.RequiresAttributes,
+		RequiresCompatibilities: details.RequiresCompatibilities,
+		Revision:                details.Revision,
+		Status:                  details.Status,
+		TaskRoleArn:             details.TaskRoleArn,
+		Volumes:                 details.Volumes,
+	}
+}
+
+// PollEcsTaskDefinitions gathers information on each ECS Task Definition family for an AWS account.
+func PollEcsTaskDefinitions(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ECS Task Definition resource poller")
+	taskDefinitionSnapshots := make(map[string]*awsmodels.EcsTaskDefinition)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "ecs") {
+		ecsSvc, err := getEcsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all task definition families
+		families := listTaskDefinitionFamilies(ecsSvc)
+		if len(families) == 0 {
+			zap.L().Debug("no ECS task definitions found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, family := range families {
+			taskDefinitionSnapshot := buildEcsTaskDefinitionSnapshot(ecsSvc, family)
+			if taskDefinitionSnapshot == nil {
+				continue
+			}
+			taskDefinitionSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			taskDefinitionSnapshot.Region = regionID
+
+			if _, ok := taskDefinitionSnapshots[*taskDefinitionSnapshot.ResourceID]; ok {
+				zap.L().Info(
+					"overwriting existing ECS Task Definition snapshot",
+					zap.String("resourceId", *taskDefinitionSnapshot.ResourceID),
+				)
+			}
+			taskDefinitionSnapshots[*taskDefinitionSnapshot.ResourceID] = taskDefinitionSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(taskDefinitionSnapshots))
+	for resourceID, taskDefinitionSnapshot := range taskDefinitionSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      taskDefinitionSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.EcsTaskDefinitionSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,117 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcsTaskDefinitionList(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvc([]string{"ListTaskDefinitionFamiliesPages"})
+
+	out := listTaskDefinitionFamilies(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcsTaskDefinitionListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcError([]string{"ListTaskDefinitionFamiliesPages"})
+
+	out := listTaskDefinitionFamilies(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestEcsTaskDefinitionDescribeDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockEcs{}
+	mockSvc.On("DescribeTaskDefinition", mock.Anything).
+		Return(
+			&ecs.DescribeTaskDefinitionOutput{},
+			awserr.New(ecs.ErrCodeClientException, "Unable to describe task definition.", nil),
+		)
+
+	out, err := describeTaskDefinition(mockSvc, aws.String("example-task"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcsTaskDefinitionBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcAll()
+
+	taskDefinitionSnapshot := buildEcsTaskDefinitionSnapshot(mockSvc, aws.String("example-task"))
+
+	require.NotNil(t, taskDefinitionSnapshot)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:task-definition/example-task", *taskDefinitionSnapshot.ResourceID)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:task-definition/example-task:3", *taskDefinitionSnapshot.ARN)
+	assert.Equal(t, int64(3), *taskDefinitionSnapshot.Revision)
+	assert.Equal(t, "Value1", *taskDefinitionSnapshot.Tags["Key1"])
+}
+
+func TestEcsTaskDefinitionBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEcsSvcAllError()
+
+	taskDefinitionSnapshot := buildEcsTaskDefinitionSnapshot(mockSvc, aws.String("example-task"))
+
+	assert.Nil(t, taskDefinitionSnapshot)
+}
+
+func TestEcsTaskDefinitionPoller(t *testing.T) {
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAll()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, err := PollEcsTaskDefinitions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:task-definition/example-task", string(resources[0].ID))
+}
+
+func TestEcsTaskDefinitionPollerError(t *testing.T) {
+	awstest.MockEcsForSetup = awstest.BuildMockEcsSvcAllError()
+
+	EcsClientFunc = awstest.SetupMockEcs
+
+	resources, err := PollEcsTaskDefinitions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
@@ -0,0 +1,188 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var EksClientFunc = setupEksClient
+
+func setupEksClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return eks.New(sess, cfg)
+}
+
+func getEksClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (eksiface.EKSAPI, error) {
+	client, err := getClient(pollerResourceInput, EksClientFunc, "eks", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(eksiface.EKSAPI), nil
+}
+
+// PollEKSCluster polls a single EKS cluster resource
+func PollEKSCluster(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEksClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form "cluster/<name>"
+	snapshot := buildEksClusterSnapshot(client, aws.String(resourceARN.Resource[len("cluster/"):]))
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.Region = aws.String(resourceARN.Region)
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+
+	return snapshot, nil
+}
+
+// listEksClusters returns the names of all EKS clusters in the account
+func listEksClusters(eksSvc eksiface.EKSAPI) (clusters []*string) {
+	err := eksSvc.ListClustersPages(&eks.ListClustersInput{},
+		func(page *eks.ListClustersOutput, lastPage bool) bool {
+			clusters = append(clusters, page.Clusters...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("EKS.ListClustersPages", err)
+	}
+	return
+}
+
+// describeEksCluster provides detailed information for a given EKS cluster
+func describeEksCluster(eksSvc eksiface.EKSAPI, name *string) (*eks.Cluster, error) {
+	out, err := eksSvc.DescribeCluster(&eks.DescribeClusterInput{Name: name})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == eks.ErrCodeResourceNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *name),
+				zap.String("resourceType", awsmodels.EksClusterSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("EKS.DescribeCluster", err)
+		return nil, err
+	}
+
+	return out.Cluster, nil
+}
+
+// buildEksClusterSnapshot returns a complete snapshot of an EKS cluster
+func buildEksClusterSnapshot(eksSvc eksiface.EKSAPI, name *string) *awsmodels.EksCluster {
+	if name == nil {
+		return nil
+	}
+
+	details, err := describeEksCluster(eksSvc, name)
+	if err != nil || details == nil {
+		return nil
+	}
+
+	return &awsmodels.EksCluster{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   details.Arn,
+			ResourceType: aws.String(awsmodels.EksClusterSchema),
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(details.CreatedAt)),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  details.Arn,
+			Name: details.Name,
+			Tags: details.Tags,
+		},
+		CertificateAuthority: details.CertificateAuthority,
+		EncryptionConfig:     details.EncryptionConfig,
+		Endpoint:             details.Endpoint,
+		Identity:             details.Identity,
+		Logging:              details.Logging,
+		PlatformVersion:      details.PlatformVersion,
+		ResourcesVpcConfig:   details.ResourcesVpcConfig,
+		RoleArn:              details.RoleArn,
+		Status:               details.Status,
+		Version:              details.Version,
+	}
+}
+
+// PollEksClusters gathers information on each EKS Cluster for an AWS account.
+func PollEksClusters(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting EKS Cluster resource poller")
+	eksClusterSnapshots := make(map[string]*awsmodels.EksCluster)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "eks") {
+		eksSvc, err := getEksClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all clusters
+		clusters := listEksClusters(eksSvc)
+		if len(clusters) == 0 {
+			zap.L().Debug("no EKS clusters found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, clusterName := range clusters {
+			eksClusterSnapshot := buildEksClusterSnapshot(eksSvc, clusterName)
+			if eksClusterSnapshot == nil {
+				continue
+			}
+			eksClusterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			eksClusterSnapshot.Region = regionID
+
+			if _, ok := eksClusterSnapshots[*eksClusterSnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing EKS Cluster snapshot",
+					zap.String("resourceId", *eksClusterSnapshot.ARN),
+				)
+			}
+			eksClusterSnapshots[*eksClusterSnapshot.ARN] = eksClusterSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(eksClusterSnapshots))
+	for resourceID, eksSnapshot := range eksClusterSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      eksSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.EksClusterSchema,
+		})
+	}
+
+	return resources, nil
+}
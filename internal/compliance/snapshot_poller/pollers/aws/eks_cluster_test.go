@@ -0,0 +1,132 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEksClusterList(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"ListClustersPages"})
+
+	out := listEksClusters(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestEksClusterListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcError([]string{"ListClustersPages"})
+
+	out := listEksClusters(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestEksClusterDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"DescribeCluster"})
+
+	out, err := describeEksCluster(mockSvc, aws.String("example-cluster"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEksClusterDescribeDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockEks{}
+	mockSvc.On("DescribeCluster", mock.Anything).
+		Return(
+			&eks.DescribeClusterOutput{},
+			awserr.New(eks.ErrCodeResourceNotFoundException, "cluster does not exist", nil),
+		)
+
+	out, err := describeEksCluster(mockSvc, aws.String("example-cluster"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEksClusterDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcError([]string{"DescribeCluster"})
+
+	out, err := describeEksCluster(mockSvc, aws.String("example-cluster"))
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEksClusterBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcAll()
+
+	clusterSnapshot := buildEksClusterSnapshot(mockSvc, aws.String("example-cluster"))
+
+	require.NotNil(t, clusterSnapshot)
+	assert.Equal(t, awstest.ExampleEksClusterArn, clusterSnapshot.ARN)
+	assert.False(t, *clusterSnapshot.ResourcesVpcConfig.EndpointPublicAccess)
+	assert.Equal(t, "Value1", *clusterSnapshot.Tags["Key1"])
+}
+
+func TestEksClusterBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcAllError()
+
+	clusterSnapshot := buildEksClusterSnapshot(mockSvc, aws.String("example-cluster"))
+
+	assert.Nil(t, clusterSnapshot)
+}
+
+func TestEksClusterPoller(t *testing.T) {
+	awstest.MockEksForSetup = awstest.BuildMockEksSvcAll()
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resources, err := PollEksClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, *awstest.ExampleEksClusterArn, string(resources[0].ID))
+}
+
+func TestEksClusterPollerError(t *testing.T) {
+	awstest.MockEksForSetup = awstest.BuildMockEksSvcAllError()
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resources, err := PollEksClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
@@ -0,0 +1,270 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const elbLoadBalancerResourcePrefix = "loadbalancer/"
+
+// Set as variables to be overridden in testing
+var ElbClientFunc = setupElbClient
+
+func setupElbClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return elb.New(sess, cfg)
+}
+
+func getElbClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (elbiface.ELBAPI, error) {
+	client, err := getClient(pollerResourceInput, ElbClientFunc, "elb", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(elbiface.ELBAPI), nil
+}
+
+// elbLoadBalancerARN builds the ARN of a classic load balancer, which the ELB API does not return
+//
+// Format: arn:aws:elasticloadbalancing:region:account-id:loadbalancer/name
+func elbLoadBalancerARN(region, accountID string, name *string) *string {
+	return aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "elasticloadbalancing",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  elbLoadBalancerResourcePrefix + aws.StringValue(name),
+	}.String())
+}
+
+// PollELBLoadBalancer polls a single classic ELB load balancer resource
+func PollELBLoadBalancer(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getElbClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(resourceARN.Resource, elbLoadBalancerResourcePrefix)
+	loadBalancer, err := describeElbLoadBalancer(client, aws.String(name))
+	if err != nil || loadBalancer == nil {
+		return nil, err
+	}
+
+	snapshot := buildElbLoadBalancerSnapshot(client, loadBalancer, resourceARN.Region, resourceARN.AccountID)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+
+	return snapshot, nil
+}
+
+// describeElbLoadBalancers returns all the classic load balancers in the account in the current region
+func describeElbLoadBalancers(elbSvc elbiface.ELBAPI) (loadBalancers []*elb.LoadBalancerDescription, err error) {
+	err = elbSvc.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{},
+		func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+			loadBalancers = append(loadBalancers, page.LoadBalancerDescriptions...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "ELB.DescribeLoadBalancersPages")
+	}
+	return
+}
+
+// describeElbLoadBalancer returns a specific classic load balancer
+func describeElbLoadBalancer(elbSvc elbiface.ELBAPI, name *string) (*elb.LoadBalancerDescription, error) {
+	out, err := elbSvc.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{name}})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elb.ErrCodeAccessPointNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *name),
+				zap.String("resourceType", awsmodels.ElbLoadBalancerSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ELB.DescribeLoadBalancers: %s", aws.StringValue(name))
+	}
+
+	if len(out.LoadBalancerDescriptions) == 0 {
+		return nil, nil
+	}
+	return out.LoadBalancerDescriptions[0], nil
+}
+
+// describeElbLoadBalancerAttributes returns the access logging, connection draining and cross zone
+// configuration of a classic load balancer
+func describeElbLoadBalancerAttributes(elbSvc elbiface.ELBAPI, name *string) (*elb.LoadBalancerAttributes, error) {
+	out, err := elbSvc.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{LoadBalancerName: name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ELB.DescribeLoadBalancerAttributes: %s", aws.StringValue(name))
+	}
+	return out.LoadBalancerAttributes, nil
+}
+
+// describeElbLoadBalancerPolicies returns the policies of a classic load balancer, including the SSL
+// negotiation policies that determine the TLS protocols and ciphers of its listeners
+func describeElbLoadBalancerPolicies(elbSvc elbiface.ELBAPI, name *string) ([]*elb.PolicyDescription, error) {
+	out, err := elbSvc.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{LoadBalancerName: name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ELB.DescribeLoadBalancerPolicies: %s", aws.StringValue(name))
+	}
+	return out.PolicyDescriptions, nil
+}
+
+// describeElbTags returns the tags of a classic load balancer
+func describeElbTags(elbSvc elbiface.ELBAPI, name *string) ([]*elb.Tag, error) {
+	out, err := elbSvc.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{name}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ELB.DescribeTags: %s", aws.StringValue(name))
+	}
+	if len(out.TagDescriptions) == 0 {
+		return nil, nil
+	}
+	return out.TagDescriptions[0].Tags, nil
+}
+
+// buildElbLoadBalancerSnapshot makes all the calls to build up a snapshot of a given classic load balancer
+func buildElbLoadBalancerSnapshot(
+	elbSvc elbiface.ELBAPI,
+	lb *elb.LoadBalancerDescription,
+	region, accountID string,
+) *awsmodels.ElbLoadBalancer {
+
+	if lb == nil {
+		return nil
+	}
+
+	lbARN := elbLoadBalancerARN(region, accountID, lb.LoadBalancerName)
+	loadBalancer := &awsmodels.ElbLoadBalancer{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   lbARN,
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(lb.CreatedTime)),
+			ResourceType: aws.String(awsmodels.ElbLoadBalancerSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  lbARN,
+			Name: lb.LoadBalancerName,
+		},
+		AvailabilityZones:         lb.AvailabilityZones,
+		BackendServerDescriptions: lb.BackendServerDescriptions,
+		CanonicalHostedZoneName:   lb.CanonicalHostedZoneName,
+		CanonicalHostedZoneNameID: lb.CanonicalHostedZoneNameID,
+		DNSName:                   lb.DNSName,
+		HealthCheck:               lb.HealthCheck,
+		Instances:                 lb.Instances,
+		ListenerDescriptions:      lb.ListenerDescriptions,
+		Policies:                  lb.Policies,
+		Scheme:                    lb.Scheme,
+		SecurityGroups:            lb.SecurityGroups,
+		SourceSecurityGroup:       lb.SourceSecurityGroup,
+		Subnets:                   lb.Subnets,
+		VPCId:                     lb.VPCId,
+	}
+
+	var err error
+	loadBalancer.Attributes, err = describeElbLoadBalancerAttributes(elbSvc, lb.LoadBalancerName)
+	if err != nil {
+		utils.LogAWSError("ELB.DescribeLoadBalancerAttributes", err)
+		return nil
+	}
+
+	loadBalancer.PolicyDescriptions, err = describeElbLoadBalancerPolicies(elbSvc, lb.LoadBalancerName)
+	if err != nil {
+		utils.LogAWSError("ELB.DescribeLoadBalancerPolicies", err)
+		return nil
+	}
+
+	tags, err := describeElbTags(elbSvc, lb.LoadBalancerName)
+	if err != nil {
+		utils.LogAWSError("ELB.DescribeTags", err)
+		return nil
+	}
+	loadBalancer.Tags = utils.ParseTagSlice(tags)
+
+	return loadBalancer
+}
+
+// PollElbLoadBalancers gathers information on each classic load balancer for an AWS account.
+func PollElbLoadBalancers(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ELB Load Balancer resource poller")
+	elbLoadBalancerSnapshots := make(map[string]*awsmodels.ElbLoadBalancer)
+	accountID := pollerInput.AuthSourceParsedARN.AccountID
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "elasticloadbalancing") {
+		elbSvc, err := getElbClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		loadBalancers, err := describeElbLoadBalancers(elbSvc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "PollElbLoadBalancers(%#v) in region %s", *pollerInput, *regionID)
+		}
+
+		for _, loadBalancer := range loadBalancers {
+			snapshot := buildElbLoadBalancerSnapshot(elbSvc, loadBalancer, *regionID, accountID)
+			if snapshot == nil {
+				continue
+			}
+			snapshot.AccountID = aws.String(accountID)
+			snapshot.Region = regionID
+
+			if _, ok := elbLoadBalancerSnapshots[*snapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing ELB Load Balancer snapshot",
+					zap.String("resourceId", *snapshot.ARN),
+				)
+			}
+			elbLoadBalancerSnapshots[*snapshot.ARN] = snapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(elbLoadBalancerSnapshots))
+	for resourceID, snapshot := range elbLoadBalancerSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.ElbLoadBalancerSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,150 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestElbDescribeLoadBalancers(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvc([]string{"DescribeLoadBalancersPages"})
+
+	out, err := describeElbLoadBalancers(mockSvc)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestElbDescribeLoadBalancersError(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcError([]string{"DescribeLoadBalancersPages"})
+
+	out, err := describeElbLoadBalancers(mockSvc)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElbDescribeLoadBalancerDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockElb{}
+	mockSvc.On("DescribeLoadBalancers", mock.Anything).
+		Return(
+			&elb.DescribeLoadBalancersOutput{},
+			awserr.New(elb.ErrCodeAccessPointNotFoundException, "There is no ACTIVE Load Balancer named 'example-classic'", nil),
+		)
+
+	out, err := describeElbLoadBalancer(mockSvc, aws.String("example-classic"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElbDescribeLoadBalancerError(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcError([]string{"DescribeLoadBalancers"})
+
+	out, err := describeElbLoadBalancer(mockSvc, aws.String("example-classic"))
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElbBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcAll()
+
+	lbSnapshot := buildElbLoadBalancerSnapshot(mockSvc, awstest.ExampleElbLoadBalancer, "us-west-2", "123456789012")
+
+	require.NotNil(t, lbSnapshot)
+	assert.Equal(t, awstest.ExampleElbLoadBalancerArn, lbSnapshot.ARN)
+	assert.True(t, *lbSnapshot.Attributes.AccessLog.Enabled)
+	assert.Equal(t, "SSLNegotiationPolicyType", *lbSnapshot.PolicyDescriptions[0].PolicyTypeName)
+	assert.Equal(t, "Value1", *lbSnapshot.Tags["Key1"])
+}
+
+func TestElbBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockElbSvcAllError()
+
+	lbSnapshot := buildElbLoadBalancerSnapshot(mockSvc, awstest.ExampleElbLoadBalancer, "us-west-2", "123456789012")
+
+	assert.Nil(t, lbSnapshot)
+}
+
+func TestElbPollSingle(t *testing.T) {
+	awstest.MockElbForSetup = awstest.BuildMockElbSvcAll()
+
+	ElbClientFunc = awstest.SetupMockElb
+
+	resourceARN, err := arn.Parse(*awstest.ExampleElbLoadBalancerArn)
+	require.NoError(t, err)
+
+	snapshot, err := PollELBLoadBalancer(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleElbLoadBalancerArn})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, awstest.ExampleElbLoadBalancerArn, snapshot.(*awsmodels.ElbLoadBalancer).ResourceID)
+}
+
+func TestElbPoller(t *testing.T) {
+	awstest.MockElbForSetup = awstest.BuildMockElbSvcAll()
+
+	ElbClientFunc = awstest.SetupMockElb
+
+	resources, err := PollElbLoadBalancers(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	// The ARN is built from the region, so there is one load balancer per region
+	assert.Len(t, resources, len(awstest.ExampleRegions))
+	assert.Contains(t, string(resources[0].ID), ":loadbalancer/example-classic")
+}
+
+func TestElbPollerError(t *testing.T) {
+	awstest.MockElbForSetup = awstest.BuildMockElbSvcAllError()
+
+	ElbClientFunc = awstest.SetupMockElb
+
+	resources, err := PollElbLoadBalancers(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Empty(t, resources)
+}
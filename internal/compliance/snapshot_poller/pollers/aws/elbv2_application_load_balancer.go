@@ -72,7 +72,7 @@ func PollELBV2LoadBalancer(
 		return nil, err
 	}
 
-	loadBalancer := getApplicationLoadBalancer(elbv2Client, scanRequest.ResourceID)
+	loadBalancer := getElbv2LoadBalancer(elbv2Client, scanRequest.ResourceID, awsmodels.Elbv2LoadBalancerSchema)
 
 	snapshot := buildElbv2ApplicationLoadBalancerSnapshot(elbv2Client, wafClient, loadBalancer)
 	if snapshot == nil {
@@ -84,8 +84,8 @@ func PollELBV2LoadBalancer(
 	return snapshot, nil
 }
 
-// getApplicationLoadBalancer returns a specifc ELBV2 application load balancer
-func getApplicationLoadBalancer(svc elbv2iface.ELBV2API, loadBalancerARN *string) *elbv2.LoadBalancer {
+// getElbv2LoadBalancer returns a specific ELBV2 load balancer
+func getElbv2LoadBalancer(svc elbv2iface.ELBV2API, loadBalancerARN *string, resourceType string) *elbv2.LoadBalancer {
 	loadBalancer, err := svc.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
 		LoadBalancerArns: []*string{loadBalancerARN},
 	})
@@ -94,7 +94,7 @@ func getApplicationLoadBalancer(svc elbv2iface.ELBV2API, loadBalancerARN *string
 			if awsErr.Code() == "LoadBalancerNotFound" {
 				zap.L().Warn("tried to scan non-existent resource",
 					zap.String("resource", *loadBalancerARN),
-					zap.String("resourceType", awsmodels.Elbv2LoadBalancerSchema))
+					zap.String("resourceType", resourceType))
 				return nil
 			}
 		}
@@ -178,6 +178,30 @@ func generateSSLPolicies(svc elbv2iface.ELBV2API) {
 	}
 }
 
+// describeListenersAndSSLPolicies builds the list of listeners and associated SSL Policies for a load balancer
+func describeListenersAndSSLPolicies(
+	elbv2Svc elbv2iface.ELBV2API, arn *string) (listeners []*elbv2.Listener, policies map[string]*elbv2.SslPolicy) {
+
+	listeners = describeListeners(elbv2Svc, arn)
+	if len(listeners) == 0 {
+		return nil, nil
+	}
+
+	policies = make(map[string]*elbv2.SslPolicy)
+	for _, listener := range listeners {
+		if listener.SslPolicy == nil {
+			continue
+		}
+		if sslPolicies == nil {
+			generateSSLPolicies(elbv2Svc)
+		}
+		if policy, ok := sslPolicies[*listener.SslPolicy]; ok {
+			policies[*listener.SslPolicy] = policy
+		}
+	}
+	return listeners, policies
+}
+
 // buildElbv2ApplicationLoadBalancerSnapshot makes all the calls to build up a snapshot of a given
 // application load balancer
 func buildElbv2ApplicationLoadBalancerSnapshot(
@@ -216,23 +240,7 @@ func buildElbv2ApplicationLoadBalancerSnapshot(
 		applicationLoadBalancer.Tags = utils.ParseTagSlice(tags)
 	}
 
-	// Build the list of listeners and associated SSL Policies for the load balancer
-	listeners := describeListeners(elbv2Svc, lb.LoadBalancerArn)
-	if len(listeners) != 0 {
-		applicationLoadBalancer.Listeners = listeners
-		applicationLoadBalancer.SSLPolicies = make(map[string]*elbv2.SslPolicy)
-		for _, listener := range listeners {
-			if listener.SslPolicy == nil {
-				continue
-			}
-			if sslPolicies == nil {
-				generateSSLPolicies(elbv2Svc)
-			}
-			if policy, ok := sslPolicies[*listener.SslPolicy]; ok {
-				applicationLoadBalancer.SSLPolicies[*listener.SslPolicy] = policy
-			}
-		}
-	}
+	applicationLoadBalancer.Listeners, applicationLoadBalancer.SSLPolicies = describeListenersAndSSLPolicies(elbv2Svc, lb.LoadBalancerArn)
 
 	// Try to find a webACL ID
 	webACL, err := getWebACLForResource(wafRegionalSvc, lb.LoadBalancerArn)
@@ -286,6 +294,10 @@ func PollElbv2ApplicationLoadBalancers(pollerInput *awsmodels.ResourcePollerInpu
 		generateSSLPolicies(elbv2Svc)
 
 		for _, loadBalancer := range loadBalancers {
+			// Network load balancers are tracked as their own resource type
+			if aws.StringValue(loadBalancer.Type) != elbv2.LoadBalancerTypeEnumApplication {
+				continue
+			}
 			elbv2LoadBalancer := buildElbv2ApplicationLoadBalancerSnapshot(
 				elbv2Svc,
 				wafRegionalSvc,
@@ -142,6 +142,8 @@ func TestElbv2ApplicationLoadBalancersPoller(t *testing.T) {
 	})
 
 	require.NoError(t, err)
+	// Network load balancers are not included
+	require.Len(t, resources, 1)
 	assert.Equal(
 		t,
 		*awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[0].LoadBalancerArn,
@@ -0,0 +1,172 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// PollELBV2NetworkLoadBalancer polls a single ELBV2 Network Load Balancer resource
+func PollELBV2NetworkLoadBalancer(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	elbv2Client, err := getElbv2Client(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancer := getElbv2LoadBalancer(elbv2Client, scanRequest.ResourceID, awsmodels.Elbv2NetworkLoadBalancerSchema)
+
+	snapshot := buildElbv2NetworkLoadBalancerSnapshot(elbv2Client, loadBalancer)
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeLoadBalancerAttributes returns the attributes of a load balancer, such as its access logging configuration
+func describeLoadBalancerAttributes(svc elbv2iface.ELBV2API, arn *string) (map[string]*string, error) {
+	out, err := svc.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: arn})
+	if err != nil {
+		utils.LogAWSError("ELBV2.DescribeLoadBalancerAttributes", err)
+		return nil, err
+	}
+
+	attributes := make(map[string]*string, len(out.Attributes))
+	for _, attribute := range out.Attributes {
+		attributes[aws.StringValue(attribute.Key)] = attribute.Value
+	}
+	return attributes, nil
+}
+
+// buildElbv2NetworkLoadBalancerSnapshot makes all the calls to build up a snapshot of a given
+// network load balancer
+func buildElbv2NetworkLoadBalancerSnapshot(elbv2Svc elbv2iface.ELBV2API, lb *elbv2.LoadBalancer) *awsmodels.Elbv2NetworkLoadBalancer {
+	if lb == nil {
+		return nil
+	}
+
+	networkLoadBalancer := &awsmodels.Elbv2NetworkLoadBalancer{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   lb.LoadBalancerArn,
+			TimeCreated:  utils.DateTimeFormat(*lb.CreatedTime),
+			ResourceType: aws.String(awsmodels.Elbv2NetworkLoadBalancerSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  lb.LoadBalancerArn,
+			Name: lb.LoadBalancerName,
+		},
+		AvailabilityZones:     lb.AvailabilityZones,
+		CanonicalHostedZoneId: lb.CanonicalHostedZoneId,
+		DNSName:               lb.DNSName,
+		IpAddressType:         lb.IpAddressType,
+		Scheme:                lb.Scheme,
+		State:                 lb.State,
+		Type:                  lb.Type,
+		VpcId:                 lb.VpcId,
+	}
+
+	tags, err := describeTags(elbv2Svc, lb.LoadBalancerArn)
+	if err == nil {
+		networkLoadBalancer.Tags = utils.ParseTagSlice(tags)
+	}
+
+	attributes, err := describeLoadBalancerAttributes(elbv2Svc, lb.LoadBalancerArn)
+	if err == nil {
+		networkLoadBalancer.Attributes = attributes
+	}
+
+	networkLoadBalancer.Listeners, networkLoadBalancer.SSLPolicies = describeListenersAndSSLPolicies(elbv2Svc, lb.LoadBalancerArn)
+
+	return networkLoadBalancer
+}
+
+// PollElbv2NetworkLoadBalancers gathers information on each network load balancer for an AWS account.
+func PollElbv2NetworkLoadBalancers(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ELBV2 Network Load Balancer resource poller")
+	networkLoadBalancerSnapshots := make(map[string]*awsmodels.Elbv2NetworkLoadBalancer)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "elasticloadbalancing") {
+		elbv2Svc, err := getElbv2Client(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		loadBalancers, err := describeLoadBalancers(elbv2Svc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "PollElbv2NetworkLoadBalancers(%#v) in region %s", *pollerInput, *regionID)
+		}
+
+		// The SSL policies are shared by all the load balancer snapshots in the region
+		policiesGenerated := false
+		for _, loadBalancer := range loadBalancers {
+			if aws.StringValue(loadBalancer.Type) != elbv2.LoadBalancerTypeEnumNetwork {
+				continue
+			}
+			if !policiesGenerated {
+				generateSSLPolicies(elbv2Svc)
+				policiesGenerated = true
+			}
+
+			snapshot := buildElbv2NetworkLoadBalancerSnapshot(elbv2Svc, loadBalancer)
+			if snapshot == nil {
+				continue
+			}
+			snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			snapshot.Region = regionID
+
+			if _, ok := networkLoadBalancerSnapshots[*snapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing ELB v2 Network Load Balancer snapshot",
+					zap.String("resourceId", *snapshot.ARN),
+				)
+			}
+			networkLoadBalancerSnapshots[*snapshot.ARN] = snapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(networkLoadBalancerSnapshots))
+	for resourceID, snapshot := range networkLoadBalancerSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.Elbv2NetworkLoadBalancerSchema,
+		})
+	}
+
+	return resources, nil
+}
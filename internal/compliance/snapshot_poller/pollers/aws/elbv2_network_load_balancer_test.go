@@ -0,0 +1,106 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestElbv2DescribeLoadBalancerAttributes(t *testing.T) {
+	mockSvc := awstest.BuildMockElbv2Svc([]string{"DescribeLoadBalancerAttributes"})
+
+	out, err := describeLoadBalancerAttributes(mockSvc, awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[1].LoadBalancerArn)
+	require.NoError(t, err)
+	assert.Equal(t, "true", *out["access_logs.s3.enabled"])
+}
+
+func TestElbv2DescribeLoadBalancerAttributesError(t *testing.T) {
+	mockSvc := awstest.BuildMockElbv2SvcError([]string{"DescribeLoadBalancerAttributes"})
+
+	out, err := describeLoadBalancerAttributes(mockSvc, awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[1].LoadBalancerArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildElbv2NetworkLoadBalancerSnapshot(t *testing.T) {
+	mockElbv2Svc := awstest.BuildMockElbv2SvcAll()
+
+	nlbSnapshot := buildElbv2NetworkLoadBalancerSnapshot(mockElbv2Svc, awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[1])
+
+	require.NotNil(t, nlbSnapshot)
+	assert.Equal(t, awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[1].LoadBalancerArn, nlbSnapshot.ResourceID)
+	assert.Equal(t, "network", *nlbSnapshot.Type)
+	assert.NotEmpty(t, nlbSnapshot.Attributes)
+	assert.NotEmpty(t, nlbSnapshot.Listeners)
+	assert.NotEmpty(t, nlbSnapshot.Tags)
+}
+
+func TestBuildElbv2NetworkLoadBalancerSnapshotError(t *testing.T) {
+	mockElbv2Svc := awstest.BuildMockElbv2SvcAllError()
+
+	nlbSnapshot := buildElbv2NetworkLoadBalancerSnapshot(mockElbv2Svc, awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[1])
+
+	require.NotNil(t, nlbSnapshot)
+	assert.Nil(t, nlbSnapshot.Attributes)
+	assert.Nil(t, nlbSnapshot.Listeners)
+	assert.Nil(t, nlbSnapshot.Tags)
+}
+
+func TestElbv2NetworkLoadBalancersPoller(t *testing.T) {
+	awstest.MockElbv2ForSetup = awstest.BuildMockElbv2SvcAll()
+
+	Elbv2ClientFunc = awstest.SetupMockElbv2
+
+	resources, err := PollElbv2NetworkLoadBalancers(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[1].LoadBalancerArn, string(resources[0].ID))
+	assert.Equal(t, awsmodels.Elbv2NetworkLoadBalancerSchema, string(resources[0].Type))
+	assert.NotNil(t, resources[0].Attributes.(*awsmodels.Elbv2NetworkLoadBalancer).SSLPolicies["ELBSecurityPolicy1"])
+}
+
+func TestElbv2NetworkLoadBalancersPollerError(t *testing.T) {
+	awstest.MockElbv2ForSetup = awstest.BuildMockElbv2SvcAllError()
+
+	Elbv2ClientFunc = awstest.SetupMockElbv2
+
+	resources, err := PollElbv2NetworkLoadBalancers(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Empty(t, resources)
+}
@@ -121,7 +121,7 @@ func getRolePolicy(iamSvc iamiface.IAMAPI, roleName *string, policyName *string)
 // getRolePolicies aggregates all the policies assigned to a user by polling both
 // the ListRolePolicies and ListAttachedRolePolicies APIs.
 func getRolePolicies(iamSvc iamiface.IAMAPI, roleName *string) (
-	inlinePolicies []*string, managedPolicies []*string, err error) {
+	inlinePolicies []*string, managedPolicies []*iam.AttachedPolicy, err error) {
 
 	err = iamSvc.ListRolePoliciesPages(
 		&iam.ListRolePoliciesInput{RoleName: roleName},
@@ -137,9 +137,7 @@ func getRolePolicies(iamSvc iamiface.IAMAPI, roleName *string) (
 	err = iamSvc.ListAttachedRolePoliciesPages(
 		&iam.ListAttachedRolePoliciesInput{RoleName: roleName},
 		func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
-			for _, attachedPolicy := range page.AttachedPolicies {
-				managedPolicies = append(managedPolicies, attachedPolicy.PolicyName)
-			}
+			managedPolicies = append(managedPolicies, page.AttachedPolicies...)
 			return true
 		},
 	)
@@ -150,6 +148,24 @@ func getRolePolicies(iamSvc iamiface.IAMAPI, roleName *string) (
 	return
 }
 
+// listInstanceProfilesForRole returns the ARNs of the instance profiles which pass a role to EC2 instances
+func listInstanceProfilesForRole(iamSvc iamiface.IAMAPI, roleName *string) (instanceProfiles []*string, err error) {
+	err = iamSvc.ListInstanceProfilesForRolePages(
+		&iam.ListInstanceProfilesForRoleInput{RoleName: roleName},
+		func(page *iam.ListInstanceProfilesForRoleOutput, lastPage bool) bool {
+			for _, instanceProfile := range page.InstanceProfiles {
+				instanceProfiles = append(instanceProfiles, instanceProfile.Arn)
+			}
+			return true
+		},
+	)
+	if err != nil {
+		utils.LogAWSError("IAM.ListInstanceProfilesForRole", err)
+	}
+
+	return
+}
+
 // buildIAMRoleSnapshot builds an IAMRoleSnapshot for a given IAM Role
 func BuildIAMRoleSnapshot(iamSvc iamiface.IAMAPI, role *iam.Role) *awsmodels.IAMRole {
 	if role == nil {
@@ -190,7 +206,10 @@ func BuildIAMRoleSnapshot(iamSvc iamiface.IAMAPI, role *iam.Role) *awsmodels.IAM
 	// There is no error logging here because it is logged in getRolePolicies.
 	inlinePolicies, managedPolicies, err := getRolePolicies(iamSvc, role.RoleName)
 	if err == nil {
-		iamRoleSnapshot.ManagedPolicyNames = managedPolicies
+		for _, managedPolicy := range managedPolicies {
+			iamRoleSnapshot.ManagedPolicyARNs = append(iamRoleSnapshot.ManagedPolicyARNs, managedPolicy.PolicyArn)
+			iamRoleSnapshot.ManagedPolicyNames = append(iamRoleSnapshot.ManagedPolicyNames, managedPolicy.PolicyName)
+		}
 		if inlinePolicies != nil {
 			iamRoleSnapshot.InlinePolicies = make(map[string]*string, len(inlinePolicies))
 			for _, inlinePolicy := range inlinePolicies {
@@ -199,10 +218,17 @@ func BuildIAMRoleSnapshot(iamSvc iamiface.IAMAPI, role *iam.Role) *awsmodels.IAM
 		}
 	}
 
+	// There is no error logging here because it is logged in listInstanceProfilesForRole.
+	if instanceProfiles, err := listInstanceProfilesForRole(iamSvc, role.RoleName); err == nil {
+		iamRoleSnapshot.InstanceProfileArns = instanceProfiles
+	}
+
 	return iamRoleSnapshot
 }
 
 // PollIAMRoles generates a snapshot for each IAM Role.
+//
+// Scans cut short by throttling are resumed from the first incomplete role.
 func PollIAMRoles(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
 	zap.L().Debug("starting IAM Role resource poller")
 	iamSvc, err := getIAMClient(pollerInput, defaultRegion)
@@ -211,15 +237,20 @@ func PollIAMRoles(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddR
 	}
 
 	// List all IAM Roles in the account
-	roles := listRoles(iamSvc)
-	if len(roles) == 0 {
-		zap.L().Debug("no IAM roles found")
-		return nil, nil
+	rolesByName := make(map[string]*iam.Role)
+	listRoleNames := func() []*string {
+		roles := listRoles(iamSvc)
+		names := make([]*string, 0, len(roles))
+		for _, role := range roles {
+			rolesByName[*role.RoleName] = role
+			names = append(names, role.RoleName)
+		}
+		return names
 	}
 
 	// Create IAM Role snapshots
-	var resources []*apimodels.AddResourceEntry
-	for _, role := range roles {
+	buildRole := func(roleName *string) (*apimodels.AddResourceEntry, error) {
+		role := rolesByName[*roleName]
 		// The IAM.Role struct has a Tags field, indicating what tags the Role has
 		// The API call IAM.GetRole returns an IAM.Role struct, with all appropriate fields set
 		// The API call IAM.ListRoles returns a slice of IAM.Role structs, but does not set the tags
@@ -228,18 +259,19 @@ func PollIAMRoles(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddR
 		fullRole := getRole(iamSvc, role.RoleName)
 		iamRoleSnapshot := BuildIAMRoleSnapshot(iamSvc, fullRole)
 		if iamRoleSnapshot == nil {
-			continue
+			return nil, nil
 		}
 		iamRoleSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
 
-		resources = append(resources, &apimodels.AddResourceEntry{
+		return &apimodels.AddResourceEntry{
 			Attributes:      iamRoleSnapshot,
 			ID:              apimodels.ResourceID(*role.Arn),
 			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
 			IntegrationType: apimodels.IntegrationTypeAws,
 			Type:            awsmodels.IAMRoleSchema,
-		})
+		}, nil
 	}
 
-	return resources, nil
+	// Accounts can have thousands of roles, each taking several requests to describe
+	return resumableScan(pollerInput, awsmodels.IAMRoleSchema, listRoleNames, buildRole)
 }
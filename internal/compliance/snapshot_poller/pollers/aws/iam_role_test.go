@@ -67,7 +67,7 @@ func TestIAMRolesGetPolicies(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(
 		t,
-		[]*string{aws.String("AdministratorAccess")},
+		awstest.ExampleListAttachedRolePoliciesOutput.AttachedPolicies,
 		managedPolicies,
 	)
 	assert.Equal(
@@ -89,6 +89,26 @@ func TestIAMRolesGetPoliciesErrors(t *testing.T) {
 	assert.Empty(t, managedPolicies)
 }
 
+func TestIAMRolesListInstanceProfiles(t *testing.T) {
+	mockSvc := awstest.BuildMockIAMSvc([]string{"ListInstanceProfilesForRolePages"})
+
+	instanceProfiles, err := listInstanceProfilesForRole(mockSvc, aws.String("Franklin"))
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*string{aws.String("arn:aws:iam::123456789012:instance-profile/ExampleProfile")},
+		instanceProfiles,
+	)
+}
+
+func TestIAMRolesListInstanceProfilesError(t *testing.T) {
+	mockSvc := awstest.BuildMockIAMSvcError([]string{"ListInstanceProfilesForRolePages"})
+
+	instanceProfiles, err := listInstanceProfilesForRole(mockSvc, aws.String("Franklin"))
+	require.Error(t, err)
+	assert.Empty(t, instanceProfiles)
+}
+
 func TestIAMRolesPoller(t *testing.T) {
 	awstest.MockIAMForSetup = awstest.BuildMockIAMSvcAll()
 
@@ -105,6 +125,12 @@ func TestIAMRolesPoller(t *testing.T) {
 	assert.NotEmpty(t, resources)
 	assert.Len(t, resources, 1)
 	assert.Equal(t, awstest.ExampleIAMRole.Arn, resources[0].Attributes.(*awsmodels.IAMRole).ARN)
+	assert.Equal(
+		t,
+		[]*string{aws.String("arn:aws:iam::aws:policy/AdministratorAccess")},
+		resources[0].Attributes.(*awsmodels.IAMRole).ManagedPolicyARNs,
+	)
+	assert.NotEmpty(t, resources[0].Attributes.(*awsmodels.IAMRole).InstanceProfileArns)
 }
 
 func TestIAMRolesPollerError(t *testing.T) {
@@ -319,7 +319,7 @@ func listUsers(iamSvc iamiface.IAMAPI) (users []*iam.User) {
 // getUserPolicies aggregates all the policies assigned to a user by polling both
 // the ListUserPolicies and ListAttachedUserPolicies APIs.
 func getUserPolicies(iamSvc iamiface.IAMAPI, userName *string) (
-	inlinePolicies []*string, managedPolicies []*string, err error) {
+	inlinePolicies []*string, managedPolicies []*iam.AttachedPolicy, err error) {
 
 	err = iamSvc.ListUserPoliciesPages(
 		&iam.ListUserPoliciesInput{UserName: userName},
@@ -335,9 +335,7 @@ func getUserPolicies(iamSvc iamiface.IAMAPI, userName *string) (
 	err = iamSvc.ListAttachedUserPoliciesPages(
 		&iam.ListAttachedUserPoliciesInput{UserName: userName},
 		func(page *iam.ListAttachedUserPoliciesOutput, lastPage bool) bool {
-			for _, attachedPolicy := range page.AttachedPolicies {
-				managedPolicies = append(managedPolicies, attachedPolicy.PolicyName)
-			}
+			managedPolicies = append(managedPolicies, page.AttachedPolicies...)
 			return true
 		},
 	)
@@ -446,7 +444,10 @@ func buildIAMUserSnapshot(iamSvc iamiface.IAMAPI, user *iam.User) *awsmodels.IAM
 	// There is no error logging here because it is logged in getUserPolicies.
 	inlinePolicyNames, managedPolicies, err := getUserPolicies(iamSvc, user.UserName)
 	if err == nil {
-		iamUserSnapshot.ManagedPolicyNames = managedPolicies
+		for _, managedPolicy := range managedPolicies {
+			iamUserSnapshot.ManagedPolicyARNs = append(iamUserSnapshot.ManagedPolicyARNs, managedPolicy.PolicyArn)
+			iamUserSnapshot.ManagedPolicyNames = append(iamUserSnapshot.ManagedPolicyNames, managedPolicy.PolicyName)
+		}
 		if inlinePolicyNames != nil {
 			iamUserSnapshot.InlinePolicies = make(map[string]*string, len(inlinePolicyNames))
 			for _, inlinePolicy := range inlinePolicyNames {
@@ -546,6 +547,7 @@ func PollIAMUsers(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddR
 					Entries: []*pollermodels.ScanEntry{{
 						AWSAccountID:  aws.String(pollerInput.AuthSourceParsedARN.AccountID),
 						IntegrationID: pollerInput.IntegrationID,
+						OrgUnitPath:   pollerInput.OrgUnitPath,
 						ResourceType:  aws.String(awsmodels.IAMUserSchema),
 					}},
 				}, credentialReportRequeueDelaySeconds)
@@ -587,6 +589,7 @@ func PollIAMUsers(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddR
 					{
 						AWSAccountID:  iamUserSnapshot.AccountID,
 						IntegrationID: pollerInput.IntegrationID,
+						OrgUnitPath:   pollerInput.OrgUnitPath,
 						ResourceID:    iamUserSnapshot.ResourceID,
 						ResourceType:  iamUserSnapshot.ResourceType,
 					},
@@ -611,6 +614,7 @@ func PollIAMUsers(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddR
 				{
 					AWSAccountID:  aws.String(pollerInput.AuthSourceParsedARN.AccountID),
 					IntegrationID: pollerInput.IntegrationID,
+					OrgUnitPath:   pollerInput.OrgUnitPath,
 					ResourceType:  aws.String(awsmodels.IAMRootUserSchema),
 				},
 			},
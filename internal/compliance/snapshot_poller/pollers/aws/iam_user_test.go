@@ -148,7 +148,7 @@ func TestIAMUsersGetPolicies(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(
 		t,
-		[]*string{aws.String("ForceMFA"), aws.String("IAMAdministrator")},
+		awstest.ExampleListAttachedUserPolicies.AttachedPolicies,
 		managedPolicies,
 	)
 	assert.Equal(
@@ -257,6 +257,10 @@ func TestIAMUsersPoller(t *testing.T) {
 				"KinesisWriteOnly": aws.String("JSON POLICY DOCUMENT"),
 				"SQSCreateQueue":   aws.String("JSON POLICY DOCUMENT"),
 			},
+			ManagedPolicyARNs: []*string{
+				aws.String("arn:aws:iam::123456789012:policy/ForceMFA"),
+				aws.String("arn:aws:iam::123456789012:policy/IAMAdministrator"),
+			},
 			ManagedPolicyNames: []*string{aws.String("ForceMFA"), aws.String("IAMAdministrator")},
 		},
 		{
@@ -283,6 +287,10 @@ func TestIAMUsersPoller(t *testing.T) {
 				"KinesisWriteOnly": aws.String("JSON POLICY DOCUMENT"),
 				"SQSCreateQueue":   aws.String("JSON POLICY DOCUMENT"),
 			},
+			ManagedPolicyARNs: []*string{
+				aws.String("arn:aws:iam::123456789012:policy/ForceMFA"),
+				aws.String("arn:aws:iam::123456789012:policy/IAMAdministrator"),
+			},
 			ManagedPolicyNames: []*string{aws.String("ForceMFA"), aws.String("IAMAdministrator")},
 		},
 	}
@@ -21,6 +21,7 @@ package aws
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
@@ -49,31 +50,45 @@ var (
 	// functions for resources whose ID is their ARN.
 	IndividualARNResourcePollers = map[string]func(
 		input *awsmodels.ResourcePollerInput, arn arn.ARN, entry *pollermodels.ScanEntry) (interface{}, error){
-		awsmodels.AcmCertificateSchema:      PollACMCertificate,
-		awsmodels.CloudFormationStackSchema: PollCloudFormationStack,
-		awsmodels.CloudTrailSchema:          PollCloudTrailTrail,
-		awsmodels.CloudWatchLogGroupSchema:  PollCloudWatchLogsLogGroup,
-		awsmodels.DynamoDBTableSchema:       PollDynamoDBTable,
-		awsmodels.Ec2AmiSchema:              PollEC2Image,
-		awsmodels.Ec2InstanceSchema:         PollEC2Instance,
-		awsmodels.Ec2NetworkAclSchema:       PollEC2NetworkACL,
-		awsmodels.Ec2SecurityGroupSchema:    PollEC2SecurityGroup,
-		awsmodels.Ec2VolumeSchema:           PollEC2Volume,
-		awsmodels.Ec2VpcSchema:              PollEC2VPC,
-		awsmodels.EcsClusterSchema:          PollECSCluster,
-		awsmodels.Elbv2LoadBalancerSchema:   PollELBV2LoadBalancer,
-		awsmodels.IAMGroupSchema:            PollIAMGroup,
-		awsmodels.IAMPolicySchema:           PollIAMPolicy,
-		awsmodels.IAMRoleSchema:             PollIAMRole,
-		awsmodels.IAMUserSchema:             PollIAMUser,
-		awsmodels.IAMRootUserSchema:         PollIAMRootUser,
-		awsmodels.KmsKeySchema:              PollKMSKey,
-		awsmodels.LambdaFunctionSchema:      PollLambdaFunction,
-		awsmodels.RDSInstanceSchema:         PollRDSInstance,
-		awsmodels.RedshiftClusterSchema:     PollRedshiftCluster,
-		awsmodels.S3BucketSchema:            PollS3Bucket,
-		awsmodels.WafWebAclSchema:           PollWAFWebACL,
-		awsmodels.WafRegionalWebAclSchema:   PollWAFRegionalWebACL,
+		awsmodels.AcmCertificateSchema:           PollACMCertificate,
+		awsmodels.APIGatewayRestAPISchema:        PollAPIGatewayRestAPI,
+		awsmodels.APIGatewayV2APISchema:          PollAPIGatewayV2API,
+		awsmodels.CloudFormationStackSchema:      PollCloudFormationStack,
+		awsmodels.CloudFrontDistributionSchema:   PollCloudFrontDistribution,
+		awsmodels.CloudTrailSchema:               PollCloudTrailTrail,
+		awsmodels.CloudWatchLogGroupSchema:       PollCloudWatchLogsLogGroup,
+		awsmodels.DynamoDBTableSchema:            PollDynamoDBTable,
+		awsmodels.Ec2AmiSchema:                   PollEC2Image,
+		awsmodels.Ec2InstanceSchema:              PollEC2Instance,
+		awsmodels.Ec2NetworkAclSchema:            PollEC2NetworkACL,
+		awsmodels.Ec2SecurityGroupSchema:         PollEC2SecurityGroup,
+		awsmodels.Ec2VolumeSchema:                PollEC2Volume,
+		awsmodels.Ec2VpcSchema:                   PollEC2VPC,
+		awsmodels.EcrRepositorySchema:            PollECRRepository,
+		awsmodels.EcsClusterSchema:               PollECSCluster,
+		awsmodels.EcsServiceSchema:               PollECSService,
+		awsmodels.EcsTaskDefinitionSchema:        PollECSTaskDefinition,
+		awsmodels.EksClusterSchema:               PollEKSCluster,
+		awsmodels.ElbLoadBalancerSchema:          PollELBLoadBalancer,
+		awsmodels.Elbv2LoadBalancerSchema:        PollELBV2LoadBalancer,
+		awsmodels.Elbv2NetworkLoadBalancerSchema: PollELBV2NetworkLoadBalancer,
+		awsmodels.IAMGroupSchema:                 PollIAMGroup,
+		awsmodels.IAMPolicySchema:                PollIAMPolicy,
+		awsmodels.IAMRoleSchema:                  PollIAMRole,
+		awsmodels.IAMUserSchema:                  PollIAMUser,
+		awsmodels.IAMRootUserSchema:              PollIAMRootUser,
+		awsmodels.KmsKeySchema:                   PollKMSKey,
+		awsmodels.LambdaFunctionSchema:           PollLambdaFunction,
+		awsmodels.RDSInstanceSchema:              PollRDSInstance,
+		awsmodels.RedshiftClusterSchema:          PollRedshiftCluster,
+		awsmodels.Route53HostedZoneSchema:        PollRoute53HostedZone,
+		awsmodels.S3BucketSchema:                 PollS3Bucket,
+		awsmodels.SecretsManagerSecretSchema:     PollSecretsManagerSecret,
+		awsmodels.SfnStateMachineSchema:          PollSfnStateMachine,
+		awsmodels.SnsTopicSchema:                 PollSNSTopic,
+		awsmodels.SqsQueueSchema:                 PollSQSQueue,
+		awsmodels.WafWebAclSchema:                PollWAFWebACL,
+		awsmodels.WafRegionalWebAclSchema:        PollWAFRegionalWebACL,
 	}
 
 	// IndividualResourcePollers maps resource types to their corresponding individual polling
@@ -108,16 +123,79 @@ var (
 		awsmodels.GuardDutySchema:           {"GuardDutyDetector", PollGuardDutyDetectors},
 		awsmodels.IAMUserSchema:             {"IAMUser", PollIAMUsers},
 		// Service scan for the resource type IAMRootUserSchema is not defined! Do not do it!
-		awsmodels.IAMRoleSchema:         {"IAMRoles", PollIAMRoles},
-		awsmodels.IAMGroupSchema:        {"IAMGroups", PollIamGroups},
-		awsmodels.IAMPolicySchema:       {"IAMPolicies", PollIamPolicies},
-		awsmodels.LambdaFunctionSchema:  {"LambdaFunctions", PollLambdaFunctions},
-		awsmodels.PasswordPolicySchema:  {"PasswordPolicy", PollPasswordPolicy},
-		awsmodels.RDSInstanceSchema:     {"RDSInstance", PollRDSInstances},
-		awsmodels.RedshiftClusterSchema: {"RedshiftCluster", PollRedshiftClusters},
+		awsmodels.IAMRoleSchema:                  {"IAMRoles", PollIAMRoles},
+		awsmodels.IAMGroupSchema:                 {"IAMGroups", PollIamGroups},
+		awsmodels.IAMPolicySchema:                {"IAMPolicies", PollIamPolicies},
+		awsmodels.LambdaFunctionSchema:           {"LambdaFunctions", PollLambdaFunctions},
+		awsmodels.PasswordPolicySchema:           {"PasswordPolicy", PollPasswordPolicy},
+		awsmodels.RDSInstanceSchema:              {"RDSInstance", PollRDSInstances},
+		awsmodels.RedshiftClusterSchema:          {"RedshiftCluster", PollRedshiftClusters},
+		awsmodels.APIGatewayRestAPISchema:        {"APIGatewayRestAPI", PollAPIGatewayRestAPIs},
+		awsmodels.APIGatewayV2APISchema:          {"APIGatewayV2API", PollAPIGatewayV2APIs},
+		awsmodels.SecretsManagerSecretSchema:     {"SecretsManagerSecret", PollSecretsManagerSecrets},
+		awsmodels.SfnStateMachineSchema:          {"StepFunctionsStateMachine", PollSfnStateMachines},
+		awsmodels.SnsTopicSchema:                 {"SNSTopic", PollSnsTopics},
+		awsmodels.SqsQueueSchema:                 {"SQSQueue", PollSqsQueues},
+		awsmodels.EcrRepositorySchema:            {"ECRRepository", PollEcrRepositories},
+		awsmodels.EcsServiceSchema:               {"ECSService", PollEcsServices},
+		awsmodels.EcsTaskDefinitionSchema:        {"ECSTaskDefinition", PollEcsTaskDefinitions},
+		awsmodels.EksClusterSchema:               {"EKSCluster", PollEksClusters},
+		awsmodels.CloudFrontDistributionSchema:   {"CloudFrontDistribution", PollCloudFrontDistributions},
+		awsmodels.ElbLoadBalancerSchema:          {"ELBLoadBalancer", PollElbLoadBalancers},
+		awsmodels.Elbv2NetworkLoadBalancerSchema: {"ELBV2NetworkLoadBalancer", PollElbv2NetworkLoadBalancers},
+		awsmodels.Route53HostedZoneSchema:        {"Route53HostedZone", PollRoute53HostedZones},
+	}
+
+	// GlobalResourceTypes lists the resource types whose service scans cover every region at once,
+	// so they are never split into single region scans.
+	GlobalResourceTypes = map[string]bool{
+		awsmodels.CloudFrontDistributionSchema: true,
+		awsmodels.IAMGroupSchema:               true,
+		awsmodels.IAMPolicySchema:              true,
+		awsmodels.IAMRoleSchema:                true,
+		awsmodels.IAMUserSchema:                true,
+		awsmodels.PasswordPolicySchema:         true,
+		awsmodels.Route53HostedZoneSchema:      true,
+		awsmodels.S3BucketSchema:               true,
+		awsmodels.WafWebAclSchema:              true,
 	}
 )
 
+// ResourceTypeScanEntries builds the entries which scan all resources of one type in an account.
+//
+// A regional resource type gets one entry per region if regions are given, otherwise a single
+// entry covers every enabled region of the account. A global resource type always gets a single
+// entry, which is limited to the given regions (e.g. S3 buckets are only built in those regions).
+func ResourceTypeScanEntries(
+	integration pollermodels.ScanEntry, resourceType string, regions []*string) []*pollermodels.ScanEntry {
+
+	integration.ResourceType = &resourceType
+	if GlobalResourceTypes[resourceType] {
+		integration.ScanRegions = regions
+		return []*pollermodels.ScanEntry{&integration}
+	}
+	if len(regions) == 0 {
+		return []*pollermodels.ScanEntry{&integration}
+	}
+
+	entries := make([]*pollermodels.ScanEntry, len(regions))
+	for i, region := range regions {
+		entry := integration
+		entry.Region = region
+		entries[i] = &entry
+	}
+	return entries
+}
+
+// ScanEntryID generates an ID for an account wide scan entry of: IntegrationID-AWSResourceType[-Region]
+func ScanEntryID(entry *pollermodels.ScanEntry) string {
+	id := *entry.IntegrationID + "-" + strings.Replace(*entry.ResourceType, ".", "", -1)
+	if entry.Region != nil {
+		id += "-" + strings.Replace(*entry.Region, "-", "", -1)
+	}
+	return id
+}
+
 // Poll coordinates AWS generatedEvents gathering across all relevant resources for compliance monitoring.
 func Poll(scanRequest *pollermodels.ScanEntry) (
 	generatedEvents []*resourcesapimodels.AddResourceEntry, err error) {
@@ -125,6 +203,9 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 	if scanRequest.AWSAccountID == nil {
 		return nil, errors.New("no valid AWS AccountID provided")
 	}
+	defer func() {
+		setOrgUnitPath(generatedEvents, scanRequest.OrgUnitPath)
+	}()
 
 	// Build the audit role manually
 	// 	Format: arn:aws:iam::$(ACCOUNT_ID):role/PantherAuditRole-($REGION)
@@ -145,9 +226,12 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 	pollerResourceInput := &awsmodels.ResourcePollerInput{
 		AuthSource:          &auditRoleARN,
 		AuthSourceParsedARN: roleArn,
+		ContinuationMarker:  scanRequest.ContinuationMarker,
 		IntegrationID:       scanRequest.IntegrationID,
+		OrgUnitPath:         scanRequest.OrgUnitPath,
 		// This will be overwritten if this is not a single resource or single region service scan
-		Regions: []*string{scanRequest.Region},
+		Regions:         []*string{scanRequest.Region},
+		RequeueAttempts: scanRequest.RequeueAttempts,
 		// Note: The resources-api expects a strfmt.DateTime formatted string.
 		Timestamp: utils.DateTimeFormat(utils.TimeNowFunc()),
 	}
@@ -179,6 +263,9 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 	}
 
 	regions := utils.GetRegions(ec2Client)
+	if len(scanRequest.ScanRegions) > 0 {
+		regions = utils.LimitRegions(regions, scanRequest.ScanRegions)
+	}
 	if regions == nil {
 		zap.L().Info("no valid regions to scan")
 		return nil, nil
@@ -212,6 +299,18 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 	return nil, nil
 }
 
+// setOrgUnitPath records the organizational unit of the scanned account on each resource, so policies can use it
+func setOrgUnitPath(resources []*resourcesapimodels.AddResourceEntry, orgUnitPath *string) {
+	if orgUnitPath == nil {
+		return
+	}
+	for _, resource := range resources {
+		if attributes, ok := resource.Attributes.(interface{ SetOrgUnitPath(*string) }); ok {
+			attributes.SetOrgUnitPath(orgUnitPath)
+		}
+	}
+}
+
 func serviceScan(
 	pollers []resourcePoller,
 	pollerInput *awsmodels.ResourcePollerInput,
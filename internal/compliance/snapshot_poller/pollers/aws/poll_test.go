@@ -21,10 +21,60 @@ package aws
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 )
 
 // Unit tests
 func TestAssumeRoleMissingParams(t *testing.T) {
 	assert.Panics(t, func() { _ = assumeRole(nil, nil, "") })
 }
+
+func TestSetOrgUnitPath(t *testing.T) {
+	bucket := &awsmodels.S3Bucket{}
+	resources := []*resourcesapimodels.AddResourceEntry{{Attributes: bucket}}
+
+	setOrgUnitPath(resources, nil)
+	assert.Nil(t, bucket.OrgUnitPath)
+
+	setOrgUnitPath(resources, aws.String("Root/Prod"))
+	assert.Equal(t, aws.String("Root/Prod"), bucket.OrgUnitPath)
+}
+
+func TestResourceTypeScanEntries(t *testing.T) {
+	integration := pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: aws.String("45c378a7-2e36-4b12-8e16-2d3c49ff1371"),
+	}
+	regions := []*string{aws.String("us-east-1"), aws.String("us-west-2")}
+
+	// Global resource types are never split by region, the scan is limited to the regions instead
+	entries := ResourceTypeScanEntries(integration, awsmodels.IAMRoleSchema, regions)
+	assert.Len(t, entries, 1)
+	assert.Nil(t, entries[0].Region)
+	assert.Equal(t, "45c378a7-2e36-4b12-8e16-2d3c49ff1371-AWSIAMRole", ScanEntryID(entries[0]))
+
+	entries = ResourceTypeScanEntries(integration, awsmodels.S3BucketSchema, regions)
+	assert.Len(t, entries, 1)
+	assert.Nil(t, entries[0].Region)
+	assert.Equal(t, regions, entries[0].ScanRegions)
+
+	entries = ResourceTypeScanEntries(integration, awsmodels.S3BucketSchema, nil)
+	assert.Len(t, entries, 1)
+	assert.Nil(t, entries[0].ScanRegions)
+
+	entries = ResourceTypeScanEntries(integration, awsmodels.Ec2VolumeSchema, nil)
+	assert.Len(t, entries, 1)
+	assert.Nil(t, entries[0].Region)
+
+	entries = ResourceTypeScanEntries(integration, awsmodels.Ec2VolumeSchema, regions)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, aws.String("us-west-2"), entries[1].Region)
+	assert.Nil(t, entries[1].ScanRegions)
+	assert.Equal(t, aws.String(awsmodels.Ec2VolumeSchema), entries[1].ResourceType)
+	assert.Equal(t, "45c378a7-2e36-4b12-8e16-2d3c49ff1371-AWSEC2Volume-uswest2", ScanEntryID(entries[1]))
+}
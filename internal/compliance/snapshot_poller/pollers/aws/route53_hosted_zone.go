@@ -0,0 +1,244 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// The Route 53 API returns hosted zone IDs of the form "/hostedzone/<id>"
+const hostedZoneIDPrefix = "/hostedzone/"
+
+// Set as variables to be overridden in testing
+var Route53ClientFunc = setupRoute53Client
+
+func setupRoute53Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return route53.New(sess, cfg)
+}
+
+func getRoute53Client(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (route53iface.Route53API, error) {
+	client, err := getClient(pollerResourceInput, Route53ClientFunc, "route53", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(route53iface.Route53API), nil
+}
+
+// hostedZoneARN builds the ARN of a hosted zone, Route 53 ARNs include neither the region nor the account ID
+//
+// Format: arn:aws:route53:::hostedzone/id
+func hostedZoneARN(hostedZoneID *string) *string {
+	return aws.String(arn.ARN{
+		Partition: "aws",
+		Service:   "route53",
+		Resource:  "hostedzone/" + strings.TrimPrefix(aws.StringValue(hostedZoneID), hostedZoneIDPrefix),
+	}.String())
+}
+
+// PollRoute53HostedZone polls a single Route 53 hosted zone resource
+func PollRoute53HostedZone(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getRoute53Client(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	hostedZoneID := strings.TrimPrefix(resourceARN.Resource, "hostedzone/")
+	snapshot, err := buildRoute53HostedZoneSnapshot(client, aws.String(hostedZoneID))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	// Route 53 ARNs do not include the account ID
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+	return snapshot, nil
+}
+
+// listHostedZones returns the IDs of all the hosted zones in the account
+func listHostedZones(route53Svc route53iface.Route53API) (hostedZones []*string, err error) {
+	err = route53Svc.ListHostedZonesPages(&route53.ListHostedZonesInput{},
+		func(page *route53.ListHostedZonesOutput, lastPage bool) bool {
+			for _, hostedZone := range page.HostedZones {
+				hostedZones = append(hostedZones, hostedZone.Id)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "Route53.ListHostedZonesPages")
+	}
+	return
+}
+
+// getHostedZone returns a hosted zone along with the VPCs associated with it, if it is a private zone
+func getHostedZone(route53Svc route53iface.Route53API, id *string) (*route53.GetHostedZoneOutput, error) {
+	out, err := route53Svc.GetHostedZone(&route53.GetHostedZoneInput{Id: id})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *id),
+				zap.String("resourceType", awsmodels.Route53HostedZoneSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Route53.GetHostedZone: %s", aws.StringValue(id))
+	}
+	return out, nil
+}
+
+// listResourceRecordSets returns all the record sets in a hosted zone
+func listResourceRecordSets(route53Svc route53iface.Route53API, id *string) (recordSets []*route53.ResourceRecordSet, err error) {
+	err = route53Svc.ListResourceRecordSetsPages(&route53.ListResourceRecordSetsInput{HostedZoneId: id},
+		func(page *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+			recordSets = append(recordSets, page.ResourceRecordSets...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListResourceRecordSetsPages: %s", aws.StringValue(id))
+	}
+	return
+}
+
+// listQueryLoggingConfigs returns the DNS query logging configurations of a hosted zone
+func listQueryLoggingConfigs(route53Svc route53iface.Route53API, id *string) (configs []*route53.QueryLoggingConfig, err error) {
+	err = route53Svc.ListQueryLoggingConfigsPages(&route53.ListQueryLoggingConfigsInput{HostedZoneId: id},
+		func(page *route53.ListQueryLoggingConfigsOutput, lastPage bool) bool {
+			configs = append(configs, page.QueryLoggingConfigs...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListQueryLoggingConfigsPages: %s", aws.StringValue(id))
+	}
+	return
+}
+
+// listHostedZoneTags returns the tags of a hosted zone
+func listHostedZoneTags(route53Svc route53iface.Route53API, id *string) ([]*route53.Tag, error) {
+	out, err := route53Svc.ListTagsForResource(&route53.ListTagsForResourceInput{
+		ResourceId:   aws.String(strings.TrimPrefix(aws.StringValue(id), hostedZoneIDPrefix)),
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListTagsForResource: %s", aws.StringValue(id))
+	}
+	if out.ResourceTagSet == nil {
+		return nil, nil
+	}
+	return out.ResourceTagSet.Tags, nil
+}
+
+// buildRoute53HostedZoneSnapshot makes all the calls to build up a snapshot of a given hosted zone
+func buildRoute53HostedZoneSnapshot(route53Svc route53iface.Route53API, id *string) (*awsmodels.Route53HostedZone, error) {
+	out, err := getHostedZone(route53Svc, id)
+	if err != nil || out == nil {
+		return nil, err
+	}
+	hostedZone := out.HostedZone
+
+	zoneARN := hostedZoneARN(hostedZone.Id)
+	snapshot := &awsmodels.Route53HostedZone{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   zoneARN,
+			ResourceType: aws.String(awsmodels.Route53HostedZoneSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  zoneARN,
+			ID:   aws.String(strings.TrimPrefix(aws.StringValue(hostedZone.Id), hostedZoneIDPrefix)),
+			Name: hostedZone.Name,
+		},
+		CallerReference:        hostedZone.CallerReference,
+		Config:                 hostedZone.Config,
+		LinkedService:          hostedZone.LinkedService,
+		ResourceRecordSetCount: hostedZone.ResourceRecordSetCount,
+		VPCs:                   out.VPCs,
+	}
+
+	if snapshot.ResourceRecordSets, err = listResourceRecordSets(route53Svc, hostedZone.Id); err != nil {
+		return nil, err
+	}
+	if snapshot.QueryLoggingConfigs, err = listQueryLoggingConfigs(route53Svc, hostedZone.Id); err != nil {
+		return nil, err
+	}
+
+	tags, err := listHostedZoneTags(route53Svc, hostedZone.Id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollRoute53HostedZones gathers information on each Route 53 hosted zone for an AWS account.
+func PollRoute53HostedZones(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting Route 53 Hosted Zone resource poller")
+
+	// Route 53 is a global service
+	route53Svc, err := getRoute53Client(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	hostedZones, err := listHostedZones(route53Svc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "PollRoute53HostedZones(%#v)", *pollerInput)
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(hostedZones))
+	for _, id := range hostedZones {
+		snapshot, err := buildRoute53HostedZoneSnapshot(route53Svc, id)
+		if err != nil {
+			utils.LogAWSError("Route53.BuildSnapshot", err)
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              apimodels.ResourceID(*snapshot.ARN),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.Route53HostedZoneSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,143 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestRoute53HostedZoneList(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53Svc([]string{"ListHostedZonesPages"})
+
+	out, err := listHostedZones(mockSvc)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestRoute53HostedZoneListError(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcError([]string{"ListHostedZonesPages"})
+
+	out, err := listHostedZones(mockSvc)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRoute53HostedZoneGetDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockRoute53{}
+	mockSvc.On("GetHostedZone", mock.Anything).
+		Return(
+			&route53.GetHostedZoneOutput{},
+			awserr.New(route53.ErrCodeNoSuchHostedZone, "No hosted zone found with ID: Z1D633PJN98FT9", nil),
+		)
+
+	out, err := getHostedZone(mockSvc, aws.String("Z1D633PJN98FT9"))
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestRoute53HostedZoneBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcAll()
+
+	zoneSnapshot, err := buildRoute53HostedZoneSnapshot(mockSvc, aws.String("Z1D633PJN98FT9"))
+
+	require.NoError(t, err)
+	require.NotNil(t, zoneSnapshot)
+	assert.Equal(t, awstest.ExampleRoute53HostedZoneArn, zoneSnapshot.ARN)
+	assert.Equal(t, "Z1D633PJN98FT9", *zoneSnapshot.ID)
+	assert.Len(t, zoneSnapshot.ResourceRecordSets, 2)
+	assert.NotEmpty(t, zoneSnapshot.QueryLoggingConfigs)
+	assert.Equal(t, "Value1", *zoneSnapshot.Tags["Key1"])
+}
+
+func TestRoute53HostedZoneBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcAllError()
+
+	zoneSnapshot, err := buildRoute53HostedZoneSnapshot(mockSvc, aws.String("Z1D633PJN98FT9"))
+
+	require.Error(t, err)
+	assert.Nil(t, zoneSnapshot)
+}
+
+func TestRoute53HostedZonePollSingle(t *testing.T) {
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAll()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resourceARN, err := arn.Parse(*awstest.ExampleRoute53HostedZoneArn)
+	require.NoError(t, err)
+
+	snapshot, err := PollRoute53HostedZone(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: awstest.ExampleRoute53HostedZoneArn})
+
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, "123456789012", *snapshot.(*awsmodels.Route53HostedZone).AccountID)
+}
+
+func TestRoute53HostedZonePoller(t *testing.T) {
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAll()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resources, err := PollRoute53HostedZones(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, *awstest.ExampleRoute53HostedZoneArn, string(resources[0].ID))
+}
+
+func TestRoute53HostedZonePollerError(t *testing.T) {
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAllError()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resources, err := PollRoute53HostedZones(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Empty(t, resources)
+}
@@ -374,71 +374,73 @@ func PollS3Buckets(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.Add
 		return nil, err // error is logged in getClient()
 	}
 
-	// Start with generating a list of all buckets
-	allBuckets := listBuckets(s3Svc)
-	if allBuckets == nil {
-		zap.L().Debug("nothing returned by S3 list buckets")
-		return nil, nil
-	}
-	zap.L().Debug("listed all S3 buckets", zap.Int("count", len(allBuckets.Buckets)))
-
-	// For each bucket, determine its region, then group it with other buckets in that region
-	// so a session can be created for each region to build the snapshots. Only pay attention to
-	// buckets from active regions/regions the user has requested.
-	bucketsByRegion := map[string][]*s3.Bucket{}
+	// Only pay attention to buckets from active regions/regions the user has requested
+	activeRegions := make(map[string]bool, len(pollerInput.Regions))
 	for _, region := range pollerInput.Regions {
-		bucketsByRegion[*region] = []*s3.Bucket{}
+		activeRegions[*region] = true
 	}
 
-	for _, bucket := range allBuckets.Buckets {
-		if bucket == nil {
-			zap.L().Debug("nil bucket returned by S3 list buckets")
-			continue
-		}
-		region := getBucketLocation(s3Svc, bucket.Name)
-		if region == nil {
-			continue
+	// Start with generating a list of all buckets
+	bucketsByName := make(map[string]*s3.Bucket)
+	listBucketNames := func() []*string {
+		allBuckets := listBuckets(s3Svc)
+		if allBuckets == nil {
+			zap.L().Debug("nothing returned by S3 list buckets")
+			return nil
 		}
+		zap.L().Debug("listed all S3 buckets", zap.Int("count", len(allBuckets.Buckets)))
 
-		if regionBuckets, ok := bucketsByRegion[*region]; ok {
-			bucketsByRegion[*region] = append(regionBuckets, bucket)
+		names := make([]*string, 0, len(allBuckets.Buckets))
+		for _, bucket := range allBuckets.Buckets {
+			if bucket == nil {
+				zap.L().Debug("nil bucket returned by S3 list buckets")
+				continue
+			}
+			bucketsByName[*bucket.Name] = bucket
+			names = append(names, bucket.Name)
 		}
+		return names
 	}
 
-	var resources []*apimodels.AddResourceEntry
-	for region, buckets := range bucketsByRegion {
-		s3Svc, err := getS3Client(pollerInput, region)
+	// For each bucket, determine its region, then build the snapshot with a client for that region
+	buildBucket := func(bucketName *string) (*apimodels.AddResourceEntry, error) {
+		region := getBucketLocation(s3Svc, bucketName)
+		if region == nil || !activeRegions[*region] {
+			return nil, nil
+		}
+
+		regionalSvc, err := getS3Client(pollerInput, *region)
 		if err != nil {
 			return nil, err // error is logged in getClient()
 		}
 
-		for _, bucket := range buckets {
-			s3BucketSnapshot := buildS3BucketSnapshot(s3Svc, bucket)
+		s3BucketSnapshot := buildS3BucketSnapshot(regionalSvc, bucketsByName[*bucketName])
 
-			resourceID := strings.Join(
-				[]string{"arn", pollerInput.AuthSourceParsedARN.Partition, "s3::", *s3BucketSnapshot.Name},
-				":",
-			)
+		resourceID := strings.Join(
+			[]string{"arn", pollerInput.AuthSourceParsedARN.Partition, "s3::", *s3BucketSnapshot.Name},
+			":",
+		)
 
-			// Populate generic fields
-			s3BucketSnapshot.ResourceID = aws.String(resourceID)
+		// Populate generic fields
+		s3BucketSnapshot.ResourceID = aws.String(resourceID)
 
-			// Populate AWS generic fields
-			s3BucketSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
-			s3BucketSnapshot.ARN = aws.String(resourceID)
-			s3BucketSnapshot.Region = aws.String(region)
+		// Populate AWS generic fields
+		s3BucketSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		s3BucketSnapshot.ARN = aws.String(resourceID)
+		s3BucketSnapshot.Region = region
 
-			S3BucketSnapshots[*s3BucketSnapshot.Name] = s3BucketSnapshot
+		S3BucketSnapshots[*s3BucketSnapshot.Name] = s3BucketSnapshot
 
-			resources = append(resources, &apimodels.AddResourceEntry{
-				Attributes:      s3BucketSnapshot,
-				ID:              apimodels.ResourceID(resourceID),
-				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
-				IntegrationType: apimodels.IntegrationTypeAws,
-				Type:            awsmodels.S3BucketSchema,
-			})
-		}
+		return &apimodels.AddResourceEntry{
+			Attributes:      s3BucketSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.S3BucketSchema,
+		}, nil
 	}
 
-	return resources, nil
+	// Each bucket takes a dozen requests to describe, scans cut short by throttling are resumed
+	// from the first incomplete bucket
+	return resumableScan(pollerInput, awsmodels.S3BucketSchema, listBucketNames, buildBucket)
 }
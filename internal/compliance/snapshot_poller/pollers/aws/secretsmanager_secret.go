@@ -0,0 +1,212 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var SecretsManagerClientFunc = setupSecretsManagerClient
+
+func setupSecretsManagerClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return secretsmanager.New(sess, cfg)
+}
+
+func getSecretsManagerClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (secretsmanageriface.SecretsManagerAPI, error) {
+
+	client, err := getClient(pollerResourceInput, SecretsManagerClientFunc, "secretsmanager", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(secretsmanageriface.SecretsManagerAPI), nil
+}
+
+// PollSecretsManagerSecret polls a single Secrets Manager secret resource
+func PollSecretsManagerSecret(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSecretsManagerClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildSecretsManagerSecretSnapshot(client, scanRequest.ResourceID)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listSecrets returns the ARNs of all secrets in the account
+func listSecrets(secretsSvc secretsmanageriface.SecretsManagerAPI) (secrets []*string) {
+	err := secretsSvc.ListSecretsPages(&secretsmanager.ListSecretsInput{},
+		func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+			for _, secret := range page.SecretList {
+				secrets = append(secrets, secret.ARN)
+			}
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SecretsManager.ListSecretsPages", err)
+	}
+	return
+}
+
+// describeSecret returns the metadata of a secret, its value is never read
+func describeSecret(
+	secretsSvc secretsmanageriface.SecretsManagerAPI, secretARN *string) (*secretsmanager.DescribeSecretOutput, error) {
+
+	out, err := secretsSvc.DescribeSecret(&secretsmanager.DescribeSecretInput{SecretId: secretARN})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *secretARN),
+				zap.String("resourceType", awsmodels.SecretsManagerSecretSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("SecretsManager.DescribeSecret", err)
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// getSecretPolicy returns the resource policy attached to a secret
+func getSecretPolicy(secretsSvc secretsmanageriface.SecretsManagerAPI, secretARN *string) (*string, error) {
+	out, err := secretsSvc.GetResourcePolicy(&secretsmanager.GetResourcePolicyInput{SecretId: secretARN})
+	if err != nil {
+		utils.LogAWSError("SecretsManager.GetResourcePolicy", err)
+		return nil, err
+	}
+
+	return out.ResourcePolicy, nil
+}
+
+// buildSecretsManagerSecretSnapshot makes all the calls to build up a snapshot of a given secret
+func buildSecretsManagerSecretSnapshot(
+	secretsSvc secretsmanageriface.SecretsManagerAPI, secretARN *string) *awsmodels.SecretsManagerSecret {
+
+	if secretARN == nil {
+		return nil
+	}
+
+	details, err := describeSecret(secretsSvc, secretARN)
+	if err != nil || details == nil {
+		return nil
+	}
+
+	snapshot := &awsmodels.SecretsManagerSecret{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   details.ARN,
+			ResourceType: aws.String(awsmodels.SecretsManagerSecretSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  details.ARN,
+			Name: details.Name,
+			Tags: utils.ParseTagSlice(details.Tags),
+		},
+		DeletedDate:        details.DeletedDate,
+		Description:        details.Description,
+		KmsKeyId:           details.KmsKeyId,
+		LastAccessedDate:   details.LastAccessedDate,
+		LastChangedDate:    details.LastChangedDate,
+		LastRotatedDate:    details.LastRotatedDate,
+		OwningService:      details.OwningService,
+		RotationEnabled:    details.RotationEnabled,
+		RotationLambdaARN:  details.RotationLambdaARN,
+		RotationRules:      details.RotationRules,
+		VersionIdsToStages: details.VersionIdsToStages,
+	}
+
+	policy, err := getSecretPolicy(secretsSvc, details.ARN)
+	if err == nil {
+		snapshot.Policy = policy
+	}
+
+	return snapshot
+}
+
+// PollSecretsManagerSecrets gathers information on each Secrets Manager secret for an AWS account.
+func PollSecretsManagerSecrets(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting Secrets Manager Secret resource poller")
+	secretSnapshots := make(map[string]*awsmodels.SecretsManagerSecret)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "secretsmanager") {
+		secretsSvc, err := getSecretsManagerClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all secrets
+		secrets := listSecrets(secretsSvc)
+		if len(secrets) == 0 {
+			zap.L().Debug("no Secrets Manager secrets found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, secretARN := range secrets {
+			secretSnapshot := buildSecretsManagerSecretSnapshot(secretsSvc, secretARN)
+			if secretSnapshot == nil {
+				continue
+			}
+			secretSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			secretSnapshot.Region = regionID
+
+			if _, ok := secretSnapshots[*secretSnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing Secrets Manager Secret snapshot",
+					zap.String("resourceId", *secretSnapshot.ARN),
+				)
+			}
+			secretSnapshots[*secretSnapshot.ARN] = secretSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(secretSnapshots))
+	for resourceID, secretSnapshot := range secretSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      secretSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.SecretsManagerSecretSchema,
+		})
+	}
+
+	return resources, nil
+}
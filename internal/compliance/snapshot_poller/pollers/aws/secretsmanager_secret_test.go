@@ -0,0 +1,132 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSecretsManagerSecretList(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvc([]string{"ListSecretsPages"})
+
+	out := listSecrets(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestSecretsManagerSecretListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcError([]string{"ListSecretsPages"})
+
+	out := listSecrets(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestSecretsManagerSecretDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvc([]string{"DescribeSecret"})
+
+	out, err := describeSecret(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSecretsManagerSecretDescribeDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockSecretsManager{}
+	mockSvc.On("DescribeSecret", mock.Anything).
+		Return(
+			&secretsmanager.DescribeSecretOutput{},
+			awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "secret does not exist", nil),
+		)
+
+	out, err := describeSecret(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSecretsManagerSecretDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcError([]string{"DescribeSecret"})
+
+	out, err := describeSecret(mockSvc, awstest.ExampleSecretArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSecretsManagerSecretBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcAll()
+
+	secretSnapshot := buildSecretsManagerSecretSnapshot(mockSvc, awstest.ExampleSecretArn)
+
+	require.NotNil(t, secretSnapshot)
+	assert.Equal(t, awstest.ExampleSecretArn, secretSnapshot.ARN)
+	assert.True(t, *secretSnapshot.RotationEnabled)
+	assert.NotEmpty(t, secretSnapshot.Policy)
+	assert.Equal(t, "Value1", *secretSnapshot.Tags["Key1"])
+}
+
+func TestSecretsManagerSecretBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcAllError()
+
+	secretSnapshot := buildSecretsManagerSecretSnapshot(mockSvc, awstest.ExampleSecretArn)
+
+	assert.Nil(t, secretSnapshot)
+}
+
+func TestSecretsManagerSecretPoller(t *testing.T) {
+	awstest.MockSecretsManagerForSetup = awstest.BuildMockSecretsManagerSvcAll()
+
+	SecretsManagerClientFunc = awstest.SetupMockSecretsManager
+
+	resources, err := PollSecretsManagerSecrets(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, *awstest.ExampleSecretArn, string(resources[0].ID))
+}
+
+func TestSecretsManagerSecretPollerError(t *testing.T) {
+	awstest.MockSecretsManagerForSetup = awstest.BuildMockSecretsManagerSvcAllError()
+
+	SecretsManagerClientFunc = awstest.SetupMockSecretsManager
+
+	resources, err := PollSecretsManagerSecrets(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
@@ -0,0 +1,200 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var SfnClientFunc = setupSfnClient
+
+func setupSfnClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sfn.New(sess, cfg)
+}
+
+func getSfnClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (sfniface.SFNAPI, error) {
+	client, err := getClient(pollerResourceInput, SfnClientFunc, "states", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(sfniface.SFNAPI), nil
+}
+
+// PollSfnStateMachine polls a single Step Functions state machine resource
+func PollSfnStateMachine(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSfnClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildSfnStateMachineSnapshot(client, scanRequest.ResourceID)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listStateMachines returns the ARNs of all state machines in the account
+func listStateMachines(sfnSvc sfniface.SFNAPI) (stateMachines []*string) {
+	err := sfnSvc.ListStateMachinesPages(&sfn.ListStateMachinesInput{},
+		func(page *sfn.ListStateMachinesOutput, lastPage bool) bool {
+			for _, stateMachine := range page.StateMachines {
+				stateMachines = append(stateMachines, stateMachine.StateMachineArn)
+			}
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SFN.ListStateMachinesPages", err)
+	}
+	return
+}
+
+// describeStateMachine provides detailed information for a given state machine
+func describeStateMachine(sfnSvc sfniface.SFNAPI, stateMachineARN *string) (*sfn.DescribeStateMachineOutput, error) {
+	out, err := sfnSvc.DescribeStateMachine(&sfn.DescribeStateMachineInput{StateMachineArn: stateMachineARN})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == sfn.ErrCodeStateMachineDoesNotExist {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *stateMachineARN),
+				zap.String("resourceType", awsmodels.SfnStateMachineSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("SFN.DescribeStateMachine", err)
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// listSfnTags returns the tags of a state machine
+func listSfnTags(sfnSvc sfniface.SFNAPI, stateMachineARN *string) ([]*sfn.Tag, error) {
+	out, err := sfnSvc.ListTagsForResource(&sfn.ListTagsForResourceInput{ResourceArn: stateMachineARN})
+	if err != nil {
+		utils.LogAWSError("SFN.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return out.Tags, nil
+}
+
+// buildSfnStateMachineSnapshot makes all the calls to build up a snapshot of a given state machine
+func buildSfnStateMachineSnapshot(sfnSvc sfniface.SFNAPI, stateMachineARN *string) *awsmodels.SfnStateMachine {
+	if stateMachineARN == nil {
+		return nil
+	}
+
+	details, err := describeStateMachine(sfnSvc, stateMachineARN)
+	if err != nil || details == nil {
+		return nil
+	}
+
+	snapshot := &awsmodels.SfnStateMachine{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   details.StateMachineArn,
+			ResourceType: aws.String(awsmodels.SfnStateMachineSchema),
+			TimeCreated:  utils.DateTimeFormat(aws.TimeValue(details.CreationDate)),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  details.StateMachineArn,
+			Name: details.Name,
+		},
+		Definition:           details.Definition,
+		LoggingConfiguration: details.LoggingConfiguration,
+		RoleArn:              details.RoleArn,
+		Status:               details.Status,
+		Type:                 details.Type,
+	}
+
+	tags, err := listSfnTags(sfnSvc, stateMachineARN)
+	if err == nil {
+		snapshot.Tags = utils.ParseTagSlice(tags)
+	}
+
+	return snapshot
+}
+
+// PollSfnStateMachines gathers information on each Step Functions state machine for an AWS account.
+func PollSfnStateMachines(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting Step Functions State Machine resource poller")
+	stateMachineSnapshots := make(map[string]*awsmodels.SfnStateMachine)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "states") {
+		sfnSvc, err := getSfnClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all state machines
+		stateMachines := listStateMachines(sfnSvc)
+		if len(stateMachines) == 0 {
+			zap.L().Debug("no Step Functions state machines found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, stateMachineARN := range stateMachines {
+			stateMachineSnapshot := buildSfnStateMachineSnapshot(sfnSvc, stateMachineARN)
+			if stateMachineSnapshot == nil {
+				continue
+			}
+			stateMachineSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			stateMachineSnapshot.Region = regionID
+
+			if _, ok := stateMachineSnapshots[*stateMachineSnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing Step Functions State Machine snapshot",
+					zap.String("resourceId", *stateMachineSnapshot.ARN),
+				)
+			}
+			stateMachineSnapshots[*stateMachineSnapshot.ARN] = stateMachineSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(stateMachineSnapshots))
+	for resourceID, stateMachineSnapshot := range stateMachineSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      stateMachineSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.SfnStateMachineSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,131 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSfnStateMachineList(t *testing.T) {
+	mockSvc := awstest.BuildMockSfnSvc([]string{"ListStateMachinesPages"})
+
+	out := listStateMachines(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestSfnStateMachineListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSfnSvcError([]string{"ListStateMachinesPages"})
+
+	out := listStateMachines(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestSfnStateMachineDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockSfnSvc([]string{"DescribeStateMachine"})
+
+	out, err := describeStateMachine(mockSvc, awstest.ExampleStateMachineArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSfnStateMachineDescribeDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockSfn{}
+	mockSvc.On("DescribeStateMachine", mock.Anything).
+		Return(
+			&sfn.DescribeStateMachineOutput{},
+			awserr.New(sfn.ErrCodeStateMachineDoesNotExist, "state machine does not exist", nil),
+		)
+
+	out, err := describeStateMachine(mockSvc, awstest.ExampleStateMachineArn)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSfnStateMachineDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockSfnSvcError([]string{"DescribeStateMachine"})
+
+	out, err := describeStateMachine(mockSvc, awstest.ExampleStateMachineArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSfnStateMachineBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSfnSvcAll()
+
+	stateMachineSnapshot := buildSfnStateMachineSnapshot(mockSvc, awstest.ExampleStateMachineArn)
+
+	require.NotNil(t, stateMachineSnapshot)
+	assert.Equal(t, awstest.ExampleStateMachineArn, stateMachineSnapshot.ARN)
+	assert.Equal(t, "OFF", *stateMachineSnapshot.LoggingConfiguration.Level)
+	assert.Equal(t, "Value1", *stateMachineSnapshot.Tags["Key1"])
+}
+
+func TestSfnStateMachineBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSfnSvcAllError()
+
+	stateMachineSnapshot := buildSfnStateMachineSnapshot(mockSvc, awstest.ExampleStateMachineArn)
+
+	assert.Nil(t, stateMachineSnapshot)
+}
+
+func TestSfnStateMachinePoller(t *testing.T) {
+	awstest.MockSfnForSetup = awstest.BuildMockSfnSvcAll()
+
+	SfnClientFunc = awstest.SetupMockSfn
+
+	resources, err := PollSfnStateMachines(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, *awstest.ExampleStateMachineArn, string(resources[0].ID))
+}
+
+func TestSfnStateMachinePollerError(t *testing.T) {
+	awstest.MockSfnForSetup = awstest.BuildMockSfnSvcAllError()
+
+	SfnClientFunc = awstest.SetupMockSfn
+
+	resources, err := PollSfnStateMachines(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
@@ -0,0 +1,224 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var SnsClientFunc = setupSnsClient
+
+func setupSnsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sns.New(sess, cfg)
+}
+
+func getSnsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (snsiface.SNSAPI, error) {
+	client, err := getClient(pollerResourceInput, SnsClientFunc, "sns", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(snsiface.SNSAPI), nil
+}
+
+// PollSNSTopic polls a single SNS topic resource
+func PollSNSTopic(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSnsClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildSnsTopicSnapshot(client, scanRequest.ResourceID)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listTopics returns the ARNs of all topics in the account
+func listTopics(snsSvc snsiface.SNSAPI) (topics []*string) {
+	err := snsSvc.ListTopicsPages(&sns.ListTopicsInput{},
+		func(page *sns.ListTopicsOutput, lastPage bool) bool {
+			for _, topic := range page.Topics {
+				topics = append(topics, topic.TopicArn)
+			}
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SNS.ListTopicsPages", err)
+	}
+	return
+}
+
+// getTopicAttributes returns all the attributes of a topic
+func getTopicAttributes(snsSvc snsiface.SNSAPI, topicARN *string) (map[string]*string, error) {
+	out, err := snsSvc.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: topicARN})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Attributes, nil
+}
+
+// listSubscriptionsByTopic returns all the subscriptions to a topic
+func listSubscriptionsByTopic(snsSvc snsiface.SNSAPI, topicARN *string) (subscriptions []*sns.Subscription, err error) {
+	err = snsSvc.ListSubscriptionsByTopicPages(&sns.ListSubscriptionsByTopicInput{TopicArn: topicARN},
+		func(page *sns.ListSubscriptionsByTopicOutput, lastPage bool) bool {
+			subscriptions = append(subscriptions, page.Subscriptions...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SNS.ListSubscriptionsByTopicPages", err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// listSnsTags returns the tags of a topic
+func listSnsTags(snsSvc snsiface.SNSAPI, topicARN *string) ([]*sns.Tag, error) {
+	out, err := snsSvc.ListTagsForResource(&sns.ListTagsForResourceInput{ResourceArn: topicARN})
+	if err != nil {
+		utils.LogAWSError("SNS.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return out.Tags, nil
+}
+
+// buildSnsTopicSnapshot makes all the calls to build up a snapshot of a given SNS topic
+func buildSnsTopicSnapshot(snsSvc snsiface.SNSAPI, topicARN *string) *awsmodels.SnsTopic {
+	if topicARN == nil {
+		return nil
+	}
+
+	attributes, err := getTopicAttributes(snsSvc, topicARN)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == sns.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *topicARN),
+				zap.String("resourceType", awsmodels.SnsTopicSchema))
+			return nil
+		}
+		utils.LogAWSError("SNS.GetTopicAttributes", err)
+		return nil
+	}
+
+	snapshot := &awsmodels.SnsTopic{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   topicARN,
+			ResourceType: aws.String(awsmodels.SnsTopicSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN: topicARN,
+		},
+		DeliveryPolicy:          attributes["DeliveryPolicy"],
+		DisplayName:             attributes["DisplayName"],
+		EffectiveDeliveryPolicy: attributes["EffectiveDeliveryPolicy"],
+		KmsMasterKeyId:          attributes["KmsMasterKeyId"],
+		Owner:                   attributes["Owner"],
+		Policy:                  attributes["Policy"],
+		SubscriptionsConfirmed:  utils.ParseInt64Attribute(attributes, "SubscriptionsConfirmed"),
+		SubscriptionsDeleted:    utils.ParseInt64Attribute(attributes, "SubscriptionsDeleted"),
+		SubscriptionsPending:    utils.ParseInt64Attribute(attributes, "SubscriptionsPending"),
+	}
+	if parsedARN, err := arn.Parse(*topicARN); err == nil {
+		snapshot.Name = aws.String(parsedARN.Resource)
+	}
+
+	subscriptions, err := listSubscriptionsByTopic(snsSvc, topicARN)
+	if err == nil {
+		snapshot.Subscriptions = subscriptions
+	}
+
+	tags, err := listSnsTags(snsSvc, topicARN)
+	if err == nil {
+		snapshot.Tags = utils.ParseTagSlice(tags)
+	}
+
+	return snapshot
+}
+
+// PollSnsTopics gathers information on each SNS topic for an AWS account.
+func PollSnsTopics(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting SNS Topic resource poller")
+	snsTopicSnapshots := make(map[string]*awsmodels.SnsTopic)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "sns") {
+		snsSvc, err := getSnsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all topics
+		topics := listTopics(snsSvc)
+		if len(topics) == 0 {
+			zap.L().Debug("no SNS topics found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, topicARN := range topics {
+			snsTopicSnapshot := buildSnsTopicSnapshot(snsSvc, topicARN)
+			if snsTopicSnapshot == nil {
+				continue
+			}
+			snsTopicSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			snsTopicSnapshot.Region = regionID
+
+			if _, ok := snsTopicSnapshots[*snsTopicSnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing SNS Topic snapshot",
+					zap.String("resourceId", *snsTopicSnapshot.ARN),
+				)
+			}
+			snsTopicSnapshots[*snsTopicSnapshot.ARN] = snsTopicSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(snsTopicSnapshots))
+	for resourceID, snsTopicSnapshot := range snsTopicSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      snsTopicSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.SnsTopicSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,251 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var SqsClientFunc = setupSqsClient
+
+func setupSqsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sqs.New(sess, cfg)
+}
+
+func getSqsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (sqsiface.SQSAPI, error) {
+	client, err := getClient(pollerResourceInput, SqsClientFunc, "sqs", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(sqsiface.SQSAPI), nil
+}
+
+// PollSQSQueue polls a single SQS queue resource
+func PollSQSQueue(
+	pollerInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSqsClient(pollerInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every SQS API call is made against the queue URL rather than its ARN
+	queueURL, err := getQueueURL(client, resourceARN)
+	if err != nil || queueURL == nil {
+		return nil, err
+	}
+
+	snapshot := buildSqsQueueSnapshot(client, queueURL)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getQueueURL looks up the URL of the queue with the given ARN
+func getQueueURL(sqsSvc sqsiface.SQSAPI, queueARN arn.ARN) (*string, error) {
+	out, err := sqsSvc.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName:              aws.String(queueARN.Resource),
+		QueueOwnerAWSAccountId: aws.String(queueARN.AccountID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == sqs.ErrCodeQueueDoesNotExist {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", queueARN.String()),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("SQS.GetQueueUrl", err)
+		return nil, err
+	}
+
+	return out.QueueUrl, nil
+}
+
+// listQueues returns the URLs of all queues in the account
+func listQueues(sqsSvc sqsiface.SQSAPI) (queues []*string, err error) {
+	// MaxResults must be set for ListQueues to return a NextToken
+	err = sqsSvc.ListQueuesPages(&sqs.ListQueuesInput{MaxResults: aws.Int64(1000)},
+		func(page *sqs.ListQueuesOutput, lastPage bool) bool {
+			queues = append(queues, page.QueueUrls...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "SQS.ListQueuesPages")
+	}
+	return
+}
+
+// getQueueAttributes returns all the attributes of a queue
+func getQueueAttributes(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+		QueueUrl:       queueURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Attributes, nil
+}
+
+// listQueueTags returns the tags of a queue
+func listQueueTags(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.ListQueueTags(&sqs.ListQueueTagsInput{QueueUrl: queueURL})
+	if err != nil {
+		utils.LogAWSError("SQS.ListQueueTags", err)
+		return nil, err
+	}
+
+	return out.Tags, nil
+}
+
+// buildSqsQueueSnapshot makes all the calls to build up a snapshot of a given SQS queue
+func buildSqsQueueSnapshot(sqsSvc sqsiface.SQSAPI, queueURL *string) *awsmodels.SqsQueue {
+	if queueURL == nil {
+		return nil
+	}
+
+	attributes, err := getQueueAttributes(sqsSvc, queueURL)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == sqs.ErrCodeQueueDoesNotExist {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *queueURL),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil
+		}
+		utils.LogAWSError("SQS.GetQueueAttributes", err)
+		return nil
+	}
+
+	queueARN := attributes[sqs.QueueAttributeNameQueueArn]
+	if queueARN == nil {
+		zap.L().Warn("queue attributes are missing the queue ARN", zap.String("queueUrl", *queueURL))
+		return nil
+	}
+
+	snapshot := &awsmodels.SqsQueue{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   queueARN,
+			ResourceType: aws.String(awsmodels.SqsQueueSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN: queueARN,
+			// The queue name is the last element of its URL
+			Name: aws.String((*queueURL)[strings.LastIndex(*queueURL, "/")+1:]),
+		},
+		ContentBasedDeduplication:     utils.ParseBoolAttribute(attributes, sqs.QueueAttributeNameContentBasedDeduplication),
+		DelaySeconds:                  utils.ParseInt64Attribute(attributes, sqs.QueueAttributeNameDelaySeconds),
+		FifoQueue:                     utils.ParseBoolAttribute(attributes, sqs.QueueAttributeNameFifoQueue),
+		KmsDataKeyReusePeriodSeconds:  utils.ParseInt64Attribute(attributes, sqs.QueueAttributeNameKmsDataKeyReusePeriodSeconds),
+		KmsMasterKeyId:                attributes[sqs.QueueAttributeNameKmsMasterKeyId],
+		LastModified:                  utils.ParseUnixTimeAttribute(attributes, sqs.QueueAttributeNameLastModifiedTimestamp),
+		MaximumMessageSize:            utils.ParseInt64Attribute(attributes, sqs.QueueAttributeNameMaximumMessageSize),
+		MessageRetentionPeriod:        utils.ParseInt64Attribute(attributes, sqs.QueueAttributeNameMessageRetentionPeriod),
+		Policy:                        attributes[sqs.QueueAttributeNamePolicy],
+		ReceiveMessageWaitTimeSeconds: utils.ParseInt64Attribute(attributes, sqs.QueueAttributeNameReceiveMessageWaitTimeSeconds),
+		RedrivePolicy:                 attributes[sqs.QueueAttributeNameRedrivePolicy],
+		VisibilityTimeout:             utils.ParseInt64Attribute(attributes, sqs.QueueAttributeNameVisibilityTimeout),
+		QueueUrl:                      queueURL,
+	}
+	if created := utils.ParseUnixTimeAttribute(attributes, sqs.QueueAttributeNameCreatedTimestamp); created != nil {
+		snapshot.TimeCreated = utils.DateTimeFormat(*created)
+	}
+
+	tags, err := listQueueTags(sqsSvc, queueURL)
+	if err == nil {
+		snapshot.Tags = tags
+	}
+
+	return snapshot
+}
+
+// PollSqsQueues gathers information on each SQS queue for an AWS account.
+func PollSqsQueues(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting SQS Queue resource poller")
+	sqsQueueSnapshots := make(map[string]*awsmodels.SqsQueue)
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "sqs") {
+		sqsSvc, err := getSqsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		// Start with generating a list of all queues
+		queueURLs, err := listQueues(sqsSvc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "PollSqsQueues(%#v) in region %s", *pollerInput, *regionID)
+		}
+		if len(queueURLs) == 0 {
+			zap.L().Debug("no SQS queues found", zap.String("region", *regionID))
+			continue
+		}
+
+		for _, queueURL := range queueURLs {
+			sqsQueueSnapshot := buildSqsQueueSnapshot(sqsSvc, queueURL)
+			if sqsQueueSnapshot == nil {
+				continue
+			}
+			sqsQueueSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			sqsQueueSnapshot.Region = regionID
+
+			if _, ok := sqsQueueSnapshots[*sqsQueueSnapshot.ARN]; ok {
+				zap.L().Info(
+					"overwriting existing SQS Queue snapshot",
+					zap.String("resourceId", *sqsQueueSnapshot.ARN),
+				)
+			}
+			sqsQueueSnapshots[*sqsQueueSnapshot.ARN] = sqsQueueSnapshot
+		}
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(sqsQueueSnapshots))
+	for resourceID, sqsQueueSnapshot := range sqsQueueSnapshots {
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      sqsQueueSnapshot,
+			ID:              apimodels.ResourceID(resourceID),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.SqsQueueSchema,
+		})
+	}
+
+	return resources, nil
+}
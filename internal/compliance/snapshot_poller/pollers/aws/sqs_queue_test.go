@@ -0,0 +1,136 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSqsQueueList(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"ListQueuesPages"})
+
+	out, err := listQueues(mockSvc)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+	assert.Equal(t, int64(1000), *mockSvc.Calls[0].Arguments.Get(0).(*sqs.ListQueuesInput).MaxResults)
+}
+
+func TestSqsQueueListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"ListQueuesPages"})
+
+	out, err := listQueues(mockSvc)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueGetURL(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"GetQueueUrl"})
+
+	out, err := getQueueURL(mockSvc, arn.ARN{AccountID: "123456789012", Resource: "example-queue"})
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleQueueUrl, out)
+}
+
+func TestSqsQueueGetURLDoesNotExist(t *testing.T) {
+	mockSvc := &awstest.MockSqs{}
+	mockSvc.On("GetQueueUrl", mock.Anything).
+		Return(
+			&sqs.GetQueueUrlOutput{},
+			awserr.New(sqs.ErrCodeQueueDoesNotExist, "queue does not exist", nil),
+		)
+
+	out, err := getQueueURL(mockSvc, arn.ARN{AccountID: "123456789012", Resource: "example-queue"})
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueGetURLError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"GetQueueUrl"})
+
+	out, err := getQueueURL(mockSvc, arn.ARN{AccountID: "123456789012", Resource: "example-queue"})
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAll()
+
+	queueSnapshot := buildSqsQueueSnapshot(mockSvc, awstest.ExampleQueueUrl)
+
+	require.NotNil(t, queueSnapshot)
+	assert.Equal(t, awstest.ExampleQueueArn, queueSnapshot.ARN)
+	assert.Equal(t, "example-queue", *queueSnapshot.Name)
+	assert.Equal(t, int64(30), *queueSnapshot.VisibilityTimeout)
+	assert.Equal(t, "Value1", *queueSnapshot.Tags["Key1"])
+}
+
+func TestSqsQueueBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAllError()
+
+	queueSnapshot := buildSqsQueueSnapshot(mockSvc, awstest.ExampleQueueUrl)
+
+	assert.Nil(t, queueSnapshot)
+}
+
+func TestSqsQueuePoller(t *testing.T) {
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAll()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, *awstest.ExampleQueueArn, string(resources[0].ID))
+}
+
+func TestSqsQueuePollerError(t *testing.T) {
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAllError()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	for _, event := range resources {
+		assert.Nil(t, event.Attributes)
+	}
+}
@@ -0,0 +1,120 @@
+package throttle
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// Sustained requests per second allowed to a service in a region, unless overridden below
+	defaultRate = 10
+	// Seconds worth of requests which can be sent at once after a quiet period
+	burstSeconds = 2
+)
+
+// serviceRates overrides the default rate for services whose APIs allow more, or fewer, requests.
+// Services are keyed by their endpoint prefix.
+var serviceRates = map[string]float64{
+	"cloudformation": 4,
+	"ec2":            20,
+	"iam":            8,
+	"s3":             40,
+}
+
+// tokenBucket is a token bucket rate limiter, which refills at a fixed rate up to its burst size.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens in the bucket
+	tokens float64 // goes negative when tokens are reserved ahead of time
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  rate * burstSeconds,
+		tokens: rate * burstSeconds,
+		last:   now,
+	}
+}
+
+// refill adds the tokens accumulated since the last update
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// drain empties the bucket, so the requests following a throttled request are spread out at the
+// sustained rate instead of bursting into the service again.
+func (b *tokenBucket) drain(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	b.tokens = math.Min(b.tokens, 0)
+}
+
+// Key used to give every service in every region its own bucket
+type bucketKey struct {
+	Service string
+	Region  string
+}
+
+var (
+	bucketsMu sync.Mutex
+	buckets   = make(map[bucketKey]*tokenBucket)
+)
+
+// getBucket returns the token bucket of a service in a region, creating it on first use.
+func getBucket(service, region string, now time.Time) *tokenBucket {
+	bucketsMu.Lock()
+	defer bucketsMu.Unlock()
+
+	key := bucketKey{Service: service, Region: region}
+	if bucket, ok := buckets[key]; ok {
+		return bucket
+	}
+
+	rate, ok := serviceRates[service]
+	if !ok {
+		rate = defaultRate
+	}
+	bucket := newTokenBucket(rate, now)
+	buckets[key] = bucket
+	return bucket
+}
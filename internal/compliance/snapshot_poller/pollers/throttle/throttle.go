@@ -0,0 +1,160 @@
+// Package throttle keeps the snapshot poller AWS clients within the API rate limits of the scanned accounts.
+//
+// Every request waits for a token from the bucket of its service and region, so large scans spread their calls
+// out instead of tripping the limits. Requests which are throttled anyway are retried with backoff, and the ones
+// still throttled after their last retry are counted so pollers can tell which resources are incomplete.
+package throttle
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"go.uber.org/zap"
+)
+
+const (
+	// Throttled requests get more retries than other failures, the service is healthy and only needs time
+	maxThrottleRetries = 10
+	// Throttled requests are retried after 0.5s, 1s, 2s... with jitter, up to this delay
+	maxThrottleDelay = 20 * time.Second
+)
+
+// Error codes for throttling which are not recognized by the SDK
+var extraThrottleCodes = []string{
+	"SlowDown", // S3
+}
+
+var (
+	// throttledRequests counts the requests which were still throttled after their last retry
+	throttledRequests int64
+
+	// sleepFunc waits for a rate limit token, set as a variable to be overridden in testing.
+	sleepFunc = aws.SleepWithContext
+	// timeNowFunc is the clock of the rate limiters, set as a variable to be overridden in testing.
+	timeNowFunc = time.Now
+)
+
+// Apply configures a session to rate limit, and retry the throttled requests of, every client created from it.
+//
+// The existing MaxRetries of the session is kept as the retry limit for failures other than throttling.
+func Apply(sess *session.Session) *session.Session {
+	sess.Config.Retryer = retryer{
+		DefaultRetryer: client.DefaultRetryer{
+			NumMaxRetries:    aws.IntValue(sess.Config.MaxRetries),
+			MaxThrottleDelay: maxThrottleDelay,
+		},
+	}
+	// Other handlers may have decided whether to retry before the retryer is asked, which would skip
+	// the throttling retry limit
+	sess.Config.EnforceShouldRetryCheck = aws.Bool(true)
+
+	sess.Handlers.Build.PushBackNamed(throttleCodesHandler)
+	sess.Handlers.Send.PushFrontNamed(rateLimitHandler)
+	sess.Handlers.Retry.PushBackNamed(drainHandler)
+	sess.Handlers.Complete.PushBackNamed(throttledRequestsHandler)
+	return sess
+}
+
+// ThrottledRequests returns the number of requests which failed because they were still throttled after
+// their last retry.
+//
+// Pollers compare it before and after building a resource to tell whether the resource is incomplete.
+func ThrottledRequests() int64 {
+	return atomic.LoadInt64(&throttledRequests)
+}
+
+// retryer retries throttled requests up to maxThrottleRetries, and other retryable failures up to the
+// retry limit of the session.
+type retryer struct {
+	client.DefaultRetryer
+}
+
+// MaxRetries returns the highest number of retries any request can get
+func (r retryer) MaxRetries() int {
+	if r.NumMaxRetries > maxThrottleRetries {
+		return r.NumMaxRetries
+	}
+	return maxThrottleRetries
+}
+
+// ShouldRetry returns true if the request should be retried
+func (r retryer) ShouldRetry(req *request.Request) bool {
+	if req.IsErrorThrottle() {
+		return true
+	}
+	return req.RetryCount < r.NumMaxRetries && r.DefaultRetryer.ShouldRetry(req)
+}
+
+// throttleCodesHandler adds the throttling error codes the SDK does not know about to each request
+var throttleCodesHandler = request.NamedHandler{
+	Name: "throttle.ThrottleCodesHandler",
+	Fn: func(r *request.Request) {
+		r.ThrottleErrorCodes = append(r.ThrottleErrorCodes, extraThrottleCodes...)
+	},
+}
+
+// rateLimitHandler waits for a token from the bucket of the service and region before each attempt is sent
+var rateLimitHandler = request.NamedHandler{
+	Name: "throttle.RateLimitHandler",
+	Fn: func(r *request.Request) {
+		bucket := getBucket(r.ClientInfo.ServiceName, aws.StringValue(r.Config.Region), timeNowFunc())
+		wait := bucket.reserve(timeNowFunc())
+		if wait <= 0 {
+			return
+		}
+		if err := sleepFunc(r.Context(), wait); err != nil {
+			r.Error = awserr.New(request.CanceledErrorCode, "request context canceled", err)
+		}
+	},
+}
+
+// drainHandler empties the bucket of the service and region after a request was throttled
+var drainHandler = request.NamedHandler{
+	Name: "throttle.DrainHandler",
+	Fn: func(r *request.Request) {
+		if !r.IsErrorThrottle() {
+			return
+		}
+		getBucket(r.ClientInfo.ServiceName, aws.StringValue(r.Config.Region), timeNowFunc()).drain(timeNowFunc())
+	},
+}
+
+// throttledRequestsHandler counts and logs the requests which failed because they were still throttled
+var throttledRequestsHandler = request.NamedHandler{
+	Name: "throttle.ThrottledRequestsHandler",
+	Fn: func(r *request.Request) {
+		if r.Error == nil || !r.IsErrorThrottle() {
+			return
+		}
+		atomic.AddInt64(&throttledRequests, 1)
+		zap.L().Warn("request still throttled after retrying",
+			zap.String("service", r.ClientInfo.ServiceName),
+			zap.String("operation", r.Operation.Name),
+			zap.String("region", aws.StringValue(r.Config.Region)),
+			zap.Int("retries", r.RetryCount),
+			zap.Error(r.Error))
+	},
+}
@@ -0,0 +1,146 @@
+package throttle
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/corehandlers"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(10, now)
+
+	// The burst can be used right away
+	for i := 0; i < 10*burstSeconds; i++ {
+		assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	}
+	// After that requests are spaced out at the sustained rate
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, bucket.reserve(now))
+
+	// The bucket refills over time, but never beyond its burst size
+	later := now.Add(time.Minute)
+	for i := 0; i < 10*burstSeconds; i++ {
+		assert.Equal(t, time.Duration(0), bucket.reserve(later))
+	}
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(later))
+}
+
+func TestTokenBucketDrain(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(10, now)
+
+	bucket.drain(now)
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(now))
+
+	// Draining keeps the tokens reserved ahead of time
+	bucket.drain(now)
+	assert.Equal(t, 200*time.Millisecond, bucket.reserve(now))
+}
+
+func TestGetBucketServiceRates(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, float64(serviceRates["ec2"]), getBucket("ec2", "us-west-2", now).rate)
+	assert.Equal(t, float64(defaultRate), getBucket("lambda", "us-west-2", now).rate)
+
+	// Each service and region has its own bucket
+	assert.Same(t, getBucket("ec2", "us-west-2", now), getBucket("ec2", "us-west-2", now))
+	assert.NotSame(t, getBucket("ec2", "us-west-2", now), getBucket("ec2", "us-east-1", now))
+}
+
+// testIAMClient returns an IAM client whose requests fail with the given error code
+func testIAMClient(t *testing.T, errorCode string) (*iam.IAM, *int) {
+	sleepFunc = func(context.Context, time.Duration) error { return nil }
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(2),
+		Region:      aws.String("us-east-1"),
+		SleepDelay:  func(time.Duration) {},
+	})
+	require.NoError(t, err)
+	svc := iam.New(Apply(sess))
+
+	attempts := 0
+	svc.Handlers.Send.Swap(corehandlers.SendHandler.Name, request.NamedHandler{
+		Name: "test.SendHandler",
+		Fn: func(r *request.Request) {
+			attempts++
+			r.HTTPResponse = &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}
+			r.Error = awserr.New(errorCode, "test error", nil)
+		},
+	})
+	return svc, &attempts
+}
+
+func TestThrottledRequestRetries(t *testing.T) {
+	svc, attempts := testIAMClient(t, "Throttling")
+	throttled := ThrottledRequests()
+
+	_, err := svc.ListRoles(&iam.ListRolesInput{})
+	require.Error(t, err)
+	assert.Equal(t, maxThrottleRetries+1, *attempts)
+	assert.Equal(t, throttled+1, ThrottledRequests())
+
+	// The bucket was drained by the throttled requests
+	assert.True(t, getBucket("iam", "us-east-1", time.Now()).tokens < 1)
+}
+
+func TestExtraThrottleCodes(t *testing.T) {
+	svc, attempts := testIAMClient(t, "SlowDown")
+	throttled := ThrottledRequests()
+
+	_, err := svc.ListRoles(&iam.ListRolesInput{})
+	require.Error(t, err)
+	assert.Equal(t, maxThrottleRetries+1, *attempts)
+	assert.Equal(t, throttled+1, ThrottledRequests())
+}
+
+func TestOtherErrorRetries(t *testing.T) {
+	svc, attempts := testIAMClient(t, "RequestTimeout")
+	throttled := ThrottledRequests()
+
+	_, err := svc.ListRoles(&iam.ListRolesInput{})
+	require.Error(t, err)
+	assert.Equal(t, 3, *attempts)
+	assert.Equal(t, throttled, ThrottledRequests())
+}
+
+func TestNonRetryableError(t *testing.T) {
+	svc, attempts := testIAMClient(t, iam.ErrCodeNoSuchEntityException)
+	throttled := ThrottledRequests()
+
+	_, err := svc.ListRoles(&iam.ListRolesInput{})
+	require.Error(t, err)
+	assert.Equal(t, 1, *attempts)
+	assert.Equal(t, throttled, ThrottledRequests())
+}
@@ -0,0 +1,59 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// Some services (e.g. SQS and SNS) describe resources with a map of string attributes,
+// these helpers parse the typed values out of such a map.
+//
+// Missing or malformed attributes are returned as nil.
+
+// ParseInt64Attribute parses an integer attribute
+func ParseInt64Attribute(attributes map[string]*string, name string) *int64 {
+	value, err := strconv.ParseInt(aws.StringValue(attributes[name]), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// ParseBoolAttribute parses a boolean attribute
+func ParseBoolAttribute(attributes map[string]*string, name string) *bool {
+	value, err := strconv.ParseBool(aws.StringValue(attributes[name]))
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// ParseUnixTimeAttribute parses an attribute holding an epoch timestamp in seconds
+func ParseUnixTimeAttribute(attributes map[string]*string, name string) *time.Time {
+	seconds := ParseInt64Attribute(attributes, name)
+	if seconds == nil {
+		return nil
+	}
+	value := time.Unix(*seconds, 0).UTC()
+	return &value
+}
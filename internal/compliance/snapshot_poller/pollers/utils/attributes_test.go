@@ -0,0 +1,45 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttributes(t *testing.T) {
+	attributes := map[string]*string{
+		"DelaySeconds":     aws.String("30"),
+		"FifoQueue":        aws.String("true"),
+		"CreatedTimestamp": aws.String("1590000000"),
+		"Policy":           aws.String("{}"),
+	}
+
+	assert.Equal(t, aws.Int64(30), ParseInt64Attribute(attributes, "DelaySeconds"))
+	assert.Equal(t, aws.Bool(true), ParseBoolAttribute(attributes, "FifoQueue"))
+	assert.Equal(t, aws.Time(time.Date(2020, 5, 20, 18, 40, 0, 0, time.UTC)), ParseUnixTimeAttribute(attributes, "CreatedTimestamp"))
+
+	// Missing and malformed attributes
+	assert.Nil(t, ParseInt64Attribute(attributes, "Policy"))
+	assert.Nil(t, ParseBoolAttribute(attributes, "Missing"))
+	assert.Nil(t, ParseUnixTimeAttribute(attributes, "Missing"))
+}
@@ -71,3 +71,18 @@ func GetServiceRegions(activeRegions []*string, serviceID string) (regions []*st
 	}
 	return
 }
+
+// LimitRegions returns the enabled regions which are also in the given scan regions
+func LimitRegions(enabledRegions []*string, scanRegions []*string) (regions []*string) {
+	limit := make(map[string]bool, len(scanRegions))
+	for _, region := range scanRegions {
+		limit[*region] = true
+	}
+
+	for _, region := range enabledRegions {
+		if limit[*region] {
+			regions = append(regions, region)
+		}
+	}
+	return
+}
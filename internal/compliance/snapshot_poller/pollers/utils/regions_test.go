@@ -0,0 +1,34 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitRegions(t *testing.T) {
+	enabled := aws.StringSlice([]string{"eu-west-1", "us-east-1", "us-west-2"})
+
+	assert.Equal(t, aws.StringSlice([]string{"us-east-1", "us-west-2"}),
+		LimitRegions(enabled, aws.StringSlice([]string{"us-west-2", "us-east-1", "ap-south-1"})))
+	assert.Nil(t, LimitRegions(enabled, aws.StringSlice([]string{"ap-south-1"})))
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/kelseyhightower/envconfig"
 
 	"github.com/panther-labs/panther/internal/compliance/snapshot_scheduler/scheduler"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
@@ -36,10 +37,15 @@ func lambdaHandler(ctx context.Context, request events.CloudWatchEvent) (err err
 	defer func() {
 		operation.Stop().Log(err)
 	}()
-	err = scheduler.PollAndIssueNewScans()
+	if err = scheduler.PollAndIssueNewScans(); err != nil {
+		return err
+	}
+	// Accounts which joined an organization are scanned by the sync itself, so this runs last
+	err = scheduler.SyncOrganizations()
 	return err
 }
 
 func main() {
+	envconfig.MustProcess("", &scheduler.Env)
 	lambda.Start(lambdaHandler)
 }
@@ -19,26 +19,53 @@ package scheduler
  */
 
 import (
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
-const sourceAPIFunctionName = "panther-source-api"
+const (
+	sourceAPIFunctionName = "panther-source-api"
+
+	// Used for integrations which were onboarded without a scan interval
+	defaultScanIntervalMins = 1440
+
+	// The scheduler runs hourly, so a resource type is due slightly early rather than a full hour late
+	scheduleDrift = 5 * time.Minute
+
+	maxElapsedTime = 5 * time.Second
+)
+
+type envConfig struct {
+	SnapshotPollersQueueURL string `required:"true" split_words:"true"`
+}
 
 var (
+	// Env is the parsed environment variables
+	Env envConfig
+
 	sess                               = session.Must(session.NewSession())
 	lambdaClient lambdaiface.LambdaAPI = lambda.New(sess)
+	sqsClient    sqsiface.SQSAPI       = sqs.New(sess)
 )
 
-// PollAndIssueNewScans sends messages to the snapshot-pollers when new scans need to start.
+// PollAndIssueNewScans sends messages to the snapshot-pollers for the resource types which are due to be scanned.
+//
+// Each resource type is scanned on its own interval, and the time it was scheduled is recorded on the integration.
 func PollAndIssueNewScans() error {
 	enabledIntegrations, err := getEnabledIntegrations()
 	if err != nil {
@@ -50,25 +77,88 @@ func PollAndIssueNewScans() error {
 	}
 
 	zap.L().Info("loaded enabled integrations", zap.Int("count", len(enabledIntegrations)))
-	var integrationsToScan []*models.SourceIntegrationMetadata
+	now := time.Now()
+	var sqsEntries []*sqs.SendMessageBatchRequestEntry
+	var scans []*models.UpdateIntegrationResourceScansInput
 
 	for _, integration := range enabledIntegrations {
+		// Accounts which left their organization are no longer scanned
+		if aws.BoolValue(integration.Disabled) {
+			zap.L().Debug("skipping disabled integration", zap.String("integrationID", *integration.IntegrationID))
+			continue
+		}
 		// Only add new scans if needed
-		if (scanIntervalElapsed(integration) && scanIsNotOngoing(integration)) || scanIsStuck(integration) {
-			integrationsToScan = append(integrationsToScan, &integration.SourceIntegrationMetadata)
-		} else {
+		if !scanIsNotOngoing(integration) && !scanIsStuck(integration) {
 			zap.L().Debug("skipping integration", zap.String("integrationID", *integration.IntegrationID))
+			continue
+		}
+		resourceTypes := dueResourceTypes(integration, now)
+		if len(resourceTypes) == 0 {
+			zap.L().Debug("no resource types due", zap.String("integrationID", *integration.IntegrationID))
+			continue
+		}
+
+		entries, err := scanMessages(integration, resourceTypes)
+		if err != nil {
+			return err
 		}
+		sqsEntries = append(sqsEntries, entries...)
+		scans = append(scans, &models.UpdateIntegrationResourceScansInput{
+			IntegrationID: integration.IntegrationID,
+			LastScanTime:  &now,
+			ResourceTypes: resourceTypes,
+		})
 	}
 
-	return genericapi.Invoke(
+	if len(sqsEntries) == 0 {
+		zap.L().Info("no scans to schedule")
+		return nil
+	}
+
+	zap.L().Info("scheduling new scans",
+		zap.Int("integrations", len(scans)),
+		zap.Int("count", len(sqsEntries)))
+	_, err = sqsbatch.SendMessageBatch(sqsClient, maxElapsedTime, &sqs.SendMessageBatchInput{
+		Entries:  sqsEntries,
+		QueueUrl: &Env.SnapshotPollersQueueURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Scans are only recorded once they are queued, so a failed send is retried on the next run
+	for _, scan := range scans {
+		err = genericapi.Invoke(
+			lambdaClient,
+			sourceAPIFunctionName,
+			&models.LambdaInput{UpdateIntegrationResourceScans: scan},
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncOrganizations onboards the accounts which joined, and offboards the accounts which left, each organization.
+func SyncOrganizations() error {
+	var output models.SyncOrgIntegrationsOutput
+	err := genericapi.Invoke(
 		lambdaClient,
 		sourceAPIFunctionName,
-		&models.LambdaInput{FullScan: &models.FullScanInput{
-			Integrations: integrationsToScan,
-		}},
-		nil,
+		&models.LambdaInput{SyncOrgIntegrations: &models.SyncOrgIntegrationsInput{}},
+		&output,
 	)
+	if err != nil {
+		return err
+	}
+
+	zap.L().Info("synced organizations",
+		zap.Int("created", output.Created),
+		zap.Int("updated", output.Updated),
+		zap.Int("disabled", output.Disabled))
+	return nil
 }
 
 // getEnabledIntegrations lists enabled integrations from the snapshot-api.
@@ -106,6 +196,77 @@ func scanIntervalElapsed(integration *models.SourceIntegration) bool {
 		return true
 	}
 
-	intervalMins := time.Duration(*integration.ScanIntervalMins) * time.Minute
-	return time.Since(*integration.LastScanEndTime) >= intervalMins
+	return time.Since(*integration.LastScanEndTime) >= scanInterval(integration)
+}
+
+// scanInterval is the interval of the resource types which do not have one of their own.
+func scanInterval(integration *models.SourceIntegration) time.Duration {
+	if integration.ScanIntervalMins == nil {
+		return defaultScanIntervalMins * time.Minute
+	}
+	return time.Duration(*integration.ScanIntervalMins) * time.Minute
+}
+
+// dueResourceTypes lists, in order, the resource types of an integration whose scan interval elapsed.
+func dueResourceTypes(integration *models.SourceIntegration, now time.Time) []*string {
+	intervals := make(map[string]time.Duration, len(integration.ResourceScanIntervals))
+	for _, interval := range integration.ResourceScanIntervals {
+		intervals[*interval.ResourceType] = time.Duration(*interval.IntervalMins) * time.Minute
+	}
+	lastScans := make(map[string]*time.Time, len(integration.ResourceLastScans))
+	for _, lastScan := range integration.ResourceLastScans {
+		lastScans[*lastScan.ResourceType] = lastScan.LastScanTime
+	}
+
+	var result []*string
+	for resourceType := range awspoller.ServicePollers {
+		interval, ok := intervals[resourceType]
+		if !ok {
+			interval = scanInterval(integration)
+		}
+
+		lastScan, ok := lastScans[resourceType]
+		if !ok {
+			// Types which were never scanned on their own were last covered by a full scan
+			// of the integration, at the latest when it was onboarded
+			lastScan = integration.LastScanEndTime
+			if lastScan == nil {
+				lastScan = integration.CreatedAtTime
+			}
+		}
+
+		if lastScan == nil || now.Sub(*lastScan) >= interval-scheduleDrift {
+			result = append(result, aws.String(resourceType))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return *result[i] < *result[j] })
+	return result
+}
+
+// scanMessages builds one message per due resource type, and per scan region of the regional types.
+func scanMessages(integration *models.SourceIntegration, resourceTypes []*string) (
+	[]*sqs.SendMessageBatchRequestEntry, error) {
+
+	var result []*sqs.SendMessageBatchRequestEntry
+	for _, resourceType := range resourceTypes {
+		scanEntries := awspoller.ResourceTypeScanEntries(pollermodels.ScanEntry{
+			AWSAccountID:  integration.AWSAccountID,
+			IntegrationID: integration.IntegrationID,
+			OrgUnitPath:   integration.OrgUnitPath,
+		}, *resourceType, integration.ScanRegions)
+
+		for _, scanEntry := range scanEntries {
+			body, err := jsoniter.MarshalToString(&pollermodels.ScanMsg{Entries: []*pollermodels.ScanEntry{scanEntry}})
+			if err != nil {
+				zap.L().Error("failed to marshal scan message", zap.Error(err))
+				return nil, err
+			}
+			result = append(result, &sqs.SendMessageBatchRequestEntry{
+				Id:          aws.String(awspoller.ScanEntryID(scanEntry)),
+				MessageBody: aws.String(body),
+			})
+		}
+	}
+	return result, nil
 }
@@ -26,12 +26,16 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
 //
@@ -168,10 +172,6 @@ var (
 func TestPollAndIssueNewScansNoneToRun(t *testing.T) {
 	mockLambda := &mockLambdaClient{}
 
-	mockLambda.
-		On("Invoke", mock.Anything).
-		// Pass in the first integration, which won't need a new scan.
-		Return(getTestInvokeOutput(exampleIntegrations[:1], 200), nil)
 	mockLambda.
 		On("Invoke", getTestInvokeInput()).
 		// Pass in the first integration, which won't need a new scan.
@@ -270,3 +270,123 @@ func TestGetEnabledIntegrationsError(t *testing.T) {
 	mockLambda.AssertExpectations(t)
 	require.Error(t, err)
 }
+
+func TestPollAndIssueNewScansSkipsDisabled(t *testing.T) {
+	mockLambda := &mockLambdaClient{}
+	disabled := *exampleIntegrations[2]
+	disabled.Disabled = aws.Bool(true)
+
+	mockLambda.
+		On("Invoke", getTestInvokeInput()).
+		Return(getTestInvokeOutput([]*models.SourceIntegration{&disabled}, 200), nil)
+	lambdaClient = mockLambda
+	mockSQS := &testutils.SqsMock{}
+	sqsClient = mockSQS
+
+	result := PollAndIssueNewScans()
+
+	// The disabled integration was never scanned, so nothing was scheduled or recorded
+	mockLambda.AssertExpectations(t)
+	mockLambda.AssertNumberOfCalls(t, "Invoke", 1)
+	mockSQS.AssertNotCalled(t, "SendMessageBatch", mock.Anything)
+	assert.NoError(t, result)
+}
+
+func TestPollAndIssueNewScansDueResourceTypes(t *testing.T) {
+	Env.SnapshotPollersQueueURL = "test-url"
+	integration := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			AWSAccountID:     aws.String("123456789012"),
+			IntegrationID:    aws.String("45c378a7-2e36-4b12-8e16-2d3c49ff1371"),
+			IntegrationType:  aws.String("aws-scan"),
+			ScanIntervalMins: aws.Int(1440),
+			ResourceScanIntervals: []*models.ResourceScanInterval{
+				{ResourceType: aws.String(awsmodels.IAMRoleSchema), IntervalMins: aws.Int(60)},
+				{ResourceType: aws.String(awsmodels.Ec2VolumeSchema), IntervalMins: aws.Int(180)},
+			},
+			ScanRegions: []*string{aws.String("us-east-1"), aws.String("us-west-2")},
+		},
+		SourceIntegrationStatus: models.SourceIntegrationStatus{
+			ScanStatus: aws.String("ok"),
+		},
+		SourceIntegrationScanInformation: models.SourceIntegrationScanInformation{
+			LastScanEndTime: aws.Time(time.Now().Add(-4 * time.Hour)),
+			ResourceLastScans: []*models.ResourceLastScan{
+				{ResourceType: aws.String(awsmodels.IAMRoleSchema), LastScanTime: aws.Time(time.Now().Add(-time.Hour))},
+			},
+		},
+	}
+
+	mockLambda := &mockLambdaClient{}
+	mockLambda.
+		On("Invoke", getTestInvokeInput()).
+		Return(getTestInvokeOutput([]*models.SourceIntegration{integration}, 200), nil).Once()
+	mockLambda.
+		On("Invoke", mock.Anything).
+		Return(getTestInvokeOutput(nil, 200), nil).Once()
+	lambdaClient = mockLambda
+	mockSQS := &testutils.SqsMock{}
+	mockSQS.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+	sqsClient = mockSQS
+
+	require.NoError(t, PollAndIssueNewScans())
+	mockLambda.AssertExpectations(t)
+	mockSQS.AssertExpectations(t)
+
+	// The global IAM roles are scanned once, the regional EC2 volumes once per scan region
+	sqsInput := mockSQS.Calls[0].Arguments.Get(0).(*sqs.SendMessageBatchInput)
+	require.Len(t, sqsInput.Entries, 3)
+	assert.Equal(t, "45c378a7-2e36-4b12-8e16-2d3c49ff1371-AWSEC2Volume-useast1", *sqsInput.Entries[0].Id)
+	assert.Equal(t, "45c378a7-2e36-4b12-8e16-2d3c49ff1371-AWSEC2Volume-uswest2", *sqsInput.Entries[1].Id)
+	assert.Equal(t, "45c378a7-2e36-4b12-8e16-2d3c49ff1371-AWSIAMRole", *sqsInput.Entries[2].Id)
+
+	var recordInput models.LambdaInput
+	require.NoError(t, jsoniter.Unmarshal(mockLambda.Calls[1].Arguments.Get(0).(*lambda.InvokeInput).Payload, &recordInput))
+	require.NotNil(t, recordInput.UpdateIntegrationResourceScans)
+	assert.Equal(t,
+		[]*string{aws.String(awsmodels.Ec2VolumeSchema), aws.String(awsmodels.IAMRoleSchema)},
+		recordInput.UpdateIntegrationResourceScans.ResourceTypes)
+}
+
+func TestDueResourceTypes(t *testing.T) {
+	now := time.Now()
+	integration := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			CreatedAtTime:    aws.Time(now.Add(-2 * time.Hour)),
+			ScanIntervalMins: aws.Int(1440),
+			ResourceScanIntervals: []*models.ResourceScanInterval{
+				{ResourceType: aws.String(awsmodels.IAMUserSchema), IntervalMins: aws.Int(60)},
+			},
+		},
+	}
+
+	// Never scanned since it was onboarded, so only the hourly type is due
+	assert.Equal(t, []*string{aws.String(awsmodels.IAMUserSchema)}, dueResourceTypes(integration, now))
+
+	// Scanned just under an hour ago, which is close enough to the next hourly run
+	integration.ResourceLastScans = []*models.ResourceLastScan{
+		{ResourceType: aws.String(awsmodels.IAMUserSchema), LastScanTime: aws.Time(now.Add(-58 * time.Minute))},
+	}
+	assert.Equal(t, []*string{aws.String(awsmodels.IAMUserSchema)}, dueResourceTypes(integration, now))
+
+	integration.ResourceLastScans[0].LastScanTime = aws.Time(now.Add(-10 * time.Minute))
+	assert.Empty(t, dueResourceTypes(integration, now))
+
+	// Every type is due a day after the onboarding scan
+	integration.CreatedAtTime = aws.Time(now.Add(-25 * time.Hour))
+	assert.Len(t, dueResourceTypes(integration, now), len(awspoller.ServicePollers)-1)
+}
+
+func TestSyncOrganizations(t *testing.T) {
+	mockLambda := &mockLambdaClient{}
+	payload, err := jsoniter.Marshal(&models.LambdaInput{SyncOrgIntegrations: &models.SyncOrgIntegrationsInput{}})
+	require.NoError(t, err)
+
+	mockLambda.
+		On("Invoke", &lambda.InvokeInput{FunctionName: aws.String("panther-source-api"), Payload: payload}).
+		Return(getTestInvokeOutput(&models.SyncOrgIntegrationsOutput{Created: 1}, 200), nil)
+	lambdaClient = mockLambda
+
+	assert.NoError(t, SyncOrganizations())
+	mockLambda.AssertExpectations(t)
+}
@@ -20,6 +20,8 @@ package delivery
 
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -38,6 +40,9 @@ var (
 
 	// Lazy-load the SQS client - we only need it to retry failed alerts
 	sqsClient sqsiface.SQSAPI
+
+	// Lazy-load the DynamoDB client - we only need it for outputs with digests and tickets
+	dynamoClient dynamodbiface.DynamoDBAPI
 )
 
 func getSQSClient() sqsiface.SQSAPI {
@@ -46,3 +51,10 @@ func getSQSClient() sqsiface.SQSAPI {
 	}
 	return sqsClient
 }
+
+func getDynamoClient() dynamodbiface.DynamoDBAPI {
+	if dynamoClient == nil {
+		dynamoClient = dynamodb.New(awsSession)
+	}
+	return dynamoClient
+}
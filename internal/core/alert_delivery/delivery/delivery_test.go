@@ -38,6 +38,16 @@ func (m *mockOutputsClient) Slack(alert *alertmodels.Alert, config *outputmodels
 	return args.Get(0).(*outputs.AlertDeliveryError)
 }
 
+func (m *mockOutputsClient) PagerDuty(alert *alertmodels.Alert, config *outputmodels.PagerDutyConfig) *outputs.AlertDeliveryError {
+	args := m.Called(alert, config)
+	return args.Get(0).(*outputs.AlertDeliveryError)
+}
+
+func (m *mockOutputsClient) Jira(alert *alertmodels.Alert, config *outputmodels.JiraConfig) (*outputs.Ticket, *outputs.AlertDeliveryError) {
+	args := m.Called(alert, config)
+	return args.Get(0).(*outputs.Ticket), args.Get(1).(*outputs.AlertDeliveryError)
+}
+
 type mockLambdaClient struct {
 	lambdaiface.LambdaAPI
 	mock.Mock
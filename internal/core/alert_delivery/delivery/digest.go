@@ -0,0 +1,332 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+const (
+	defaultDigestWindow    = 60 * time.Minute
+	defaultDigestMaxAlerts = 100
+	// Held alerts are removed by the table TTL if they could never be delivered
+	digestItemTTL = 7 * 24 * time.Hour
+	// Maximum time spent holding alerts again after their digest could not be sent
+	maxDigestBackoff = 30 * time.Second
+	// DigestPolicyID is the PolicyID of the summary alert sent for a digest
+	DigestPolicyID = "Panther.AlertDigest"
+)
+
+var (
+	digestTable = os.Getenv("DIGEST_TABLE_NAME")
+
+	// Severities ordered from least to most severe, used to pick the severity of a digest
+	severityRank = map[string]int{"INFO": 0, "LOW": 1, "MEDIUM": 2, "HIGH": 3, "CRITICAL": 4}
+)
+
+// digestItem is an alert held in the digest table until the digest for its output is sent.
+type digestItem struct {
+	OutputID string `json:"outputId"`
+	// AlertKey sorts held alerts by creation time, and makes holding the same alert twice idempotent
+	AlertKey  string             `json:"alertKey"`
+	Alert     *alertmodels.Alert `json:"alert"`
+	ExpiresAt int64              `json:"expiresAt"`
+}
+
+// isDigested returns true if the alert should be held for the digest of this output.
+//
+// Acknowledging or resolving an incident is never held, it applies to an incident which was already sent.
+func isDigested(alert *alertmodels.Alert, output *outputmodels.AlertOutput) bool {
+	if output.Digest == nil || aws.StringValue(alert.Severity) == "CRITICAL" || alert.IsLifecycleUpdate() {
+		return false
+	}
+	for _, severity := range output.Digest.Severities {
+		if aws.StringValue(severity) == aws.StringValue(alert.Severity) {
+			return true
+		}
+	}
+	return false
+}
+
+func digestWindow(output *outputmodels.AlertOutput) time.Duration {
+	if output.Digest.WindowMins > 0 {
+		return time.Duration(output.Digest.WindowMins) * time.Minute
+	}
+	return defaultDigestWindow
+}
+
+func digestMaxAlerts(output *outputmodels.AlertOutput) int {
+	if output.Digest.MaxAlerts > 0 {
+		return output.Digest.MaxAlerts
+	}
+	return defaultDigestMaxAlerts
+}
+
+// Hold an alert for the digest of one output (run as a child goroutine, like send).
+//
+// The digest is sent right away if the output has reached its maximum number of held alerts.
+func hold(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChannel chan outputStatus) {
+	commonFields := []zap.Field{
+		zap.String("outputID", *output.OutputID),
+		zap.String("policyId", *alert.PolicyID),
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			zap.L().Error("panic holding alert for digest", append(commonFields, zap.Any("panic", r))...)
+			statusChannel <- outputStatus{
+				outputID: *output.OutputID, success: false, needsRetry: false, message: "panic holding alert for digest"}
+		}
+	}()
+
+	alertID := aws.StringValue(alert.AlertID)
+	if alertID == "" {
+		alertID = *alert.PolicyID
+	}
+	item := &digestItem{
+		OutputID:  *output.OutputID,
+		AlertKey:  alert.CreatedAt.UTC().Format(time.RFC3339Nano) + "#" + alertID,
+		Alert:     alert,
+		ExpiresAt: time.Now().Add(digestItemTTL).Unix(),
+	}
+	dynamoItem, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		zap.L().Error("failed to marshal digest item", append(commonFields, zap.Error(err))...)
+		statusChannel <- outputStatus{
+			outputID: *output.OutputID, success: false, needsRetry: false, message: "digest marshal error: " + err.Error()}
+		return
+	}
+
+	_, err = getDynamoClient().PutItem(&dynamodb.PutItemInput{Item: dynamoItem, TableName: aws.String(digestTable)})
+	if err != nil {
+		zap.L().Warn("failed to hold alert for digest", append(commonFields, zap.Error(err))...)
+		statusChannel <- outputStatus{
+			outputID: *output.OutputID, success: false, needsRetry: true, message: "digest hold error: " + err.Error()}
+		return
+	}
+	zap.L().Info("alert held for digest", commonFields...)
+
+	// The alert itself has been handled, a failed digest is retried by the next scheduled flush
+	flushDigest(output)
+	statusChannel <- outputStatus{outputID: *output.OutputID, success: true, needsRetry: false}
+}
+
+// FlushDigests sends the digest of every output which has held alerts past its window or count.
+//
+// This is triggered by a scheduled event.
+func FlushDigests() error {
+	outputs, err := getOutputs()
+	if err != nil {
+		return err
+	}
+	for _, output := range outputs {
+		if output.Digest != nil {
+			flushDigest(output)
+		}
+	}
+	return nil
+}
+
+// flushDigest sends and removes the held alerts of an output if its digest is due.
+func flushDigest(output *outputmodels.AlertOutput) {
+	items, err := getHeldAlerts(*output.OutputID)
+	if err != nil {
+		zap.L().Warn("failed to query held alerts", zap.String("outputID", *output.OutputID), zap.Error(err))
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	// Items are sorted by creation time, so the first one is the oldest
+	if len(items) < digestMaxAlerts(output) && time.Since(*items[0].Alert.CreatedAt) < digestWindow(output) {
+		return
+	}
+
+	// Concurrent deliveries and the scheduled flush can see the same held alerts,
+	// only the alerts claimed by this invocation are sent.
+	items = claimHeldAlerts(items)
+	if len(items) == 0 {
+		return
+	}
+
+	statusChannel := make(chan outputStatus, 1)
+	send(generateDigest(items), output, statusChannel)
+	if status := <-statusChannel; !status.success && status.needsRetry {
+		// Hold the alerts again so the digest is retried by the next scheduled flush
+		releaseHeldAlerts(*output.OutputID, items)
+	}
+}
+
+// claimHeldAlerts removes the held alerts from the table, returning the ones this invocation removed.
+func claimHeldAlerts(items []*digestItem) []*digestItem {
+	condition, err := expression.NewBuilder().
+		WithCondition(expression.AttributeExists(expression.Name("alertKey"))).Build()
+	if err != nil {
+		zap.L().Error("failed to build digest claim condition", zap.Error(err))
+		return nil
+	}
+
+	claimed := make([]*digestItem, 0, len(items))
+	for _, item := range items {
+		_, err := getDynamoClient().DeleteItem(&dynamodb.DeleteItemInput{
+			ConditionExpression:      condition.Condition(),
+			ExpressionAttributeNames: condition.Names(),
+			Key: map[string]*dynamodb.AttributeValue{
+				"outputId": {S: aws.String(item.OutputID)},
+				"alertKey": {S: aws.String(item.AlertKey)},
+			},
+			TableName: aws.String(digestTable),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+				zap.L().Warn("failed to claim held alert",
+					zap.String("outputID", item.OutputID), zap.String("alertKey", item.AlertKey), zap.Error(err))
+			}
+			// Claimed by another invocation, or left for the next flush
+			continue
+		}
+		claimed = append(claimed, item)
+	}
+	return claimed
+}
+
+// releaseHeldAlerts puts claimed alerts back in the table after their digest could not be sent.
+func releaseHeldAlerts(outputID string, items []*digestItem) {
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{digestTable: make([]*dynamodb.WriteRequest, 0, len(items))},
+	}
+	for _, item := range items {
+		dynamoItem, err := dynamodbattribute.MarshalMap(item)
+		if err != nil {
+			zap.L().Error("failed to marshal digest item", zap.String("outputID", outputID), zap.Error(err))
+			continue
+		}
+		input.RequestItems[digestTable] = append(input.RequestItems[digestTable],
+			&dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: dynamoItem}})
+	}
+	if err := dynamodbbatch.BatchWriteItem(getDynamoClient(), maxDigestBackoff, input); err != nil {
+		zap.L().Error("failed to hold alerts again after the digest could not be sent",
+			zap.String("outputID", outputID), zap.Error(err))
+	}
+}
+
+// getHeldAlerts returns the alerts held for an output, oldest first.
+func getHeldAlerts(outputID string) ([]*digestItem, error) {
+	keyCondition := expression.Key("outputId").Equal(expression.Value(outputID))
+	queryExpression, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*digestItem
+	var unmarshalErr error
+	err = getDynamoClient().QueryPages(&dynamodb.QueryInput{
+		TableName:                 aws.String(digestTable),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		ConsistentRead:            aws.Bool(true),
+	}, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var pageItems []*digestItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &pageItems); unmarshalErr != nil {
+			return false
+		}
+		items = append(items, pageItems...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, unmarshalErr
+}
+
+// digestRule summarizes the held alerts of one rule or policy
+type digestRule struct {
+	name   string
+	count  int
+	latest *alertmodels.Alert
+}
+
+// generateDigest builds a single summary alert with per-rule counts and links.
+func generateDigest(items []*digestItem) *alertmodels.Alert {
+	rules := make(map[string]*digestRule)
+	severity := "INFO"
+	for _, item := range items {
+		alert := item.Alert
+		rule, ok := rules[*alert.PolicyID]
+		if !ok {
+			rule = &digestRule{name: aws.StringValue(alert.PolicyName)}
+			if rule.name == "" {
+				rule.name = *alert.PolicyID
+			}
+			rules[*alert.PolicyID] = rule
+		}
+		rule.count++
+		// Items are sorted by creation time, so the last one is the latest
+		rule.latest = alert
+
+		if severityRank[*alert.Severity] > severityRank[severity] {
+			severity = *alert.Severity
+		}
+	}
+
+	sortedRules := make([]*digestRule, 0, len(rules))
+	for _, rule := range rules {
+		sortedRules = append(sortedRules, rule)
+	}
+	sort.Slice(sortedRules, func(i, j int) bool {
+		if sortedRules[i].count != sortedRules[j].count {
+			return sortedRules[i].count > sortedRules[j].count
+		}
+		return sortedRules[i].name < sortedRules[j].name
+	})
+
+	lines := make([]string, len(sortedRules))
+	for i, rule := range sortedRules {
+		lines[i] = fmt.Sprintf("%s (%s): %d alert(s) - %s",
+			rule.name, aws.StringValue(rule.latest.Severity), rule.count, outputs.GenerateURL(rule.latest))
+	}
+
+	return &alertmodels.Alert{
+		CreatedAt:         aws.Time(time.Now().UTC()),
+		PolicyID:          aws.String(DigestPolicyID),
+		PolicyName:        aws.String("Alert Digest"),
+		PolicyDescription: aws.String(strings.Join(lines, "\n")),
+		Severity:          aws.String(severity),
+		Type:              aws.String(alertmodels.DigestType),
+		Title: aws.String(fmt.Sprintf("%d alerts from %d rules since %s",
+			len(items), len(rules), items[0].Alert.CreatedAt.UTC().Format(time.RFC3339))),
+	}
+}
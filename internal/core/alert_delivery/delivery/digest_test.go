@@ -0,0 +1,225 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var digestOutput = &outputmodels.AlertOutput{
+	OutputType:  aws.String("slack"),
+	DisplayName: aws.String("slack:digest"),
+	OutputConfig: &outputmodels.OutputConfig{
+		Slack: &outputmodels.SlackConfig{WebhookURL: "https://slack.com"},
+	},
+	OutputID: aws.String("output-id"),
+	Digest: &outputmodels.DigestConfig{
+		Severities: aws.StringSlice([]string{"INFO", "LOW"}),
+		MaxAlerts:  2,
+	},
+}
+
+// mockHeldAlerts makes QueryPages return the given alerts as held for the digest output
+func mockHeldAlerts(dynamoMock *testutils.DynamoDBMock, alerts ...*alertmodels.Alert) {
+	items := make([]*digestItem, len(alerts))
+	for i, alert := range alerts {
+		items[i] = &digestItem{OutputID: "output-id", AlertKey: alert.CreatedAt.String(), Alert: alert}
+	}
+	page, err := dynamodbattribute.MarshalList(items)
+	if err != nil {
+		panic(err)
+	}
+	queryOutput := &dynamodb.QueryOutput{Items: make([]map[string]*dynamodb.AttributeValue, len(page))}
+	for i, item := range page {
+		queryOutput.Items[i] = item.M
+	}
+	dynamoMock.On("QueryPages", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(func(*dynamodb.QueryOutput, bool) bool)(queryOutput, true)
+	})
+}
+
+func TestIsDigested(t *testing.T) {
+	alert := sampleAlert()
+	assert.True(t, isDigested(alert, digestOutput))
+	assert.False(t, isDigested(alert, alertOutput))
+
+	alert.Severity = aws.String("HIGH")
+	assert.False(t, isDigested(alert, digestOutput))
+
+	// CRITICAL alerts are never held, even if the output is misconfigured
+	alert.Severity = aws.String("CRITICAL")
+	output := *digestOutput
+	output.Digest = &outputmodels.DigestConfig{Severities: aws.StringSlice([]string{"CRITICAL"})}
+	assert.False(t, isDigested(alert, &output))
+}
+
+func TestGenerateDigest(t *testing.T) {
+	first, second, third := sampleAlert(), sampleAlert(), sampleAlert()
+	second.Severity = aws.String("LOW")
+	third.PolicyID = aws.String("other-rule-id")
+	third.PolicyName = nil
+	items := []*digestItem{{Alert: first}, {Alert: second}, {Alert: third}}
+
+	digest := generateDigest(items)
+	assert.Equal(t, alertmodels.DigestType, *digest.Type)
+	assert.Equal(t, DigestPolicyID, *digest.PolicyID)
+	assert.Equal(t, "LOW", *digest.Severity)
+	assert.Contains(t, *digest.Title, "3 alerts from 2 rules since ")
+	assert.Equal(t,
+		"test_rule_name (LOW): 2 alert(s) - "+outputs.GenerateURL(second)+"\n"+
+			"other-rule-id (INFO): 1 alert(s) - "+outputs.GenerateURL(third),
+		*digest.PolicyDescription)
+}
+
+func TestHoldUnderThreshold(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	alert := sampleAlert()
+	dynamoMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	mockHeldAlerts(dynamoMock, alert)
+
+	ch := make(chan outputStatus, 1)
+	hold(alert, digestOutput, ch)
+	assert.Equal(t, outputStatus{outputID: "output-id", success: true}, <-ch)
+	dynamoMock.AssertExpectations(t)
+	mockClient.AssertExpectations(t) // nothing was sent
+}
+
+func TestHoldSendsDigestAtMaxAlerts(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	alert := sampleAlert()
+	dynamoMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	mockHeldAlerts(dynamoMock, sampleAlert(), alert)
+	mockClient.On("Slack", mock.MatchedBy(func(digest *alertmodels.Alert) bool {
+		return *digest.Type == alertmodels.DigestType
+	}), mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+	dynamoMock.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil).Twice()
+
+	ch := make(chan outputStatus, 1)
+	hold(alert, digestOutput, ch)
+	assert.Equal(t, outputStatus{outputID: "output-id", success: true}, <-ch)
+	dynamoMock.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHoldPutItemError(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	dynamoMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, errors.New("throttled"))
+
+	ch := make(chan outputStatus, 1)
+	hold(sampleAlert(), digestOutput, ch)
+	assert.Equal(t, outputStatus{
+		outputID: "output-id", needsRetry: true, message: "digest hold error: throttled"}, <-ch)
+	dynamoMock.AssertExpectations(t)
+}
+
+func TestFlushDigestWindowElapsed(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	alert := sampleAlert()
+	alert.CreatedAt = aws.Time(time.Now().Add(-2 * time.Hour))
+	mockHeldAlerts(dynamoMock, alert)
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+	dynamoMock.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil).Once()
+
+	flushDigest(digestOutput)
+	dynamoMock.AssertExpectations(t)
+	dynamoMock.AssertNotCalled(t, "BatchWriteItem", mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestFlushDigestSkipsClaimedAlerts(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	alert := sampleAlert()
+	alert.CreatedAt = aws.Time(time.Now().Add(-2 * time.Hour))
+	mockHeldAlerts(dynamoMock, alert)
+	// Another invocation already claimed the alert
+	dynamoMock.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "claimed", nil)).Once()
+
+	flushDigest(digestOutput)
+	dynamoMock.AssertExpectations(t)
+	mockClient.AssertExpectations(t) // nothing was sent
+}
+
+func TestFlushDigestKeepsAlertsOnTransientFailure(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	alert := sampleAlert()
+	alert.CreatedAt = aws.Time(time.Now().Add(-2 * time.Hour))
+	mockHeldAlerts(dynamoMock, alert)
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
+	dynamoMock.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil).Once()
+	// The claimed alert is held again
+	dynamoMock.On("BatchWriteItem", mock.MatchedBy(func(input *dynamodb.BatchWriteItemInput) bool {
+		requests := input.RequestItems[digestTable]
+		return len(requests) == 1 && requests[0].PutRequest != nil
+	})).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+
+	flushDigest(digestOutput)
+	dynamoMock.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestFlushDigests(t *testing.T) {
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	cache = &outputsCache{
+		Outputs:   []*outputmodels.AlertOutput{alertOutput, digestOutput},
+		Timestamp: time.Now(),
+	}
+	// Only the output with a digest is queried
+	mockHeldAlerts(dynamoMock)
+
+	require.NoError(t, FlushDigests())
+	dynamoMock.AssertNumberOfCalls(t, "QueryPages", 1)
+}
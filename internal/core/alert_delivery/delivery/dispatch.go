@@ -19,6 +19,8 @@ package delivery
  */
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"go.uber.org/zap"
 
@@ -27,11 +29,19 @@ import (
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
 )
 
+// Output types which track incidents, and so receive acknowledge and resolve actions
+var incidentOutputTypes = map[string]bool{
+	"pagerduty": true,
+	"opsgenie":  true,
+}
+
 // outputStatus communicates parallelized alert delivery status via channels.
 type outputStatus struct {
 	outputID   string
 	success    bool
 	needsRetry bool
+	retryAfter time.Duration // delay requested by the output before the next attempt
+	message    string        // description of the delivery failure
 }
 
 // Send an alert to one specific output (run as a child goroutine).
@@ -47,7 +57,8 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		// Otherwise, the main routine will wait forever for this to finish.
 		if r := recover(); r != nil {
 			zap.L().Error("panic sending alert", append(commonFields, zap.Any("panic", r))...)
-			statusChannel <- outputStatus{outputID: *output.OutputID, success: false, needsRetry: false}
+			statusChannel <- outputStatus{
+				outputID: *output.OutputID, success: false, needsRetry: false, message: "panic sending alert"}
 		}
 	}()
 
@@ -57,17 +68,18 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 	)
 
 	var alertDeliveryError *outputs.AlertDeliveryError
+	var ticket *outputs.Ticket
 	switch *output.OutputType {
 	case "slack":
 		alertDeliveryError = outputClient.Slack(alert, output.OutputConfig.Slack)
 	case "pagerduty":
 		alertDeliveryError = outputClient.PagerDuty(alert, output.OutputConfig.PagerDuty)
 	case "github":
-		alertDeliveryError = outputClient.Github(alert, output.OutputConfig.Github)
+		ticket, alertDeliveryError = outputClient.Github(alert, output.OutputConfig.Github)
 	case "opsgenie":
 		alertDeliveryError = outputClient.Opsgenie(alert, output.OutputConfig.Opsgenie)
 	case "jira":
-		alertDeliveryError = outputClient.Jira(alert, output.OutputConfig.Jira)
+		ticket, alertDeliveryError = outputClient.Jira(alert, output.OutputConfig.Jira)
 	case "msteams":
 		alertDeliveryError = outputClient.MsTeams(alert, output.OutputConfig.MsTeams)
 	case "sqs":
@@ -78,26 +90,42 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		alertDeliveryError = outputClient.Asana(alert, output.OutputConfig.Asana)
 	case "customwebhook":
 		alertDeliveryError = outputClient.CustomWebhook(alert, output.OutputConfig.CustomWebhook)
+	case "email":
+		alertDeliveryError = outputClient.Email(alert, output.OutputConfig.Email)
+	case "syslog":
+		alertDeliveryError = outputClient.Syslog(alert, output.OutputConfig.Syslog)
+	case "splunk":
+		alertDeliveryError = outputClient.Splunk(alert, output.OutputConfig.Splunk)
 	default:
 		zap.L().Warn("unsupported output type", commonFields...)
-		statusChannel <- outputStatus{outputID: *output.OutputID, success: false, needsRetry: false}
+		statusChannel <- outputStatus{
+			outputID: *output.OutputID, success: false, needsRetry: false, message: "unsupported output type"}
 		return
 	}
 	if alertDeliveryError != nil {
 		zap.L().Warn("failed to send alert", append(commonFields, zap.Error(alertDeliveryError))...)
 		statusChannel <- outputStatus{
-			outputID: *output.OutputID, success: false, needsRetry: !alertDeliveryError.Permanent}
+			outputID:   *output.OutputID,
+			success:    false,
+			needsRetry: !alertDeliveryError.Permanent,
+			retryAfter: alertDeliveryError.RetryAfter,
+			message:    alertDeliveryError.Message,
+		}
 		return
 	}
 
 	zap.L().Info("alert success", commonFields...)
+	if ticket != nil {
+		recordTicket(alert, *output.OutputID, ticket)
+	}
 	statusChannel <- outputStatus{outputID: *output.OutputID, success: true, needsRetry: false}
 }
 
 // Dispatch sends the alert to each of its designated outputs.
 //
-// Returns true if the alert was sent successfully, false if it needs to be retried.
-func dispatch(alert *alertmodels.Alert) bool {
+// Returns the status of every output which failed, both permanently and temporarily.
+// An error means the outputs could not be determined and the whole alert needs to be retried.
+func dispatch(alert *alertmodels.Alert) ([]outputStatus, error) {
 	outputs, err := getAlertOutputs(alert)
 
 	if err != nil {
@@ -106,7 +134,12 @@ func dispatch(alert *alertmodels.Alert) bool {
 			zap.String("severity", *alert.Severity),
 			zap.Error(err),
 		)
-		return false
+		return nil, err
+	}
+
+	// Only outputs which track incidents have anything to acknowledge or resolve
+	if alert.IsLifecycleUpdate() {
+		outputs = incidentOutputs(outputs)
 	}
 
 	if len(outputs) == 0 {
@@ -114,34 +147,39 @@ func dispatch(alert *alertmodels.Alert) bool {
 			zap.String("policyId", *alert.PolicyID),
 			zap.String("severity", *alert.Severity),
 		)
-		return true
+		return nil, nil
 	}
 
 	// Dispatch all outputs in parallel.
 	// This ensures one slow or failing output won't block the others.
+	// Outputs with a digest for this severity hold the alert instead of sending it.
 	statusChannel := make(chan outputStatus)
 	for _, output := range outputs {
-		go send(alert, output, statusChannel)
+		if isDigested(alert, output) {
+			go hold(alert, output, statusChannel)
+		} else {
+			go send(alert, output, statusChannel)
+		}
 	}
 
-	// Wait until all outputs have finished, gathering any that need to be retried.
-	var retryOutputs []*string
+	// Wait until all outputs have finished, gathering any that failed.
+	var failed []outputStatus
 	for range outputs {
 		status := <-statusChannel
-		if status.needsRetry {
-			retryOutputs = append(retryOutputs, aws.String(status.outputID))
-		} else if !status.success {
-			zap.L().Error(
-				"permanently failed to send alert to output",
-				zap.String("outputID", status.outputID),
-			)
+		if !status.success {
+			failed = append(failed, status)
 		}
 	}
+	return failed, nil
+}
 
-	if len(retryOutputs) > 0 {
-		alert.OutputIDs = retryOutputs // Replace the outputs with the set that failed
-		return false
+// incidentOutputs filters the outputs which track incidents.
+func incidentOutputs(outputs []*outputmodels.AlertOutput) []*outputmodels.AlertOutput {
+	result := make([]*outputmodels.AlertOutput, 0, len(outputs))
+	for _, output := range outputs {
+		if incidentOutputTypes[aws.StringValue(output.OutputType)] {
+			result = append(result, output)
+		}
 	}
-
-	return true
+	return result
 }
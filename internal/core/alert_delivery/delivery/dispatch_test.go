@@ -69,7 +69,7 @@ func TestSendPanic(t *testing.T) {
 		panic("panicking")
 	})
 	go send(sampleAlert(), alertOutput, ch)
-	require.Equal(t, outputStatus{outputID: *alertOutput.OutputID}, <-ch)
+	require.Equal(t, outputStatus{outputID: *alertOutput.OutputID, message: "panic sending alert"}, <-ch)
 	mockOutputsClient.AssertExpectations(t)
 }
 
@@ -79,8 +79,10 @@ func TestSendUnsupportedOutput(t *testing.T) {
 	setCaches()
 	ch := make(chan outputStatus, 1)
 
-	send(sampleAlert(), alertOutput, ch)
-	assert.Equal(t, outputStatus{outputID: *alertOutput.OutputID}, <-ch)
+	unsupportedOutput := *alertOutput
+	unsupportedOutput.OutputType = aws.String("carrier-pigeon")
+	send(sampleAlert(), &unsupportedOutput, ch)
+	assert.Equal(t, outputStatus{outputID: *alertOutput.OutputID, message: "unsupported output type"}, <-ch)
 	mockClient.AssertExpectations(t)
 }
 
@@ -89,10 +91,12 @@ func TestSendTransientFailure(t *testing.T) {
 	outputClient = mockClient
 	setCaches()
 	ch := make(chan outputStatus, 1)
-	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(
+		&outputs.AlertDeliveryError{Message: "rate limited", RetryAfter: time.Minute})
 
 	send(sampleAlert(), alertOutput, ch)
-	assert.Equal(t, outputStatus{outputID: *alertOutput.OutputID, needsRetry: true}, <-ch)
+	assert.Equal(t, outputStatus{
+		outputID: *alertOutput.OutputID, needsRetry: true, retryAfter: time.Minute, message: "rate limited"}, <-ch)
 	mockClient.AssertExpectations(t)
 }
 
@@ -114,7 +118,9 @@ func TestDispatchFailure(t *testing.T) {
 	setCaches()
 	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
 
-	assert.False(t, dispatch(sampleAlert()))
+	failed, err := dispatch(sampleAlert())
+	require.NoError(t, err)
+	assert.Equal(t, []outputStatus{{outputID: "output-id", needsRetry: true}}, failed)
 	mockClient.AssertExpectations(t)
 }
 
@@ -123,7 +129,9 @@ func TestDispatchSuccess(t *testing.T) {
 	outputClient = mockClient
 	setCaches()
 	mockClient.On("Slack", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
-	assert.True(t, dispatch(sampleAlert()))
+	failed, err := dispatch(sampleAlert())
+	require.NoError(t, err)
+	assert.Empty(t, failed)
 }
 
 func TestDispatchUseCachedDefault(t *testing.T) {
@@ -134,7 +142,9 @@ func TestDispatchUseCachedDefault(t *testing.T) {
 	alert := sampleAlert()
 	alert.OutputIDs = nil //Setting OutputIds in the alert to nil, in order to fetch default outputs
 
-	assert.True(t, dispatch(alert))
+	failed, err := dispatch(alert)
+	require.NoError(t, err)
+	assert.Empty(t, failed)
 	mockLambdaClient.AssertExpectations(t)
 }
 
@@ -159,7 +169,8 @@ func TestDispatchUseNonCachedDefault(t *testing.T) {
 	alert := sampleAlert()
 	alert.OutputIDs = nil //Setting OutputIds in the alert to nil, in order to fetch default outputs
 	cache = nil           // Setting cache to nil, so we fetch latest outputs IDs from Lambda
-	assert.True(t, dispatch(alert))
+	_, err = dispatch(alert)
+	assert.NoError(t, err)
 	mockLambdaClient.AssertExpectations(t)
 }
 
@@ -189,6 +200,52 @@ func TestAllGoRoutinesShouldComplete(t *testing.T) {
 	alert.OutputIDs = nil //Setting OutputIds in the alert to nil, in order to fetch default outputs
 	cache = nil           // Clearing the default output ids cache
 
-	assert.True(t, dispatch(alert))
+	_, err = dispatch(alert)
+	assert.NoError(t, err)
 	mockLambdaClient.AssertExpectations(t)
 }
+
+func TestDispatchResolveOnlyIncidentOutputs(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	pagerDutyOutput := &outputmodels.AlertOutput{
+		OutputType:   aws.String("pagerduty"),
+		DisplayName:  aws.String("pagerduty:oncall"),
+		OutputConfig: &outputmodels.OutputConfig{PagerDuty: &outputmodels.PagerDutyConfig{IntegrationKey: "key"}},
+		OutputID:     aws.String("pagerduty-id"),
+		// Resolving an incident is never held for a digest
+		Digest: &outputmodels.DigestConfig{Severities: aws.StringSlice([]string{"INFO"})},
+	}
+	cache = &outputsCache{
+		Outputs:   []*outputmodels.AlertOutput{alertOutput, pagerDutyOutput},
+		Timestamp: time.Now(),
+	}
+	mockClient.On("PagerDuty", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	alert.Action = aws.String(alertmodels.ResolveAction)
+	alert.OutputIDs = aws.StringSlice([]string{"output-id", "pagerduty-id"})
+
+	failed, err := dispatch(alert)
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+	// Slack has no incident to resolve
+	mockClient.AssertNotCalled(t, "Slack", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDispatchResolveNoIncidentOutputs(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	setCaches()
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	alert.Action = aws.String(alertmodels.AcknowledgeAction)
+
+	failed, err := dispatch(alert)
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+	mockClient.AssertExpectations(t)
+}
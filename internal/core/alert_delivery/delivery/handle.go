@@ -41,33 +41,79 @@ func getMaxRetryDuration() time.Duration {
 	return time.Duration(mustParseInt(os.Getenv("ALERT_RETRY_DURATION_MINS"))) * time.Minute
 }
 
-// HandleAlerts sends each alert to its outputs and puts failed alerts back on the queue to retry.
-func HandleAlerts(alerts []*models.Alert) {
-	var failedAlerts []*models.Alert
+// failedDeliveries gathers the alerts to retry and to dead-letter after a batch is dispatched.
+type failedDeliveries struct {
+	retries     []*delayedAlert
+	deadLetters []*models.Alert
+}
+
+// add schedules a retry for a failed delivery, or dead-letters it if it can't be retried.
+func (f *failedDeliveries) add(alert *models.Alert, status outputStatus, now time.Time) {
+	if alert.RetryStartedAt == nil {
+		alert.RetryStartedAt = &now
+	}
+	commonFields := []zap.Field{
+		zap.Strings("outputIds", aws.StringValueSlice(alert.OutputIDs)),
+		zap.String("policyId", *alert.PolicyID),
+		zap.String("severity", *alert.Severity),
+		zap.String("error", status.message),
+	}
+
+	if status.needsRetry && now.Sub(*alert.RetryStartedAt) <= getMaxRetryDuration() {
+		alert.DeliveryAttempts++
+		delay := retryDelay(alert.DeliveryAttempts, status.retryAfter)
+		zap.L().Warn("will retry delivery of alert", append(commonFields,
+			zap.Int("attempt", alert.DeliveryAttempts),
+			zap.Duration("delay", delay),
+		)...)
+		f.retries = append(f.retries, &delayedAlert{alert: alert, delay: delay})
+		return
+	}
 
+	if status.needsRetry {
+		zap.L().Error("alert delivery permanently failed, exceeded max retry duration", append(commonFields,
+			zap.Time("retryStartedAt", *alert.RetryStartedAt),
+		)...)
+	} else {
+		zap.L().Error("alert delivery permanently failed", commonFields...)
+	}
+	// Reset the retry state so a redriven alert gets a fresh set of retries
+	alert.DeliveryAttempts = 0
+	alert.RetryStartedAt = nil
+	alert.DeliveryError = aws.String(status.message)
+	f.deadLetters = append(f.deadLetters, alert)
+}
+
+// HandleAlerts sends each alert to its outputs.
+//
+// Each output which failed is retried on its own with exponential backoff, so the outputs which already
+// received the alert don't get duplicates. Outputs which failed permanently or ran out of retries
+// are sent to the dead-letter queue.
+func HandleAlerts(alerts []*models.Alert) {
+	var failures failedDeliveries
 	zap.L().Info("starting processing alerts", zap.Int("alerts", len(alerts)))
 
 	for _, alert := range alerts {
-		if !dispatch(alert) {
-			if time.Since(*alert.CreatedAt) > getMaxRetryDuration() {
-				zap.L().Error(
-					"alert delivery permanently failed, exceeded max retry duration",
-					zap.Strings("failedOutputs", aws.StringValueSlice(alert.OutputIDs)),
-					zap.Time("alertCreatedAt", *alert.CreatedAt),
-					zap.String("policyId", *alert.PolicyID),
-					zap.String("severity", *alert.Severity),
-				)
-			} else {
-				zap.L().Warn("will retry delivery of alert",
-					zap.String("policyId", *alert.PolicyID),
-					zap.String("severity", *alert.Severity),
-				)
-				failedAlerts = append(failedAlerts, alert)
-			}
+		failed, err := dispatch(alert)
+		now := time.Now()
+		if err != nil {
+			// The outputs are unknown, so the whole alert is retried
+			alertCopy := *alert
+			failures.add(&alertCopy, outputStatus{needsRetry: true, message: err.Error()}, now)
+			continue
+		}
+
+		for _, status := range failed {
+			outputAlert := *alert
+			outputAlert.OutputIDs = []*string{aws.String(status.outputID)}
+			failures.add(&outputAlert, status, now)
 		}
 	}
 
-	if len(failedAlerts) > 0 {
-		retry(failedAlerts)
+	if len(failures.retries) > 0 {
+		retry(failures.retries)
+	}
+	if len(failures.deadLetters) > 0 {
+		deadLetter(failures.deadLetters)
 	}
 }
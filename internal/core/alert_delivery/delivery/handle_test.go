@@ -23,9 +23,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
 )
@@ -35,24 +39,51 @@ func TestMustParseIntPanic(t *testing.T) {
 }
 
 func TestHandleAlertsPermanentlyFailed(t *testing.T) {
-	createdAtTime, _ := time.Parse(time.RFC3339, "2019-05-03T11:40:13Z")
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{Message: "invalid webhook", Permanent: true})
+	sqsClient = &mockSQSClient{}
+	setCaches()
+	os.Setenv("ALERT_RETRY_DURATION_MINS", "5")
+	os.Setenv("ALERT_QUEUE_URL", "sqs.url")
+	os.Setenv("ALERT_DLQ_URL", "dlq.url")
+	alert := sampleAlert()
+	alerts := []*models.Alert{alert, alert, alert}
+	resetSQSMessages()
+
+	HandleAlerts(alerts)
+	assert.Empty(t, sqsQueueMessages["sqs.url"])
+	require.Len(t, sqsQueueMessages["dlq.url"], 3)
+
+	var deadLetter models.Alert
+	require.NoError(t, jsoniter.UnmarshalFromString(*sqsQueueMessages["dlq.url"][0].MessageBody, &deadLetter))
+	assert.Equal(t, aws.String("invalid webhook"), deadLetter.DeliveryError)
+	assert.Nil(t, deadLetter.RetryStartedAt)
+	assert.Equal(t, int64(0), *sqsQueueMessages["dlq.url"][0].DelaySeconds)
+}
+
+func TestHandleAlertsRetryDurationExceeded(t *testing.T) {
+	retryStartedAt, _ := time.Parse(time.RFC3339, "2019-05-03T11:40:13Z")
 	mockClient := &mockOutputsClient{}
 	outputClient = mockClient
 	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
 	sqsClient = &mockSQSClient{}
 	setCaches()
 	os.Setenv("ALERT_RETRY_DURATION_MINS", "5")
+	os.Setenv("ALERT_QUEUE_URL", "sqs.url")
+	os.Setenv("ALERT_DLQ_URL", "dlq.url")
 	alert := sampleAlert()
-	alert.CreatedAt = &createdAtTime
+	alert.RetryStartedAt = &retryStartedAt
+	alert.DeliveryAttempts = 8
 	alerts := []*models.Alert{alert, alert, alert}
-	sqsMessages = 0
+	resetSQSMessages()
 
 	HandleAlerts(alerts)
-	assert.Equal(t, 0, sqsMessages)
+	assert.Empty(t, sqsQueueMessages["sqs.url"])
+	assert.Len(t, sqsQueueMessages["dlq.url"], 3)
 }
 
 func TestHandleAlertsTemporarilyFailed(t *testing.T) {
-	createdAtTime := time.Now()
 	mockClient := &mockOutputsClient{}
 	outputClient = mockClient
 	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
@@ -63,10 +94,48 @@ func TestHandleAlertsTemporarilyFailed(t *testing.T) {
 	os.Setenv("MIN_RETRY_DELAY_SECS", "10")
 	os.Setenv("MAX_RETRY_DELAY_SECS", "30")
 	alert := sampleAlert()
-	alert.CreatedAt = &createdAtTime
 	alerts := []*models.Alert{alert, alert, alert}
-	sqsMessages = 0
+	resetSQSMessages()
 
 	HandleAlerts(alerts)
 	assert.Equal(t, 3, sqsMessages)
+	require.Len(t, sqsQueueMessages["sqs.url"], 3)
+
+	var retried models.Alert
+	require.NoError(t, jsoniter.UnmarshalFromString(*sqsQueueMessages["sqs.url"][0].MessageBody, &retried))
+	assert.Equal(t, 1, retried.DeliveryAttempts)
+	assert.NotNil(t, retried.RetryStartedAt)
+	assert.Equal(t, int64(10), *sqsQueueMessages["sqs.url"][0].DelaySeconds)
+}
+
+func TestHandleAlertsRetriesOnlyFailedOutputs(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+	mockClient.On("PagerDuty", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{RetryAfter: time.Minute})
+	sqsClient = &mockSQSClient{}
+	setCaches()
+	cache.Outputs = append(cache.Outputs, &outputmodels.AlertOutput{
+		OutputType:   aws.String("pagerduty"),
+		DisplayName:  aws.String("pagerduty:oncall"),
+		OutputConfig: &outputmodels.OutputConfig{PagerDuty: &outputmodels.PagerDutyConfig{IntegrationKey: "key"}},
+		OutputID:     aws.String("output-id-2"),
+	})
+	os.Setenv("ALERT_RETRY_DURATION_MINS", "5")
+	os.Setenv("ALERT_QUEUE_URL", "sqs.url")
+	os.Setenv("MIN_RETRY_DELAY_SECS", "10")
+	os.Setenv("MAX_RETRY_DELAY_SECS", "30")
+	alert := sampleAlert()
+	alert.OutputIDs = aws.StringSlice([]string{"output-id", "output-id-2"})
+	resetSQSMessages()
+
+	HandleAlerts([]*models.Alert{alert})
+	require.Len(t, sqsQueueMessages["sqs.url"], 1)
+
+	var retried models.Alert
+	require.NoError(t, jsoniter.UnmarshalFromString(*sqsQueueMessages["sqs.url"][0].MessageBody, &retried))
+	assert.Equal(t, aws.StringSlice([]string{"output-id-2"}), retried.OutputIDs)
+	// Retry-After from the output is longer than the backoff
+	assert.Equal(t, int64(60), *sqsQueueMessages["sqs.url"][0].DelaySeconds)
+	mockClient.AssertExpectations(t)
 }
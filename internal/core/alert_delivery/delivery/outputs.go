@@ -49,8 +49,8 @@ var (
 	refreshInterval = getRefreshInterval()
 )
 
-// Get output ids for an alert
-func getAlertOutputs(alert *alertmodels.Alert) ([]*outputmodels.AlertOutput, error) {
+// Get all outputs, refreshing the cache if needed
+func getOutputs() ([]*outputmodels.AlertOutput, error) {
 	if cache == nil || time.Since(cache.Timestamp) > refreshInterval {
 		zap.L().Debug("getting cached default outputs")
 		input := outputmodels.LambdaInput{GetOutputsWithSecrets: &outputmodels.GetOutputsWithSecretsInput{}}
@@ -63,6 +63,14 @@ func getAlertOutputs(alert *alertmodels.Alert) ([]*outputmodels.AlertOutput, err
 			Timestamp: time.Now().UTC(),
 		}
 	}
+	return cache.Outputs, nil
+}
+
+// Get output ids for an alert
+func getAlertOutputs(alert *alertmodels.Alert) ([]*outputmodels.AlertOutput, error) {
+	if _, err := getOutputs(); err != nil {
+		return nil, err
+	}
 
 	// If alert doesn't have outputs IDs specified, return the defaults for the severity
 	if len(alert.OutputIDs) == 0 {
@@ -33,39 +33,95 @@ import (
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 )
 
-const maxSQSBackoff = 30 * time.Second
+const (
+	maxSQSBackoff = 30 * time.Second
 
-// Generate a random int between lower (inclusive) and upper (exclusive).
-func randomInt(lower, upper int) int {
-	return rand.Intn(upper-lower) + lower
+	// maxSQSDelay is the longest delay SQS allows for a message
+	maxSQSDelay = 15 * time.Minute
+)
+
+func init() {
+	rand.Seed(time.Now().UnixNano())
+}
+
+// delayedAlert is an alert to be put back on the queue after the given delay.
+type delayedAlert struct {
+	alert *models.Alert
+	delay time.Duration
+}
+
+// retryDelay returns how long to wait before the given delivery attempt (starting at 1).
+//
+// The backoff starts at MIN_RETRY_DELAY_SECS and doubles with each attempt up to MAX_RETRY_DELAY_SECS.
+// Half of the backoff is random jitter so failed alerts don't all retry at the same time.
+// A longer Retry-After requested by the output always takes precedence.
+func retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	minDelay := time.Duration(mustParseInt(os.Getenv("MIN_RETRY_DELAY_SECS"))) * time.Second
+	maxDelay := time.Duration(mustParseInt(os.Getenv("MAX_RETRY_DELAY_SECS"))) * time.Second
+
+	backoff := minDelay
+	for i := 1; i < attempt && backoff < maxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > maxDelay {
+		backoff = maxDelay
+	}
+
+	delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	if delay < minDelay {
+		delay = minDelay
+	}
+	if delay < retryAfter {
+		delay = retryAfter
+	}
+	if delay > maxSQSDelay {
+		delay = maxSQSDelay
+	}
+	return delay
 }
 
-// retry a batch of failed outputs by putting them all back on the queue with random delays.
-func retry(alerts []*models.Alert) {
+// retry a batch of failed outputs by putting them back on the queue with their backoff delays.
+func retry(alerts []*delayedAlert) {
 	zap.L().Warn("queueing failed alerts for future retry", zap.Int("failedAlerts", len(alerts)))
+	if err := sendAlerts(os.Getenv("ALERT_QUEUE_URL"), alerts); err != nil {
+		zap.L().Error("unable to retry failed alerts", zap.Error(err))
+	}
+}
+
+// deadLetter sends alerts which can't be delivered to the dead-letter queue.
+//
+// They can be inspected and redriven to the alert queue with the requeue tool.
+func deadLetter(alerts []*models.Alert) {
+	zap.L().Error("sending undeliverable alerts to the dead-letter queue", zap.Int("failedAlerts", len(alerts)))
+	entries := make([]*delayedAlert, len(alerts))
+	for i, alert := range alerts {
+		entries[i] = &delayedAlert{alert: alert}
+	}
+	if err := sendAlerts(os.Getenv("ALERT_DLQ_URL"), entries); err != nil {
+		zap.L().Error("unable to dead-letter failed alerts", zap.Error(err))
+	}
+}
+
+// sendAlerts puts a batch of alerts on an SQS queue.
+func sendAlerts(queueURL string, alerts []*delayedAlert) error {
 	input := &sqs.SendMessageBatchInput{
 		Entries:  make([]*sqs.SendMessageBatchRequestEntry, len(alerts)),
-		QueueUrl: aws.String(os.Getenv("ALERT_QUEUE_URL")),
+		QueueUrl: aws.String(queueURL),
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	minDelaySeconds := mustParseInt(os.Getenv("MIN_RETRY_DELAY_SECS"))
-	maxDelaySeconds := mustParseInt(os.Getenv("MAX_RETRY_DELAY_SECS"))
-
-	for i, alert := range alerts {
-		body, err := jsoniter.MarshalToString(alert)
+	for i, entry := range alerts {
+		body, err := jsoniter.MarshalToString(entry.alert)
 		if err != nil {
 			zap.L().Panic("error encoding alert as JSON", zap.Error(err))
 		}
 
 		input.Entries[i] = &sqs.SendMessageBatchRequestEntry{
-			DelaySeconds: aws.Int64(int64(randomInt(minDelaySeconds, maxDelaySeconds))),
+			DelaySeconds: aws.Int64(int64(entry.delay / time.Second)),
 			Id:           aws.String(strconv.Itoa(i)),
 			MessageBody:  aws.String(body),
 		}
 	}
 
-	if _, err := sqsbatch.SendMessageBatch(getSQSClient(), maxSQSBackoff, input); err != nil {
-		zap.L().Error("unable to retry failed alerts", zap.Error(err))
-	}
+	_, err := sqsbatch.SendMessageBatch(getSQSClient(), maxSQSBackoff, input)
+	return err
 }
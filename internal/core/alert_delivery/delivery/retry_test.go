@@ -20,9 +20,15 @@ package delivery
 
 import (
 	"errors"
+	"os"
+	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockSQSClient struct {
@@ -32,12 +38,52 @@ type mockSQSClient struct {
 
 var sqsMessages int // store number of messages here for tests to verify
 
+// sent messages are also stored by queue URL
+var sqsQueueMessages = make(map[string][]*sqs.SendMessageBatchRequestEntry)
+
 func (m mockSQSClient) SendMessageBatch(input *sqs.SendMessageBatchInput) (*sqs.SendMessageBatchOutput, error) {
 	if m.err {
 		return nil, errors.New("internal service error")
 	}
 	sqsMessages = len(input.Entries)
+	sqsQueueMessages[*input.QueueUrl] = append(sqsQueueMessages[*input.QueueUrl], input.Entries...)
 	return &sqs.SendMessageBatchOutput{
 		Successful: make([]*sqs.SendMessageBatchResultEntry, len(input.Entries)),
 	}, nil
 }
+
+func resetSQSMessages() {
+	sqsMessages = 0
+	sqsQueueMessages = make(map[string][]*sqs.SendMessageBatchRequestEntry)
+}
+
+func TestRetryDelay(t *testing.T) {
+	os.Setenv("MIN_RETRY_DELAY_SECS", "10")
+	os.Setenv("MAX_RETRY_DELAY_SECS", "80")
+
+	assert.Equal(t, 10*time.Second, retryDelay(1, 0))
+	for i := 0; i < 100; i++ {
+		delay := retryDelay(3, 0)
+		assert.True(t, delay >= 20*time.Second && delay <= 40*time.Second, delay)
+		delay = retryDelay(50, 0)
+		assert.True(t, delay >= 40*time.Second && delay <= 80*time.Second, delay)
+	}
+}
+
+func TestRetryDelayRetryAfter(t *testing.T) {
+	os.Setenv("MIN_RETRY_DELAY_SECS", "10")
+	os.Setenv("MAX_RETRY_DELAY_SECS", "80")
+
+	assert.Equal(t, 5*time.Minute, retryDelay(1, 5*time.Minute))
+	assert.Equal(t, maxSQSDelay, retryDelay(1, time.Hour))
+}
+
+func TestRetry(t *testing.T) {
+	sqsClient = &mockSQSClient{}
+	os.Setenv("ALERT_QUEUE_URL", "sqs.url")
+	resetSQSMessages()
+
+	retry([]*delayedAlert{{alert: sampleAlert(), delay: 90 * time.Second}})
+	require.Len(t, sqsQueueMessages["sqs.url"], 1)
+	assert.Equal(t, aws.Int64(90), sqsQueueMessages["sqs.url"][0].DelaySeconds)
+}
@@ -0,0 +1,62 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+var (
+	alertsTableName  = os.Getenv("ALERTS_TABLE_NAME")
+	ticketsTableName = os.Getenv("TICKETS_TABLE_NAME")
+)
+
+// recordTicket stores the issue created for an alert, so the ticket webhook can sync changes back to the alert.
+//
+// The alert was already delivered: failures are logged but never retried, which would create the issue twice.
+func recordTicket(alert *alertmodels.Alert, outputID string, ticket *outputs.Ticket) {
+	if alert.AlertID == nil || aws.StringValue(alert.Type) == alertmodels.DigestType {
+		return
+	}
+
+	alertsTable := &table.AlertsTable{
+		AlertsTableName:  alertsTableName,
+		TicketsTableName: ticketsTableName,
+		Client:           getDynamoClient(),
+	}
+	err := alertsTable.AddTicket(*alert.AlertID, &table.AlertTicket{
+		OutputID:  outputID,
+		System:    ticket.System,
+		Key:       ticket.Key,
+		URL:       ticket.URL,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		zap.L().Error("failed to record ticket",
+			zap.String("alertId", *alert.AlertID), zap.String("ticket", ticket.Key), zap.Error(err))
+	}
+}
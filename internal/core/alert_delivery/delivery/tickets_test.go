@@ -0,0 +1,95 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var jiraOutput = &outputmodels.AlertOutput{
+	OutputType:   aws.String("jira"),
+	DisplayName:  aws.String("jira:alerts"),
+	OutputConfig: &outputmodels.OutputConfig{Jira: &outputmodels.JiraConfig{ProjectKey: "QR"}},
+	OutputID:     aws.String("jira-output-id"),
+}
+
+var jiraTicket = &outputs.Ticket{System: "jira", Key: "QR-12", URL: "https://panther.atlassian.net/browse/QR-12"}
+
+func TestSendRecordsTicket(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient.On("Jira", mock.Anything, mock.Anything).Return(jiraTicket, (*outputs.AlertDeliveryError)(nil))
+	dynamoMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+	dynamoMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alertId")
+	ch := make(chan outputStatus, 1)
+	send(alert, jiraOutput, ch)
+	assert.Equal(t, outputStatus{outputID: "jira-output-id", success: true}, <-ch)
+
+	put := dynamoMock.Calls[1].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, "jira:QR-12", *put.Item["ticketId"].S)
+	assert.Equal(t, "alertId", *put.Item["alertId"].S)
+	assert.Equal(t, "jira-output-id", *put.Item["outputId"].S)
+	mockClient.AssertExpectations(t)
+	dynamoMock.AssertExpectations(t)
+}
+
+func TestSendTicketNotRecorded(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient.On("Jira", mock.Anything, mock.Anything).Return(jiraTicket, (*outputs.AlertDeliveryError)(nil))
+	dynamoMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("throttled"))
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alertId")
+	ch := make(chan outputStatus, 1)
+	send(alert, jiraOutput, ch)
+	// The issue was created, so the alert is not retried
+	assert.Equal(t, outputStatus{outputID: "jira-output-id", success: true}, <-ch)
+	dynamoMock.AssertExpectations(t)
+}
+
+func TestSendTicketWithoutAlertID(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = dynamoMock
+	mockClient.On("Jira", mock.Anything, mock.Anything).Return(jiraTicket, (*outputs.AlertDeliveryError)(nil))
+
+	ch := make(chan outputStatus, 1)
+	send(sampleAlert(), jiraOutput, ch)
+	assert.Equal(t, outputStatus{outputID: "jira-output-id", success: true}, <-ch)
+	dynamoMock.AssertExpectations(t)
+}
@@ -37,7 +37,15 @@ import (
 
 var validate = validator.New()
 
-func lambdaHandler(ctx context.Context, event events.SQSEvent) (err error) {
+// deliveryEvent is either a batch of alerts from SQS or a scheduled event to flush digests.
+type deliveryEvent struct {
+	events.SQSEvent
+	DetailType string `json:"detail-type"`
+}
+
+const scheduledEventDetailType = "Scheduled Event"
+
+func lambdaHandler(ctx context.Context, event deliveryEvent) (err error) {
 	var alerts []*models.Alert
 
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
@@ -46,6 +54,10 @@ func lambdaHandler(ctx context.Context, event events.SQSEvent) (err error) {
 		operation.Stop().Log(err, zap.Int("numEvents", len(event.Records)), zap.Int("numAlerts", len(alerts)))
 	}()
 
+	if event.DetailType == scheduledEventDetailType {
+		return delivery.FlushDigests()
+	}
+
 	for _, record := range event.Records {
 		alert := &models.Alert{}
 		if err = jsoniter.UnmarshalFromString(record.Body, alert); err != nil {
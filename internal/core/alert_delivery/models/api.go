@@ -18,7 +18,11 @@ package models
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-import "time"
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
 
 // RuleType identifies the Alert to be for a Policy
 const RuleType = "RULE"
@@ -26,6 +30,16 @@ const RuleType = "RULE"
 // PolicyType identifies the Alert to be for a Policy
 const PolicyType = "POLICY"
 
+// DigestType identifies the Alert to be a summary of several held alerts
+const DigestType = "DIGEST"
+
+// Incident lifecycle actions, understood by outputs which track incidents (PagerDuty and Opsgenie).
+const (
+	TriggerAction     = "trigger"
+	AcknowledgeAction = "acknowledge"
+	ResolveAction     = "resolve"
+)
+
 // Alert is the schema for each row in the Dynamo alerts table.
 type Alert struct {
 
@@ -60,8 +74,32 @@ type Alert struct {
 	AlertID *string `json:"alertId,omitempty"`
 
 	// Type specifies if an alert is for a policy or a rule
-	Type *string `json:"type" validate:"oneof=RULE POLICY"`
+	Type *string `json:"type" validate:"oneof=RULE POLICY DIGEST"`
 
 	// Title is the optional title for the alert
 	Title *string `json:"title,omitempty"`
+
+	// ResourceID is the resource which failed the policy, for policy alerts.
+	ResourceID *string `json:"resourceId,omitempty"`
+
+	// SampleEvents are the first events matched by the rule, each a trimmed JSON object, for rule alerts.
+	SampleEvents []string `json:"sampleEvents,omitempty"`
+
+	// DeliveryAttempts is the number of times delivery to the OutputIDs has been retried.
+	DeliveryAttempts int `json:"deliveryAttempts,omitempty"`
+
+	// RetryStartedAt is when the first delivery attempt to the OutputIDs failed.
+	RetryStartedAt *time.Time `json:"retryStartedAt,omitempty"`
+
+	// Action is the incident lifecycle change for this alert. Alerts without an action trigger a new incident.
+	Action *string `json:"action,omitempty" validate:"omitempty,oneof=trigger acknowledge resolve"`
+
+	// DeliveryError is the last delivery failure, set when the alert is sent to the dead-letter queue.
+	DeliveryError *string `json:"deliveryError,omitempty"`
+}
+
+// IsLifecycleUpdate returns true if the alert acknowledges or resolves a previously triggered incident.
+func (alert *Alert) IsLifecycleUpdate() bool {
+	action := aws.StringValue(alert.Action)
+	return action == AcknowledgeAction || action == ResolveAction
 }
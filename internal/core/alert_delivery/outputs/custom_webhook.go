@@ -19,8 +19,11 @@ package outputs
  */
 
 import (
+	"encoding/base64"
 	"time"
 
+	jsoniter "github.com/json-iterator/go"
+
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
@@ -30,6 +33,44 @@ import (
 func (client *OutputClient) CustomWebhook(
 	alert *alertmodels.Alert, config *outputmodels.CustomWebhookConfig) *AlertDeliveryError {
 
+	outputMessage := generateCustomWebhookOutputMessage(alert)
+
+	requestURL := config.WebhookURL
+	postInput := &PostInput{
+		url:           requestURL,
+		body:          outputMessage,
+		headers:       generateCustomWebhookHeaders(config),
+		signingSecret: config.SigningSecret,
+	}
+	return client.httpWrapper.post(postInput)
+}
+
+// generateCustomWebhookHeaders returns the custom headers and Authorization header for a webhook.
+func generateCustomWebhookHeaders(config *outputmodels.CustomWebhookConfig) map[string]string {
+	if len(config.Headers) == 0 && config.AuthType == "" {
+		return nil
+	}
+
+	headers := make(map[string]string, len(config.Headers)+1)
+	for _, header := range config.Headers {
+		headers[header.Key] = header.Value
+	}
+
+	// Authentication takes precedence over a custom Authorization header
+	switch config.AuthType {
+	case outputmodels.CustomWebhookAuthBearer:
+		headers[AuthorizationHTTPHeader] = "Bearer " + config.AuthSecret
+	case outputmodels.CustomWebhookAuthBasic:
+		credentials := config.AuthUserName + ":" + config.AuthSecret
+		headers[AuthorizationHTTPHeader] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+	return headers
+}
+
+// generateCustomWebhookOutputMessage builds the generic JSON representation of an alert.
+//
+// This is also used as the event payload by outputs which forward alerts to a SIEM.
+func generateCustomWebhookOutputMessage(alert *alertmodels.Alert) *CustomWebhookOutputMessage {
 	// Get a link to the Panther Dashboard to one of the following:
 	//   1. The PolicyID (if no AlertID is present)
 	//   2. The AlertID
@@ -49,16 +90,16 @@ func (client *OutputClient) CustomWebhook(
 		Version:     alert.PolicyVersionID,
 		CreatedAt:   alert.CreatedAt,
 	}
+	for _, event := range alert.SampleEvents {
+		// Events are embedded as-is, skip any which would break the message
+		if jsoniter.Valid([]byte(event)) {
+			outputMessage.SampleEvents = append(outputMessage.SampleEvents, jsoniter.RawMessage(event))
+		}
+	}
 
 	// Ensure we have slices instead of `null` array fields
 	gatewayapi.ReplaceMapSliceNils(outputMessage)
-
-	requestURL := config.WebhookURL
-	postInput := &PostInput{
-		url:  requestURL,
-		body: outputMessage,
-	}
-	return client.httpWrapper.post(postInput)
+	return outputMessage
 }
 
 // CustomWebhookOutputMessage describes the details of an alert in a Custom Webhook message
@@ -102,4 +143,7 @@ type CustomWebhookOutputMessage struct {
 
 	// [REQUIRED] CreatedAt is the timestamp (RFC3339) of the alert at creation.
 	CreatedAt *time.Time `json:"createdAt" validate:"required"`
+
+	// SampleEvents are the first events which matched a rule, trimmed to their key fields
+	SampleEvents []jsoniter.RawMessage `json:"sampleEvents"`
 }
@@ -86,3 +86,50 @@ func TestCustomWebhookAlert(t *testing.T) {
 	require.Nil(t, client.CustomWebhook(alert, customWebhookConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestCustomWebhookAlertWithAuthentication(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputmodels.CustomWebhookConfig{
+		WebhookURL: "custom-webhook-url",
+		Headers: []*outputmodels.CustomWebhookHeader{
+			{Key: "X-Tenant", Value: "security"},
+			{Key: AuthorizationHTTPHeader, Value: "overwritten"},
+		},
+		AuthType:      outputmodels.CustomWebhookAuthBasic,
+		AuthUserName:  "panther",
+		AuthSecret:    "password",
+		SigningSecret: "signing-secret",
+	}
+
+	createdAtTime, _ := time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+	alert := &alertmodels.Alert{
+		PolicyID:  aws.String("policyId"),
+		CreatedAt: &createdAtTime,
+		Severity:  aws.String("INFO"),
+	}
+
+	expectedPostInput := &PostInput{
+		url:  config.WebhookURL,
+		body: generateCustomWebhookOutputMessage(alert),
+		headers: map[string]string{
+			"X-Tenant":              "security",
+			AuthorizationHTTPHeader: "Basic cGFudGhlcjpwYXNzd29yZA==",
+		},
+		signingSecret: "signing-secret",
+	}
+
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.CustomWebhook(alert, config))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestGenerateCustomWebhookHeadersBearer(t *testing.T) {
+	config := &outputmodels.CustomWebhookConfig{
+		AuthType:   outputmodels.CustomWebhookAuthBearer,
+		AuthSecret: "token",
+	}
+	require.Equal(t, map[string]string{AuthorizationHTTPHeader: "Bearer token"}, generateCustomWebhookHeaders(config))
+	require.Nil(t, generateCustomWebhookHeaders(&outputmodels.CustomWebhookConfig{}))
+}
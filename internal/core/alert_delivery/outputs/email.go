@@ -0,0 +1,265 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+const smtpTimeout = 30 * time.Second
+
+var emailHTMLTemplate = template.Must(template.New("email").Parse(`<html>
+<body>
+<h2>{{.Title}}</h2>
+<p>{{.Message}}</p>
+<table>
+<tr><td><b>Severity</b></td><td>{{.Severity}}</td></tr>
+{{if .Tags}}<tr><td><b>Tags</b></td><td>{{.Tags}}</td></tr>{{end}}
+{{if .Description}}<tr><td><b>Description</b></td><td>{{.Description}}</td></tr>{{end}}
+{{if .Runbook}}<tr><td><b>Runbook</b></td><td>{{.Runbook}}</td></tr>{{end}}
+</table>
+<p><a href="{{.Link}}">Click here to view in the Panther UI</a></p>
+</body>
+</html>
+`))
+
+// emailTemplateInput holds the alert fields rendered in the HTML body
+type emailTemplateInput struct {
+	Title       string
+	Message     string
+	Severity    string
+	Tags        string
+	Description string
+	Runbook     string
+	Link        string
+}
+
+// EmailInput is a single email to be sent through an SMTP server
+type EmailInput struct {
+	config  *outputmodels.EmailConfig
+	subject string
+	text    string
+	html    string
+}
+
+// Mailer is the interface for our SMTP client, which can be replaced with a stand-in for testing.
+type Mailer interface {
+	send(*EmailInput) *AlertDeliveryError
+}
+
+// SMTPMailer sends email using the SMTP server defined in the output configuration
+type SMTPMailer struct {
+	// tlsConfig overrides the default TLS settings (only used in tests)
+	tlsConfig *tls.Config
+}
+
+// Email sends an alert to a list of recipients through an SMTP server.
+func (client *OutputClient) Email(alert *alertmodels.Alert, config *outputmodels.EmailConfig) *AlertDeliveryError {
+	templateInput := &emailTemplateInput{
+		Title:       generateAlertTitle(alert),
+		Message:     generateAlertMessage(alert),
+		Severity:    aws.StringValue(alert.Severity),
+		Tags:        strings.Join(aws.StringValueSlice(alert.Tags), ", "),
+		Description: aws.StringValue(alert.PolicyDescription),
+		Runbook:     aws.StringValue(alert.Runbook),
+		Link:        generateURL(alert),
+	}
+
+	var htmlBody bytes.Buffer
+	if err := emailHTMLTemplate.Execute(&htmlBody, templateInput); err != nil {
+		return &AlertDeliveryError{Message: "email template error: " + err.Error(), Permanent: true}
+	}
+
+	emailInput := &EmailInput{
+		config:  config,
+		subject: generateAlertTitle(alert),
+		text:    generateDetailedAlertMessage(alert),
+		html:    htmlBody.String(),
+	}
+	return client.mailer.send(emailInput)
+}
+
+// send delivers a multipart (text and HTML) email through the configured SMTP server.
+func (m *SMTPMailer) send(input *EmailInput) *AlertDeliveryError {
+	config := input.config
+	message, err := buildEmailMessage(input)
+	if err != nil {
+		return &AlertDeliveryError{Message: "email encoding error: " + err.Error(), Permanent: true}
+	}
+
+	tlsConfig := m.tlsConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+	}
+
+	address := net.JoinHostPort(config.Host, strconv.Itoa(emailPort(config)))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	var conn net.Conn
+	if config.Security == outputmodels.EmailSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return &AlertDeliveryError{Message: "network error: " + err.Error()}
+	}
+	if err = conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		conn.Close()
+		return &AlertDeliveryError{Message: "network error: " + err.Error()}
+	}
+
+	smtpClient, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		conn.Close()
+		return smtpDeliveryError("connect", err)
+	}
+	defer smtpClient.Close()
+
+	if config.Security == "" || config.Security == outputmodels.EmailSecurityStartTLS {
+		if ok, _ := smtpClient.Extension("STARTTLS"); !ok {
+			return &AlertDeliveryError{Message: "smtp server does not support STARTTLS", Permanent: true}
+		}
+		if err = smtpClient.StartTLS(tlsConfig); err != nil {
+			return smtpDeliveryError("starttls", err)
+		}
+	}
+
+	if config.UserName != "" {
+		if err = smtpClient.Auth(smtp.PlainAuth("", config.UserName, config.Password, config.Host)); err != nil {
+			return smtpDeliveryError("auth", err)
+		}
+	}
+
+	if err = smtpClient.Mail(config.From); err != nil {
+		return smtpDeliveryError("mail from", err)
+	}
+	for _, recipient := range config.Recipients {
+		if err = smtpClient.Rcpt(recipient); err != nil {
+			return smtpDeliveryError("rcpt to", err)
+		}
+	}
+
+	writer, err := smtpClient.Data()
+	if err != nil {
+		return smtpDeliveryError("data", err)
+	}
+	if _, err = writer.Write(message); err != nil {
+		return smtpDeliveryError("data", err)
+	}
+	if err = writer.Close(); err != nil {
+		return smtpDeliveryError("data", err)
+	}
+
+	// The message has already been accepted, so a failed QUIT is not a delivery failure
+	_ = smtpClient.Quit()
+	return nil
+}
+
+// emailPort returns the configured port, or the standard port for the security mode.
+func emailPort(config *outputmodels.EmailConfig) int {
+	if config.Port != 0 {
+		return config.Port
+	}
+	switch config.Security {
+	case outputmodels.EmailSecurityTLS:
+		return 465
+	case outputmodels.EmailSecurityNone:
+		return 25
+	default:
+		return 587
+	}
+}
+
+// smtpDeliveryError converts an SMTP error into an AlertDeliveryError.
+//
+// 5xx replies (e.g. bad credentials or a rejected recipient) are permanent, everything else is retried.
+func smtpDeliveryError(command string, err error) *AlertDeliveryError {
+	result := &AlertDeliveryError{Message: "smtp " + command + " failed: " + err.Error()}
+	if protoErr, ok := err.(*textproto.Error); ok && protoErr.Code >= 500 {
+		result.Permanent = true
+	}
+	return result
+}
+
+// buildEmailMessage encodes the headers and a multipart/alternative body as defined by RFC 2046.
+func buildEmailMessage(input *EmailInput) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		// Clients display the last part they support, so the text part must come first
+		{contentType: "text/plain; charset=UTF-8", content: input.text},
+		{contentType: "text/html; charset=UTF-8", content: input.html},
+	}
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err = encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err = encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := [][2]string{
+		{"From", input.config.From},
+		{"To", strings.Join(input.config.Recipients, ", ")},
+		{"Subject", mime.QEncoding.Encode("UTF-8", input.subject)},
+		{"Date", time.Now().UTC().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + writer.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
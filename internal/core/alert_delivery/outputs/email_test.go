@@ -0,0 +1,214 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+type mockMailer struct {
+	mock.Mock
+}
+
+func (m *mockMailer) send(input *EmailInput) *AlertDeliveryError {
+	args := m.Called(input)
+	return args.Get(0).(*AlertDeliveryError)
+}
+
+var emailAlert = &alertmodels.Alert{
+	PolicyID:          aws.String("policyId"),
+	PolicyName:        aws.String("policyName"),
+	PolicyDescription: aws.String("<b>description</b>"),
+	Severity:          aws.String("HIGH"),
+	Runbook:           aws.String("runbook"),
+	Tags:              aws.StringSlice([]string{"tag1", "tag2"}),
+	CreatedAt:         aws.Time(time.Now()),
+}
+
+func TestEmailAlert(t *testing.T) {
+	mailer := &mockMailer{}
+	client := &OutputClient{mailer: mailer}
+	config := &outputmodels.EmailConfig{
+		Host:       "smtp.example.com",
+		From:       "panther@example.com",
+		Recipients: []string{"auditors@example.com"},
+	}
+
+	mailer.On("send", mock.Anything).Return((*AlertDeliveryError)(nil))
+	require.Nil(t, client.Email(emailAlert, config))
+	mailer.AssertExpectations(t)
+
+	input := mailer.Calls[0].Arguments.Get(0).(*EmailInput)
+	assert.Equal(t, config, input.config)
+	assert.Equal(t, "Policy Failure: policyName", input.subject)
+	assert.Equal(t, generateDetailedAlertMessage(emailAlert), input.text)
+	assert.Contains(t, input.html, "https://panther.io/policies/policyId")
+	assert.Contains(t, input.html, "tag1, tag2")
+	// HTML in the alert fields must be escaped
+	assert.Contains(t, input.html, "&lt;b&gt;description&lt;/b&gt;")
+}
+
+func TestEmailPort(t *testing.T) {
+	assert.Equal(t, 587, emailPort(&outputmodels.EmailConfig{}))
+	assert.Equal(t, 465, emailPort(&outputmodels.EmailConfig{Security: outputmodels.EmailSecurityTLS}))
+	assert.Equal(t, 25, emailPort(&outputmodels.EmailConfig{Security: outputmodels.EmailSecurityNone}))
+	assert.Equal(t, 2525, emailPort(&outputmodels.EmailConfig{Port: 2525}))
+}
+
+func TestSMTPDeliveryError(t *testing.T) {
+	assert.True(t, smtpDeliveryError("auth", &textproto.Error{Code: 535, Msg: "bad credentials"}).Permanent)
+	assert.False(t, smtpDeliveryError("rcpt to", &textproto.Error{Code: 451, Msg: "try later"}).Permanent)
+	assert.False(t, smtpDeliveryError("data", errors.New("connection reset")).Permanent)
+}
+
+// smtpStandIn is a minimal local SMTP server which records the last message it received
+type smtpStandIn struct {
+	listener   net.Listener
+	rcptReply  string
+	from       string
+	recipients []string
+	data       string
+	auth       string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &smtpStandIn{listener: listener, rcptReply: "250 OK"}
+	go server.serve()
+	return server
+}
+
+func (s *smtpStandIn) config() *outputmodels.EmailConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return &outputmodels.EmailConfig{
+		Host:       host,
+		Port:       portNumber,
+		Security:   outputmodels.EmailSecurityNone,
+		UserName:   "user",
+		Password:   "secret",
+		From:       "panther@example.com",
+		Recipients: []string{"a@example.com", "b@example.com"},
+	}
+}
+
+func (s *smtpStandIn) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	writer := textproto.NewWriter(bufio.NewWriter(conn))
+
+	_ = writer.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO":
+			_ = writer.PrintfLine("250-localhost")
+			_ = writer.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			s.auth = line
+			_ = writer.PrintfLine("235 Authenticated")
+		case "MAIL":
+			s.from = line
+			_ = writer.PrintfLine("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, line)
+			_ = writer.PrintfLine(s.rcptReply)
+		case "DATA":
+			_ = writer.PrintfLine("354 Go ahead")
+			data, err := reader.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			_ = writer.PrintfLine("250 Queued")
+		case "QUIT":
+			_ = writer.PrintfLine("221 Bye")
+			return
+		default:
+			_ = writer.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	server := newSMTPStandIn(t)
+	defer server.listener.Close()
+
+	input := &EmailInput{
+		config:  server.config(),
+		subject: "New Alert: rule",
+		text:    "plain text body",
+		html:    "<p>html body</p>",
+	}
+	require.Nil(t, (&SMTPMailer{}).send(input))
+
+	assert.Equal(t, "MAIL FROM:<panther@example.com>", server.from)
+	assert.Equal(t, []string{"RCPT TO:<a@example.com>", "RCPT TO:<b@example.com>"}, server.recipients)
+	assert.True(t, strings.HasPrefix(server.auth, "AUTH PLAIN"))
+	assert.Contains(t, server.data, "Subject: New Alert: rule\n")
+	assert.Contains(t, server.data, "To: a@example.com, b@example.com\n")
+	assert.Contains(t, server.data, "Content-Type: multipart/alternative; boundary=")
+	assert.Contains(t, server.data, "plain text body")
+	assert.Contains(t, server.data, "<p>html body</p>")
+}
+
+func TestSMTPMailerRejectedRecipient(t *testing.T) {
+	server := newSMTPStandIn(t)
+	defer server.listener.Close()
+	server.rcptReply = "550 No such user"
+
+	input := &EmailInput{config: server.config(), subject: "subject", text: "text", html: "html"}
+	result := (&SMTPMailer{}).send(input)
+	require.NotNil(t, result)
+	assert.True(t, result.Permanent)
+}
+
+func TestSMTPMailerConnectionRefused(t *testing.T) {
+	server := newSMTPStandIn(t)
+	config := server.config()
+	server.listener.Close()
+
+	result := (&SMTPMailer{}).send(&EmailInput{config: config})
+	require.NotNil(t, result)
+	assert.False(t, result.Permanent)
+}
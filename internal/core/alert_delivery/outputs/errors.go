@@ -18,6 +18,8 @@ package outputs
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
+import "time"
+
 // AlertDeliveryError indicates whether a failed alert should be retried.
 type AlertDeliveryError struct {
 	// Message is the description of the problem: what went wrong.
@@ -27,6 +29,10 @@ type AlertDeliveryError struct {
 	// For example, outputs which don't exist or errors creating the request are permanent failures.
 	// But any error talking to the output itself can be retried by the Lambda function later.
 	Permanent bool
+
+	// RetryAfter is how long the output asked us to wait before trying again (e.g. an HTTP Retry-After header).
+	// Zero if the output did not specify a delay.
+	RetryAfter time.Duration
 }
 
 func (e *AlertDeliveryError) Error() string { return e.Message }
@@ -19,6 +19,7 @@ package outputs
  */
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -33,9 +34,15 @@ const (
 	requestType    = "/issues"
 )
 
-// Github alert send an issue.
+// githubIssue is the response to creating an issue
+type githubIssue struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// Github alert send an issue, returns the created issue if it could be read from the response.
 func (client *OutputClient) Github(
-	alert *alertmodels.Alert, config *outputmodels.GithubConfig) *AlertDeliveryError {
+	alert *alertmodels.Alert, config *outputmodels.GithubConfig) (*Ticket, *AlertDeliveryError) {
 
 	var tagsItem = aws.StringValueSlice(alert.Tags)
 
@@ -44,10 +51,11 @@ func (client *OutputClient) Github(
 	runBook := "\n **Runbook:** " + aws.StringValue(alert.Runbook)
 	severity := "\n **Severity:** " + aws.StringValue(alert.Severity)
 	tags := "\n **Tags:** " + strings.Join(tagsItem, ", ")
+	sampleEvents := generateSampleEventsText(alert.SampleEvents, "\n **Sample Events:**", "\n```json\n", "\n```", githubMaxSampleEventBytes)
 
 	githubRequest := map[string]interface{}{
 		"title": generateAlertTitle(alert),
-		"body":  description + link + runBook + severity + tags,
+		"body":  description + link + runBook + severity + tags + sampleEvents,
 	}
 
 	token := "token " + config.Token
@@ -56,10 +64,18 @@ func (client *OutputClient) Github(
 		AuthorizationHTTPHeader: token,
 	}
 
+	issue := &githubIssue{}
 	postInput := &PostInput{
-		url:     repoURL,
-		body:    githubRequest,
-		headers: requestHeader,
+		url:      repoURL,
+		body:     githubRequest,
+		headers:  requestHeader,
+		response: issue,
+	}
+	if err := client.httpWrapper.post(postInput); err != nil {
+		return nil, err
+	}
+	if issue.Number == 0 {
+		return nil, nil
 	}
-	return client.httpWrapper.post(postInput)
+	return &Ticket{System: "github", Key: config.RepoName + "#" + strconv.Itoa(issue.Number), URL: issue.HTMLURL}, nil
 }
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
@@ -58,13 +60,31 @@ func TestGithubAlert(t *testing.T) {
 	}
 	requestEndpoint := "https://api.github.com/repos/profile/reponame/issues"
 	expectedPostInput := &PostInput{
-		url:     requestEndpoint,
-		body:    githubRequest,
-		headers: requestHeader,
+		url:      requestEndpoint,
+		body:     githubRequest,
+		headers:  requestHeader,
+		response: &githubIssue{},
 	}
 
-	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil)).Run(func(args mock.Arguments) {
+		issue := args.Get(0).(*PostInput).response.(*githubIssue)
+		issue.Number = 12
+		issue.HTMLURL = "https://github.com/profile/reponame/issues/12"
+	})
 
-	require.Nil(t, client.Github(alert, githubConfig))
+	ticket, err := client.Github(alert, githubConfig)
+	require.Nil(t, err)
+	assert.Equal(t, &Ticket{System: "github", Key: "profile/reponame#12", URL: "https://github.com/profile/reponame/issues/12"}, ticket)
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestGithubAlertError(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	alert := &alertmodels.Alert{PolicyID: aws.String("ruleId"), Severity: aws.String("INFO")}
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryError{Message: "request failed"})
+
+	ticket, err := client.Github(alert, githubConfig)
+	assert.NotNil(t, err)
+	assert.Nil(t, ticket)
+}
@@ -30,11 +30,17 @@ import (
 
 const (
 	jiraEndpoint = "/rest/api/latest/issue/"
+	jiraBrowse   = "/browse/"
 )
 
-// Jira alert send an issue.
+// jiraIssue is the response to creating an issue
+type jiraIssue struct {
+	Key string `json:"key"`
+}
+
+// Jira alert send an issue, returns the created issue if it could be read from the response.
 func (client *OutputClient) Jira(
-	alert *alertmodels.Alert, config *outputmodels.JiraConfig) *AlertDeliveryError {
+	alert *alertmodels.Alert, config *outputmodels.JiraConfig) (*Ticket, *AlertDeliveryError) {
 
 	var tagsItem = aws.StringValueSlice(alert.Tags)
 
@@ -43,10 +49,11 @@ func (client *OutputClient) Jira(
 	runBook := "\n *Runbook:* " + aws.StringValue(alert.Runbook)
 	severity := "\n *Severity:* " + aws.StringValue(alert.Severity)
 	tags := "\n *Tags:* " + strings.Join(tagsItem, ", ")
+	sampleEvents := generateSampleEventsText(alert.SampleEvents, "\n *Sample Events:*", "\n{code:json}", "{code}", jiraMaxSampleEventBytes)
 
 	fields := map[string]interface{}{
 		"summary":     generateAlertTitle(alert),
-		"description": description + link + runBook + severity + tags,
+		"description": description + link + runBook + severity + tags + sampleEvents,
 		"project": map[string]*string{
 			"key": aws.String(config.ProjectKey),
 		},
@@ -72,10 +79,18 @@ func (client *OutputClient) Jira(
 		AuthorizationHTTPHeader: basicAuthToken,
 	}
 
+	issue := &jiraIssue{}
 	postInput := &PostInput{
-		url:     jiraRestURL,
-		body:    jiraRequest,
-		headers: requestHeader,
+		url:      jiraRestURL,
+		body:     jiraRequest,
+		headers:  requestHeader,
+		response: issue,
+	}
+	if err := client.httpWrapper.post(postInput); err != nil {
+		return nil, err
+	}
+	if issue.Key == "" {
+		return nil, nil
 	}
-	return client.httpWrapper.post(postInput)
+	return &Ticket{System: "jira", Key: issue.Key, URL: config.OrgDomain + jiraBrowse + issue.Key}, nil
 }
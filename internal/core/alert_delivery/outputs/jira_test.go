@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
@@ -76,13 +78,44 @@ func TestJiraAlert(t *testing.T) {
 	}
 	requestEndpoint := "https://panther-labs.atlassian.net/rest/api/latest/issue/"
 	expectedPostInput := &PostInput{
-		url:     requestEndpoint,
-		body:    jiraPayload,
-		headers: requestHeader,
+		url:      requestEndpoint,
+		body:     jiraPayload,
+		headers:  requestHeader,
+		response: &jiraIssue{},
 	}
 
-	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil)).Run(func(args mock.Arguments) {
+		args.Get(0).(*PostInput).response.(*jiraIssue).Key = "QR-12"
+	})
 
-	require.Nil(t, client.Jira(alert, jiraConfig))
+	ticket, err := client.Jira(alert, jiraConfig)
+	require.Nil(t, err)
+	assert.Equal(t, &Ticket{System: "jira", Key: "QR-12", URL: "https://panther-labs.atlassian.net/browse/QR-12"}, ticket)
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestJiraAlertSampleEvents(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	var createdAtTime, _ = time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+	alert := &alertmodels.Alert{
+		PolicyID:     aws.String("ruleId"),
+		CreatedAt:    &createdAtTime,
+		Severity:     aws.String("INFO"),
+		SampleEvents: []string{`{"p_log_type":"AWS.S3","key":"value"}`},
+	}
+
+	expectedDescription := "*Description:* \n " +
+		"[Click here to view in the Panther UI](https://panther.io/policies/ruleId)\n" +
+		" *Runbook:* \n *Severity:* INFO\n *Tags:* " +
+		"\n *Sample Events:*\n{code:json}{\"p_log_type\":\"AWS.S3\",\"key\":\"value\"}{code}"
+	httpWrapper.On("post", mock.MatchedBy(func(input *PostInput) bool {
+		fields := input.body.(map[string]interface{})["fields"].(map[string]interface{})
+		return fields["description"] == expectedDescription
+	})).Return((*AlertDeliveryError)(nil))
+
+	_, err := client.Jira(alert, jiraConfig)
+	require.Nil(t, err)
 	httpWrapper.AssertExpectations(t)
 }
@@ -19,6 +19,9 @@ package outputs
  */
 
 import (
+	"net/url"
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
@@ -37,26 +40,53 @@ var pantherToOpsGeniePriority = map[string]string{
 	"INFO":     "P5",
 }
 
+// Opsgenie actions which change the state of an existing alert, keyed by Panther lifecycle action
+var opsgenieActions = map[string]struct{ path, note string }{
+	alertmodels.AcknowledgeAction: {path: "acknowledge", note: "Alert triaged in Panther: "},
+	alertmodels.ResolveAction:     {path: "close", note: "Alert resolved in Panther: "},
+}
+
 // Opsgenie alert send an alert.
+//
+// The Panther alert ID is the Opsgenie alias, so re-sending an alert is de-duplicated by Opsgenie
+// and acknowledge or resolve actions apply to the Opsgenie alert it created.
 func (client *OutputClient) Opsgenie(
 	alert *alertmodels.Alert, config *outputmodels.OpsgenieConfig) *AlertDeliveryError {
 
+	requestHeader := map[string]string{
+		AuthorizationHTTPHeader: "GenieKey " + config.APIKey,
+	}
+
+	if alert.IsLifecycleUpdate() {
+		if alert.AlertID == nil {
+			return &AlertDeliveryError{Message: "alert ID is required to " + *alert.Action + " an Opsgenie alert", Permanent: true}
+		}
+		action := opsgenieActions[*alert.Action]
+		postInput := &PostInput{
+			url:     opsgenieEndpoint + "/" + url.PathEscape(*alert.AlertID) + "/" + action.path + "?identifierType=alias",
+			body:    map[string]interface{}{"source": "Panther", "note": action.note + generateURL(alert)},
+			headers: requestHeader,
+		}
+		return client.httpWrapper.post(postInput)
+	}
+
 	tagsItem := aws.StringValueSlice(alert.Tags)
 
 	description := "<strong>Description:</strong> " + aws.StringValue(alert.PolicyDescription)
 	link := "\n<a href=\"" + generateURL(alert) + "\">Click here to view in the Panther UI</a>"
 	runBook := "\n <strong>Runbook:</strong> " + aws.StringValue(alert.Runbook)
 	severity := "\n <strong>Severity:</strong> " + aws.StringValue(alert.Severity)
+	sampleEvents := generateSampleEventsText(
+		escapeOpsgenieSampleEvents(alert.SampleEvents), "\n <strong>Sample Events:</strong>", "\n<pre>", "</pre>", opsgenieMaxSampleEventBytes)
 
 	opsgenieRequest := map[string]interface{}{
 		"message":     generateAlertTitle(alert),
-		"description": description + link + runBook + severity,
+		"description": description + link + runBook + severity + sampleEvents,
 		"tags":        tagsItem,
 		"priority":    pantherToOpsGeniePriority[aws.StringValue(alert.Severity)],
 	}
-	authorization := "GenieKey " + config.APIKey
-	requestHeader := map[string]string{
-		AuthorizationHTTPHeader: authorization,
+	if alert.AlertID != nil {
+		opsgenieRequest["alias"] = *alert.AlertID
 	}
 
 	postInput := &PostInput{
@@ -66,3 +96,17 @@ func (client *OutputClient) Opsgenie(
 	}
 	return client.httpWrapper.post(postInput)
 }
+
+var opsgenieHTMLEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeOpsgenieSampleEvents escapes sample events for the HTML alert description.
+func escapeOpsgenieSampleEvents(events []string) []string {
+	if len(events) == 0 {
+		return nil
+	}
+	escaped := make([]string, len(events))
+	for i, event := range events {
+		escaped[i] = opsgenieHTMLEscaper.Replace(event)
+	}
+	return escaped
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
@@ -74,3 +75,83 @@ func TestOpsgenieAlert(t *testing.T) {
 	require.Nil(t, client.Opsgenie(alert, opsgenieConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestOpsgenieAlertAlias(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	alert := &alertmodels.Alert{
+		AlertID:    aws.String("alertId"),
+		PolicyID:   aws.String("ruleId"),
+		CreatedAt:  aws.Time(time.Now()),
+		PolicyName: aws.String("ruleName"),
+		Severity:   aws.String("HIGH"),
+		Type:       aws.String(alertmodels.RuleType),
+	}
+
+	httpWrapper.On("post", mock.MatchedBy(func(input *PostInput) bool {
+		return input.url == "https://api.opsgenie.com/v2/alerts" && input.body.(map[string]interface{})["alias"] == "alertId"
+	})).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.Opsgenie(alert, opsgenieConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestOpsgenieResolve(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	alert := &alertmodels.Alert{
+		AlertID:  aws.String("alertId"),
+		PolicyID: aws.String("ruleId"),
+		Severity: aws.String("HIGH"),
+		Type:     aws.String(alertmodels.RuleType),
+		Action:   aws.String(alertmodels.ResolveAction),
+	}
+
+	expectedPostInput := &PostInput{
+		url: "https://api.opsgenie.com/v2/alerts/alertId/close?identifierType=alias",
+		body: map[string]interface{}{
+			"source": "Panther",
+			"note":   "Alert resolved in Panther: https://panther.io/alerts/alertId",
+		},
+		headers: map[string]string{AuthorizationHTTPHeader: "GenieKey apikey"},
+	}
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.Opsgenie(alert, opsgenieConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestOpsgenieAcknowledge(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	alert := &alertmodels.Alert{
+		AlertID:  aws.String("alertId"),
+		PolicyID: aws.String("ruleId"),
+		Severity: aws.String("HIGH"),
+		Type:     aws.String(alertmodels.RuleType),
+		Action:   aws.String(alertmodels.AcknowledgeAction),
+	}
+
+	httpWrapper.On("post", mock.MatchedBy(func(input *PostInput) bool {
+		return input.url == "https://api.opsgenie.com/v2/alerts/alertId/acknowledge?identifierType=alias"
+	})).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.Opsgenie(alert, opsgenieConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestOpsgenieResolveWithoutAlertID(t *testing.T) {
+	client := &OutputClient{httpWrapper: &mockHTTPWrapper{}}
+	alert := &alertmodels.Alert{
+		PolicyID: aws.String("policyId"),
+		Severity: aws.String("HIGH"),
+		Action:   aws.String(alertmodels.ResolveAction),
+	}
+
+	result := client.Opsgenie(alert, opsgenieConfig)
+	require.NotNil(t, result)
+	require.True(t, result.Permanent)
+}
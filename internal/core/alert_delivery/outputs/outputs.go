@@ -47,6 +47,17 @@ type PostInput struct {
 	url     string
 	body    interface{}
 	headers map[string]string
+	// signingSecret, if set, is used to add webhooksig signature headers
+	signingSecret string
+	// response, if set, is a pointer the JSON response body is decoded into
+	response interface{}
+}
+
+// Ticket is the issue created for an alert by a ticketing output (Jira or GitHub)
+type Ticket struct {
+	System string // the output type
+	Key    string // the Jira issue key (PANTHER-12) or the GitHub issue (org/repo#12)
+	URL    string
 }
 
 // HTTPWrapperiface is the interface for our wrapper around Golang's http client
@@ -63,20 +74,25 @@ type HTTPiface interface {
 type API interface {
 	Slack(*alertmodels.Alert, *outputmodels.SlackConfig) *AlertDeliveryError
 	PagerDuty(*alertmodels.Alert, *outputmodels.PagerDutyConfig) *AlertDeliveryError
-	Github(*alertmodels.Alert, *outputmodels.GithubConfig) *AlertDeliveryError
-	Jira(*alertmodels.Alert, *outputmodels.JiraConfig) *AlertDeliveryError
+	Github(*alertmodels.Alert, *outputmodels.GithubConfig) (*Ticket, *AlertDeliveryError)
+	Jira(*alertmodels.Alert, *outputmodels.JiraConfig) (*Ticket, *AlertDeliveryError)
 	Opsgenie(*alertmodels.Alert, *outputmodels.OpsgenieConfig) *AlertDeliveryError
 	MsTeams(*alertmodels.Alert, *outputmodels.MsTeamsConfig) *AlertDeliveryError
 	Sqs(*alertmodels.Alert, *outputmodels.SqsConfig) *AlertDeliveryError
 	Sns(*alertmodels.Alert, *outputmodels.SnsConfig) *AlertDeliveryError
 	Asana(*alertmodels.Alert, *outputmodels.AsanaConfig) *AlertDeliveryError
 	CustomWebhook(*alertmodels.Alert, *outputmodels.CustomWebhookConfig) *AlertDeliveryError
+	Email(*alertmodels.Alert, *outputmodels.EmailConfig) *AlertDeliveryError
+	Syslog(*alertmodels.Alert, *outputmodels.SyslogConfig) *AlertDeliveryError
+	Splunk(*alertmodels.Alert, *outputmodels.SplunkConfig) *AlertDeliveryError
 }
 
 // OutputClient encapsulates the clients that allow sending alerts to multiple outputs
 type OutputClient struct {
 	session     *session.Session
 	httpWrapper HTTPWrapperiface
+	mailer      Mailer
+	syslog      SyslogWriter
 	// Map from region -> client
 	sqsClients map[string]sqsiface.SQSAPI
 	snsClients map[string]snsiface.SNSAPI
@@ -90,6 +106,8 @@ func New(sess *session.Session) *OutputClient {
 	return &OutputClient{
 		session:     sess,
 		httpWrapper: &HTTPWrapper{httpClient: &http.Client{}},
+		mailer:      &SMTPMailer{},
+		syslog:      &TCPSyslogWriter{},
 		// TODO Lazy initialization of clients
 		sqsClients: make(map[string]sqsiface.SQSAPI),
 		snsClients: make(map[string]snsiface.SNSAPI),
@@ -99,6 +117,9 @@ func New(sess *session.Session) *OutputClient {
 const detailedMessageTemplate = "%s\nFor more details please visit: %s\nSeverity: %s\nRunbook: %s\nDescription: %s"
 
 func generateAlertMessage(alert *alertmodels.Alert) string {
+	if aws.StringValue(alert.Type) == alertmodels.DigestType {
+		return aws.StringValue(alert.Title)
+	}
 	if aws.StringValue(alert.Type) == alertmodels.RuleType {
 		return getDisplayName(alert) + " triggered"
 	}
@@ -117,6 +138,9 @@ func generateDetailedAlertMessage(alert *alertmodels.Alert) string {
 }
 
 func generateAlertTitle(alert *alertmodels.Alert) string {
+	if aws.StringValue(alert.Type) == alertmodels.DigestType {
+		return "Alert Digest: " + aws.StringValue(alert.Title)
+	}
 	if alert.Title != nil {
 		return "New Alert: " + *alert.Title
 	}
@@ -133,7 +157,16 @@ func getDisplayName(alert *alertmodels.Alert) string {
 	return *alert.PolicyID
 }
 
+// GenerateURL returns the link to an alert (rules) or policy (policies) in the Panther UI.
+func GenerateURL(alert *alertmodels.Alert) string {
+	return generateURL(alert)
+}
+
 func generateURL(alert *alertmodels.Alert) string {
+	// Digests link to the alerts list, each rule is linked from the digest description
+	if aws.StringValue(alert.Type) == alertmodels.DigestType {
+		return alertURLPrefix
+	}
 	if aws.StringValue(alert.Type) == alertmodels.RuleType {
 		return alertURLPrefix + *alert.AlertID
 	}
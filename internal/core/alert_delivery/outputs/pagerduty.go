@@ -28,8 +28,7 @@ import (
 )
 
 var (
-	pagerDutyEndpoint  = "https://events.pagerduty.com/v2/enqueue"
-	triggerEventAction = "trigger"
+	pagerDutyEndpoint = "https://events.pagerduty.com/v2/enqueue"
 )
 
 func pantherSeverityToPagerDuty(severity *string) (*string, *AlertDeliveryError) {
@@ -48,27 +47,53 @@ func pantherSeverityToPagerDuty(severity *string) (*string, *AlertDeliveryError)
 }
 
 // PagerDuty sends an alert to a pager duty integration endpoint.
+//
+// The Panther alert ID is the PagerDuty dedup_key, so re-sending an alert updates the open incident
+// and acknowledge or resolve actions apply to the incident the alert triggered.
 func (client *OutputClient) PagerDuty(alert *alertmodels.Alert, config *outputmodels.PagerDutyConfig) *AlertDeliveryError {
+	if alert.IsLifecycleUpdate() {
+		if alert.AlertID == nil {
+			return &AlertDeliveryError{Message: "alert ID is required to " + *alert.Action + " a PagerDuty incident", Permanent: true}
+		}
+		postInput := &PostInput{
+			url: pagerDutyEndpoint,
+			body: map[string]interface{}{
+				"routing_key":  config.IntegrationKey,
+				"event_action": *alert.Action,
+				"dedup_key":    *alert.AlertID,
+			},
+		}
+		return client.httpWrapper.post(postInput)
+	}
+
 	severity, err := pantherSeverityToPagerDuty(alert.Severity)
 	if err != nil {
 		return err
 	}
 
+	customDetails := map[string]string{
+		"description": aws.StringValue(alert.PolicyDescription),
+		"runbook":     aws.StringValue(alert.Runbook),
+	}
+	if sampleEvents := generateSampleEventsText(alert.SampleEvents, "", "", "\n", pagerDutyMaxSampleEventBytes); sampleEvents != "" {
+		customDetails["sample_events"] = sampleEvents
+	}
+
 	payload := map[string]interface{}{
-		"summary":   generateAlertTitle(alert),
-		"severity":  aws.StringValue(severity),
-		"timestamp": alert.CreatedAt.Format(time.RFC3339),
-		"source":    "pantherlabs",
-		"custom_details": map[string]string{
-			"description": aws.StringValue(alert.PolicyDescription),
-			"runbook":     aws.StringValue(alert.Runbook),
-		},
+		"summary":        generateAlertTitle(alert),
+		"severity":       aws.StringValue(severity),
+		"timestamp":      alert.CreatedAt.Format(time.RFC3339),
+		"source":         "pantherlabs",
+		"custom_details": customDetails,
 	}
 
 	pagerDutyRequest := map[string]interface{}{
 		"payload":      payload,
 		"routing_key":  config.IntegrationKey,
-		"event_action": triggerEventAction,
+		"event_action": alertmodels.TriggerAction,
+	}
+	if alert.AlertID != nil {
+		pagerDutyRequest["dedup_key"] = *alert.AlertID
 	}
 
 	postInput := &PostInput{
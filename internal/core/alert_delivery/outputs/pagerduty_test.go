@@ -84,3 +84,55 @@ func TestSendPagerDutyAlertPostError(t *testing.T) {
 	require.Error(t, outputClient.PagerDuty(pagerDutyAlert, pagerDutyConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestSendPagerDutyAlertDedupKey(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	outputClient := &OutputClient{httpWrapper: httpWrapper}
+
+	alert := *pagerDutyAlert
+	alert.AlertID = aws.String("alertId")
+	httpWrapper.On("post", mock.MatchedBy(func(input *PostInput) bool {
+		body := input.body.(map[string]interface{})
+		return body["dedup_key"] == "alertId" && body["event_action"] == "trigger"
+	})).Return((*AlertDeliveryError)(nil))
+
+	assert.Nil(t, outputClient.PagerDuty(&alert, pagerDutyConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestSendPagerDutyResolve(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	outputClient := &OutputClient{httpWrapper: httpWrapper}
+
+	alert := &alertmodels.Alert{
+		AlertID:  aws.String("alertId"),
+		PolicyID: aws.String("policyId"),
+		Severity: aws.String("INFO"),
+		Action:   aws.String(alertmodels.ResolveAction),
+	}
+	expectedPostInput := &PostInput{
+		url: "https://events.pagerduty.com/v2/enqueue",
+		body: map[string]interface{}{
+			"routing_key":  "integrationKey",
+			"event_action": "resolve",
+			"dedup_key":    "alertId",
+		},
+	}
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+
+	assert.Nil(t, outputClient.PagerDuty(alert, pagerDutyConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestSendPagerDutyAcknowledgeWithoutAlertID(t *testing.T) {
+	outputClient := &OutputClient{httpWrapper: &mockHTTPWrapper{}}
+	alert := &alertmodels.Alert{
+		PolicyID: aws.String("policyId"),
+		Severity: aws.String("INFO"),
+		Action:   aws.String(alertmodels.AcknowledgeAction),
+	}
+
+	result := outputClient.PagerDuty(alert, pagerDutyConfig)
+	require.NotNil(t, result)
+	assert.True(t, result.Permanent)
+}
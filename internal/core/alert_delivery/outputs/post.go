@@ -22,8 +22,13 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/pkg/webhooksig"
 )
 
 const (
@@ -50,6 +55,11 @@ func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryError {
 		request.Header.Set(key, value)
 	}
 
+	// The signature is computed over the exact bytes we send
+	if input.signingSecret != "" {
+		webhooksig.SignRequest(request, []byte(input.signingSecret), time.Now(), payload)
+	}
+
 	response, err := client.httpClient.Do(request)
 	if err != nil {
 		return &AlertDeliveryError{Message: "network error: " + err.Error()}
@@ -59,8 +69,34 @@ func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryError {
 	if response.StatusCode < 200 || response.StatusCode > 299 {
 		body, _ := ioutil.ReadAll(response.Body)
 		return &AlertDeliveryError{
-			Message: "request failed: " + response.Status + ": " + string(body)}
+			Message:    "request failed: " + response.Status + ": " + string(body),
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
+	if input.response != nil {
+		// The alert was delivered, retrying because the response can't be read would deliver it twice
+		if err = jsoniter.NewDecoder(response.Body).Decode(input.response); err != nil {
+			zap.L().Warn("failed to decode response", zap.String("url", input.url), zap.Error(err))
+		}
+	}
 	return nil
 }
+
+// parseRetryAfter returns the delay requested by a Retry-After header, which is either
+// a number of seconds or an HTTP date. Returns 0 if the header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
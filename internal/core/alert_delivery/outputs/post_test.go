@@ -24,15 +24,23 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/webhooksig"
 )
 
 type mockHTTPClient struct {
 	HTTPiface
-	statusCode   int
-	requestError bool
-	requestBody  string // Request body is saved here for tests to verify
+	statusCode    int
+	requestError  bool
+	requestBody   string // Request body is saved here for tests to verify
+	requestHeader http.Header
+	retryAfter    string // Retry-After response header
+	responseBody  string
 }
 
 var requestEndpoint = "https://runpanther.io"
@@ -46,9 +54,18 @@ func (m *mockHTTPClient) Do(request *http.Request) (*http.Response, error) {
 		panic(err)
 	}
 	m.requestBody = string(requestBytes)
+	m.requestHeader = request.Header
 
-	responseBody := ioutil.NopCloser(bytes.NewReader([]byte("response")))
-	return &http.Response{Body: responseBody, StatusCode: m.statusCode}, nil
+	response := "response"
+	if m.responseBody != "" {
+		response = m.responseBody
+	}
+	responseBody := ioutil.NopCloser(bytes.NewReader([]byte(response)))
+	responseHeader := http.Header{}
+	if m.retryAfter != "" {
+		responseHeader.Set("Retry-After", m.retryAfter)
+	}
+	return &http.Response{Body: responseBody, StatusCode: m.statusCode, Header: responseHeader}, nil
 }
 
 func TestPostInvalidJSON(t *testing.T) {
@@ -96,3 +113,66 @@ func TestPostCreated(t *testing.T) {
 	}
 	assert.Nil(t, c.post(postInput))
 }
+
+func TestPostSigned(t *testing.T) {
+	httpClient := &mockHTTPClient{statusCode: http.StatusOK}
+	c := &HTTPWrapper{httpClient: httpClient}
+	postInput := &PostInput{
+		url:           requestEndpoint,
+		body:          &CustomWebhookOutputMessage{AnalysisID: aws.String("policyId")},
+		headers:       map[string]string{"X-Custom": "value"},
+		signingSecret: "secret",
+	}
+	assert.Nil(t, c.post(postInput))
+
+	assert.Equal(t, "value", httpClient.requestHeader.Get("X-Custom"))
+	assert.NoError(t, webhooksig.Verify(
+		httpClient.requestHeader, []byte("secret"), []byte(httpClient.requestBody), webhooksig.DefaultTolerance, time.Now()))
+}
+
+func TestPostDecodesResponse(t *testing.T) {
+	c := &HTTPWrapper{httpClient: &mockHTTPClient{statusCode: http.StatusCreated, responseBody: `{"key": "QR-12"}`}}
+	issue := &jiraIssue{}
+	postInput := &PostInput{
+		url:      requestEndpoint,
+		body:     &CustomWebhookOutputMessage{AnalysisID: aws.String("policyId")},
+		response: issue,
+	}
+	assert.Nil(t, c.post(postInput))
+	assert.Equal(t, "QR-12", issue.Key)
+}
+
+func TestPostInvalidResponse(t *testing.T) {
+	c := &HTTPWrapper{httpClient: &mockHTTPClient{statusCode: http.StatusCreated}}
+	issue := &jiraIssue{}
+	postInput := &PostInput{
+		url:      requestEndpoint,
+		body:     &CustomWebhookOutputMessage{AnalysisID: aws.String("policyId")},
+		response: issue,
+	}
+	// The alert was delivered even though the response can't be read
+	assert.Nil(t, c.post(postInput))
+	assert.Empty(t, issue.Key)
+}
+
+func TestPostRetryAfter(t *testing.T) {
+	c := &HTTPWrapper{httpClient: &mockHTTPClient{statusCode: http.StatusTooManyRequests, retryAfter: "120"}}
+	postInput := &PostInput{
+		url:  requestEndpoint,
+		body: &CustomWebhookOutputMessage{AnalysisID: aws.String("policyId")},
+	}
+	result := c.post(postInput)
+	require.NotNil(t, result)
+	assert.False(t, result.Permanent)
+	assert.Equal(t, 2*time.Minute, result.RetryAfter)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-5", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter("Fri, 01 May 2020 12:01:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Fri, 01 May 2020 11:00:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
@@ -0,0 +1,88 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Byte budgets for the sample events rendered by each output.
+//
+// These are kept well below each vendor's hard limit to leave room for the rest of the message.
+const (
+	slackMaxSampleEventBytes     = 12000
+	slackMaxSectionBytes         = 3000 // Slack rejects section blocks with more text than this
+	jiraMaxSampleEventBytes      = 16000
+	githubMaxSampleEventBytes    = 32000
+	opsgenieMaxSampleEventBytes  = 10000
+	pagerDutyMaxSampleEventBytes = 16000
+)
+
+// fitSampleEvents returns the sample events which fit in the given budget.
+//
+// Events are dropped rather than cut so that every returned event is still valid JSON.
+// Events larger than maxEventBytes are skipped, a later smaller event may still fit.
+func fitSampleEvents(sampleEvents []string, maxEventBytes, maxTotalBytes int) (events []string, omitted int) {
+	total := 0
+	for _, event := range sampleEvents {
+		if len(event) > maxEventBytes || total+len(event) > maxTotalBytes {
+			omitted++
+			continue
+		}
+		total += len(event)
+		events = append(events, event)
+	}
+	return events, omitted
+}
+
+// sampleEventsNote describes the sample events which did not fit in a message.
+func sampleEventsNote(omitted int) string {
+	if omitted == 0 {
+		return ""
+	}
+	if omitted == 1 {
+		return "1 more sample event omitted, view the alert in the Panther UI for all events"
+	}
+	return strconv.Itoa(omitted) + " more sample events omitted, view the alert in the Panther UI for all events"
+}
+
+// generateSampleEventsText renders sample events as a text section.
+//
+// Each event is wrapped in the given start and end markup (e.g. a markdown code block).
+// An empty string is returned if the alert has no sample events.
+func generateSampleEventsText(sampleEvents []string, heading, start, end string, maxTotalBytes int) string {
+	events, omitted := fitSampleEvents(sampleEvents, maxTotalBytes, maxTotalBytes)
+	if len(events) == 0 && omitted == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString(heading)
+	for _, event := range events {
+		builder.WriteString(start)
+		builder.WriteString(event)
+		builder.WriteString(end)
+	}
+	if note := sampleEventsNote(omitted); note != "" {
+		builder.WriteString("\n")
+		builder.WriteString(note)
+	}
+	return builder.String()
+}
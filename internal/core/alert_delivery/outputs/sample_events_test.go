@@ -0,0 +1,105 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+func TestFitSampleEvents(t *testing.T) {
+	sampleEvents := []string{`{"a":1}`, `{"b":"` + strings.Repeat("x", 20) + `"}`, `{"c":3}`, `{"d":4}`}
+
+	events, omitted := fitSampleEvents(sampleEvents, 10, 20)
+	assert.Equal(t, []string{`{"a":1}`, `{"c":3}`}, events)
+	assert.Equal(t, 2, omitted)
+
+	events, omitted = fitSampleEvents(nil, 10, 20)
+	assert.Empty(t, events)
+	assert.Equal(t, 0, omitted)
+}
+
+func TestGenerateSampleEventsText(t *testing.T) {
+	assert.Equal(t, "", generateSampleEventsText(nil, "Events:", "<", ">", 100))
+	assert.Equal(t, "Events:<{\"a\":1}><{\"b\":2}>",
+		generateSampleEventsText([]string{`{"a":1}`, `{"b":2}`}, "Events:", "<", ">", 100))
+	assert.Equal(t, "Events:<{\"a\":1}>\n1 more sample event omitted, view the alert in the Panther UI for all events",
+		generateSampleEventsText([]string{`{"a":1}`, `{"b":2}`}, "Events:", "<", ">", 10))
+	// Nothing fits, only the note is rendered
+	assert.Equal(t, "Events:\n2 more sample events omitted, view the alert in the Panther UI for all events",
+		generateSampleEventsText([]string{`{"a":1}`, `{"b":2}`}, "Events:", "<", ">", 5))
+}
+
+func TestSlackSampleEventBlocks(t *testing.T) {
+	assert.Nil(t, generateSlackSampleEventBlocks(&alertmodels.Alert{}))
+
+	alert := &alertmodels.Alert{
+		SampleEvents: []string{`{"a":1}`, `{"b":"` + strings.Repeat("x", slackMaxSectionBytes) + `"}`},
+	}
+	expected := []map[string]interface{}{
+		{"type": "section", "text": map[string]interface{}{"type": "mrkdwn", "text": "*Sample Events*"}},
+		{"type": "section", "text": map[string]interface{}{"type": "mrkdwn", "text": "```{\"a\":1}```"}},
+		{
+			"type": "context",
+			"elements": []map[string]interface{}{
+				{"type": "mrkdwn", "text": "1 more sample event omitted, view the alert in the Panther UI for all events"},
+			},
+		},
+	}
+	assert.Equal(t, expected, generateSlackSampleEventBlocks(alert))
+
+	// Slack control characters are escaped
+	alert.SampleEvents = []string{`{"html":"<a href='x'>a & b</a>"}`}
+	blocks := generateSlackSampleEventBlocks(alert)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, map[string]interface{}{"type": "mrkdwn", "text": "```{\"html\":\"&lt;a href='x'&gt;a &amp; b&lt;/a&gt;\"}```"},
+		blocks[1]["text"])
+}
+
+func TestCustomWebhookSampleEvents(t *testing.T) {
+	createdAtTime, err := time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+	require.NoError(t, err)
+	alert := &alertmodels.Alert{
+		PolicyID:     aws.String("ruleId"),
+		CreatedAt:    &createdAtTime,
+		Severity:     aws.String("INFO"),
+		SampleEvents: []string{`{"p_log_type":"AWS.S3","key":"value"}`, `{"invalid`},
+	}
+
+	message := generateCustomWebhookOutputMessage(alert)
+	assert.Equal(t, []jsoniter.RawMessage{jsoniter.RawMessage(`{"p_log_type":"AWS.S3","key":"value"}`)}, message.SampleEvents)
+
+	body, err := jsoniter.MarshalToString(message)
+	require.NoError(t, err)
+	assert.Contains(t, body, `"sampleEvents":[{"p_log_type":"AWS.S3","key":"value"}]`)
+
+	// No sample events are an empty array rather than null
+	alert.SampleEvents = nil
+	body, err = jsoniter.MarshalToString(generateCustomWebhookOutputMessage(alert))
+	require.NoError(t, err)
+	assert.Contains(t, body, `"sampleEvents":[]`)
+}
@@ -20,6 +20,7 @@ package outputs
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 
@@ -58,16 +59,24 @@ func (client *OutputClient) Slack(alert *alertmodels.Alert, config *outputmodels
 		},
 	}
 
-	payload := map[string]interface{}{
-		"attachments": []map[string]interface{}{
-			{
-				"fallback": generateAlertTitle(alert),
-				"color":    severityColors[aws.StringValue(alert.Severity)],
-				"title":    generateAlertTitle(alert),
-				"fields":   fields,
-			},
+	attachments := []map[string]interface{}{
+		{
+			"fallback": generateAlertTitle(alert),
+			"color":    severityColors[aws.StringValue(alert.Severity)],
+			"title":    generateAlertTitle(alert),
+			"fields":   fields,
 		},
 	}
+	if blocks := generateSlackSampleEventBlocks(alert); len(blocks) > 0 {
+		attachments = append(attachments, map[string]interface{}{
+			"color":  severityColors[aws.StringValue(alert.Severity)],
+			"blocks": blocks,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"attachments": attachments,
+	}
 	requestEndpoint := config.WebhookURL
 	postInput := &PostInput{
 		url:  requestEndpoint,
@@ -76,3 +85,39 @@ func (client *OutputClient) Slack(alert *alertmodels.Alert, config *outputmodels
 
 	return client.httpWrapper.post(postInput)
 }
+
+// Slack mrkdwn treats these characters as control characters, even in code blocks
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// generateSlackSampleEventBlocks renders each sample event as a code block in its own section.
+func generateSlackSampleEventBlocks(alert *alertmodels.Alert) []map[string]interface{} {
+	const codeStart, codeEnd = "```", "```"
+	// Events are escaped before they are measured, the escaped text is what counts towards the limits
+	escaped := make([]string, len(alert.SampleEvents))
+	for i, event := range alert.SampleEvents {
+		escaped[i] = slackEscaper.Replace(event)
+	}
+	events, omitted := fitSampleEvents(escaped, slackMaxSectionBytes-len(codeStart+codeEnd), slackMaxSampleEventBytes)
+	if len(events) == 0 && omitted == 0 {
+		return nil
+	}
+
+	blocks := []map[string]interface{}{slackTextBlock("section", "*Sample Events*")}
+	for _, event := range events {
+		blocks = append(blocks, slackTextBlock("section", codeStart+event+codeEnd))
+	}
+	if note := sampleEventsNote(omitted); note != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type":     "context",
+			"elements": []map[string]interface{}{{"type": "mrkdwn", "text": note}},
+		})
+	}
+	return blocks
+}
+
+func slackTextBlock(blockType, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": blockType,
+		"text": map[string]interface{}{"type": "mrkdwn", "text": text},
+	}
+}
@@ -0,0 +1,65 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+const (
+	splunkSource            = "panther"
+	splunkDefaultSourceType = "panther:alert"
+)
+
+// splunkEvent is the envelope expected by the Splunk HTTP Event Collector
+type splunkEvent struct {
+	Time       int64                       `json:"time"`
+	Source     string                      `json:"source"`
+	SourceType string                      `json:"sourcetype"`
+	Index      string                      `json:"index,omitempty"`
+	Event      *CustomWebhookOutputMessage `json:"event"`
+}
+
+// Splunk sends an alert to a Splunk HTTP Event Collector.
+func (client *OutputClient) Splunk(alert *alertmodels.Alert, config *outputmodels.SplunkConfig) *AlertDeliveryError {
+	sourceType := config.SourceType
+	if sourceType == "" {
+		sourceType = splunkDefaultSourceType
+	}
+
+	event := &splunkEvent{
+		Time:       aws.TimeValue(alert.CreatedAt).Unix(),
+		Source:     splunkSource,
+		SourceType: sourceType,
+		Index:      config.Index,
+		Event:      generateCustomWebhookOutputMessage(alert),
+	}
+
+	postInput := &PostInput{
+		url:  config.URL,
+		body: event,
+		headers: map[string]string{
+			AuthorizationHTTPHeader: "Splunk " + config.Token,
+		},
+	}
+	return client.httpWrapper.post(postInput)
+}
@@ -0,0 +1,248 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+const (
+	syslogTimeout = 30 * time.Second
+	// All messages are sent with the local0 facility
+	syslogFacility = 16
+	syslogAppName  = "panther"
+	syslogMsgID    = "alert"
+	// Structured data ID, using the IANA enterprise number reserved for documentation (RFC5612)
+	syslogSDID = "panther@32473"
+
+	// Vendor, product and version reported in CEF and LEEF headers
+	siemVendor  = "Panther"
+	siemProduct = "Panther"
+	siemVersion = "1.0"
+)
+
+// Panther severities mapped to syslog severities (RFC5424 section 6.2.1)
+var pantherToSyslogSeverity = map[string]int{
+	"CRITICAL": 2,
+	"HIGH":     3,
+	"MEDIUM":   4,
+	"LOW":      5,
+	"INFO":     6,
+}
+
+// Panther severities mapped to the 0-10 scale used by CEF and LEEF
+var pantherToSIEMSeverity = map[string]int{
+	"CRITICAL": 10,
+	"HIGH":     8,
+	"MEDIUM":   5,
+	"LOW":      3,
+	"INFO":     1,
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	leefValueEscaper    = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	sdParamEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+)
+
+// SyslogInput is a single framed syslog message to be written to a collector
+type SyslogInput struct {
+	config  *outputmodels.SyslogConfig
+	message string
+}
+
+// SyslogWriter is the interface for our syslog client, which can be replaced with a stand-in for testing.
+type SyslogWriter interface {
+	write(*SyslogInput) *AlertDeliveryError
+}
+
+// TCPSyslogWriter sends syslog messages over TCP or TLS (RFC5425 / RFC6587)
+type TCPSyslogWriter struct {
+	// tlsConfig overrides the default TLS settings (only used in tests)
+	tlsConfig *tls.Config
+}
+
+// Syslog sends an alert to a syslog collector.
+func (client *OutputClient) Syslog(alert *alertmodels.Alert, config *outputmodels.SyslogConfig) *AlertDeliveryError {
+	message, err := generateSyslogMessage(alert, config.Format, time.Now().UTC())
+	if err != nil {
+		return &AlertDeliveryError{Message: "syslog message error: " + err.Error(), Permanent: true}
+	}
+	return client.syslog.write(&SyslogInput{config: config, message: message})
+}
+
+// write sends one message using octet-counting framing.
+func (w *TCPSyslogWriter) write(input *SyslogInput) *AlertDeliveryError {
+	config := input.config
+	address := net.JoinHostPort(config.Host, strconv.Itoa(syslogPort(config)))
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+
+	var conn net.Conn
+	var err error
+	if config.Protocol == outputmodels.SyslogProtocolTCP {
+		conn, err = dialer.Dial("tcp", address)
+	} else {
+		tlsConfig := w.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	}
+	if err != nil {
+		return &AlertDeliveryError{Message: "network error: " + err.Error()}
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return &AlertDeliveryError{Message: "network error: " + err.Error()}
+	}
+	if _, err = fmt.Fprintf(conn, "%d %s", len(input.message), input.message); err != nil {
+		return &AlertDeliveryError{Message: "network error: " + err.Error()}
+	}
+	return nil
+}
+
+// syslogPort returns the configured port, or the standard port for the protocol.
+func syslogPort(config *outputmodels.SyslogConfig) int {
+	if config.Port != 0 {
+		return config.Port
+	}
+	if config.Protocol == outputmodels.SyslogProtocolTCP {
+		return 514
+	}
+	return 6514
+}
+
+// generateSyslogMessage builds an RFC5424 message whose body is in the requested format.
+func generateSyslogMessage(alert *alertmodels.Alert, format string, now time.Time) (string, error) {
+	var body string
+	switch format {
+	case outputmodels.SyslogFormatCEF:
+		body = generateCEFMessage(alert)
+	case outputmodels.SyslogFormatLEEF:
+		body = generateLEEFMessage(alert)
+	default:
+		var err error
+		if body, err = jsoniter.MarshalToString(generateCustomWebhookOutputMessage(alert)); err != nil {
+			return "", err
+		}
+	}
+
+	severity, ok := pantherToSyslogSeverity[aws.StringValue(alert.Severity)]
+	if !ok {
+		severity = pantherToSyslogSeverity["INFO"]
+	}
+
+	structuredData := fmt.Sprintf(`[%s alertId="%s" policyId="%s" severity="%s"]`,
+		syslogSDID,
+		sdParamEscaper.Replace(aws.StringValue(alert.AlertID)),
+		sdParamEscaper.Replace(aws.StringValue(alert.PolicyID)),
+		sdParamEscaper.Replace(aws.StringValue(alert.Severity)),
+	)
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf("<%d>1 %s - %s - %s %s %s",
+		syslogFacility*8+severity,
+		now.Format(time.RFC3339Nano),
+		syslogAppName,
+		syslogMsgID,
+		structuredData,
+		body,
+	), nil
+}
+
+// generateCEFMessage formats an alert as an ArcSight Common Event Format message.
+func generateCEFMessage(alert *alertmodels.Alert) string {
+	header := strings.Join([]string{
+		"CEF:0",
+		cefHeaderEscaper.Replace(siemVendor),
+		cefHeaderEscaper.Replace(siemProduct),
+		cefHeaderEscaper.Replace(siemVersion),
+		cefHeaderEscaper.Replace(aws.StringValue(alert.PolicyID)),
+		cefHeaderEscaper.Replace(generateAlertTitle(alert)),
+		strconv.Itoa(pantherToSIEMSeverity[aws.StringValue(alert.Severity)]),
+	}, "|")
+
+	// Custom string extensions (csN) are described by a matching csNLabel
+	extensions := []struct {
+		key, label, value string
+	}{
+		{key: "rt", value: strconv.FormatInt(aws.TimeValue(alert.CreatedAt).UnixNano()/int64(time.Millisecond), 10)},
+		{key: "cat", value: aws.StringValue(alert.Type)},
+		{key: "cs1", label: "alertId", value: aws.StringValue(alert.AlertID)},
+		{key: "cs2", label: "tags", value: strings.Join(aws.StringValueSlice(alert.Tags), ",")},
+		{key: "cs3", label: "runbook", value: aws.StringValue(alert.Runbook)},
+		{key: "request", value: generateURL(alert)},
+		{key: "msg", value: aws.StringValue(alert.PolicyDescription)},
+	}
+	pairs := make([]string, 0, 2*len(extensions))
+	for _, extension := range extensions {
+		if extension.value == "" {
+			continue
+		}
+		if extension.label != "" {
+			pairs = append(pairs, extension.key+"Label="+extension.label)
+		}
+		pairs = append(pairs, extension.key+"="+cefExtensionEscaper.Replace(extension.value))
+	}
+	return header + "|" + strings.Join(pairs, " ")
+}
+
+// generateLEEFMessage formats an alert as an IBM QRadar Log Event Extended Format (1.0) message.
+func generateLEEFMessage(alert *alertmodels.Alert) string {
+	header := strings.Join([]string{
+		"LEEF:1.0",
+		siemVendor,
+		siemProduct,
+		siemVersion,
+		aws.StringValue(alert.PolicyID),
+	}, "|")
+
+	attributes := [][2]string{
+		{"devTime", aws.TimeValue(alert.CreatedAt).UTC().Format("Jan 02 2006 15:04:05")},
+		{"devTimeFormat", "MMM dd yyyy HH:mm:ss"},
+		{"sev", strconv.Itoa(pantherToSIEMSeverity[aws.StringValue(alert.Severity)])},
+		{"cat", aws.StringValue(alert.Type)},
+		{"alertId", aws.StringValue(alert.AlertID)},
+		{"title", generateAlertTitle(alert)},
+		{"tags", strings.Join(aws.StringValueSlice(alert.Tags), ",")},
+		{"url", generateURL(alert)},
+		{"description", aws.StringValue(alert.PolicyDescription)},
+	}
+	pairs := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		if attribute[1] == "" {
+			continue
+		}
+		pairs = append(pairs, attribute[0]+"="+leefValueEscaper.Replace(attribute[1]))
+	}
+	return header + "|" + strings.Join(pairs, "\t")
+}
@@ -0,0 +1,154 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+type mockSyslogWriter struct {
+	mock.Mock
+}
+
+func (m *mockSyslogWriter) write(input *SyslogInput) *AlertDeliveryError {
+	args := m.Called(input)
+	return args.Get(0).(*AlertDeliveryError)
+}
+
+var syslogCreatedAt, _ = time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+
+var syslogAlert = &alertmodels.Alert{
+	AlertID:           aws.String("alertId"),
+	PolicyID:          aws.String("rule|id"),
+	PolicyName:        aws.String("ruleName"),
+	PolicyDescription: aws.String("a=b\nc"),
+	Type:              aws.String(alertmodels.RuleType),
+	Severity:          aws.String("HIGH"),
+	Tags:              aws.StringSlice([]string{"tag1", "tag2"}),
+	CreatedAt:         &syslogCreatedAt,
+}
+
+func TestSyslogAlert(t *testing.T) {
+	writer := &mockSyslogWriter{}
+	client := &OutputClient{syslog: writer}
+	config := &outputmodels.SyslogConfig{Host: "siem.example.com", Format: outputmodels.SyslogFormatCEF}
+
+	writer.On("write", mock.Anything).Return((*AlertDeliveryError)(nil))
+	require.Nil(t, client.Syslog(syslogAlert, config))
+	writer.AssertExpectations(t)
+
+	input := writer.Calls[0].Arguments.Get(0).(*SyslogInput)
+	assert.Equal(t, config, input.config)
+	assert.True(t, strings.HasPrefix(input.message, "<131>1 "))
+	assert.Contains(t, input.message, " CEF:0|Panther|Panther|1.0|")
+}
+
+func TestGenerateSyslogMessageRFC5424(t *testing.T) {
+	message, err := generateSyslogMessage(syslogAlert, "", syslogCreatedAt)
+	require.NoError(t, err)
+	expectedPrefix := `<131>1 2019-08-03T11:40:13Z - panther - alert ` +
+		`[panther@32473 alertId="alertId" policyId="rule|id" severity="HIGH"] {"analysisId":"rule|id"`
+	assert.True(t, strings.HasPrefix(message, expectedPrefix), message)
+}
+
+func TestGenerateCEFMessage(t *testing.T) {
+	expected := `CEF:0|Panther|Panther|1.0|rule\|id|New Alert: ruleName|8|` +
+		`rt=1564832413000 cat=RULE cs1Label=alertId cs1=alertId cs2Label=tags cs2=tag1,tag2 ` +
+		`request=https://panther.io/alerts/alertId msg=a\=b\nc`
+	assert.Equal(t, expected, generateCEFMessage(syslogAlert))
+}
+
+func TestGenerateLEEFMessage(t *testing.T) {
+	expected := "LEEF:1.0|Panther|Panther|1.0|rule|id|" + strings.Join([]string{
+		"devTime=Aug 03 2019 11:40:13",
+		"devTimeFormat=MMM dd yyyy HH:mm:ss",
+		"sev=8",
+		"cat=RULE",
+		"alertId=alertId",
+		"title=New Alert: ruleName",
+		"tags=tag1,tag2",
+		"url=https://panther.io/alerts/alertId",
+		"description=a=b c",
+	}, "\t")
+	assert.Equal(t, expected, generateLEEFMessage(syslogAlert))
+}
+
+func TestSyslogPort(t *testing.T) {
+	assert.Equal(t, 6514, syslogPort(&outputmodels.SyslogConfig{}))
+	assert.Equal(t, 514, syslogPort(&outputmodels.SyslogConfig{Protocol: outputmodels.SyslogProtocolTCP}))
+	assert.Equal(t, 1514, syslogPort(&outputmodels.SyslogConfig{Port: 1514}))
+}
+
+func TestTCPSyslogWriter(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	input := &SyslogInput{
+		config: &outputmodels.SyslogConfig{
+			Host:     host,
+			Port:     portNumber,
+			Protocol: outputmodels.SyslogProtocolTCP,
+		},
+		message: "<134>1 message\n",
+	}
+	require.Nil(t, (&TCPSyslogWriter{}).write(input))
+	assert.Equal(t, "15 <134>1 message\n", <-received)
+}
+
+func TestTCPSyslogWriterConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	listener.Close()
+
+	input := &SyslogInput{
+		config: &outputmodels.SyslogConfig{Host: host, Port: portNumber, Protocol: outputmodels.SyslogProtocolTCP},
+	}
+	result := (&TCPSyslogWriter{}).write(input)
+	require.NotNil(t, result)
+	assert.False(t, result.Permanent)
+}
@@ -280,6 +280,13 @@ func typeNormalizeTableItem(item *tableItem, config analysis.Config) {
 		} else {
 			item.DedupPeriodMinutes = models.DedupPeriodMinutes(config.DedupPeriodMinutes)
 		}
+		// If there is no threshold set, alert on the first matching event
+		if config.Threshold == 0 {
+			item.Threshold = defaultThreshold
+		} else {
+			item.Threshold = models.Threshold(config.Threshold)
+		}
+		item.ThresholdWindowMinutes = models.ThresholdWindowMinutes(config.ThresholdWindowMinutes)
 
 		// These "syntax sugar" re-mappings are to make managing rules from the CLI more intuitive
 		if config.PolicyID == "" {
@@ -365,5 +372,16 @@ func validateUploadedPolicy(item *tableItem, userID models.UserID) error {
 		return fmt.Errorf("policy ID %s invalid: display name: %v", policy.ID, genericapi.ErrContainsHTML)
 	}
 
+	if item.Type == typeRule {
+		if err := item.Threshold.Validate(nil); err != nil {
+			return fmt.Errorf("rule ID %s is invalid: threshold: %s", policy.ID, err)
+		}
+		if item.ThresholdWindowMinutes != 0 {
+			if err := item.ThresholdWindowMinutes.Validate(nil); err != nil {
+				return fmt.Errorf("rule ID %s is invalid: threshold window: %s", policy.ID, err)
+			}
+		}
+	}
+
 	return nil
 }
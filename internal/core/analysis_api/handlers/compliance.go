@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	complianceops "github.com/panther-labs/panther/api/gateway/compliance/client/operations"
 	compliancemodels "github.com/panther-labs/panther/api/gateway/compliance/models"
 )
@@ -166,9 +167,11 @@ func updateComplianceStatus(oldItem, newItem *tableItem) error {
 	// At this point, we know the compliance value (PASS/FAIL) won't change for any (policy, resource) pairs.
 	// In other words, we don't need to re-evaluate the policy with the Python engine.
 	//
-	// But the compliance table has columns for severity and suppression -
-	// if either of those changed, we can update the compliance API directly.
-	if oldItem.Severity != newItem.Severity || !setEquality(oldItem.Suppressions, newItem.Suppressions) {
+	// But the compliance table has columns for severity, suppression and report mappings -
+	// if any of those changed, we can update the compliance API directly.
+	if oldItem.Severity != newItem.Severity || !setEquality(oldItem.Suppressions, newItem.Suppressions) ||
+		!compliance.ReportsEqual(oldItem.Reports, newItem.Reports) {
+
 		return updateComplianceMetadata(newItem)
 	}
 
@@ -178,7 +181,7 @@ func updateComplianceStatus(oldItem, newItem *tableItem) error {
 
 // Update compliance status entries directly.
 //
-// This is used when only the policy severity / suppressions change - we don't need to rescan
+// This is used when only the policy severity / suppressions / reports change - we don't need to rescan
 // all affected resources in this case.
 func updateComplianceMetadata(policy *tableItem) error {
 	zap.L().Info("updating compliance status entry",
@@ -187,6 +190,7 @@ func updateComplianceMetadata(policy *tableItem) error {
 	_, err := complianceClient.Operations.UpdateMetadata(&complianceops.UpdateMetadataParams{
 		Body: &compliancemodels.UpdateMetadata{
 			PolicyID:     compliancemodels.PolicyID(policy.ID),
+			Reports:      compliancemodels.PolicyReports(policy.Reports),
 			Severity:     compliancemodels.PolicySeverity(policy.Severity),
 			Suppressions: compliancemodels.IgnoreSet(policy.Suppressions),
 		},
@@ -47,6 +47,7 @@ func CreatePolicy(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 		Enabled:                   input.Enabled,
 		ID:                        input.ID,
 		Reference:                 input.Reference,
+		Reports:                   input.Reports,
 		ResourceTypes:             input.ResourceTypes,
 		Runbook:                   input.Runbook,
 		Severity:                  input.Severity,
@@ -33,6 +33,7 @@ import (
 
 const (
 	defaultDedupPeriodMinutes = 60
+	defaultThreshold          = 1
 )
 
 // CreateRule adds a new rule to the Dynamo table.
@@ -43,19 +44,21 @@ func CreateRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyR
 	}
 
 	item := &tableItem{
-		Body:               input.Body,
-		Description:        input.Description,
-		DisplayName:        input.DisplayName,
-		Enabled:            input.Enabled,
-		ID:                 input.ID,
-		Reference:          input.Reference,
-		ResourceTypes:      input.LogTypes,
-		Runbook:            input.Runbook,
-		Severity:           input.Severity,
-		Tags:               input.Tags,
-		Tests:              input.Tests,
-		Type:               typeRule,
-		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		Body:                   input.Body,
+		Description:            input.Description,
+		DisplayName:            input.DisplayName,
+		Enabled:                input.Enabled,
+		ID:                     input.ID,
+		Reference:              input.Reference,
+		ResourceTypes:          input.LogTypes,
+		Runbook:                input.Runbook,
+		Severity:               input.Severity,
+		Tags:                   input.Tags,
+		Tests:                  input.Tests,
+		Type:                   typeRule,
+		DedupPeriodMinutes:     input.DedupPeriodMinutes,
+		Threshold:              input.Threshold,
+		ThresholdWindowMinutes: input.ThresholdWindowMinutes,
 	}
 
 	if _, err := writeItem(item, input.UserID, aws.Bool(false)); err != nil {
@@ -79,6 +82,10 @@ func parseUpdateRule(request *events.APIGatewayProxyRequest) (*models.UpdateRule
 	if result.DedupPeriodMinutes == 0 {
 		result.DedupPeriodMinutes = defaultDedupPeriodMinutes
 	}
+	// By default an alert is sent for the first matching event
+	if result.Threshold == 0 {
+		result.Threshold = defaultThreshold
+	}
 
 	if err := result.Validate(nil); err != nil {
 		return nil, err
@@ -68,6 +68,8 @@ type tableItem struct {
 	VersionID                 models.VersionID                 `json:"versionId,omitempty"`
 	DedupPeriodMinutes        models.DedupPeriodMinutes        `json:"dedupPeriodMinutes,omitempty"`
 	Reports                   models.Reports                   `json:"reports,omitempty"`
+	Threshold                 models.Threshold                 `json:"threshold,omitempty"`
+	ThresholdWindowMinutes    models.ThresholdWindowMinutes    `json:"thresholdWindowMinutes,omitempty"`
 
 	// Logic type (policy or rule)
 	Type string `json:"type"`
@@ -109,6 +111,7 @@ func (r *tableItem) Policy(status models.ComplianceStatus) *models.Policy {
 		LastModified:              r.LastModified,
 		LastModifiedBy:            r.LastModifiedBy,
 		Reference:                 r.Reference,
+		Reports:                   r.Reports,
 		ResourceTypes:             r.ResourceTypes,
 		Runbook:                   r.Runbook,
 		Severity:                  r.Severity,
@@ -132,6 +135,7 @@ func (r *tableItem) PolicySummary(status models.ComplianceStatus) *models.Policy
 		Enabled:                   r.Enabled,
 		ID:                        r.ID,
 		LastModified:              r.LastModified,
+		Reports:                   r.Reports,
 		ResourceTypes:             r.ResourceTypes,
 		Severity:                  r.Severity,
 		Suppressions:              r.Suppressions,
@@ -145,23 +149,25 @@ func (r *tableItem) PolicySummary(status models.ComplianceStatus) *models.Policy
 func (r *tableItem) Rule() *models.Rule {
 	r.normalize()
 	result := &models.Rule{
-		Body:               r.Body,
-		CreatedAt:          r.CreatedAt,
-		CreatedBy:          r.CreatedBy,
-		Description:        r.Description,
-		DisplayName:        r.DisplayName,
-		Enabled:            r.Enabled,
-		ID:                 r.ID,
-		LastModified:       r.LastModified,
-		LastModifiedBy:     r.LastModifiedBy,
-		LogTypes:           r.ResourceTypes,
-		Reference:          r.Reference,
-		Runbook:            r.Runbook,
-		Severity:           r.Severity,
-		Tags:               r.Tags,
-		Tests:              r.Tests,
-		VersionID:          r.VersionID,
-		DedupPeriodMinutes: r.DedupPeriodMinutes,
+		Body:                   r.Body,
+		CreatedAt:              r.CreatedAt,
+		CreatedBy:              r.CreatedBy,
+		Description:            r.Description,
+		DisplayName:            r.DisplayName,
+		Enabled:                r.Enabled,
+		ID:                     r.ID,
+		LastModified:           r.LastModified,
+		LastModifiedBy:         r.LastModifiedBy,
+		LogTypes:               r.ResourceTypes,
+		Reference:              r.Reference,
+		Runbook:                r.Runbook,
+		Severity:               r.Severity,
+		Tags:                   r.Tags,
+		Tests:                  r.Tests,
+		VersionID:              r.VersionID,
+		DedupPeriodMinutes:     r.DedupPeriodMinutes,
+		Threshold:              r.Threshold,
+		ThresholdWindowMinutes: r.ThresholdWindowMinutes,
 	}
 	gatewayapi.ReplaceMapSliceNils(result)
 	return result
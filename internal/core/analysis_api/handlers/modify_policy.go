@@ -43,6 +43,7 @@ func ModifyPolicy(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 		Enabled:                   input.Enabled,
 		ID:                        input.ID,
 		Reference:                 input.Reference,
+		Reports:                   input.Reports,
 		ResourceTypes:             input.ResourceTypes,
 		Runbook:                   input.Runbook,
 		Severity:                  input.Severity,
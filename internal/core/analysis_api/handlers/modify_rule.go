@@ -35,19 +35,21 @@ func ModifyRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyR
 	}
 
 	item := &tableItem{
-		Body:               input.Body,
-		Description:        input.Description,
-		DisplayName:        input.DisplayName,
-		Enabled:            input.Enabled,
-		ID:                 input.ID,
-		Reference:          input.Reference,
-		ResourceTypes:      input.LogTypes,
-		Runbook:            input.Runbook,
-		Severity:           input.Severity,
-		Tags:               input.Tags,
-		Tests:              input.Tests,
-		Type:               typeRule,
-		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		Body:                   input.Body,
+		Description:            input.Description,
+		DisplayName:            input.DisplayName,
+		Enabled:                input.Enabled,
+		ID:                     input.ID,
+		Reference:              input.Reference,
+		ResourceTypes:          input.LogTypes,
+		Runbook:                input.Runbook,
+		Severity:               input.Severity,
+		Tags:                   input.Tags,
+		Tests:                  input.Tests,
+		Type:                   typeRule,
+		DedupPeriodMinutes:     input.DedupPeriodMinutes,
+		Threshold:              input.Threshold,
+		ThresholdWindowMinutes: input.ThresholdWindowMinutes,
 	}
 
 	if _, err := writeItem(item, input.UserID, aws.Bool(true)); err != nil {
@@ -32,6 +32,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/api/gateway/compliance"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
@@ -211,8 +212,10 @@ func itemUpdated(oldItem, newItem *tableItem) bool {
 		oldItem.Enabled == newItem.Enabled && oldItem.Reference == newItem.Reference &&
 		oldItem.Runbook == newItem.Runbook && oldItem.Severity == newItem.Severity &&
 		oldItem.DedupPeriodMinutes == newItem.DedupPeriodMinutes &&
+		oldItem.Threshold == newItem.Threshold && oldItem.ThresholdWindowMinutes == newItem.ThresholdWindowMinutes &&
 		setEquality(oldItem.ResourceTypes, newItem.ResourceTypes) &&
 		setEquality(oldItem.Suppressions, newItem.Suppressions) && setEquality(oldItem.Tags, newItem.Tags) &&
+		compliance.ReportsEqual(oldItem.Reports, newItem.Reports) &&
 		len(oldItem.AutoRemediationParameters) == len(newItem.AutoRemediationParameters) &&
 		len(oldItem.Tests) == len(newItem.Tests)
 
@@ -61,6 +61,7 @@ func (API) AddOutput(input *models.AddOutputInput) (*models.AddOutputOutput, err
 		OutputType:         outputType,
 		OutputConfig:       input.OutputConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
+		Digest:             input.Digest,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
@@ -71,6 +71,7 @@ func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOu
 		OutputID:           input.OutputID,
 		OutputConfig:       newConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
+		Digest:             input.Digest,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
@@ -42,6 +42,7 @@ func AlertOutputToItem(input *models.AlertOutput) (*table.AlertOutputItem, error
 		OutputID:           input.OutputID,
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
+		Digest:             input.Digest,
 	}
 
 	if input.OutputConfig != nil {
@@ -66,6 +67,7 @@ func ItemToAlertOutput(input *table.AlertOutputItem) (alertOutput *models.AlertO
 		OutputID:           input.OutputID,
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
+		Digest:             input.Digest,
 	}
 
 	// Decrypt the output before returning to the caller
@@ -87,9 +89,11 @@ func redactOutput(outputConfig *models.OutputConfig) {
 	}
 	if outputConfig.Github != nil {
 		outputConfig.Github.Token = redacted
+		outputConfig.Github.WebhookSecret = redacted
 	}
 	if outputConfig.Jira != nil {
 		outputConfig.Jira.APIKey = redacted
+		outputConfig.Jira.WebhookSecret = redacted
 	}
 	if outputConfig.Opsgenie != nil {
 		outputConfig.Opsgenie.APIKey = redacted
@@ -102,6 +106,18 @@ func redactOutput(outputConfig *models.OutputConfig) {
 	}
 	if outputConfig.CustomWebhook != nil {
 		outputConfig.CustomWebhook.WebhookURL = redacted
+		outputConfig.CustomWebhook.AuthSecret = redacted
+		outputConfig.CustomWebhook.SigningSecret = redacted
+		// Headers usually carry credentials, only their names are returned
+		for _, header := range outputConfig.CustomWebhook.Headers {
+			header.Value = redacted
+		}
+	}
+	if outputConfig.Email != nil {
+		outputConfig.Email.Password = redacted
+	}
+	if outputConfig.Splunk != nil {
+		outputConfig.Splunk.Token = redacted
 	}
 }
 
@@ -136,6 +152,15 @@ func getOutputType(outputConfig *models.OutputConfig) (*string, error) {
 	if outputConfig.CustomWebhook != nil {
 		return aws.String("customwebhook"), nil
 	}
+	if outputConfig.Email != nil {
+		return aws.String("email"), nil
+	}
+	if outputConfig.Syslog != nil {
+		return aws.String("syslog"), nil
+	}
+	if outputConfig.Splunk != nil {
+		return aws.String("splunk"), nil
+	}
 
 	return nil, errors.New("no valid output configuration specified for alert output")
 }
@@ -151,8 +176,9 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 			Message: "Unable to extract existing configuration from dynamo",
 		}
 	}
-	// Turn the bytes into a map so we can work with it more easily
-	var oldMap map[string]map[string]string
+	// Turn the bytes into a map so we can work with it more easily.
+	// Values are not all strings (e.g. Asana project IDs, the Email port and recipients).
+	var oldMap map[string]map[string]interface{}
 	err = jsoniter.Unmarshal(oldBytes, &oldMap)
 	if err != nil {
 		return nil, &genericapi.InternalError{
@@ -167,7 +193,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 			Message: "Unable to extract the new configuration",
 		}
 	}
-	var newMap map[string]map[string]string
+	var newMap map[string]map[string]interface{}
 	err = jsoniter.Unmarshal(newBytes, &newMap)
 	if err != nil {
 		return nil, &genericapi.InternalError{
@@ -178,7 +204,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 	// Overwrite the existing configurations with the new configurations
 	for configType, configMap := range newMap {
 		for configKey, configValue := range configMap {
-			if configValue == "" {
+			if configValue == nil || configValue == "" {
 				continue
 			}
 			oldMap[configType][configKey] = configValue
@@ -202,9 +228,26 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 		}
 	}
 
+	if oldConfig.CustomWebhook != nil && combinedConfig.CustomWebhook != nil {
+		keepHeaderValues(oldConfig.CustomWebhook.Headers, combinedConfig.CustomWebhook.Headers)
+	}
 	return combinedConfig, nil
 }
 
+// Header values are redacted when outputs are returned, so a header sent back without a value
+// keeps the value it had before.
+func keepHeaderValues(oldHeaders, newHeaders []*models.CustomWebhookHeader) {
+	oldValues := make(map[string]string, len(oldHeaders))
+	for _, header := range oldHeaders {
+		oldValues[header.Key] = header.Value
+	}
+	for _, header := range newHeaders {
+		if header.Value == redacted {
+			header.Value = oldValues[header.Key]
+		}
+	}
+}
+
 func validateConfigByType(config *models.OutputConfig, outputType *string) error {
 	switch *outputType {
 	case "slack":
@@ -245,7 +288,19 @@ func validateConfigByType(config *models.OutputConfig, outputType *string) error
 			return nil
 		}
 	case "customwebhook":
-		if config.CustomWebhook.WebhookURL != "" {
+		if config.CustomWebhook.WebhookURL != "" && (config.CustomWebhook.AuthType == "" || config.CustomWebhook.AuthSecret != "") {
+			return nil
+		}
+	case "email":
+		if config.Email.Host != "" && config.Email.From != "" && len(config.Email.Recipients) != 0 {
+			return nil
+		}
+	case "syslog":
+		if config.Syslog.Host != "" {
+			return nil
+		}
+	case "splunk":
+		if config.Splunk.URL != "" && config.Splunk.Token != "" {
 			return nil
 		}
 	}
@@ -0,0 +1,63 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestRedactOutputCustomWebhookHeaders(t *testing.T) {
+	config := &models.OutputConfig{CustomWebhook: &models.CustomWebhookConfig{
+		WebhookURL: "https://example.com/hook",
+		Headers: []*models.CustomWebhookHeader{
+			{Key: "Authorization", Value: "Bearer secret"},
+			{Key: "X-Api-Key", Value: "key"},
+		},
+	}}
+
+	redactOutput(config)
+	assert.Equal(t, redacted, config.CustomWebhook.WebhookURL)
+	assert.Equal(t, []*models.CustomWebhookHeader{
+		{Key: "Authorization", Value: redacted},
+		{Key: "X-Api-Key", Value: redacted},
+	}, config.CustomWebhook.Headers)
+}
+
+func TestKeepHeaderValues(t *testing.T) {
+	oldHeaders := []*models.CustomWebhookHeader{
+		{Key: "Authorization", Value: "Bearer secret"},
+		{Key: "X-Api-Key", Value: "key"},
+	}
+	newHeaders := []*models.CustomWebhookHeader{
+		{Key: "Authorization", Value: redacted},
+		{Key: "X-Api-Key", Value: "new-key"},
+		{Key: "X-Team", Value: redacted},
+	}
+
+	keepHeaderValues(oldHeaders, newHeaders)
+	assert.Equal(t, []*models.CustomWebhookHeader{
+		{Key: "Authorization", Value: "Bearer secret"},
+		{Key: "X-Api-Key", Value: "new-key"},
+		{Key: "X-Team", Value: ""},
+	}, newHeaders)
+}
@@ -44,6 +44,12 @@ func (table *OutputsTable) UpdateOutput(alertOutput *AlertOutputItem) (*AlertOut
 	if alertOutput.DefaultForSeverity != nil {
 		updateExpression.Set(expression.Name("defaultForSeverity"), expression.Value(alertOutput.DefaultForSeverity))
 	}
+	if alertOutput.Digest != nil {
+		updateExpression.Set(expression.Name("digest"), expression.Value(alertOutput.Digest))
+	} else {
+		// A missing digest turns digest delivery off
+		updateExpression.Remove(expression.Name("digest"))
+	}
 
 	conditionExpression := expression.Name("outputId").Equal(expression.Value(alertOutput.OutputID))
 	combinedExpression, err := expression.NewBuilder().
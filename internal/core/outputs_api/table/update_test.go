@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
@@ -57,7 +58,8 @@ func TestUpdateOutput(t *testing.T) {
 		Set(expression.Name("lastModifiedTime"), expression.Value(mockUpdateItemAlertOutput.LastModifiedTime)).
 		Set(expression.Name("displayName"), expression.Value(mockUpdateItemAlertOutput.DisplayName)).
 		Set(expression.Name("encryptedConfig"), expression.Value(mockUpdateItemAlertOutput.EncryptedConfig)).
-		Set(expression.Name("defaultForSeverity"), expression.Value(mockUpdateItemAlertOutput.DefaultForSeverity))
+		Set(expression.Name("defaultForSeverity"), expression.Value(mockUpdateItemAlertOutput.DefaultForSeverity)).
+		Remove(expression.Name("digest"))
 
 	expectedConditionExpression := expression.Name("outputId").Equal(expression.Value(mockUpdateItemAlertOutput.OutputID))
 
@@ -88,6 +90,23 @@ func TestUpdateOutput(t *testing.T) {
 	dynamoDBClient.AssertExpectations(t)
 }
 
+func TestUpdateOutputSetsDigest(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &OutputsTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	item := *mockUpdateItemAlertOutput
+	item.Digest = &models.DigestConfig{Severities: aws.StringSlice([]string{"INFO"}), WindowMins: 60}
+
+	dynamoDBClient.On("UpdateItem", mock.Anything).Return(mockUpdateItemOutput, nil)
+	_, err := table.UpdateOutput(&item)
+	assert.NoError(t, err)
+
+	input := dynamoDBClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Contains(t, *input.UpdateExpression, "SET")
+	assert.NotContains(t, *input.UpdateExpression, "REMOVE")
+	dynamoDBClient.AssertExpectations(t)
+}
+
 func TestUpdateOutputDoesNotExist(t *testing.T) {
 	dynamoDBClient := &mockDynamoDB{}
 	table := &OutputsTable{client: dynamoDBClient, Name: aws.String("TableName")}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"go.uber.org/zap"
@@ -54,6 +55,8 @@ func (API) CheckIntegration(input *models.CheckIntegrationInput) (*models.Source
 		return checkAwsScanIntegration(input), nil
 	case models.IntegrationTypeAWS3:
 		return checkAwsS3Integration(input), nil
+	case models.IntegrationTypeAWSOrg:
+		return checkAwsOrgIntegration(input), nil
 	default:
 		return nil, checkIntegrationInternalError
 	}
@@ -77,6 +80,38 @@ func checkAwsScanIntegration(input *models.CheckIntegrationInput) *models.Source
 	return out
 }
 
+// checkAwsOrgIntegration runs the cloudsec checks against the management account of an organization,
+// and then verifies the audit role can read the organization that account manages.
+func checkAwsOrgIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	out := checkAwsScanIntegration(input)
+	if aws.BoolValue(out.AuditRoleStatus.Healthy) {
+		out.OrganizationStatus = checkOrganization(auditRoleCredentials(*input.AWSAccountID), *input.AWSAccountID)
+	}
+	return out
+}
+
+func checkOrganization(roleCredentials *credentials.Credentials, accountID string) models.SourceIntegrationItemStatus {
+	org, err := organizationsClientFunc(roleCredentials).DescribeOrganization(&organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      aws.Bool(false),
+			ErrorMessage: aws.String(err.Error()),
+		}
+	}
+
+	if aws.StringValue(org.Organization.MasterAccountId) != accountID {
+		return models.SourceIntegrationItemStatus{
+			Healthy: aws.Bool(false),
+			ErrorMessage: aws.String(fmt.Sprintf("account %s is not the management account of organization %s",
+				accountID, aws.StringValue(org.Organization.Id))),
+		}
+	}
+
+	return models.SourceIntegrationItemStatus{
+		Healthy: aws.Bool(true),
+	}
+}
+
 func checkAwsS3Integration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
 	out := &models.SourceIntegrationHealth{
 		AWSAccountID:    aws.StringValue(input.AWSAccountID),
@@ -172,7 +207,7 @@ func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (st
 	}
 
 	switch aws.StringValue(integration.IntegrationType) {
-	case models.IntegrationTypeAWSScan:
+	case models.IntegrationTypeAWSScan, models.IntegrationTypeAWSOrg:
 		if !aws.BoolValue(status.AuditRoleStatus.Healthy) {
 			return "cannot assume audit role", false, nil
 		}
@@ -184,6 +219,10 @@ func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (st
 		if aws.BoolValue(integration.EnableCWESetup) && !aws.BoolValue(status.CWERoleStatus.Healthy) {
 			return "cannot assume cwe role", false, nil
 		}
+
+		if *integration.IntegrationType == models.IntegrationTypeAWSOrg && !aws.BoolValue(status.OrganizationStatus.Healthy) {
+			return "cannot describe organization managed by this account", false, nil
+		}
 		return "", true, nil
 	case models.IntegrationTypeAWS3:
 		if !aws.BoolValue(status.ProcessingRoleStatus.Healthy) {
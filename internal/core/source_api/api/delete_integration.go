@@ -92,6 +92,24 @@ func (API) DeleteIntegration(input *models.DeleteIntegrationInput) (err error) {
 			}
 			integrationForDeletePermissions = itemToIntegration(integrationItem)
 		}
+	case models.IntegrationTypeAWSOrg:
+		// Offboard the member accounts which were onboarded through the organization
+		existingIntegrations, err := dynamoClient.ScanIntegrations(aws.String(models.IntegrationTypeAWSScan))
+		if err != nil {
+			return deleteIntegrationInternalError
+		}
+
+		for _, existingIntegration := range existingIntegrations {
+			if aws.StringValue(existingIntegration.OrgIntegrationID) != *integrationItem.IntegrationID {
+				continue
+			}
+			if err = dynamoClient.DeleteItem(existingIntegration.IntegrationID); err != nil {
+				zap.L().Error("failed to delete member account integration",
+					zap.String("integrationId", *existingIntegration.IntegrationID),
+					zap.Error(err))
+				return deleteIntegrationInternalError
+			}
+		}
 	}
 
 	err = dynamoClient.DeleteItem(input.IntegrationID)
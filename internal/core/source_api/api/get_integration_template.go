@@ -87,8 +87,8 @@ func (API) GetIntegrationTemplate(input *models.GetIntegrationTemplateInput) (*m
 	formattedTemplate := strings.Replace(template, accountIDFind,
 		fmt.Sprintf(accountIDReplace, *input.AWSAccountID), 1)
 
-	// Cloud Security replacements
-	if *input.IntegrationType == models.IntegrationTypeAWSScan {
+	// Cloud Security replacements, organizations deploy the same template to their management account
+	if isCloudSecIntegration(*input.IntegrationType) {
 		formattedTemplate = strings.Replace(formattedTemplate, regionFind,
 			fmt.Sprintf(regionReplace, *awsSession.Config.Region), 1)
 		formattedTemplate = strings.Replace(formattedTemplate, cweFind,
@@ -134,7 +134,7 @@ func getTemplate(integrationType *string) (string, error) {
 	templateRequest := &s3.GetObjectInput{
 		Bucket: aws.String(TemplateBucket),
 	}
-	if *integrationType == models.IntegrationTypeAWSScan {
+	if isCloudSecIntegration(*integrationType) {
 		templateRequest.Key = aws.String(CloudSecurityTemplateKey)
 	} else {
 		templateRequest.Key = aws.String(LogAnalysisTemplateKey)
@@ -162,12 +162,16 @@ func getTemplate(integrationType *string) (string, error) {
 }
 
 func getStackName(integrationType string, label string) string {
-	if integrationType == models.IntegrationTypeAWSScan {
+	if isCloudSecIntegration(integrationType) {
 		return CloudSecStackName
 	}
 	return fmt.Sprintf(LogAnalysisStackNameTemplate, normalizedLabel(label))
 }
 
+func isCloudSecIntegration(integrationType string) bool {
+	return integrationType == models.IntegrationTypeAWSScan || integrationType == models.IntegrationTypeAWSOrg
+}
+
 // Generates the ARN of the log processing role
 func generateLogProcessingRoleArn(awsAccountID string, label string) string {
 	return fmt.Sprintf(logProcessingRoleFormat, awsAccountID, normalizedLabel(label))
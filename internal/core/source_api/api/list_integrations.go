@@ -19,6 +19,8 @@ package api
  */
 
 import (
+	"strings"
+
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
@@ -34,10 +36,22 @@ func (API) ListIntegrations(
 		return nil, &genericapi.InternalError{Message: "Failed to list integrations"}
 	}
 
-	result := make([]*models.SourceIntegration, len(integrationItems))
-	for i, item := range integrationItems {
-		result[i] = itemToIntegration(item)
+	result := make([]*models.SourceIntegration, 0, len(integrationItems))
+	for _, item := range integrationItems {
+		if input.OrgUnitPath != nil && !inOrgUnit(item.OrgUnitPath, *input.OrgUnitPath) {
+			continue
+		}
+		result = append(result, itemToIntegration(item))
 	}
 
 	return result, nil
 }
+
+// inOrgUnit returns true if the path is the given organizational unit or a unit nested below it
+func inOrgUnit(path *string, orgUnitPath string) bool {
+	if path == nil {
+		return false
+	}
+	orgUnitPath = strings.TrimSuffix(orgUnitPath, orgUnitPathSeparator)
+	return *path == orgUnitPath || strings.HasPrefix(*path, orgUnitPath+orgUnitPathSeparator)
+}
@@ -20,7 +20,6 @@ package api
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -68,6 +67,10 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (*models.Source
 		}
 	}
 
+	if err := validateScanSettings(input.ResourceScanIntervals, input.ScanRegions); err != nil {
+		return nil, err
+	}
+
 	// Filter out existing integrations
 	if err := api.integrationAlreadyExists(input); err != nil {
 		return nil, err
@@ -117,12 +120,20 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (*models.Source
 		return newIntegration, nil
 	}
 
-	if *input.IntegrationType == models.IntegrationTypeAWSScan {
+	switch *input.IntegrationType {
+	case models.IntegrationTypeAWSScan:
 		err = api.FullScan(&models.FullScanInput{Integrations: []*models.SourceIntegrationMetadata{&newIntegration.SourceIntegrationMetadata}})
 		if err != nil {
 			err = errors.Wrap(err, "failed to trigger scanning of resources")
 			return nil, putIntegrationInternalError
 		}
+	case models.IntegrationTypeAWSOrg:
+		// Onboard the member accounts, which also triggers their first scan
+		_, err = api.SyncOrgIntegrations(&models.SyncOrgIntegrationsInput{IntegrationID: newIntegration.IntegrationID})
+		if err != nil {
+			err = errors.Wrap(err, "failed to sync organization accounts")
+			return nil, putIntegrationInternalError
+		}
 	}
 	return newIntegration, nil
 }
@@ -146,6 +157,13 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 					}
 				}
 				return nil
+			case models.IntegrationTypeAWSOrg:
+				if *existingIntegration.AWSAccountID == *input.AWSAccountID {
+					// Each organization is onboarded once, through its management account
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Organization of account %s already onboarded", *input.AWSAccountID),
+					}
+				}
 			case models.IntegrationTypeAWS3:
 				if *existingIntegration.AWSAccountID == *input.AWSAccountID &&
 					*existingIntegration.IntegrationLabel == *input.IntegrationLabel {
@@ -165,35 +183,31 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 
 // FullScan schedules scans for each Resource type for each integration.
 //
-// Each Resource type is sent within its own SQS message.
+// Each Resource type is sent within its own SQS message, split by region if the integration has scan regions.
 func (api API) FullScan(input *models.FullScanInput) error {
 	var sqsEntries []*sqs.SendMessageBatchRequestEntry
 
 	// For each integration, add a ScanMsg to the queue per service
 	for _, integration := range input.Integrations {
 		for resourceType := range awspoller.ServicePollers {
-			scanMsg := &pollermodels.ScanMsg{
-				Entries: []*pollermodels.ScanEntry{
-					{
-						AWSAccountID:  integration.AWSAccountID,
-						IntegrationID: integration.IntegrationID,
-						ResourceType:  aws.String(resourceType),
-					},
-				},
-			}
+			scanEntries := awspoller.ResourceTypeScanEntries(pollermodels.ScanEntry{
+				AWSAccountID:  integration.AWSAccountID,
+				IntegrationID: integration.IntegrationID,
+				OrgUnitPath:   integration.OrgUnitPath,
+			}, resourceType, integration.ScanRegions)
 
-			messageBodyBytes, err := jsoniter.MarshalToString(scanMsg)
-			if err != nil {
-				return &genericapi.InternalError{Message: err.Error()}
-			}
+			for _, scanEntry := range scanEntries {
+				scanMsg := &pollermodels.ScanMsg{Entries: []*pollermodels.ScanEntry{scanEntry}}
+				messageBodyBytes, err := jsoniter.MarshalToString(scanMsg)
+				if err != nil {
+					return &genericapi.InternalError{Message: err.Error()}
+				}
 
-			sqsEntries = append(sqsEntries, &sqs.SendMessageBatchRequestEntry{
-				// Generates an ID of: IntegrationID-AWSResourceType
-				Id: aws.String(
-					*integration.IntegrationID + "-" + strings.Replace(resourceType, ".", "", -1),
-				),
-				MessageBody: aws.String(messageBodyBytes),
-			})
+				sqsEntries = append(sqsEntries, &sqs.SendMessageBatchRequestEntry{
+					Id:          aws.String(awspoller.ScanEntryID(scanEntry)),
+					MessageBody: aws.String(messageBodyBytes),
+				})
+			}
 		}
 	}
 
@@ -221,11 +235,13 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 	}
 
 	switch aws.StringValue(input.IntegrationType) {
-	case models.IntegrationTypeAWSScan:
+	case models.IntegrationTypeAWSScan, models.IntegrationTypeAWSOrg:
 		metadata.AWSAccountID = input.AWSAccountID
 		metadata.CWEEnabled = input.CWEEnabled
 		metadata.RemediationEnabled = input.RemediationEnabled
 		metadata.ScanIntervalMins = input.ScanIntervalMins
+		metadata.ResourceScanIntervals = input.ResourceScanIntervals
+		metadata.ScanRegions = input.ScanRegions
 		metadata.StackName = aws.String(getStackName(*input.IntegrationType, *input.IntegrationLabel))
 	case models.IntegrationTypeAWS3:
 		metadata.AWSAccountID = input.AWSAccountID
@@ -0,0 +1,309 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	// orgUnitPathSeparator joins the names of the organizational units from the root down to an account
+	orgUnitPathSeparator = "/"
+
+	// Leading and trailing characters dropped from the labels of member accounts
+	labelTrimChars = " -"
+)
+
+var (
+	// Set as a variable to be overridden in testing
+	organizationsClientFunc = func(roleCredentials *credentials.Credentials) organizationsiface.OrganizationsAPI {
+		return organizations.New(awsSession, &aws.Config{Credentials: roleCredentials})
+	}
+
+	// Characters not allowed in integration labels are replaced when labeling member accounts
+	invalidLabelCharsRegex = regexp.MustCompile("[^0-9a-zA-Z- ]+")
+
+	syncOrgIntegrationsInternalError = &genericapi.InternalError{Message: "Failed to sync organization sources. Please try again later"}
+)
+
+// orgAccount is an active member account of an organization
+type orgAccount struct {
+	Name        string
+	OrgUnitPath string
+}
+
+// SyncOrgIntegrations onboards the member accounts of aws-org integrations as aws-scan integrations.
+//
+// Accounts that joined an organization get a new integration which inherits the settings of the organization,
+// accounts that moved get their organizational unit path updated, and the integrations of accounts that left
+// the organization are disabled. Accounts that were onboarded by hand keep their settings, only their
+// organizational unit path is tracked.
+func (api API) SyncOrgIntegrations(input *models.SyncOrgIntegrationsInput) (*models.SyncOrgIntegrationsOutput, error) {
+	items, err := dynamoClient.ScanIntegrations(nil)
+	if err != nil {
+		zap.L().Error("failed to list integrations", zap.Error(err))
+		return nil, syncOrgIntegrationsInternalError
+	}
+
+	var orgs []*ddb.IntegrationItem
+	// aws-scan integrations, keyed by account ID
+	accountIntegrations := make(map[string]*ddb.IntegrationItem)
+	for _, item := range items {
+		switch aws.StringValue(item.IntegrationType) {
+		case models.IntegrationTypeAWSOrg:
+			if input.IntegrationID == nil || *input.IntegrationID == *item.IntegrationID {
+				orgs = append(orgs, item)
+			}
+		case models.IntegrationTypeAWSScan:
+			accountIntegrations[*item.AWSAccountID] = item
+		}
+	}
+	if input.IntegrationID != nil && len(orgs) == 0 {
+		return nil, &genericapi.DoesNotExistError{Message: "Organization integration does not exist"}
+	}
+
+	result := &models.SyncOrgIntegrationsOutput{}
+	var newIntegrations []*models.SourceIntegrationMetadata
+	for _, org := range orgs {
+		created, err := syncOrganization(org, accountIntegrations, result)
+		if err != nil {
+			zap.L().Error("failed to sync organization",
+				zap.String("integrationId", *org.IntegrationID),
+				zap.Error(err))
+			return nil, syncOrgIntegrationsInternalError
+		}
+		newIntegrations = append(newIntegrations, created...)
+	}
+
+	zap.L().Info("synced organizations",
+		zap.Int("organizations", len(orgs)),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("disabled", result.Disabled))
+
+	// New accounts are scanned right away rather than waiting for the next scheduled scan
+	if len(newIntegrations) > 0 {
+		if err = api.FullScan(&models.FullScanInput{Integrations: newIntegrations}); err != nil {
+			zap.L().Error("failed to trigger scanning of new member accounts", zap.Error(err))
+			return nil, syncOrgIntegrationsInternalError
+		}
+	}
+	return result, nil
+}
+
+// syncOrganization reconciles the aws-scan integrations with the member accounts of a single organization.
+//
+// The outcome of the sync is recorded as the scan status of the organization integration.
+func syncOrganization(
+	org *ddb.IntegrationItem,
+	accountIntegrations map[string]*ddb.IntegrationItem,
+	result *models.SyncOrgIntegrationsOutput,
+) (created []*models.SourceIntegrationMetadata, err error) {
+
+	org.LastScanStartTime = aws.Time(time.Now())
+	defer func() {
+		org.LastScanEndTime = aws.Time(time.Now())
+		if err != nil {
+			org.ScanStatus = aws.String(models.StatusError)
+			org.LastScanErrorMessage = aws.String(err.Error())
+		} else {
+			org.ScanStatus = aws.String(models.StatusOK)
+			org.LastScanErrorMessage = nil
+		}
+		if putErr := dynamoClient.PutItem(org); putErr != nil && err == nil {
+			err = putErr
+		}
+	}()
+
+	accounts, err := listOrganizationAccounts(organizationsClientFunc(auditRoleCredentials(*org.AWSAccountID)))
+	if err != nil {
+		return nil, err
+	}
+
+	for accountID, account := range accounts {
+		item, ok := accountIntegrations[accountID]
+		if !ok {
+			item = newMemberIntegration(org, accountID, account)
+			if err = dynamoClient.PutItem(item); err != nil {
+				return nil, err
+			}
+			accountIntegrations[accountID] = item
+			created = append(created, &itemToIntegration(item).SourceIntegrationMetadata)
+			result.Created++
+			continue
+		}
+
+		if updateMemberIntegration(item, org, account) {
+			if err = dynamoClient.PutItem(item); err != nil {
+				return nil, err
+			}
+			result.Updated++
+		}
+	}
+
+	for accountID, item := range accountIntegrations {
+		if _, ok := accounts[accountID]; ok || aws.BoolValue(item.Disabled) ||
+			aws.StringValue(item.OrgIntegrationID) != *org.IntegrationID {
+
+			continue
+		}
+		zap.L().Info("disabling integration of account which left the organization",
+			zap.String("integrationId", *item.IntegrationID),
+			zap.String("orgIntegrationId", *org.IntegrationID))
+		item.Disabled = aws.Bool(true)
+		if err = dynamoClient.PutItem(item); err != nil {
+			return nil, err
+		}
+		result.Disabled++
+	}
+
+	return created, nil
+}
+
+// newMemberIntegration builds the aws-scan integration of an account which joined the organization
+func newMemberIntegration(org *ddb.IntegrationItem, accountID string, account *orgAccount) *ddb.IntegrationItem {
+	return &ddb.IntegrationItem{
+		AWSAccountID:       aws.String(accountID),
+		CreatedAtTime:      aws.Time(time.Now()),
+		CreatedBy:          org.CreatedBy,
+		CWEEnabled:         org.CWEEnabled,
+		IntegrationID:      aws.String(uuid.New().String()),
+		IntegrationLabel:   aws.String(memberIntegrationLabel(accountID, account.Name)),
+		IntegrationType:    aws.String(models.IntegrationTypeAWSScan),
+		OrgIntegrationID:   org.IntegrationID,
+		OrgUnitPath:        aws.String(account.OrgUnitPath),
+		RemediationEnabled: org.RemediationEnabled,
+		ScanIntervalMins:   org.ScanIntervalMins,
+		StackName:          aws.String(CloudSecStackName),
+
+		ResourceScanIntervals: org.ResourceScanIntervals,
+		ScanRegions:           org.ScanRegions,
+	}
+}
+
+// updateMemberIntegration applies the organization's view of an account to its integration,
+// returning true if anything changed.
+func updateMemberIntegration(item, org *ddb.IntegrationItem, account *orgAccount) bool {
+	before := *item
+	item.OrgUnitPath = aws.String(account.OrgUnitPath)
+
+	// Integrations onboarded by hand keep their own settings
+	if item.OrgIntegrationID != nil {
+		// The account may have moved here from another organization
+		item.OrgIntegrationID = org.IntegrationID
+		item.CWEEnabled = org.CWEEnabled
+		item.RemediationEnabled = org.RemediationEnabled
+		item.ScanIntervalMins = org.ScanIntervalMins
+		item.ResourceScanIntervals = org.ResourceScanIntervals
+		item.ScanRegions = org.ScanRegions
+		item.Disabled = nil
+	}
+
+	return !reflect.DeepEqual(before, *item)
+}
+
+// memberIntegrationLabel derives a valid integration label from the name of an account
+func memberIntegrationLabel(accountID, name string) string {
+	label := strings.Trim(invalidLabelCharsRegex.ReplaceAllString(name, "-"), labelTrimChars)
+	if len(label) > models.IntegrationLabelMaxLength {
+		label = strings.Trim(label[:models.IntegrationLabelMaxLength], labelTrimChars)
+	}
+	if label == "" {
+		return accountID
+	}
+	return label
+}
+
+// listOrganizationAccounts returns the active accounts of an organization, keyed by account ID
+func listOrganizationAccounts(client organizationsiface.OrganizationsAPI) (map[string]*orgAccount, error) {
+	var roots []*organizations.Root
+	err := client.ListRootsPages(&organizations.ListRootsInput{},
+		func(page *organizations.ListRootsOutput, lastPage bool) bool {
+			roots = append(roots, page.Roots...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list organization roots")
+	}
+
+	accounts := make(map[string]*orgAccount)
+	for _, root := range roots {
+		if err = listOrgUnitAccounts(client, root.Id, aws.StringValue(root.Name), accounts); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+// listOrgUnitAccounts adds the active accounts of an organizational unit and all units nested below it
+func listOrgUnitAccounts(
+	client organizationsiface.OrganizationsAPI, parentID *string, path string, accounts map[string]*orgAccount) error {
+
+	err := client.ListAccountsForParentPages(&organizations.ListAccountsForParentInput{ParentId: parentID},
+		func(page *organizations.ListAccountsForParentOutput, lastPage bool) bool {
+			for _, account := range page.Accounts {
+				if aws.StringValue(account.Status) == organizations.AccountStatusActive {
+					accounts[*account.Id] = &orgAccount{Name: aws.StringValue(account.Name), OrgUnitPath: path}
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list accounts of %s", path)
+	}
+
+	var units []*organizations.OrganizationalUnit
+	err = client.ListOrganizationalUnitsForParentPages(&organizations.ListOrganizationalUnitsForParentInput{ParentId: parentID},
+		func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+			units = append(units, page.OrganizationalUnits...)
+			return true
+		})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list organizational units of %s", path)
+	}
+
+	for _, unit := range units {
+		if err = listOrgUnitAccounts(client, unit.Id, path+orgUnitPathSeparator+aws.StringValue(unit.Name), accounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditRoleCredentials returns the credentials of the audit role deployed in an account
+func auditRoleCredentials(accountID string) *credentials.Credentials {
+	return stscreds.NewCredentials(awsSession, fmt.Sprintf(auditRoleFormat, accountID, *awsSession.Config.Region))
+}
@@ -0,0 +1,254 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testOrgIntegrationID = "d4fa2ae1-9e1b-4d4c-8c6a-3e4c6e0c0f5e"
+
+// mockOrganizationsClient serves an organization with the member accounts:
+//
+//	Root: 123456789012 (management)
+//	Root/Prod: 111111111111
+//	Root/Prod/Web: 222222222222
+//	Root/Dev: 333333333333 (suspended)
+type mockOrganizationsClient struct {
+	organizationsiface.OrganizationsAPI
+	mock.Mock
+}
+
+func (m *mockOrganizationsClient) ListRootsPages(
+	input *organizations.ListRootsInput, fn func(*organizations.ListRootsOutput, bool) bool) error {
+
+	args := m.Called(input)
+	if args.Error(0) == nil {
+		fn(&organizations.ListRootsOutput{
+			Roots: []*organizations.Root{{Id: aws.String("r-root"), Name: aws.String("Root")}},
+		}, true)
+	}
+	return args.Error(0)
+}
+
+func (m *mockOrganizationsClient) ListAccountsForParentPages(
+	input *organizations.ListAccountsForParentInput, fn func(*organizations.ListAccountsForParentOutput, bool) bool) error {
+
+	accounts := map[string][]*organizations.Account{
+		"r-root":  {{Id: aws.String(testAccountID), Name: aws.String("Management"), Status: aws.String("ACTIVE")}},
+		"ou-prod": {{Id: aws.String("111111111111"), Name: aws.String("Prod (Main)"), Status: aws.String("ACTIVE")}},
+		"ou-web":  {{Id: aws.String("222222222222"), Name: aws.String("Web"), Status: aws.String("ACTIVE")}},
+		"ou-dev":  {{Id: aws.String("333333333333"), Name: aws.String("Dev"), Status: aws.String("SUSPENDED")}},
+	}
+	fn(&organizations.ListAccountsForParentOutput{Accounts: accounts[*input.ParentId]}, true)
+	return nil
+}
+
+func (m *mockOrganizationsClient) ListOrganizationalUnitsForParentPages(
+	input *organizations.ListOrganizationalUnitsForParentInput,
+	fn func(*organizations.ListOrganizationalUnitsForParentOutput, bool) bool,
+) error {
+
+	units := map[string][]*organizations.OrganizationalUnit{
+		"r-root": {
+			{Id: aws.String("ou-prod"), Name: aws.String("Prod")},
+			{Id: aws.String("ou-dev"), Name: aws.String("Dev")},
+		},
+		"ou-prod": {{Id: aws.String("ou-web"), Name: aws.String("Web")}},
+	}
+	fn(&organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: units[*input.ParentId]}, true)
+	return nil
+}
+
+func setupOrganizationsMock(err error) {
+	awsSession = session.Must(session.NewSession(&aws.Config{Region: aws.String(endpoints.UsEast1RegionID)}))
+	client := &mockOrganizationsClient{}
+	client.On("ListRootsPages", mock.Anything).Return(err)
+	organizationsClientFunc = func(*credentials.Credentials) organizationsiface.OrganizationsAPI { return client }
+}
+
+func orgIntegrationAttributes() map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"awsAccountId":     {S: aws.String(testAccountID)},
+		"cweEnabled":       {BOOL: aws.Bool(true)},
+		"integrationId":    {S: aws.String(testOrgIntegrationID)},
+		"integrationLabel": {S: aws.String("Organization")},
+		"integrationType":  {S: aws.String(models.IntegrationTypeAWSOrg)},
+		"scanIntervalMins": {N: aws.String("1440")},
+	}
+}
+
+func TestListOrganizationAccounts(t *testing.T) {
+	setupOrganizationsMock(nil)
+
+	accounts, err := listOrganizationAccounts(organizationsClientFunc(nil))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*orgAccount{
+		testAccountID:  {Name: "Management", OrgUnitPath: "Root"},
+		"111111111111": {Name: "Prod (Main)", OrgUnitPath: "Root/Prod"},
+		"222222222222": {Name: "Web", OrgUnitPath: "Root/Prod/Web"},
+	}, accounts)
+}
+
+func TestListOrganizationAccountsError(t *testing.T) {
+	setupOrganizationsMock(errors.New("AccessDeniedException"))
+
+	accounts, err := listOrganizationAccounts(organizationsClientFunc(nil))
+	require.Error(t, err)
+	assert.Nil(t, accounts)
+}
+
+func TestSyncOrgIntegrations(t *testing.T) {
+	setupOrganizationsMock(nil)
+	env.SnapshotPollersQueueURL = "test-url"
+	mockSQS := &testutils.SqsMock{}
+	mockSQS.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+	sqsClient = mockSQS
+
+	dynamoClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				orgIntegrationAttributes(),
+				// Onboarded by hand, keeps its settings
+				{
+					"awsAccountId":     {S: aws.String(testAccountID)},
+					"integrationId":    {S: aws.String(testIntegrationID)},
+					"integrationLabel": {S: aws.String(testIntegrationLabel)},
+					"integrationType":  {S: aws.String(models.IntegrationTypeAWSScan)},
+					"scanIntervalMins": {N: aws.String("60")},
+				},
+				// Moved to another organizational unit
+				{
+					"awsAccountId":     {S: aws.String("222222222222")},
+					"integrationId":    {S: aws.String("5e0a4d1c-8a5b-4fb1-9a3a-0f3c3f7b6a10")},
+					"integrationType":  {S: aws.String(models.IntegrationTypeAWSScan)},
+					"orgIntegrationId": {S: aws.String(testOrgIntegrationID)},
+					"orgUnitPath":      {S: aws.String("Root/Prod")},
+					"cweEnabled":       {BOOL: aws.Bool(true)},
+					"scanIntervalMins": {N: aws.String("1440")},
+				},
+				// Left the organization
+				{
+					"awsAccountId":     {S: aws.String("444444444444")},
+					"integrationId":    {S: aws.String("0b3f6f4e-2d7e-4a6c-9a55-2b8e2f1c9d11")},
+					"integrationType":  {S: aws.String(models.IntegrationTypeAWSScan)},
+					"orgIntegrationId": {S: aws.String(testOrgIntegrationID)},
+					"orgUnitPath":      {S: aws.String("Root/Prod")},
+				},
+			},
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.SyncOrgIntegrations(&models.SyncOrgIntegrationsInput{})
+	require.NoError(t, err)
+	assert.Equal(t, &models.SyncOrgIntegrationsOutput{Created: 1, Updated: 2, Disabled: 1}, out)
+	// The new account is scanned right away
+	mockSQS.AssertExpectations(t)
+}
+
+func TestSyncOrgIntegrationsDoesNotExist(t *testing.T) {
+	setupOrganizationsMock(nil)
+	dynamoClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{orgIntegrationAttributes()},
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.SyncOrgIntegrations(&models.SyncOrgIntegrationsInput{IntegrationID: aws.String(testIntegrationID)})
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSyncOrgIntegrationsOrganizationsError(t *testing.T) {
+	setupOrganizationsMock(errors.New("AccessDeniedException"))
+	dynamoClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{orgIntegrationAttributes()},
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.SyncOrgIntegrations(&models.SyncOrgIntegrationsInput{})
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestUpdateMemberIntegration(t *testing.T) {
+	org := &ddb.IntegrationItem{
+		IntegrationID:      aws.String(testOrgIntegrationID),
+		CWEEnabled:         aws.Bool(true),
+		RemediationEnabled: aws.Bool(false),
+		ScanIntervalMins:   aws.Int(1440),
+	}
+	account := &orgAccount{Name: "Web", OrgUnitPath: "Root/Prod/Web"}
+
+	// A disabled account which rejoined the organization
+	item := &ddb.IntegrationItem{
+		Disabled:           aws.Bool(true),
+		OrgIntegrationID:   aws.String(testOrgIntegrationID),
+		OrgUnitPath:        aws.String("Root/Prod/Web"),
+		CWEEnabled:         aws.Bool(true),
+		RemediationEnabled: aws.Bool(false),
+		ScanIntervalMins:   aws.Int(1440),
+	}
+	assert.True(t, updateMemberIntegration(item, org, account))
+	assert.Nil(t, item.Disabled)
+	// Nothing left to change
+	assert.False(t, updateMemberIntegration(item, org, account))
+
+	// Settings of integrations onboarded by hand are left alone
+	item = &ddb.IntegrationItem{ScanIntervalMins: aws.Int(60)}
+	assert.True(t, updateMemberIntegration(item, org, account))
+	assert.Equal(t, &ddb.IntegrationItem{ScanIntervalMins: aws.Int(60), OrgUnitPath: aws.String("Root/Prod/Web")}, item)
+}
+
+func TestMemberIntegrationLabel(t *testing.T) {
+	assert.Equal(t, "Prod - Main", memberIntegrationLabel(testAccountID, "Prod - Main"))
+	assert.Equal(t, "Prod-Main", memberIntegrationLabel(testAccountID, "Prod(Main)"))
+	assert.Equal(t, "An account with a very long name", memberIntegrationLabel(testAccountID,
+		"An account with a very long name that does not fit"))
+	assert.Equal(t, testAccountID, memberIntegrationLabel(testAccountID, "()"))
+}
+
+func TestInOrgUnit(t *testing.T) {
+	assert.True(t, inOrgUnit(aws.String("Root/Prod"), "Root/Prod"))
+	assert.True(t, inOrgUnit(aws.String("Root/Prod/Web"), "Root/Prod/"))
+	assert.False(t, inOrgUnit(aws.String("Root/Production"), "Root/Prod"))
+	assert.False(t, inOrgUnit(nil, "Root"))
+}
@@ -20,6 +20,7 @@ package api
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
@@ -38,6 +39,10 @@ var (
 //
 // This endpoint updates attributes such as the behavior of the integration, or display information.
 func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettingsInput) (*models.SourceIntegration, error) {
+	if err := validateScanSettings(input.ResourceScanIntervals, input.ScanRegions); err != nil {
+		return nil, err
+	}
+
 	// First get the current existingIntegrationItem settings so that we can properly evaluate it
 	existingIntegrationItem, err := getItem(input.IntegrationID)
 	if err != nil {
@@ -73,9 +78,12 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 	}
 
 	switch aws.StringValue(existingIntegrationItem.IntegrationType) {
-	case models.IntegrationTypeAWSScan:
+	case models.IntegrationTypeAWSScan, models.IntegrationTypeAWSOrg:
+		// The member accounts of an organization pick up its settings when the organization is next synced
 		existingIntegrationItem.IntegrationLabel = input.IntegrationLabel
 		existingIntegrationItem.ScanIntervalMins = input.ScanIntervalMins
+		existingIntegrationItem.ResourceScanIntervals = input.ResourceScanIntervals
+		existingIntegrationItem.ScanRegions = input.ScanRegions
 		existingIntegrationItem.CWEEnabled = input.CWEEnabled
 		existingIntegrationItem.RemediationEnabled = input.RemediationEnabled
 	case models.IntegrationTypeAWS3:
@@ -133,6 +141,42 @@ func (API) UpdateIntegrationLastScanEnd(input *models.UpdateIntegrationLastScanE
 	return nil
 }
 
+// UpdateIntegrationResourceScans records when the scheduler last requested a scan of each of the given resource types.
+func (API) UpdateIntegrationResourceScans(input *models.UpdateIntegrationResourceScansInput) error {
+	existingIntegration, err := getItem(input.IntegrationID)
+	if err != nil {
+		return err
+	}
+
+	existingIntegration.ResourceLastScans = setResourceLastScans(
+		existingIntegration.ResourceLastScans, input.ResourceTypes, input.LastScanTime)
+	err = dynamoClient.PutItem(existingIntegration)
+	if err != nil {
+		return &genericapi.InternalError{Message: "Failed updating the integration resource scans"}
+	}
+	return nil
+}
+
+// setResourceLastScans updates the last scan time of each resource type, adding the types scanned for the first time
+func setResourceLastScans(
+	lastScans []*models.ResourceLastScan, resourceTypes []*string, scanTime *time.Time) []*models.ResourceLastScan {
+
+	for _, resourceType := range resourceTypes {
+		found := false
+		for _, lastScan := range lastScans {
+			if aws.StringValue(lastScan.ResourceType) == *resourceType {
+				lastScan.LastScanTime = scanTime
+				found = true
+				break
+			}
+		}
+		if !found {
+			lastScans = append(lastScans, &models.ResourceLastScan{ResourceType: resourceType, LastScanTime: scanTime})
+		}
+	}
+	return lastScans
+}
+
 func getItem(integrationID *string) (*ddb.IntegrationItem, error) {
 	item, err := dynamoClient.GetItem(integrationID)
 	if err != nil {
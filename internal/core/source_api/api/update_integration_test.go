@@ -67,6 +67,48 @@ func TestUpdateIntegrationSettingsAwsScanType(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestUpdateIntegrationSettingsAwsOrgType(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+	evaluateIntegrationFunc = func(_ API, _ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":   {S: aws.String(testOrgIntegrationID)},
+		"integrationType": {S: aws.String(models.IntegrationTypeAWSOrg)},
+	}}
+	mockClient.On("GetItem", mock.Anything).Return(getResponse, nil)
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	scanIntervals := []*models.ResourceScanInterval{
+		{ResourceType: aws.String("AWS.IAM.Role"), IntervalMins: aws.Int(60)},
+	}
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:         aws.String(testOrgIntegrationID),
+		IntegrationLabel:      aws.String("new-label"),
+		CWEEnabled:            aws.Bool(true),
+		RemediationEnabled:    aws.Bool(true),
+		ScanIntervalMins:      aws.Int(1440),
+		ResourceScanIntervals: scanIntervals,
+		ScanRegions:           aws.StringSlice([]string{"us-west-2"}),
+	})
+
+	expected := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:         aws.String(testOrgIntegrationID),
+			IntegrationType:       aws.String(models.IntegrationTypeAWSOrg),
+			IntegrationLabel:      aws.String("new-label"),
+			CWEEnabled:            aws.Bool(true),
+			RemediationEnabled:    aws.Bool(true),
+			ScanIntervalMins:      aws.Int(1440),
+			ResourceScanIntervals: scanIntervals,
+			ScanRegions:           aws.StringSlice([]string{"us-west-2"}),
+		},
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockClient.AssertExpectations(t)
+}
+
 func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	mockClient := &testutils.DynamoDBMock{}
 	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
@@ -178,3 +220,66 @@ func TestUpdateIntegrationLastScanEnd(t *testing.T) {
 	assert.NoError(t, err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestUpdateIntegrationResourceScans(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId": {S: aws.String(testIntegrationID)},
+		"resourceLastScans": {L: []*dynamodb.AttributeValue{
+			{M: map[string]*dynamodb.AttributeValue{
+				"resourceType": {S: aws.String("AWS.IAM.Role")},
+				"lastScanTime": {S: aws.String("2009-11-10T22:00:00Z")},
+			}},
+		}},
+	}}
+	mockClient.On("GetItem", mock.Anything).Return(getResponse, nil)
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	scanTime, err := time.Parse(time.RFC3339, "2009-11-10T23:00:00Z")
+	require.NoError(t, err)
+
+	err = apiTest.UpdateIntegrationResourceScans(&models.UpdateIntegrationResourceScansInput{
+		IntegrationID: aws.String(testIntegrationID),
+		LastScanTime:  &scanTime,
+		ResourceTypes: []*string{aws.String("AWS.IAM.Role"), aws.String("AWS.EC2.Volume")},
+	})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	// The existing type was updated, the new one added
+	item := mockClient.Calls[1].Arguments.Get(0).(*dynamodb.PutItemInput).Item
+	expected := &dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{
+		{M: map[string]*dynamodb.AttributeValue{
+			"resourceType": {S: aws.String("AWS.IAM.Role")},
+			"lastScanTime": {S: aws.String("2009-11-10T23:00:00Z")},
+		}},
+		{M: map[string]*dynamodb.AttributeValue{
+			"resourceType": {S: aws.String("AWS.EC2.Volume")},
+			"lastScanTime": {S: aws.String("2009-11-10T23:00:00Z")},
+		}},
+	}}
+	assert.Equal(t, expected, item["resourceLastScans"])
+}
+
+func TestValidateScanSettings(t *testing.T) {
+	assert.NoError(t, validateScanSettings(
+		[]*models.ResourceScanInterval{{ResourceType: aws.String("AWS.IAM.Role"), IntervalMins: aws.Int(60)}},
+		[]*string{aws.String("us-east-1"), aws.String("eu-west-1")},
+	))
+
+	err := validateScanSettings(
+		[]*models.ResourceScanInterval{{ResourceType: aws.String("AWS.IAM.RootUser"), IntervalMins: aws.Int(60)}}, nil)
+	assert.Equal(t, "resource type AWS.IAM.RootUser can not be scanned", err.Error())
+
+	err = validateScanSettings([]*models.ResourceScanInterval{
+		{ResourceType: aws.String("AWS.IAM.Role"), IntervalMins: aws.Int(60)},
+		{ResourceType: aws.String("AWS.IAM.Role"), IntervalMins: aws.Int(180)},
+	}, nil)
+	assert.Equal(t, "resource type AWS.IAM.Role has more than one scan interval", err.Error())
+
+	err = validateScanSettings(nil, []*string{aws.String("us-moon-1")})
+	assert.Equal(t, "region us-moon-1 does not exist", err.Error())
+}
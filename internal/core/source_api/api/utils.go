@@ -19,10 +19,15 @@ package api
  */
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 func integrationToItem(input *models.SourceIntegration) *ddb.IntegrationItem {
@@ -49,12 +54,31 @@ func integrationToItem(input *models.SourceIntegration) *ddb.IntegrationItem {
 		item.CWEEnabled = input.CWEEnabled
 		item.RemediationEnabled = input.RemediationEnabled
 		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ResourceScanIntervals = input.ResourceScanIntervals
+		item.ResourceLastScans = input.ResourceLastScans
+		item.ScanRegions = input.ScanRegions
 		item.ScanStatus = input.ScanStatus
 		item.EventStatus = input.EventStatus
 		item.LastScanErrorMessage = input.LastScanErrorMessage
 		item.LastScanStartTime = input.LastScanStartTime
 		item.LastScanEndTime = input.LastScanEndTime
 		item.StackName = input.StackName
+		item.OrgIntegrationID = input.OrgIntegrationID
+		item.OrgUnitPath = input.OrgUnitPath
+		item.Disabled = input.Disabled
+	case models.IntegrationTypeAWSOrg:
+		item.AWSAccountID = input.AWSAccountID
+		item.CWEEnabled = input.CWEEnabled
+		item.RemediationEnabled = input.RemediationEnabled
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ResourceScanIntervals = input.ResourceScanIntervals
+		item.ResourceLastScans = input.ResourceLastScans
+		item.ScanRegions = input.ScanRegions
+		item.ScanStatus = input.ScanStatus
+		item.LastScanErrorMessage = input.LastScanErrorMessage
+		item.LastScanStartTime = input.LastScanStartTime
+		item.LastScanEndTime = input.LastScanEndTime
+		item.StackName = input.StackName
 	}
 	return item
 }
@@ -82,12 +106,64 @@ func itemToIntegration(item *ddb.IntegrationItem) *models.SourceIntegration {
 		integration.CWEEnabled = item.CWEEnabled
 		integration.RemediationEnabled = item.RemediationEnabled
 		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ResourceScanIntervals = item.ResourceScanIntervals
+		integration.ResourceLastScans = item.ResourceLastScans
+		integration.ScanRegions = item.ScanRegions
 		integration.ScanStatus = item.ScanStatus
 		integration.EventStatus = item.EventStatus
 		integration.LastScanStartTime = item.LastScanStartTime
 		integration.LastScanEndTime = item.LastScanEndTime
 		integration.LastScanErrorMessage = item.LastScanErrorMessage
 		integration.StackName = item.StackName
+		integration.OrgIntegrationID = item.OrgIntegrationID
+		integration.OrgUnitPath = item.OrgUnitPath
+		integration.Disabled = item.Disabled
+	case models.IntegrationTypeAWSOrg:
+		integration.AWSAccountID = item.AWSAccountID
+		integration.CWEEnabled = item.CWEEnabled
+		integration.RemediationEnabled = item.RemediationEnabled
+		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ResourceScanIntervals = item.ResourceScanIntervals
+		integration.ResourceLastScans = item.ResourceLastScans
+		integration.ScanRegions = item.ScanRegions
+		integration.ScanStatus = item.ScanStatus
+		integration.LastScanStartTime = item.LastScanStartTime
+		integration.LastScanEndTime = item.LastScanEndTime
+		integration.LastScanErrorMessage = item.LastScanErrorMessage
+		integration.StackName = item.StackName
 	}
 	return integration
 }
+
+// validateScanSettings checks that the per resource type intervals and the scan regions of an integration exist.
+func validateScanSettings(intervals []*models.ResourceScanInterval, regions []*string) error {
+	seen := make(map[string]bool, len(intervals))
+	for _, interval := range intervals {
+		resourceType := *interval.ResourceType
+		if _, ok := awspoller.ServicePollers[resourceType]; !ok {
+			return &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("resource type %s can not be scanned", resourceType)}
+		}
+		if seen[resourceType] {
+			return &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("resource type %s has more than one scan interval", resourceType)}
+		}
+		seen[resourceType] = true
+	}
+
+	for _, region := range regions {
+		if !knownRegion(*region) {
+			return &genericapi.InvalidInputError{Message: fmt.Sprintf("region %s does not exist", *region)}
+		}
+	}
+	return nil
+}
+
+func knownRegion(region string) bool {
+	for _, partition := range endpoints.DefaultPartitions() {
+		if _, ok := partition.Regions()[region]; ok {
+			return true
+		}
+	}
+	return false
+}
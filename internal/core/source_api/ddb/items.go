@@ -18,7 +18,11 @@ package ddb
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-import "time"
+import (
+	"time"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
 
 // IntegrationItem represents an integration item as it is stored in DynamoDB.
 type IntegrationItem struct {
@@ -39,10 +43,18 @@ type IntegrationItem struct {
 	EventStatus          *string    `json:"eventStatus"`
 	ScanIntervalMins     *int       `json:"scanIntervalMins"`
 
+	ResourceScanIntervals []*models.ResourceScanInterval `json:"resourceScanIntervals,omitempty"`
+	ResourceLastScans     []*models.ResourceLastScan     `json:"resourceLastScans,omitempty"`
+	ScanRegions           []*string                      `json:"scanRegions,omitempty"`
+
 	S3Bucket          *string   `json:"s3Bucket"`
 	S3Prefix          *string   `json:"s3Prefix"`
 	KmsKey            *string   `json:"kmsKey"`
 	LogTypes          []*string `json:"logTypes" dynamodbav:"logTypes,stringset"`
 	StackName         *string   `json:"stackName,omitempty"`
 	LogProcessingRole *string   `json:"logProcessingRole,omitempty"`
+
+	OrgIntegrationID *string `json:"orgIntegrationId,omitempty"`
+	OrgUnitPath      *string `json:"orgUnitPath,omitempty"`
+	Disabled         *bool   `json:"disabled,omitempty"`
 }
@@ -0,0 +1,55 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/kelseyhightower/envconfig"
+
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+type envConfig struct {
+	AlertsTableName  string `required:"true" split_words:"true"`
+	TicketsTableName string `required:"true" split_words:"true"`
+	OutputsAPI       string `required:"true" split_words:"true"`
+}
+
+var (
+	// Env is the parsed environment variables
+	Env envConfig
+
+	alertsDB     table.API
+	lambdaClient lambdaiface.LambdaAPI
+)
+
+// Setup parses the environment and builds the AWS clients
+func Setup() {
+	envconfig.MustProcess("", &Env)
+	awsSession := session.Must(session.NewSession())
+	lambdaClient = lambda.New(awsSession)
+	alertsDB = &table.AlertsTable{
+		AlertsTableName:  Env.AlertsTableName,
+		TicketsTableName: Env.TicketsTableName,
+		Client:           dynamodb.New(awsSession),
+	}
+}
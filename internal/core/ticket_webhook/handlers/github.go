@@ -0,0 +1,103 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	alertmodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+const (
+	githubSystem = "github"
+
+	githubIssues       = "issues"
+	githubIssueComment = "issue_comment"
+)
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubEvent is the part of a GitHub "issues" or "issue_comment" webhook event used to sync the alert
+type githubEvent struct {
+	Action string `json:"action"`
+	Issue  *struct {
+		Number int `json:"number"`
+		// Set if the issue is a pull request, pull request comments are sent as issue comments
+		PullRequest *jsoniter.RawMessage `json:"pull_request"`
+	} `json:"issue"`
+	Comment *struct {
+		Body string      `json:"body"`
+		User *githubUser `json:"user"`
+	} `json:"comment"`
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender *githubUser `json:"sender"`
+}
+
+// parseGithubEvent returns the change to sync, or nil if the event does not change the alert.
+//
+// Closing and reopening an issue closes and reopens the alert, new issue comments are added to the alert.
+func parseGithubEvent(eventType string, body []byte) (*ticketChange, error) {
+	if eventType != githubIssues && eventType != githubIssueComment {
+		// e.g. the "ping" event sent when the webhook is created
+		return nil, nil
+	}
+
+	var event githubEvent
+	if err := jsoniter.Unmarshal(body, &event); err != nil {
+		return nil, errors.Wrap(err, "invalid GitHub event")
+	}
+	if event.Issue == nil || event.Repository == nil {
+		return nil, errors.New("invalid GitHub event: missing issue")
+	}
+	if event.Issue.PullRequest != nil {
+		return nil, nil
+	}
+	change := &ticketChange{
+		ticketID: table.TicketID(githubSystem, event.Repository.FullName+"#"+strconv.Itoa(event.Issue.Number)),
+		userID:   githubUserID(event.Sender),
+	}
+
+	switch {
+	case eventType == githubIssues && event.Action == "closed":
+		change.status = alertmodels.AlertStatusClosed
+	case eventType == githubIssues && event.Action == "reopened":
+		change.status = alertmodels.AlertStatusOpen
+	case eventType == githubIssueComment && event.Action == "created" && event.Comment != nil && event.Comment.Body != "":
+		change.comment = event.Comment.Body
+		change.userID = githubUserID(event.Comment.User)
+	default:
+		return nil, nil
+	}
+	return change, nil
+}
+
+func githubUserID(user *githubUser) string {
+	if user == nil || user.Login == "" {
+		return githubSystem
+	}
+	return githubSystem + ":" + user.Login
+}
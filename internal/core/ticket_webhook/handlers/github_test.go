@@ -0,0 +1,57 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGithubReopened(t *testing.T) {
+	body := `{"action": "reopened", "issue": {"number": 12}, "repository": {"full_name": "org/repo"},
+		"sender": {"login": "octocat"}}`
+	change, err := parseGithubEvent("issues", []byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, &ticketChange{ticketID: "github:org/repo#12", userID: "github:octocat", status: "OPEN"}, change)
+}
+
+func TestParseGithubIgnoredEvents(t *testing.T) {
+	change, err := parseGithubEvent("ping", []byte(`{"zen": "Keep it logically awesome."}`))
+	require.NoError(t, err)
+	assert.Nil(t, change)
+
+	body := `{"action": "labeled", "issue": {"number": 12}, "repository": {"full_name": "org/repo"}}`
+	change, err = parseGithubEvent("issues", []byte(body))
+	require.NoError(t, err)
+	assert.Nil(t, change)
+
+	// Pull request comments are sent as issue comments
+	body = `{"action": "created", "issue": {"number": 12, "pull_request": {"url": "https://api.github.com"}},
+		"repository": {"full_name": "org/repo"}, "comment": {"body": "lgtm"}}`
+	change, err = parseGithubEvent("issue_comment", []byte(body))
+	require.NoError(t, err)
+	assert.Nil(t, change)
+}
+
+func TestParseGithubInvalidEvent(t *testing.T) {
+	_, err := parseGithubEvent("issues", []byte(`{"action": "closed"}`))
+	assert.Error(t, err)
+}
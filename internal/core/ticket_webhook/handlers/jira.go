@@ -0,0 +1,116 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	alertmodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+const (
+	jiraSystem = "jira"
+
+	jiraIssueUpdated   = "jira:issue_updated"
+	jiraCommentCreated = "comment_created"
+)
+
+// Jira status categories are the same for every workflow, unlike the statuses
+var jiraCategoryStatus = map[string]string{
+	"new":           alertmodels.AlertStatusOpen,
+	"indeterminate": alertmodels.AlertStatusTriaged,
+	"done":          alertmodels.AlertStatusClosed,
+}
+
+type jiraUser struct {
+	DisplayName string `json:"displayName"`
+}
+
+// jiraEvent is the part of a Jira issue or comment webhook event used to sync the alert
+type jiraEvent struct {
+	WebhookEvent string    `json:"webhookEvent"`
+	User         *jiraUser `json:"user"`
+	Issue        *struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Status *struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	} `json:"issue"`
+	Changelog *struct {
+		Items []struct {
+			Field string `json:"field"`
+		} `json:"items"`
+	} `json:"changelog"`
+	Comment *struct {
+		Body   string    `json:"body"`
+		Author *jiraUser `json:"author"`
+	} `json:"comment"`
+}
+
+// parseJiraEvent returns the change to sync, or nil if the event does not change the alert.
+//
+// Status transitions are read from "jira:issue_updated" events and comments from "comment_created" events.
+func parseJiraEvent(body []byte) (*ticketChange, error) {
+	var event jiraEvent
+	if err := jsoniter.Unmarshal(body, &event); err != nil {
+		return nil, errors.Wrap(err, "invalid Jira event")
+	}
+	if event.Issue == nil || event.Issue.Key == "" {
+		return nil, errors.New("invalid Jira event: missing issue")
+	}
+	change := &ticketChange{ticketID: table.TicketID(jiraSystem, event.Issue.Key)}
+
+	switch event.WebhookEvent {
+	case jiraIssueUpdated:
+		if event.Changelog == nil || event.Issue.Fields.Status == nil {
+			return nil, nil
+		}
+		for _, item := range event.Changelog.Items {
+			if item.Field == "status" {
+				change.status = jiraCategoryStatus[event.Issue.Fields.Status.StatusCategory.Key]
+			}
+		}
+		if change.status == "" {
+			return nil, nil
+		}
+		change.userID = jiraUserID(event.User)
+	case jiraCommentCreated:
+		if event.Comment == nil || event.Comment.Body == "" {
+			return nil, nil
+		}
+		change.comment = event.Comment.Body
+		change.userID = jiraUserID(event.Comment.Author)
+	default:
+		return nil, nil
+	}
+	return change, nil
+}
+
+func jiraUserID(user *jiraUser) string {
+	if user == nil || user.DisplayName == "" {
+		return jiraSystem
+	}
+	return jiraSystem + ":" + user.DisplayName
+}
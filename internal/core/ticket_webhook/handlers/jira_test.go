@@ -0,0 +1,66 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJiraTransition(t *testing.T) {
+	body := `{"webhookEvent": "jira:issue_updated", "user": {"displayName": "Jane Doe"},
+		"issue": {"key": "PANTHER-12", "fields": {"status": {"name": "Done", "statusCategory": {"key": "done"}}}},
+		"changelog": {"items": [{"field": "resolution"}, {"field": "status"}]}}`
+	change, err := parseJiraEvent([]byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, &ticketChange{ticketID: "jira:PANTHER-12", userID: "jira:Jane Doe", status: "CLOSED"}, change)
+
+	body = `{"webhookEvent": "jira:issue_updated",
+		"issue": {"key": "PANTHER-12", "fields": {"status": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}}}},
+		"changelog": {"items": [{"field": "status"}]}}`
+	change, err = parseJiraEvent([]byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, &ticketChange{ticketID: "jira:PANTHER-12", userID: "jira", status: "TRIAGED"}, change)
+}
+
+func TestParseJiraUpdateWithoutTransition(t *testing.T) {
+	body := `{"webhookEvent": "jira:issue_updated",
+		"issue": {"key": "PANTHER-12", "fields": {"status": {"statusCategory": {"key": "done"}}}},
+		"changelog": {"items": [{"field": "assignee"}]}}`
+	change, err := parseJiraEvent([]byte(body))
+	require.NoError(t, err)
+	assert.Nil(t, change)
+}
+
+func TestParseJiraComment(t *testing.T) {
+	body := `{"webhookEvent": "comment_created", "issue": {"key": "PANTHER-12"},
+		"comment": {"body": "false positive, closing", "author": {"displayName": "Jane Doe"}}}`
+	change, err := parseJiraEvent([]byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, &ticketChange{ticketID: "jira:PANTHER-12", userID: "jira:Jane Doe", comment: "false positive, closing"}, change)
+}
+
+func TestParseJiraInvalidEvent(t *testing.T) {
+	_, err := parseJiraEvent([]byte(`not json`))
+	assert.Error(t, err)
+	_, err = parseJiraEvent([]byte(`{"webhookEvent": "comment_created"}`))
+	assert.Error(t, err)
+}
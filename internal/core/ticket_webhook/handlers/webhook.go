@@ -0,0 +1,237 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"go.uber.org/zap"
+
+	alertmodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	// GitHub sends the signature of webhooks with a secret in this header
+	githubSignatureHeader = "X-Hub-Signature-256"
+	// Jira sends the same signature format in the older header name
+	jiraSignatureHeader = "X-Hub-Signature"
+	signaturePrefix     = "sha256="
+
+	maxCommentLength = 4000
+)
+
+var (
+	errMissingSignature = errors.New("missing webhook signature")
+	errInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// ticketChange is a change made to a ticket which is synced to its alert
+type ticketChange struct {
+	ticketID string
+	userID   string // the user who made the change, prefixed with the ticketing system
+	status   string // the new alert status if the ticket was transitioned, closed or reopened
+	comment  string
+}
+
+// HandleWebhook syncs a Jira or GitHub issue event to the alert the issue was created for.
+//
+// The webhook is configured per output, the events are verified with the webhook secret of the output.
+// Events for issues which were not created by Panther are ignored.
+func HandleWebhook(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	outputID := request.PathParameters["outputId"]
+	output, err := getOutput(outputID)
+	if err != nil {
+		zap.L().Error("failed to get output", zap.String("outputId", outputID), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if output == nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+
+	body := []byte(request.Body)
+	if request.IsBase64Encoded {
+		if body, err = base64.StdEncoding.DecodeString(request.Body); err != nil {
+			return badRequest(err)
+		}
+	}
+
+	var secret string
+	var parse func() (*ticketChange, error)
+	switch {
+	case output.OutputConfig.Jira != nil:
+		secret = output.OutputConfig.Jira.WebhookSecret
+		parse = func() (*ticketChange, error) { return parseJiraEvent(body) }
+	case output.OutputConfig.Github != nil:
+		secret = output.OutputConfig.Github.WebhookSecret
+		parse = func() (*ticketChange, error) {
+			return parseGithubEvent(header(request, "X-GitHub-Event"), body)
+		}
+	default:
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+	if secret == "" {
+		// Ticket sync is not enabled for this output
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusForbidden}
+	}
+	if err = verifySignature(request, secret, body); err != nil {
+		return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusUnauthorized}
+	}
+
+	change, err := parse()
+	if err != nil {
+		return badRequest(err)
+	}
+	if change == nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
+	}
+
+	ticket, err := alertsDB.GetTicket(change.ticketID)
+	if err != nil {
+		zap.L().Error("failed to get ticket", zap.String("ticketId", change.ticketID), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if ticket == nil || ticket.OutputID != outputID {
+		zap.L().Debug("ignoring event for unknown ticket", zap.String("ticketId", change.ticketID))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
+	}
+
+	if err = applyChange(ticket.AlertID, change); err != nil {
+		zap.L().Error("failed to update alert", zap.String("alertId", ticket.AlertID), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
+}
+
+// getOutput returns the output with its secrets, or nil if it does not exist
+func getOutput(outputID string) (*outputmodels.AlertOutput, error) {
+	input := outputmodels.LambdaInput{GetOutputsWithSecrets: &outputmodels.GetOutputsWithSecretsInput{}}
+	var outputs outputmodels.GetOutputsOutput
+	if err := genericapi.Invoke(lambdaClient, Env.OutputsAPI, &input, &outputs); err != nil {
+		return nil, err
+	}
+	for _, output := range outputs {
+		if output.OutputID != nil && *output.OutputID == outputID && output.OutputConfig != nil {
+			return output, nil
+		}
+	}
+	return nil, nil
+}
+
+// verifySignature checks the HMAC-SHA256 signature of the body, which is sent as "sha256=<hex digest>"
+func verifySignature(request *events.APIGatewayProxyRequest, secret string, body []byte) error {
+	signature := header(request, githubSignatureHeader)
+	if signature == "" {
+		signature = header(request, jiraSignatureHeader)
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errMissingSignature
+	}
+
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return errInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), actual) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// truncateComment cuts a comment to maxCommentLength bytes without splitting a multi-byte character
+func truncateComment(comment string) string {
+	if len(comment) <= maxCommentLength {
+		return comment
+	}
+	end := maxCommentLength
+	for end > 0 && !utf8.RuneStart(comment[end]) {
+		end--
+	}
+	return comment[:end]
+}
+
+// applyChange records a ticket comment or status change in the alert activity
+func applyChange(alertID string, change *ticketChange) error {
+	now := time.Now().UTC()
+	if change.comment != "" {
+		activity := &table.AlertActivity{
+			Type:    alertmodels.AlertActivityComment,
+			UserID:  change.userID,
+			Time:    now,
+			Comment: truncateComment(change.comment),
+		}
+		if _, err := alertsDB.AddComment(alertID, activity); err != nil {
+			return err
+		}
+	}
+
+	if change.status == "" {
+		return nil
+	}
+	alert, err := alertsDB.GetAlert(&alertID)
+	if err != nil || alert == nil {
+		return err
+	}
+	status := alert.Status
+	if status == "" {
+		status = alertmodels.AlertStatusOpen
+	}
+	// An alert which was already resolved in Panther keeps its resolution when the ticket is closed
+	if status == change.status ||
+		(change.status == alertmodels.AlertStatusClosed && status == alertmodels.AlertStatusFalsePositive) {
+
+		return nil
+	}
+
+	activity := &table.AlertActivity{
+		Type:   alertmodels.AlertActivityStatus,
+		UserID: change.userID,
+		Time:   now,
+		Status: change.status,
+	}
+	_, err = alertsDB.UpdateStatus(alertID, change.status, activity)
+	return err
+}
+
+// header returns a request header, API Gateway passes them with the case they were sent with
+func header(request *events.APIGatewayProxyRequest, name string) string {
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func badRequest(err error) *events.APIGatewayProxyResponse {
+	return &events.APIGatewayProxyResponse{Body: err.Error(), StatusCode: http.StatusBadRequest}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type tableMock struct {
+	table.API
+	mock.Mock
+}
+
+func (m *tableMock) GetAlert(alertID *string) (*table.AlertItem, error) {
+	args := m.Called(alertID)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) UpdateStatus(alertID, status string, activity *table.AlertActivity) (*table.AlertItem, error) {
+	args := m.Called(alertID, status, activity)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) AddComment(alertID string, activity *table.AlertActivity) (*table.AlertItem, error) {
+	args := m.Called(alertID, activity)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) GetTicket(ticketID string) (*table.TicketItem, error) {
+	args := m.Called(ticketID)
+	return args.Get(0).(*table.TicketItem), args.Error(1)
+}
+
+const githubIssueClosed = `{"action": "closed", "issue": {"number": 12}, "repository": {"full_name": "org/repo"},
+	"sender": {"login": "octocat"}}`
+
+func initTest(t *testing.T) (*tableMock, *testutils.LambdaMock) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	lambdaMock := &testutils.LambdaMock{}
+	lambdaClient = lambdaMock
+
+	outputs := outputmodels.GetOutputsOutput{
+		{
+			OutputID:     aws.String("github-output"),
+			OutputType:   aws.String("github"),
+			OutputConfig: &outputmodels.OutputConfig{Github: &outputmodels.GithubConfig{RepoName: "org/repo", WebhookSecret: "secret"}},
+		},
+		{
+			OutputID:     aws.String("jira-output"),
+			OutputType:   aws.String("jira"),
+			OutputConfig: &outputmodels.OutputConfig{Jira: &outputmodels.JiraConfig{ProjectKey: "PANTHER"}},
+		},
+	}
+	payload, err := jsoniter.Marshal(outputs)
+	require.NoError(t, err)
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil)
+	return tableMock, lambdaMock
+}
+
+func signedRequest(outputID, event, body, secret string) *events.APIGatewayProxyRequest {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return &events.APIGatewayProxyRequest{
+		Body: body,
+		Headers: map[string]string{
+			"x-github-event":      event,
+			"x-hub-signature-256": "sha256=" + hex.EncodeToString(mac.Sum(nil)),
+		},
+		PathParameters: map[string]string{"outputId": outputID},
+	}
+}
+
+func TestHandleWebhookClosesAlert(t *testing.T) {
+	tableMock, _ := initTest(t)
+	tableMock.On("GetTicket", "github:org/repo#12").Return(
+		&table.TicketItem{TicketID: "github:org/repo#12", AlertID: "alertId", OutputID: "github-output"}, nil)
+	tableMock.On("GetAlert", aws.String("alertId")).Return(&table.AlertItem{AlertID: "alertId"}, nil)
+	tableMock.On("UpdateStatus", "alertId", "CLOSED", mock.Anything).Return(&table.AlertItem{}, nil)
+
+	result := HandleWebhook(signedRequest("github-output", "issues", githubIssueClosed, "secret"))
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+
+	activity := tableMock.Calls[2].Arguments.Get(2).(*table.AlertActivity)
+	assert.Equal(t, "STATUS", activity.Type)
+	assert.Equal(t, "github:octocat", activity.UserID)
+	tableMock.AssertExpectations(t)
+}
+
+func TestHandleWebhookAlreadyResolved(t *testing.T) {
+	tableMock, _ := initTest(t)
+	tableMock.On("GetTicket", "github:org/repo#12").Return(
+		&table.TicketItem{TicketID: "github:org/repo#12", AlertID: "alertId", OutputID: "github-output"}, nil)
+	tableMock.On("GetAlert", aws.String("alertId")).Return(&table.AlertItem{AlertID: "alertId", Status: "FALSE_POSITIVE"}, nil)
+
+	result := HandleWebhook(signedRequest("github-output", "issues", githubIssueClosed, "secret"))
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	tableMock.AssertExpectations(t)
+	tableMock.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleWebhookAddsComment(t *testing.T) {
+	tableMock, _ := initTest(t)
+	tableMock.On("GetTicket", "github:org/repo#12").Return(
+		&table.TicketItem{TicketID: "github:org/repo#12", AlertID: "alertId", OutputID: "github-output"}, nil)
+	tableMock.On("AddComment", "alertId", mock.Anything).Return(&table.AlertItem{}, nil)
+
+	body := `{"action": "created", "issue": {"number": 12}, "repository": {"full_name": "org/repo"},
+		"comment": {"body": "looks benign", "user": {"login": "analyst"}}}`
+	result := HandleWebhook(signedRequest("github-output", "issue_comment", body, "secret"))
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+
+	activity := tableMock.Calls[1].Arguments.Get(1).(*table.AlertActivity)
+	assert.Equal(t, &table.AlertActivity{Type: "COMMENT", UserID: "github:analyst", Time: activity.Time, Comment: "looks benign"}, activity)
+	tableMock.AssertExpectations(t)
+}
+
+func TestHandleWebhookUnknownTicket(t *testing.T) {
+	tableMock, _ := initTest(t)
+	tableMock.On("GetTicket", "github:org/repo#12").Return((*table.TicketItem)(nil), nil)
+
+	result := HandleWebhook(signedRequest("github-output", "issues", githubIssueClosed, "secret"))
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	tableMock.AssertExpectations(t)
+}
+
+func TestHandleWebhookTicketOfOtherOutput(t *testing.T) {
+	tableMock, _ := initTest(t)
+	tableMock.On("GetTicket", "github:org/repo#12").Return(
+		&table.TicketItem{TicketID: "github:org/repo#12", AlertID: "alertId", OutputID: "other-output"}, nil)
+
+	result := HandleWebhook(signedRequest("github-output", "issues", githubIssueClosed, "secret"))
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	tableMock.AssertExpectations(t)
+	tableMock.AssertNotCalled(t, "GetAlert", mock.Anything)
+}
+
+func TestHandleWebhookInvalidSignature(t *testing.T) {
+	tableMock, _ := initTest(t)
+
+	result := HandleWebhook(signedRequest("github-output", "issues", githubIssueClosed, "wrong-secret"))
+	assert.Equal(t, http.StatusUnauthorized, result.StatusCode)
+	tableMock.AssertNotCalled(t, "GetTicket", mock.Anything)
+}
+
+func TestHandleWebhookSyncDisabled(t *testing.T) {
+	initTest(t)
+	result := HandleWebhook(signedRequest("jira-output", "", `{}`, "secret"))
+	assert.Equal(t, http.StatusForbidden, result.StatusCode)
+}
+
+func TestHandleWebhookUnknownOutput(t *testing.T) {
+	initTest(t)
+	result := HandleWebhook(signedRequest("deleted-output", "issues", githubIssueClosed, "secret"))
+	assert.Equal(t, http.StatusNotFound, result.StatusCode)
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"webhookEvent": "comment_created"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	// Jira sends the signature in the X-Hub-Signature header
+	request := &events.APIGatewayProxyRequest{Headers: map[string]string{"X-Hub-Signature": signature}}
+	assert.NoError(t, verifySignature(request, "secret", body))
+	assert.Equal(t, errInvalidSignature, verifySignature(request, "secret", []byte("tampered")))
+
+	request = &events.APIGatewayProxyRequest{Headers: map[string]string{"X-Hub-Signature": "sha1=abc"}}
+	assert.Equal(t, errMissingSignature, verifySignature(request, "secret", body))
+	assert.Equal(t, errMissingSignature, verifySignature(&events.APIGatewayProxyRequest{}, "secret", body))
+}
+
+func TestTruncateComment(t *testing.T) {
+	assert.Equal(t, "short", truncateComment("short"))
+
+	ascii := strings.Repeat("a", maxCommentLength+10)
+	assert.Equal(t, ascii[:maxCommentLength], truncateComment(ascii))
+
+	// The three-byte character at the limit is dropped instead of being split
+	multiByte := strings.Repeat("a", maxCommentLength-1) + "€€"
+	truncated := truncateComment(multiByte)
+	assert.True(t, utf8.ValidString(truncated))
+	assert.Equal(t, strings.Repeat("a", maxCommentLength-1), truncated)
+}
@@ -38,21 +38,32 @@ var (
 	ddbClient  dynamodbiface.DynamoDBAPI
 	sqsClient  sqsiface.SQSAPI
 
+	// The fields of sample events kept for each log type, log types which are not listed keep every field
+	sampleEventFields map[string]map[string]bool
+
 	httpClient   *http.Client
 	policyConfig *policiesclient.TransportConfig
 	policyClient *policiesclient.PantherAnalysis
 )
 
 type envConfig struct {
-	AlertsTable      string `required:"true" split_words:"true"`
-	AlertingQueueURL string `required:"true" split_words:"true"`
-	AnalysisAPIHost  string `required:"true" split_words:"true"`
-	AnalysisAPIPath  string `required:"true" split_words:"true"`
+	AlertsTable       string `required:"true" split_words:"true"`
+	SuppressionsTable string `required:"true" split_words:"true"`
+	AlertingQueueURL  string `required:"true" split_words:"true"`
+	AnalysisAPIHost   string `required:"true" split_words:"true"`
+	AnalysisAPIPath   string `required:"true" split_words:"true"`
+	// The number of sample events sent in alert notifications, and a JSON object with the field allowlist of each log type
+	SampleEvents      int    `split_words:"true" default:"3"`
+	SampleEventFields string `split_words:"true"`
 }
 
 // Setup parses the environment and builds the AWS and http clients.
 func Setup() {
 	envconfig.MustProcess("", &env)
+	var err error
+	if sampleEventFields, err = parseSampleEventFields(env.SampleEventFields); err != nil {
+		panic(err)
+	}
 
 	awsSession = session.Must(session.NewSession())
 	ddbClient = dynamodb.New(awsSession)
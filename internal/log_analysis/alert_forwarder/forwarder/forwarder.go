@@ -30,6 +30,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	policiesoperations "github.com/panther-labs/panther/api/gateway/analysis/client/operations"
 	"github.com/panther-labs/panther/api/gateway/analysis/models"
@@ -38,9 +39,12 @@ import (
 
 const defaultTimePartition = "defaultPartition"
 
-func Handle(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEvent) error {
+// Handle creates or updates the alert of a dedup event.
+//
+// The suppressions should be shared by all the events of a batch, so they are only read once.
+func Handle(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEvent, suppressions *Suppressions) error {
 	if needToCreateNewAlert(oldAlertDedupEvent, newAlertDedupEvent) {
-		return handleNewAlert(newAlertDedupEvent)
+		return handleNewAlert(newAlertDedupEvent, suppressions)
 	}
 	return updateExistingAlert(newAlertDedupEvent)
 }
@@ -49,15 +53,34 @@ func needToCreateNewAlert(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEven
 	return oldAlertDedupEvent == nil || oldAlertDedupEvent.AlertCount != newAlertDedupEvent.AlertCount
 }
 
-func handleNewAlert(event *AlertDedupEvent) error {
+func handleNewAlert(event *AlertDedupEvent, suppressions *Suppressions) error {
 	ruleInfo, err := getRuleInfo(event)
 	if err != nil {
 		return errors.Wrap(err, "failed to get rule information")
 	}
 
-	if err := storeNewAlert(ruleInfo, event); err != nil {
+	alert := newAlert(ruleInfo, event)
+	suppression, err := suppressions.find(alert)
+	if err != nil {
+		return errors.Wrap(err, "failed to get alert suppressions")
+	}
+	if suppression != nil {
+		alert.Suppressed = true
+		alert.SuppressionID = aws.String(suppression.ID)
+	}
+	alert.Pending = !alert.Suppressed && !thresholdReached(alert)
+
+	if err := storeNewAlert(alert); err != nil {
 		return errors.Wrap(err, "failed to store new alert in DDB")
 	}
+	if alert.Suppressed {
+		zap.L().Info("alert suppressed", zap.String("alertId", alert.ID), zap.String("suppressionId", suppression.ID))
+		return nil
+	}
+	if alert.Pending {
+		zap.L().Debug("alert pending until the rule threshold is reached", zap.String("alertId", alert.ID))
+		return nil
+	}
 	return sendAlertNotification(ruleInfo, event)
 }
 
@@ -83,25 +106,43 @@ func updateExistingAlert(event *AlertDedupEvent) error {
 		Key: map[string]*dynamodb.AttributeValue{
 			alertTablePartitionKey: {S: aws.String(generateAlertID(event))},
 		},
+		ReturnValues: aws.String(dynamodb.ReturnValueAllNew),
 	}
 
-	_, err = ddbClient.UpdateItem(updateInput)
+	response, err := ddbClient.UpdateItem(updateInput)
 	if err != nil {
 		return errors.Wrap(err, "failed to update alert")
 	}
+
+	// The event counts and times of the update are already known, only the threshold is read from the alert
+	alert := &Alert{ID: generateAlertID(event), AlertDedupEvent: *event}
+	if err = dynamodbattribute.UnmarshalMap(response.Attributes, &alert.AlertThreshold); err != nil {
+		return errors.Wrap(err, "failed to unmarshal updated alert")
+	}
+	if alert.Pending && thresholdReached(alert) {
+		return sendPendingAlert(alert, event)
+	}
 	return nil
 }
 
-func storeNewAlert(rule *models.Rule, alertDedup *AlertDedupEvent) error {
-	alert := &Alert{
+func newAlert(rule *models.Rule, alertDedup *AlertDedupEvent) *Alert {
+	return &Alert{
 		ID:              generateAlertID(alertDedup),
+		Type:            alertModel.RuleType,
 		TimePartition:   defaultTimePartition,
 		Severity:        string(rule.Severity),
 		RuleDisplayName: getRuleDisplayName(rule),
 		Title:           getAlertTitle(rule, alertDedup),
+		RuleTags:        rule.Tags,
+		AlertThreshold: AlertThreshold{
+			Threshold:              int64(rule.Threshold),
+			ThresholdWindowMinutes: int64(rule.ThresholdWindowMinutes),
+		},
 		AlertDedupEvent: *alertDedup,
 	}
+}
 
+func storeNewAlert(alert *Alert) error {
 	marshaledAlert, err := dynamodbattribute.MarshalMap(alert)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal alert")
@@ -130,6 +171,7 @@ func sendAlertNotification(rule *models.Rule, alertDedup *AlertDedupEvent) error
 		Type:              aws.String(alertModel.RuleType),
 		AlertID:           aws.String(generateAlertID(alertDedup)),
 		Title:             aws.String(getAlertTitle(rule, alertDedup)),
+		SampleEvents:      sampleEvents(alertDedup),
 	}
 
 	msgBody, err := jsoniter.MarshalToString(alertNotification)
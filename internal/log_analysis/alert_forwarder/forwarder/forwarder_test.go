@@ -75,6 +75,7 @@ var (
 		EventCount:          oldAlertDedupEvent.EventCount,
 		LogTypes:            oldAlertDedupEvent.LogTypes,
 		GeneratedTitle:      oldAlertDedupEvent.GeneratedTitle,
+		SampleEvents:        []string{`{"p_log_type": "Log.Type.1", "key": "value"}`},
 	}
 
 	testRuleResponse = &models.Rule{
@@ -89,7 +90,9 @@ var (
 
 func init() {
 	env.AlertsTable = "alertsTable"
+	env.SuppressionsTable = "suppressionsTable"
 	env.AlertingQueueURL = "queueUrl"
+	env.SampleEvents = 3
 }
 
 func TestHandleStoreAndSendNotification(t *testing.T) {
@@ -118,6 +121,7 @@ func TestHandleStoreAndSendNotification(t *testing.T) {
 		Type:              aws.String(alertModel.RuleType),
 		AlertID:           aws.String("b25dc23fb2a0b362da8428dbec1381a8"),
 		Title:             newAlertDedupEvent.GeneratedTitle,
+		SampleEvents:      []string{`{"p_log_type":"Log.Type.1","key":"value"}`},
 	}
 	expectedMarshaledAlertNotification, err := jsoniter.MarshalToString(expectedAlertNotification)
 	require.NoError(t, err)
@@ -131,7 +135,9 @@ func TestHandleStoreAndSendNotification(t *testing.T) {
 
 	expectedAlert := &Alert{
 		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		Type:            "RULE",
 		TimePartition:   "defaultPartition",
+		RuleTags:        []string{"Tag"},
 		Severity:        string(testRuleResponse.Severity),
 		RuleDisplayName: aws.String(string(testRuleResponse.DisplayName)),
 		Title:           aws.StringValue(newAlertDedupEvent.GeneratedTitle),
@@ -146,8 +152,9 @@ func TestHandleStoreAndSendNotification(t *testing.T) {
 		TableName: aws.String("alertsTable"),
 	}
 
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
 	ddbMock.On("PutItem", expectedPutItemRequest).Return(&dynamodb.PutItemOutput{}, nil)
-	assert.NoError(t, Handle(oldAlertDedupEvent, newAlertDedupEvent))
+	assert.NoError(t, Handle(oldAlertDedupEvent, newAlertDedupEvent, &Suppressions{}))
 
 	ddbMock.AssertExpectations(t)
 	sqsMock.AssertExpectations(t)
@@ -211,7 +218,9 @@ func TestHandleStoreAndSendNotificationNoRuleDisplayNameNoTitle(t *testing.T) {
 
 	expectedAlert := &Alert{
 		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		Type:            "RULE",
 		TimePartition:   "defaultPartition",
+		RuleTags:        []string{"Tag"},
 		Severity:        string(testRuleResponse.Severity),
 		Title:           newAlertDedupEventWithoutTitle.RuleID,
 		AlertDedupEvent: *newAlertDedupEventWithoutTitle,
@@ -225,8 +234,9 @@ func TestHandleStoreAndSendNotificationNoRuleDisplayNameNoTitle(t *testing.T) {
 		TableName: aws.String("alertsTable"),
 	}
 
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
 	ddbMock.On("PutItem", expectedPutItemRequest).Return(&dynamodb.PutItemOutput{}, nil)
-	assert.NoError(t, Handle(oldAlertDedupEvent, newAlertDedupEventWithoutTitle))
+	assert.NoError(t, Handle(oldAlertDedupEvent, newAlertDedupEventWithoutTitle, &Suppressions{}))
 
 	ddbMock.AssertExpectations(t)
 	sqsMock.AssertExpectations(t)
@@ -272,7 +282,9 @@ func TestHandleStoreAndSendNotificationNoGeneratedTitle(t *testing.T) {
 
 	expectedAlert := &Alert{
 		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		Type:            "RULE",
 		TimePartition:   "defaultPartition",
+		RuleTags:        []string{"Tag"},
 		Severity:        string(testRuleResponse.Severity),
 		RuleDisplayName: aws.String(string(testRuleResponse.DisplayName)),
 		Title:           "DisplayName",
@@ -298,8 +310,9 @@ func TestHandleStoreAndSendNotificationNoGeneratedTitle(t *testing.T) {
 		LogTypes:            newAlertDedupEvent.LogTypes,
 	}
 
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
 	ddbMock.On("PutItem", expectedPutItemRequest).Return(&dynamodb.PutItemOutput{}, nil)
-	assert.NoError(t, Handle(oldAlertDedupEvent, dedupEventWithoutTitle))
+	assert.NoError(t, Handle(oldAlertDedupEvent, dedupEventWithoutTitle, &Suppressions{}))
 
 	ddbMock.AssertExpectations(t)
 	sqsMock.AssertExpectations(t)
@@ -332,6 +345,7 @@ func TestHandleStoreAndSendNotificationNilOldDedup(t *testing.T) {
 		Type:              aws.String(alertModel.RuleType),
 		AlertID:           aws.String("b25dc23fb2a0b362da8428dbec1381a8"),
 		Title:             newAlertDedupEvent.GeneratedTitle,
+		SampleEvents:      []string{`{"p_log_type":"Log.Type.1","key":"value"}`},
 	}
 	expectedMarshaledAlertNotification, err := jsoniter.MarshalToString(expectedAlertNotification)
 	require.NoError(t, err)
@@ -345,7 +359,9 @@ func TestHandleStoreAndSendNotificationNilOldDedup(t *testing.T) {
 
 	expectedAlert := &Alert{
 		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		Type:            "RULE",
 		TimePartition:   "defaultPartition",
+		RuleTags:        []string{"Tag"},
 		Severity:        string(testRuleResponse.Severity),
 		Title:           aws.StringValue(newAlertDedupEvent.GeneratedTitle),
 		RuleDisplayName: aws.String(string(testRuleResponse.DisplayName)),
@@ -360,8 +376,9 @@ func TestHandleStoreAndSendNotificationNilOldDedup(t *testing.T) {
 		TableName: aws.String("alertsTable"),
 	}
 
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
 	ddbMock.On("PutItem", expectedPutItemRequest).Return(&dynamodb.PutItemOutput{}, nil)
-	require.NoError(t, Handle(nil, newAlertDedupEvent))
+	require.NoError(t, Handle(nil, newAlertDedupEvent, &Suppressions{}))
 
 	ddbMock.AssertExpectations(t)
 	sqsMock.AssertExpectations(t)
@@ -399,10 +416,11 @@ func TestHandleUpdateAlert(t *testing.T) {
 		UpdateExpression:          expr.Update(),
 		ExpressionAttributeValues: expr.Values(),
 		ExpressionAttributeNames:  expr.Names(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
 	}
 
 	ddbMock.On("UpdateItem", expectedUpdateItemInput).Return(&dynamodb.UpdateItemOutput{}, nil)
-	assert.NoError(t, Handle(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	assert.NoError(t, Handle(newAlertDedupEvent, dedupEventWithUpdatedFields, &Suppressions{}))
 
 	ddbMock.AssertExpectations(t)
 }
@@ -424,7 +442,7 @@ func TestHandleUpdateAlertDDBError(t *testing.T) {
 	}
 
 	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("error"))
-	assert.Error(t, Handle(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	assert.Error(t, Handle(newAlertDedupEvent, dedupEventWithUpdatedFields, &Suppressions{}))
 }
 
 func generateResponse(body interface{}, httpCode int) *http.Response {
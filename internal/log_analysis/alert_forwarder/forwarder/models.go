@@ -31,6 +31,7 @@ const (
 	alertTableLogTypesAttribute   = "logTypes"
 	alertTableEventCountAttribute = "eventCount"
 	alertTableUpdateTimeAttribute = "updateTime"
+	alertTablePendingAttribute    = "pending"
 )
 
 // AlertDedupEvent represents the event stored in the alert dedup DDB table by the rules engine
@@ -44,19 +45,34 @@ type AlertDedupEvent struct {
 	LogTypes            []string  `dynamodbav:"logTypes,stringset"`
 	GeneratedTitle      *string   `dynamodbav:"-"` // The title that was generated dynamically using Python. Might be null.
 	AlertCount          int64     `dynamodbav:"-"` // There is no need to store this item in DDB
+	SampleEvents        []string  `dynamodbav:"-"` // The first events of the alert, sent in its notification
 }
 
 // Alert contains all the fields associated to the alert stored in DDB
 type Alert struct {
 	ID              string  `dynamodbav:"id,string"`
+	Type            string  `dynamodbav:"type,string"` // RULE, policy alerts are stored in the same table
 	TimePartition   string  `dynamodbav:"timePartition,string"`
 	Severity        string  `dynamodbav:"severity,string"`
 	RuleDisplayName *string `dynamodbav:"ruleDisplayName,string"`
 	Title           string  `dynamodbav:"title,string"` // The alert title. It will be the Python-generated title or a default one if
 	// no Python-generated title is available.
+	RuleTags []string `dynamodbav:"ruleTags,stringset,omitempty"` // The rule tags, stored so alerts can be filtered by them
+	// Suppressed alerts are stored but not delivered, SuppressionID is the suppression which matched the alert
+	Suppressed    bool    `dynamodbav:"suppressed,omitempty"`
+	SuppressionID *string `dynamodbav:"suppressionId,string,omitempty"`
+	AlertThreshold
 	AlertDedupEvent
 }
 
+// AlertThreshold holds the threshold of the alert rule at the time the alert was created.
+// Alerts of rules with a threshold are pending, and not delivered, until enough events match within the window.
+type AlertThreshold struct {
+	Threshold              int64 `dynamodbav:"threshold,omitempty"`
+	ThresholdWindowMinutes int64 `dynamodbav:"thresholdWindowMinutes,omitempty"`
+	Pending                bool  `dynamodbav:"pending,omitempty"`
+}
+
 func FromDynamodDBAttribute(input map[string]events.DynamoDBAttributeValue) (event *AlertDedupEvent, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -127,6 +143,13 @@ func FromDynamodDBAttribute(input map[string]events.DynamoDBAttributeValue) (eve
 	if generatedTitle != nil {
 		result.GeneratedTitle = aws.String(generatedTitle.String())
 	}
+
+	// Alerts created before sample events were stored have none
+	if sampleEvents := getOptionalAttribute("sampleEvents", input); sampleEvents != nil {
+		for _, sampleEvent := range sampleEvents.List() {
+			result.SampleEvents = append(result.SampleEvents, sampleEvent.String())
+		}
+	}
 	return result, nil
 }
 
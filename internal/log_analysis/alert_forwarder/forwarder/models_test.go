@@ -45,6 +45,17 @@ func TestConvertAttribute(t *testing.T) {
 	require.Equal(t, expectedAlertDedup, alertDedupEvent)
 }
 
+func TestConvertAttributeWithSampleEvents(t *testing.T) {
+	ddbItem := getNewTestCase()
+	ddbItem["sampleEvents"] = events.NewListAttribute([]events.DynamoDBAttributeValue{
+		events.NewStringAttribute(`{"key": "value1"}`),
+		events.NewStringAttribute(`{"key": "value2"}`),
+	})
+	alertDedupEvent, err := FromDynamodDBAttribute(ddbItem)
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"key": "value1"}`, `{"key": "value2"}`}, alertDedupEvent.SampleEvents)
+}
+
 func TestConvertNilValue(t *testing.T) {
 	alertDedupEvent, err := FromDynamodDBAttribute(nil)
 	require.NoError(t, err)
@@ -0,0 +1,138 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// Longer field values of sample events are truncated
+	maxSampleValueLength = 256
+	// Fields which don't fit in this size are left out of a sample event
+	maxSampleEventBytes = 4096
+
+	logTypeField   = "p_log_type"
+	truncateSuffix = "..."
+)
+
+// parseSampleEventFields reads the field allowlists of sample events, a JSON object of field names by log type
+func parseSampleEventFields(config string) (map[string]map[string]bool, error) {
+	if config == "" {
+		return nil, nil
+	}
+	var fieldsByLogType map[string][]string
+	if err := jsoniter.UnmarshalFromString(config, &fieldsByLogType); err != nil {
+		return nil, errors.Wrap(err, "invalid sample event fields")
+	}
+
+	result := make(map[string]map[string]bool, len(fieldsByLogType))
+	for logType, fields := range fieldsByLogType {
+		allowed := make(map[string]bool, len(fields)+1)
+		for _, field := range fields {
+			allowed[field] = true
+		}
+		allowed[logTypeField] = true // always kept, so responders know what the event is
+		result[logType] = allowed
+	}
+	return result, nil
+}
+
+// sampleEvents trims the first events of an alert so they can be sent in its notification.
+//
+// Only the allowed fields of each log type are kept, long values are truncated and events which can't be
+// read are skipped.
+func sampleEvents(event *AlertDedupEvent) []string {
+	var result []string
+	for _, sampleEvent := range event.SampleEvents {
+		if len(result) >= env.SampleEvents {
+			break
+		}
+		trimmed, err := trimSampleEvent(sampleEvent)
+		if err != nil {
+			zap.L().Warn("skipping invalid sample event", zap.String("ruleId", event.RuleID), zap.Error(err))
+			continue
+		}
+		result = append(result, trimmed)
+	}
+	return result
+}
+
+// trimSampleEvent rewrites an event with the allowed fields of its log type, truncating long values
+func trimSampleEvent(event string) (string, error) {
+	allowed := sampleEventFields[jsoniter.Get([]byte(event), logTypeField).ToString()]
+
+	iter := jsoniter.ParseString(jsoniter.ConfigDefault, event)
+	stream := jsoniter.NewStream(jsoniter.ConfigDefault, nil, len(event))
+	stream.WriteObjectStart()
+	empty := true
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, field string) bool {
+		value := bytes.TrimSpace(iter.SkipAndReturnBytes())
+		if allowed != nil && !allowed[field] {
+			return true
+		}
+
+		start := stream.Buffered()
+		if !empty {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField(field)
+		writeSampleValue(stream, value)
+		if stream.Buffered() > maxSampleEventBytes {
+			// Leave out the field, but keep looking for smaller ones which fit
+			stream.SetBuffer(stream.Buffer()[:start])
+			return true
+		}
+		empty = false
+		return true
+	})
+	if iter.Error != nil {
+		return "", errors.Wrap(iter.Error, "failed to read sample event")
+	}
+	stream.WriteObjectEnd()
+	return string(stream.Buffer()), nil
+}
+
+// writeSampleValue writes a JSON value, truncating long values to a string
+func writeSampleValue(stream *jsoniter.Stream, value []byte) {
+	if len(value) <= maxSampleValueLength {
+		stream.WriteRaw(string(value))
+		return
+	}
+
+	text := string(value)
+	var unquoted string
+	if value[0] == '"' && jsoniter.Unmarshal(value, &unquoted) == nil {
+		text = unquoted
+	}
+	stream.WriteString(truncate(text, maxSampleValueLength) + truncateSuffix)
+}
+
+// truncate shortens text to at most maxLength characters
+func truncate(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	return string([]rune(text)[:maxLength])
+}
@@ -0,0 +1,98 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSampleEventFields(t *testing.T) {
+	fields, err := parseSampleEventFields(`{"AWS.CloudTrail": ["eventName", "sourceIPAddress"]}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]bool{
+		"AWS.CloudTrail": {"eventName": true, "sourceIPAddress": true, "p_log_type": true},
+	}, fields)
+
+	fields, err = parseSampleEventFields("")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+
+	_, err = parseSampleEventFields("eventName")
+	assert.Error(t, err)
+}
+
+func TestSampleEvents(t *testing.T) {
+	env.SampleEvents = 2
+	sampleEventFields = nil
+	event := &AlertDedupEvent{
+		RuleID: "ruleId",
+		SampleEvents: []string{
+			`{"p_log_type": "AWS.CloudTrail", "eventName": "GetObject"}`,
+			`not json`,
+			`{"p_log_type": "AWS.CloudTrail", "eventName": "PutObject"}`,
+			`{"p_log_type": "AWS.CloudTrail", "eventName": "DeleteObject"}`,
+		},
+	}
+	assert.Equal(t, []string{
+		`{"p_log_type":"AWS.CloudTrail","eventName":"GetObject"}`,
+		`{"p_log_type":"AWS.CloudTrail","eventName":"PutObject"}`,
+	}, sampleEvents(event))
+
+	assert.Empty(t, sampleEvents(&AlertDedupEvent{RuleID: "ruleId"}))
+}
+
+func TestTrimSampleEventAllowlist(t *testing.T) {
+	sampleEventFields = map[string]map[string]bool{
+		"AWS.CloudTrail": {"p_log_type": true, "eventName": true, "userIdentity": true},
+	}
+	defer func() { sampleEventFields = nil }()
+
+	trimmed, err := trimSampleEvent(
+		`{"p_log_type": "AWS.CloudTrail", "eventName": "GetObject", "userIdentity": {"type": "Root"}, "secret": "value"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"p_log_type":"AWS.CloudTrail","eventName":"GetObject","userIdentity":{"type": "Root"}}`, trimmed)
+
+	// Log types without an allowlist keep every field
+	trimmed, err = trimSampleEvent(`{"p_log_type": "AWS.VPCFlow", "srcAddr": "10.0.0.1"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"p_log_type":"AWS.VPCFlow","srcAddr":"10.0.0.1"}`, trimmed)
+}
+
+func TestTrimSampleEventTruncates(t *testing.T) {
+	sampleEventFields = nil
+	long := strings.Repeat("a", 300)
+	trimmed, err := trimSampleEvent(`{"message": "` + long + `", "list": [` + strings.Repeat("1,", 200) + `1]}`)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"message":"`+strings.Repeat("a", 256)+`...","list":"[`+strings.Repeat("1,", 127)+`1..."}`, trimmed)
+
+	// Fields which don't fit in the event size are left out
+	var fields []string
+	for i := 0; i < 20; i++ {
+		fields = append(fields, `"field`+strings.Repeat("x", i%10)+string(rune('a'+i))+`": "`+long+`"`)
+	}
+	trimmed, err = trimSampleEvent(`{` + strings.Join(fields, ",") + `, "small": 1}`)
+	require.NoError(t, err)
+	assert.True(t, len(trimmed) <= maxSampleEventBytes)
+	assert.True(t, strings.HasSuffix(trimmed, `"small":1}`))
+}
@@ -0,0 +1,93 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+// The alert fields a suppression can match, besides the rule and dedup string
+const (
+	suppressionFieldTitle    = "title"
+	suppressionFieldSeverity = "severity"
+	suppressionFieldLogType  = "logType"
+)
+
+// Suppressions are the active alert suppressions, they are managed by the alerts-api.
+//
+// They are read from the table the first time an alert is checked, and then reused for the rest of the batch.
+type Suppressions struct {
+	items  []*table.SuppressionItem
+	loaded bool
+}
+
+// find returns the first active suppression matching the alert, nil if the alert is not suppressed
+func (s *Suppressions) find(alert *Alert) (*table.SuppressionItem, error) {
+	if !s.loaded {
+		suppressionsTable := &table.AlertsTable{SuppressionsTableName: env.SuppressionsTable, Client: ddbClient}
+		items, err := suppressionsTable.ListSuppressions(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		s.items, s.loaded = items, true
+	}
+
+	for _, suppression := range s.items {
+		if suppressionMatches(suppression, alert) {
+			return suppression, nil
+		}
+	}
+	return nil, nil
+}
+
+// suppressionMatches returns true if every criteria set on the suppression matches the alert:
+// the rule, the dedup string and an alert field value.
+func suppressionMatches(s *table.SuppressionItem, alert *Alert) bool {
+	if s.RuleID != nil && *s.RuleID != alert.RuleID {
+		return false
+	}
+	if s.DedupString != nil && *s.DedupString != alert.DeduplicationString {
+		return false
+	}
+	if s.Field == nil {
+		return true
+	}
+
+	value := aws.StringValue(s.Value)
+	switch *s.Field {
+	case suppressionFieldTitle:
+		return alert.Title == value
+	case suppressionFieldSeverity:
+		return alert.Severity == value
+	case suppressionFieldLogType:
+		for _, logType := range alert.LogTypes {
+			if logType == value {
+				return true
+			}
+		}
+		return false
+	default:
+		// A field this version doesn't know about can't match, the alert is delivered
+		return false
+	}
+}
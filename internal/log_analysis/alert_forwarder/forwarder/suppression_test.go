@@ -0,0 +1,141 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	policiesclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestSuppressionMatches(t *testing.T) {
+	alert := &Alert{
+		Title:    "root login",
+		Severity: "HIGH",
+		AlertDedupEvent: AlertDedupEvent{
+			RuleID:              "ruleId",
+			DeduplicationString: "dedupString",
+			LogTypes:            []string{"AWS.CloudTrail"},
+		},
+	}
+
+	tests := []struct {
+		suppression *table.SuppressionItem
+		matches     bool
+	}{
+		{&table.SuppressionItem{RuleID: aws.String("ruleId")}, true},
+		{&table.SuppressionItem{RuleID: aws.String("otherRule")}, false},
+		{&table.SuppressionItem{DedupString: aws.String("dedupString")}, true},
+		{&table.SuppressionItem{RuleID: aws.String("ruleId"), DedupString: aws.String("other")}, false},
+		{&table.SuppressionItem{RuleID: aws.String("ruleId"), Field: aws.String("title"), Value: aws.String("root login")}, true},
+		{&table.SuppressionItem{RuleID: aws.String("ruleId"), Field: aws.String("severity"), Value: aws.String("LOW")}, false},
+		{&table.SuppressionItem{RuleID: aws.String("ruleId"), Field: aws.String("logType"), Value: aws.String("AWS.CloudTrail")}, true},
+		{&table.SuppressionItem{RuleID: aws.String("ruleId"), Field: aws.String("unknown"), Value: aws.String("x")}, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.matches, suppressionMatches(test.suppression, alert))
+	}
+}
+
+func TestSuppressionsLoadedOnce(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+
+	item, err := dynamodbattribute.MarshalMap(&table.SuppressionItem{ID: "suppressionId", RuleID: aws.String("ruleId")})
+	require.NoError(t, err)
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(
+		&dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{item}}, nil).Once()
+
+	suppressions := &Suppressions{}
+	suppression, err := suppressions.find(&Alert{AlertDedupEvent: AlertDedupEvent{RuleID: "ruleId"}})
+	require.NoError(t, err)
+	require.NotNil(t, suppression)
+	assert.Equal(t, "suppressionId", suppression.ID)
+
+	suppression, err = suppressions.find(&Alert{AlertDedupEvent: AlertDedupEvent{RuleID: "otherRule"}})
+	require.NoError(t, err)
+	assert.Nil(t, suppression)
+
+	input := ddbMock.Calls[0].Arguments.Get(0).(*dynamodb.ScanInput)
+	assert.Equal(t, "suppressionsTable", *input.TableName)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestHandleSuppressedAlert(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+	policyConfig = policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient = policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+
+	suppression, err := dynamodbattribute.MarshalMap(&table.SuppressionItem{
+		ID:          "suppressionId",
+		DedupString: aws.String(newAlertDedupEvent.DeduplicationString),
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	expectedAlert := &Alert{
+		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		Type:            "RULE",
+		TimePartition:   "defaultPartition",
+		RuleTags:        []string{"Tag"},
+		Severity:        string(testRuleResponse.Severity),
+		RuleDisplayName: aws.String(string(testRuleResponse.DisplayName)),
+		Title:           aws.StringValue(newAlertDedupEvent.GeneratedTitle),
+		Suppressed:      true,
+		SuppressionID:   aws.String("suppressionId"),
+		AlertDedupEvent: *newAlertDedupEvent,
+	}
+	expectedMarshaledAlert, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(
+		&dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{suppression}}, nil)
+	ddbMock.On("PutItem", &dynamodb.PutItemInput{
+		Item:      expectedMarshaledAlert,
+		TableName: aws.String("alertsTable"),
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+
+	require.NoError(t, Handle(oldAlertDedupEvent, newAlertDedupEvent, &Suppressions{}))
+
+	ddbMock.AssertExpectations(t)
+	// The alert is stored but never sent for delivery
+	sqsMock.AssertNotCalled(t, "SendMessage", mock.Anything)
+	mockRoundTripper.AssertExpectations(t)
+}
@@ -0,0 +1,123 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// thresholdReached checks if enough events matched the alert rule to deliver it.
+//
+// Events are counted from the first event of the alert, an alert which doesn't reach the threshold within
+// the window remains pending until the rules engine starts a new alert.
+func thresholdReached(alert *Alert) bool {
+	if alert.EventCount < alert.Threshold {
+		return false
+	}
+	if alert.ThresholdWindowMinutes == 0 {
+		return true
+	}
+	window := time.Duration(alert.ThresholdWindowMinutes) * time.Minute
+	return !alert.UpdateTime.After(alert.CreationTime.Add(window))
+}
+
+// sendPendingAlert delivers an alert which just reached its threshold and marks it as no longer pending.
+//
+// The alert is claimed first by conditionally removing the pending flag, so it is sent at most once when
+// several updates reach the threshold at the same time. If the notification fails, the flag is restored so the
+// retry of the batch can send it.
+func sendPendingAlert(alert *Alert, event *AlertDedupEvent) error {
+	claimed, err := claimPendingAlert(alert.ID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		zap.L().Debug("pending alert already sent", zap.String("alertId", alert.ID))
+		return nil
+	}
+
+	ruleInfo, err := getRuleInfo(event)
+	if err == nil {
+		err = sendAlertNotification(ruleInfo, event)
+	}
+	if err != nil {
+		if restoreErr := setAlertPending(alert.ID); restoreErr != nil {
+			zap.L().Error("failed to restore pending alert", zap.String("alertId", alert.ID), zap.Error(restoreErr))
+		}
+		return errors.Wrap(err, "failed to send pending alert")
+	}
+	return nil
+}
+
+// claimPendingAlert removes the pending flag of an alert, it returns false if the alert is no longer pending.
+func claimPendingAlert(alertID string) (bool, error) {
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Remove(expression.Name(alertTablePendingAttribute))).
+		WithCondition(expression.AttributeExists(expression.Name(alertTablePendingAttribute))).
+		Build()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = ddbClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 aws.String(env.AlertsTable),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			alertTablePartitionKey: {S: aws.String(alertID)},
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to claim pending alert")
+	}
+	return true, nil
+}
+
+// setAlertPending marks an alert as pending again after its notification could not be sent.
+func setAlertPending(alertID string) error {
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name(alertTablePendingAttribute), expression.Value(true))).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = ddbClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 aws.String(env.AlertsTable),
+		UpdateExpression:          expr.Update(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			alertTablePartitionKey: {S: aws.String(alertID)},
+		},
+	})
+	return err
+}
@@ -0,0 +1,230 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	policiesclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var thresholdRuleResponse = &models.Rule{
+	ID:                     "ruleId",
+	DisplayName:            "DisplayName",
+	Severity:               "HIGH",
+	Tags:                   []string{"Tag"},
+	Threshold:              20,
+	ThresholdWindowMinutes: 10,
+}
+
+func mockPolicyClient() *mockRoundTripper {
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+	policyConfig = policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient = policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	return mockRoundTripper
+}
+
+func TestThresholdReached(t *testing.T) {
+	creationTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	alert := &Alert{
+		AlertThreshold:  AlertThreshold{Threshold: 20, ThresholdWindowMinutes: 10},
+		AlertDedupEvent: AlertDedupEvent{CreationTime: creationTime, UpdateTime: creationTime.Add(5 * time.Minute)},
+	}
+
+	alert.EventCount = 19
+	assert.False(t, thresholdReached(alert))
+	alert.EventCount = 20
+	assert.True(t, thresholdReached(alert))
+
+	// Reached too late
+	alert.UpdateTime = creationTime.Add(11 * time.Minute)
+	assert.False(t, thresholdReached(alert))
+
+	// Without a window, only the count matters
+	alert.ThresholdWindowMinutes = 0
+	assert.True(t, thresholdReached(alert))
+
+	// Rules without a threshold alert on the first event
+	assert.True(t, thresholdReached(&Alert{AlertDedupEvent: AlertDedupEvent{EventCount: 1}}))
+}
+
+func TestHandleNewAlertPending(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	mockRoundTripper := mockPolicyClient()
+
+	event := *newAlertDedupEvent
+	event.EventCount = 1
+	expectedAlert := &Alert{
+		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		Type:            "RULE",
+		TimePartition:   "defaultPartition",
+		RuleTags:        []string{"Tag"},
+		Severity:        "HIGH",
+		RuleDisplayName: aws.String("DisplayName"),
+		Title:           aws.StringValue(event.GeneratedTitle),
+		AlertThreshold:  AlertThreshold{Threshold: 20, ThresholdWindowMinutes: 10, Pending: true},
+		AlertDedupEvent: event,
+	}
+	expectedMarshaledAlert, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(thresholdRuleResponse, http.StatusOK), nil).Once()
+	ddbMock.On("ScanPages", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
+	ddbMock.On("PutItem", &dynamodb.PutItemInput{
+		Item:      expectedMarshaledAlert,
+		TableName: aws.String("alertsTable"),
+	}).Return(&dynamodb.PutItemOutput{}, nil)
+
+	require.NoError(t, Handle(oldAlertDedupEvent, &event, &Suppressions{}))
+
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertNotCalled(t, "SendMessage", mock.Anything)
+	mockRoundTripper.AssertExpectations(t)
+}
+
+func TestHandleUpdateAlertThresholdReached(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	mockRoundTripper := mockPolicyClient()
+
+	event := *newAlertDedupEvent
+	event.EventCount = 20
+	event.UpdateTime = event.CreationTime.Add(time.Minute)
+	updatedAlert, err := dynamodbattribute.MarshalMap(&Alert{
+		ID:              "b25dc23fb2a0b362da8428dbec1381a8",
+		AlertThreshold:  AlertThreshold{Threshold: 20, ThresholdWindowMinutes: 10, Pending: true},
+		AlertDedupEvent: event,
+	})
+	require.NoError(t, err)
+
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Once()
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(thresholdRuleResponse, http.StatusOK), nil).Once()
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Once()
+
+	require.NoError(t, Handle(newAlertDedupEvent, &event, &Suppressions{}))
+
+	claim := ddbMock.Calls[1].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "REMOVE #0\n", *claim.UpdateExpression)
+	assert.Equal(t, "attribute_exists (#0)", *claim.ConditionExpression)
+	assert.Equal(t, aws.String("pending"), claim.ExpressionAttributeNames["#0"])
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+	mockRoundTripper.AssertExpectations(t)
+}
+
+func TestHandleUpdateAlertThresholdAlreadySent(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+
+	event := *newAlertDedupEvent
+	event.EventCount = 20
+	event.UpdateTime = event.CreationTime.Add(time.Minute)
+	updatedAlert, err := dynamodbattribute.MarshalMap(&Alert{
+		AlertThreshold:  AlertThreshold{Threshold: 20, Pending: true},
+		AlertDedupEvent: event,
+	})
+	require.NoError(t, err)
+
+	// Another update reached the threshold first and claimed the alert
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Once()
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+
+	require.NoError(t, Handle(newAlertDedupEvent, &event, &Suppressions{}))
+
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertNotCalled(t, "SendMessage", mock.Anything)
+}
+
+func TestHandleUpdateAlertThresholdSendFails(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	mockRoundTripper := mockPolicyClient()
+
+	event := *newAlertDedupEvent
+	event.EventCount = 20
+	event.UpdateTime = event.CreationTime.Add(time.Minute)
+	updatedAlert, err := dynamodbattribute.MarshalMap(&Alert{
+		AlertThreshold:  AlertThreshold{Threshold: 20, Pending: true},
+		AlertDedupEvent: event,
+	})
+	require.NoError(t, err)
+
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Times(3)
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(thresholdRuleResponse, http.StatusOK), nil).Once()
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, errors.New("error")).Once()
+
+	require.Error(t, Handle(newAlertDedupEvent, &event, &Suppressions{}))
+
+	// The alert is pending again, so the retry of the batch sends it
+	restore := ddbMock.Calls[2].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "SET #0 = :0\n", *restore.UpdateExpression)
+	assert.Equal(t, aws.String("pending"), restore.ExpressionAttributeNames["#0"])
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+	mockRoundTripper.AssertExpectations(t)
+}
+
+func TestHandleUpdateAlertStillPending(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbClient = ddbMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+
+	event := *newAlertDedupEvent
+	event.EventCount = 5
+	updatedAlert, err := dynamodbattribute.MarshalMap(&Alert{
+		AlertThreshold:  AlertThreshold{Threshold: 20, Pending: true},
+		AlertDedupEvent: event,
+	})
+	require.NoError(t, err)
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Once()
+
+	require.NoError(t, Handle(newAlertDedupEvent, &event, &Suppressions{}))
+
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertNotCalled(t, "SendMessage", mock.Anything)
+}
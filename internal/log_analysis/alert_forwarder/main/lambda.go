@@ -53,6 +53,7 @@ func reporterHandler(lc *lambdacontext.LambdaContext, event events.DynamoDBEvent
 	}()
 
 	// Note that if there is an error in processing any of the messages in the batch, the whole batch will be retried.
+	suppressions := &forwarder.Suppressions{}
 	for _, record := range event.Records {
 		oldAlertDedupEvent, unmarshalErr := forwarder.FromDynamodDBAttribute(record.Change.OldImage)
 		if unmarshalErr != nil {
@@ -76,7 +77,7 @@ func reporterHandler(lc *lambdacontext.LambdaContext, event events.DynamoDBEvent
 			continue
 		}
 
-		if err = forwarder.Handle(oldAlertDedupEvent, newAlertDedupEvent); err != nil {
+		if err = forwarder.Handle(oldAlertDedupEvent, newAlertDedupEvent, suppressions); err != nil {
 			return errors.Wrap(err, "encountered issue while handling deduplication event")
 		}
 	}
@@ -23,9 +23,13 @@ import (
 	"encoding/base64"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/kelseyhightower/envconfig"
 
@@ -36,10 +40,12 @@ import (
 type API struct{}
 
 var (
-	env        envConfig
-	awsSession *session.Session
-	alertsDB   table.API
-	s3Client   s3iface.S3API
+	env          envConfig
+	awsSession   *session.Session
+	alertsDB     table.API
+	s3Client     s3iface.S3API
+	athenaClient athenaiface.AthenaAPI
+	sqsClient    sqsiface.SQSAPI
 )
 
 type envConfig struct {
@@ -48,7 +54,13 @@ type envConfig struct {
 	AlertsTableName     string `required:"true" split_words:"true"`
 	RuleIndexName       string `required:"true" split_words:"true"`
 	TimeIndexName       string `required:"true" split_words:"true"`
+	SuppressionsTable   string `required:"true" split_words:"true"`
 	ProcessedDataBucket string `required:"true" split_words:"true"`
+	AthenaResultsBucket string `required:"true" split_words:"true"`
+	AlertingQueueURL    string `required:"true" split_words:"true"`
+	// Events of alerts spanning more hours or hourly partitions than this are found with Athena instead of S3 Select
+	AthenaMinHours      int `split_words:"true" default:"24"`
+	AthenaMinPartitions int `split_words:"true" default:"48"`
 }
 
 // Setup parses the environment and builds the AWS and http clients.
@@ -61,13 +73,17 @@ func Setup() {
 		Client:                             dynamodb.New(awsSession),
 		RuleIDCreationTimeIndexName:        env.RuleIndexName,
 		TimePartitionCreationTimeIndexName: env.TimeIndexName,
+		SuppressionsTableName:              env.SuppressionsTable,
 	}
 	s3Client = s3.New(awsSession)
+	athenaClient = athena.New(awsSession)
+	sqsClient = sqs.New(awsSession)
 }
 
 // Token used for paginating through the events in an alert
 type EventPaginationToken struct {
 	LogTypeToToken map[string]*LogTypeToken `json:"logTypeToToken"`
+	Athena         *AthenaToken             `json:"athena,omitempty"` // set if the events are found with Athena
 }
 
 // Token used for paginating in the events of a specific log type
@@ -76,6 +92,14 @@ type LogTypeToken struct {
 	EventIndex  int    `json:"eventIndex"`
 }
 
+// Token used for paginating through the S3 objects with alert events found by an Athena query
+type AthenaToken struct {
+	QueryExecutionID string  `json:"queryExecutionId"`
+	PageToken        *string `json:"pageToken,omitempty"` // the results page listing the S3 object, nil for the first
+	S3ObjectKey      string  `json:"s3ObjectKey"`
+	EventIndex       int     `json:"eventIndex"`
+}
+
 func newPaginationToken() *EventPaginationToken {
 	return &EventPaginationToken{LogTypeToToken: make(map[string]*LogTypeToken)}
 }
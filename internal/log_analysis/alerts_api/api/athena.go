@@ -0,0 +1,148 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/pkg/awsathena"
+)
+
+// The hourly partition of a rule matches table as a number, e.g. 2020010113 for 2020-01-01 13:00
+const (
+	partitionHourColumn = "(year * 1000000 + month * 10000 + day * 100 + hour)"
+	partitionHourFormat = "2006010215"
+)
+
+// useAthena returns true if the alert spans too many partitions to scan all of their S3 objects with S3 Select.
+func useAthena(alert *table.AlertItem) bool {
+	hours := int(alert.UpdateTime.Truncate(time.Hour).Sub(alert.CreationTime.Truncate(time.Hour))/time.Hour) + 1
+	return hours > env.AthenaMinHours || hours*len(alert.LogTypes) > env.AthenaMinPartitions
+}
+
+// getEventsWithAthena returns up to `maxResults` events of an alert.
+//
+// An Athena query lists the S3 objects which have events of the alert (instead of listing every object in every
+// partition), then only those objects are scanned with S3 Select. The query runs once, the following pages of
+// events read the next pages of the query results.
+func getEventsWithAthena(token *AthenaToken, alert *table.AlertItem, maxResults int) ([]string, *AthenaToken, error) {
+	resultToken := &AthenaToken{}
+	var queryResults *athena.GetQueryResultsOutput
+	var err error
+	if token == nil {
+		query := eventObjectsQuery(alert)
+		zap.L().Debug("listing alert events with Athena", zap.String("alertId", alert.AlertID), zap.String("query", query))
+		var startOutput *athena.StartQueryExecutionOutput
+		startOutput, err = awsathena.StartQuery(athenaClient, awsglue.RuleMatchDatabaseName, query, nil) // use default bucket
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to start Athena query")
+		}
+		resultToken.QueryExecutionID = *startOutput.QueryExecutionId
+		queryResults, err = awsathena.WaitForResults(athenaClient, resultToken.QueryExecutionID)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		*resultToken = *token
+		queryResults, err = awsathena.Results(athenaClient, token.QueryExecutionID, token.PageToken, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var result []string
+	resuming := token != nil // skip the objects before the one in the token
+	for {
+		for _, key := range eventObjectKeys(queryResults, resultToken.PageToken == nil) {
+			startIndex := 0
+			if resuming {
+				if key != token.S3ObjectKey {
+					continue
+				}
+				resuming = false
+				startIndex = token.EventIndex
+			}
+
+			events, eventIndex, err := queryS3Object(key, alert.AlertID, startIndex, maxResults-len(result))
+			if err != nil {
+				return nil, nil, err
+			}
+			result = append(result, events...)
+			resultToken.S3ObjectKey = key
+			resultToken.EventIndex = eventIndex
+			if len(result) >= maxResults {
+				return result, resultToken, nil
+			}
+		}
+
+		if queryResults.NextToken == nil {
+			return result, resultToken, nil
+		}
+		resultToken.PageToken = queryResults.NextToken
+		queryResults, err = awsathena.Results(athenaClient, resultToken.QueryExecutionID, resultToken.PageToken, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// eventObjectsQuery returns the SQL listing the S3 objects with events of the alert, in the order they are read
+func eventObjectsQuery(alert *table.AlertItem) string {
+	partitions := partitionsCondition(alert)
+	queries := make([]string, len(alert.LogTypes))
+	for i, logType := range alert.LogTypes {
+		// nolint:gosec
+		// The alertID is an MD5 hash. AlertsAPI is performing the appropriate validation
+		queries[i] = fmt.Sprintf(`SELECT DISTINCT "$path" AS path FROM %s.%s WHERE p_alert_id='%s' AND %s`,
+			awsglue.RuleMatchDatabaseName, awsglue.GetTableName(logType), alert.AlertID, partitions)
+	}
+	return strings.Join(queries, "\nUNION\n") + "\nORDER BY path"
+}
+
+// eventObjectKeys returns the S3 object keys in a page of results of the eventObjectsQuery
+func eventObjectKeys(queryResults *athena.GetQueryResultsOutput, firstPage bool) []string {
+	rows := queryResults.ResultSet.Rows
+	if firstPage && len(rows) > 0 {
+		rows = rows[1:] // the first row has the column names
+	}
+
+	bucketPrefix := "s3://" + env.ProcessedDataBucket + "/"
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Data) == 0 || row.Data[0].VarCharValue == nil {
+			continue
+		}
+		keys = append(keys, strings.TrimPrefix(*row.Data[0].VarCharValue, bucketPrefix))
+	}
+	return keys
+}
+
+// partitionsCondition returns the SQL condition selecting the hourly partitions spanned by the alert
+func partitionsCondition(alert *table.AlertItem) string {
+	return fmt.Sprintf("%s BETWEEN %s AND %s", partitionHourColumn,
+		alert.CreationTime.UTC().Format(partitionHourFormat), alert.UpdateTime.UTC().Format(partitionHourFormat))
+}
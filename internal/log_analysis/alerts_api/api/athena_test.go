@@ -0,0 +1,189 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	athenaAlertID     = "6a4b3a3679d44d33a7e59a3b9e4dd3a8"
+	firstEventObject  = "rules/logtype/year=2020/month=01/day=01/hour=01/rule_id=ruleId/20200101T010100Z-uuid4.json.gz"
+	secondEventObject = "rules/logtype/year=2020/month=01/day=03/hour=05/rule_id=ruleId/20200103T050100Z-uuid4.json.gz"
+)
+
+var athenaAlertItem = &table.AlertItem{
+	AlertID:      athenaAlertID,
+	RuleID:       "ruleId",
+	CreationTime: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC),
+	UpdateTime:   time.Date(2020, 1, 3, 5, 59, 0, 0, time.UTC),
+	Severity:     "INFO",
+	LogTypes:     []string{"Log.Type"},
+}
+
+func initAthenaTest() (*tableMock, *s3Mock, *testutils.AthenaMock) {
+	tableMock, s3Mock := initTest()
+	athenaMock := &testutils.AthenaMock{}
+	athenaClient = athenaMock
+	return tableMock, s3Mock, athenaMock
+}
+
+// selectObjectOutput returns the output of S3 Select finding the events
+func selectObjectOutput(events ...string) *s3.SelectObjectContentOutput {
+	reader := &s3SelectStreamReaderMock{}
+	reader.On("Events").Return(getChannel(events...))
+	reader.On("Err").Return(nil)
+	return &s3.SelectObjectContentOutput{EventStream: &s3.SelectObjectContentEventStream{Reader: reader}}
+}
+
+func selectObject(key string) interface{} {
+	return mock.MatchedBy(func(input *s3.SelectObjectContentInput) bool { return *input.Key == key })
+}
+
+func querySucceeded(queryID string) *athena.GetQueryExecutionOutput {
+	return &athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String(queryID),
+			Status:           &athena.QueryExecutionStatus{State: aws.String(athena.QueryExecutionStateSucceeded)},
+		},
+	}
+}
+
+func TestUseAthena(t *testing.T) {
+	alert := &table.AlertItem{
+		CreationTime: time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC),
+		UpdateTime:   time.Date(2020, 1, 1, 2, 1, 0, 0, time.UTC),
+		LogTypes:     []string{"Log.Type"},
+	}
+	assert.False(t, useAthena(alert))
+
+	alert.UpdateTime = alert.CreationTime.Add(24 * time.Hour) // 25 hourly partitions
+	assert.True(t, useAthena(alert))
+
+	alert.UpdateTime = alert.CreationTime.Add(12 * time.Hour) // 13 hours over 4 log types
+	alert.LogTypes = []string{"A", "B", "C", "D"}
+	assert.True(t, useAthena(alert))
+}
+
+func TestEventObjectsQuery(t *testing.T) {
+	alert := *athenaAlertItem
+	alert.LogTypes = []string{"AWS.CloudTrail", "AWS.S3ServerAccess"}
+	assert.Equal(t,
+		`SELECT DISTINCT "$path" AS path FROM panther_rule_matches.aws_cloudtrail `+
+			`WHERE p_alert_id='6a4b3a3679d44d33a7e59a3b9e4dd3a8' `+
+			`AND (year * 1000000 + month * 10000 + day * 100 + hour) BETWEEN 2020010101 AND 2020010305
+UNION
+SELECT DISTINCT "$path" AS path FROM panther_rule_matches.aws_s3serveraccess `+
+			`WHERE p_alert_id='6a4b3a3679d44d33a7e59a3b9e4dd3a8' `+
+			`AND (year * 1000000 + month * 10000 + day * 100 + hour) BETWEEN 2020010101 AND 2020010305
+ORDER BY path`,
+		eventObjectsQuery(&alert))
+}
+
+func TestGetAlertWithAthena(t *testing.T) {
+	tableMock, s3Mock, athenaMock := initAthenaTest()
+
+	queryResults := &athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			Rows: []*athena.Row{
+				{Data: []*athena.Datum{{VarCharValue: aws.String("path")}}},
+				{Data: []*athena.Datum{{VarCharValue: aws.String("s3://bucket/" + firstEventObject)}}},
+				{Data: []*athena.Datum{{VarCharValue: aws.String("s3://bucket/" + secondEventObject)}}},
+			},
+		},
+	}
+
+	tableMock.On("GetAlert", aws.String(athenaAlertID)).Return(athenaAlertItem, nil)
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("queryId")}, nil).Once()
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(querySucceeded("queryId"), nil).Once()
+	athenaMock.On("GetQueryResults", mock.Anything).Return(queryResults, nil)
+	s3Mock.On("SelectObjectContent", selectObject(firstEventObject)).Return(selectObjectOutput("event1"), nil).Once()
+
+	input := &models.GetAlertInput{AlertID: aws.String(athenaAlertID), EventsPageSize: aws.Int(1)}
+	result, err := API{}.GetAlert(input)
+	require.NoError(t, err)
+	assert.Equal(t, aws.StringSlice([]string{"event1"}), result.Events)
+
+	token, err := decodePaginationToken(*result.EventsLastEvaluatedKey)
+	require.NoError(t, err)
+	assert.Equal(t, &AthenaToken{QueryExecutionID: "queryId", S3ObjectKey: firstEventObject, EventIndex: 1}, token.Athena)
+
+	// The next page reads the same query results, continuing from the first object
+	s3Mock.On("SelectObjectContent", selectObject(firstEventObject)).Return(selectObjectOutput("event1"), nil).Once()
+	s3Mock.On("SelectObjectContent", selectObject(secondEventObject)).Return(selectObjectOutput("event2"), nil).Once()
+
+	input.EventsExclusiveStartKey = result.EventsLastEvaluatedKey
+	result, err = API{}.GetAlert(input)
+	require.NoError(t, err)
+	assert.Equal(t, aws.StringSlice([]string{"event2"}), result.Events)
+
+	athenaMock.AssertExpectations(t)
+	athenaMock.AssertNumberOfCalls(t, "StartQueryExecution", 1)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestGetAlertWithAthenaPagedResults(t *testing.T) {
+	tableMock, s3Mock, athenaMock := initAthenaTest()
+
+	firstPage := &athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			Rows: []*athena.Row{{Data: []*athena.Datum{{VarCharValue: aws.String("path")}}}},
+		},
+		NextToken: aws.String("nextToken"),
+	}
+	secondPage := &athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			Rows: []*athena.Row{{Data: []*athena.Datum{{VarCharValue: aws.String("s3://bucket/" + secondEventObject)}}}},
+		},
+	}
+
+	tableMock.On("GetAlert", aws.String(athenaAlertID)).Return(athenaAlertItem, nil)
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("queryId")}, nil)
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(querySucceeded("queryId"), nil)
+	athenaMock.On("GetQueryResults", &athena.GetQueryResultsInput{QueryExecutionId: aws.String("queryId")}).
+		Return(firstPage, nil)
+	athenaMock.On("GetQueryResults", &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String("queryId"),
+		NextToken:        aws.String("nextToken"),
+	}).Return(secondPage, nil)
+	s3Mock.On("SelectObjectContent", selectObject(secondEventObject)).Return(selectObjectOutput("event2"), nil)
+
+	result, err := API{}.GetAlert(&models.GetAlertInput{AlertID: aws.String(athenaAlertID), EventsPageSize: aws.Int(10)})
+	require.NoError(t, err)
+	assert.Equal(t, aws.StringSlice([]string{"event2"}), result.Events)
+
+	token, err := decodePaginationToken(*result.EventsLastEvaluatedKey)
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("nextToken"), token.Athena.PageToken)
+	athenaMock.AssertExpectations(t)
+}
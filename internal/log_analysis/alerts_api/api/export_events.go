@@ -0,0 +1,266 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/awsathena"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	exportFormatJSON = "json"
+
+	// The exports are written to the Athena results bucket, under this prefix
+	exportPrefix     = "alert_exports"
+	exportTimeFormat = "20060102T150405Z"
+
+	// How long the download links of the exported files are valid
+	exportURLExpiration = time.Hour
+)
+
+// alertEventsExport is the state of an export, encoded in its ID
+type alertEventsExport struct {
+	Format  string         `json:"format"`
+	Queries []*exportQuery `json:"queries"`
+}
+
+// exportQuery is the Athena query exporting the events of a single log type
+type exportQuery struct {
+	QueryExecutionID string `json:"queryExecutionId"`
+	Location         string `json:"location"`            // S3 prefix of the exported files
+	TempTable        string `json:"tempTable,omitempty"` // the table created by a JSON export
+}
+
+// ExportAlertEvents starts Athena queries writing every event of an alert to S3, one query per log type.
+func (API) ExportAlertEvents(input *models.ExportAlertEventsInput) (result *models.ExportAlertEventsOutput, err error) {
+	operation := common.OpLogManager.Start("exportAlertEvents")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	alertItem, err := alertsDB.GetAlert(input.AlertID)
+	if err != nil {
+		return nil, err
+	}
+	if alertItem == nil {
+		return nil, nil
+	}
+	if alertItem.Type == table.AlertTypePolicy {
+		return nil, &genericapi.InvalidInputError{Message: "policy alerts have no events to export"}
+	}
+
+	export := &alertEventsExport{Format: *input.Format}
+	exportTime := time.Now().UTC()
+	for _, logType := range alertItem.LogTypes {
+		tableName := awsglue.GetTableName(logType)
+		query := &exportQuery{
+			Location: fmt.Sprintf("s3://%s/%s/%s/%s/%s/",
+				env.AthenaResultsBucket, exportPrefix, alertItem.AlertID, exportTime.Format(exportTimeFormat), tableName),
+		}
+
+		sql := alertEventsQuery(alertItem, logType)
+		outputLocation := aws.String(query.Location)
+		if export.Format == exportFormatJSON {
+			// Athena writes query results as CSV, JSON is written by creating a table stored as JSON
+			query.TempTable = fmt.Sprintf("alert_export_%s_%d_%s", alertItem.AlertID, exportTime.Unix(), tableName)
+			sql = fmt.Sprintf("CREATE TABLE %s.%s WITH (format='JSON', external_location='%s') AS %s",
+				awsglue.TempDatabaseName, query.TempTable, query.Location, sql)
+			outputLocation = nil // use default bucket
+		}
+
+		var startOutput *athena.StartQueryExecutionOutput
+		startOutput, err = awsathena.StartQuery(athenaClient, awsglue.RuleMatchDatabaseName, sql, outputLocation)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to start export of %s events", logType)
+			return nil, err
+		}
+		query.QueryExecutionID = *startOutput.QueryExecutionId
+		export.Queries = append(export.Queries, query)
+	}
+
+	exportID, err := export.encode()
+	if err != nil {
+		return nil, err
+	}
+	return &models.ExportAlertEventsOutput{ExportID: &exportID}, nil
+}
+
+// GetAlertEventsExport returns the status of an export, with links to download the files once it succeeded.
+func (API) GetAlertEventsExport(input *models.GetAlertEventsExportInput) (result *models.GetAlertEventsExportOutput, err error) {
+	operation := common.OpLogManager.Start("getAlertEventsExport")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	export, err := decodeExportID(*input.ExportID)
+	if err != nil {
+		err = &genericapi.InvalidInputError{Message: "invalid exportId: " + err.Error()}
+		return nil, err
+	}
+
+	result = &models.GetAlertEventsExportOutput{}
+	var executions []*athena.QueryExecution
+	result.Status, result.Error, executions, err = exportStatus(export)
+	if err != nil {
+		return nil, err
+	}
+
+	if *result.Status == models.ExportStatusSucceeded {
+		for i, query := range export.Queries {
+			var files []*string
+			files, err = exportedFiles(query, executions[i])
+			if err != nil {
+				return nil, err
+			}
+			result.Files = append(result.Files, files...)
+		}
+	}
+
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result, nil
+}
+
+// exportStatus returns the status of the export queries, with the reason if they failed
+func exportStatus(export *alertEventsExport) (status, reason *string, executions []*athena.QueryExecution, err error) {
+	status = aws.String(models.ExportStatusSucceeded)
+	for _, query := range export.Queries {
+		output, err := awsathena.Status(athenaClient, query.QueryExecutionID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		execution := output.QueryExecution
+		switch aws.StringValue(execution.Status.State) {
+		case athena.QueryExecutionStateSucceeded:
+			executions = append(executions, execution)
+		case athena.QueryExecutionStateFailed, athena.QueryExecutionStateCancelled:
+			return aws.String(models.ExportStatusFailed), execution.Status.StateChangeReason, nil, nil
+		default:
+			status = aws.String(models.ExportStatusRunning)
+		}
+	}
+	return status, nil, executions, nil
+}
+
+// alertEventsQuery returns the SQL selecting the events of the alert from the rule matches table of the log type
+func alertEventsQuery(alert *table.AlertItem, logType string) string {
+	// nolint:gosec
+	// The alertID is an MD5 hash. AlertsAPI is performing the appropriate validation
+	return fmt.Sprintf("SELECT * FROM %s.%s WHERE p_alert_id='%s' AND %s ORDER BY p_event_time",
+		awsglue.RuleMatchDatabaseName, awsglue.GetTableName(logType), alert.AlertID, partitionsCondition(alert))
+}
+
+// exportedFiles returns the download links of the files written by a successful export query
+func exportedFiles(query *exportQuery, execution *athena.QueryExecution) ([]*string, error) {
+	if query.TempTable == "" {
+		// The results of the query are the CSV file
+		url, err := presignObject(*execution.ResultConfiguration.OutputLocation)
+		if err != nil {
+			return nil, err
+		}
+		return []*string{url}, nil
+	}
+
+	// The table is only needed to write the files, the files are kept when the table is dropped
+	sql := fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", awsglue.TempDatabaseName, query.TempTable)
+	if _, err := awsathena.StartQuery(athenaClient, awsglue.TempDatabaseName, sql, nil); err != nil {
+		zap.L().Warn("failed to drop export table", zap.String("table", query.TempTable), zap.Error(err))
+	}
+
+	bucket, prefix := parseS3URI(query.Location)
+	var files []*string
+	var presignErr error
+	err := s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: &bucket, Prefix: &prefix},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range page.Contents {
+				var url *string
+				url, presignErr = presignObject("s3://" + bucket + "/" + *object.Key)
+				if presignErr != nil {
+					return false
+				}
+				files = append(files, url)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list exported files in %s", query.Location)
+	}
+	return files, presignErr
+}
+
+// presignObject returns a temporary download link for the S3 object
+func presignObject(uri string) (*string, error) {
+	bucket, key := parseS3URI(uri)
+	request, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{Bucket: &bucket, Key: &key})
+	url, err := request.Presign(exportURLExpiration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to presign %s", uri)
+	}
+	return &url, nil
+}
+
+// parseS3URI splits s3://bucket/key into the bucket and the key
+func parseS3URI(uri string) (bucket, key string) {
+	parts := strings.SplitN(strings.TrimPrefix(uri, "s3://"), "/", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func (e *alertEventsExport) encode() (string, error) {
+	marshaled, err := jsoniter.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(marshaled), nil
+}
+
+func decodeExportID(exportID string) (*alertEventsExport, error) {
+	unmarshaled, err := base64.URLEncoding.DecodeString(exportID)
+	if err != nil {
+		return nil, err
+	}
+	result := &alertEventsExport{}
+	if err = jsoniter.Unmarshal(unmarshaled, result); err != nil {
+		return nil, err
+	}
+	if len(result.Queries) == 0 {
+		return nil, errors.New("no export queries")
+	}
+	return result, nil
+}
@@ -0,0 +1,211 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// presignClient builds the requests which are presigned, the presigning is done locally
+var presignClient = s3.New(session.Must(session.NewSession(&aws.Config{
+	Region:      aws.String("us-west-2"),
+	Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+})))
+
+func (m *s3Mock) GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput) {
+	m.Called(input)
+	return presignClient.GetObjectRequest(input)
+}
+
+func queryStatus(state string) *athena.GetQueryExecutionOutput {
+	return &athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			Status: &athena.QueryExecutionStatus{State: aws.String(state), StateChangeReason: aws.String("reason")},
+			ResultConfiguration: &athena.ResultConfiguration{
+				OutputLocation: aws.String("s3://results/alert_exports/alertId/20200101T000000Z/log_type/queryId.csv"),
+			},
+		},
+	}
+}
+
+func TestExportAlertEventsCSV(t *testing.T) {
+	tableMock, _, athenaMock := initAthenaTest()
+	tableMock.On("GetAlert", aws.String(athenaAlertID)).Return(athenaAlertItem, nil)
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("queryId")}, nil)
+
+	result, err := API{}.ExportAlertEvents(&models.ExportAlertEventsInput{
+		AlertID: aws.String(athenaAlertID),
+		Format:  aws.String("csv"),
+	})
+	require.NoError(t, err)
+
+	startInput := athenaMock.Calls[0].Arguments.Get(0).(*athena.StartQueryExecutionInput)
+	assert.Equal(t, "SELECT * FROM panther_rule_matches.log_type WHERE p_alert_id='6a4b3a3679d44d33a7e59a3b9e4dd3a8' "+
+		"AND (year * 1000000 + month * 10000 + day * 100 + hour) BETWEEN 2020010101 AND 2020010305 ORDER BY p_event_time",
+		*startInput.QueryString)
+	outputLocation := *startInput.ResultConfiguration.OutputLocation
+	assert.True(t, strings.HasPrefix(outputLocation, "s3://results/alert_exports/"+athenaAlertID+"/"))
+	assert.True(t, strings.HasSuffix(outputLocation, "/log_type/"))
+
+	export, err := decodeExportID(*result.ExportID)
+	require.NoError(t, err)
+	assert.Equal(t, &alertEventsExport{
+		Format:  "csv",
+		Queries: []*exportQuery{{QueryExecutionID: "queryId", Location: outputLocation}},
+	}, export)
+}
+
+func TestExportAlertEventsJSON(t *testing.T) {
+	tableMock, _, athenaMock := initAthenaTest()
+	tableMock.On("GetAlert", aws.String(athenaAlertID)).Return(athenaAlertItem, nil)
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("queryId")}, nil)
+
+	result, err := API{}.ExportAlertEvents(&models.ExportAlertEventsInput{
+		AlertID: aws.String(athenaAlertID),
+		Format:  aws.String("json"),
+	})
+	require.NoError(t, err)
+	export, err := decodeExportID(*result.ExportID)
+	require.NoError(t, err)
+	require.Len(t, export.Queries, 1)
+	assert.True(t, strings.HasPrefix(export.Queries[0].TempTable, "alert_export_"+athenaAlertID+"_"))
+
+	startInput := athenaMock.Calls[0].Arguments.Get(0).(*athena.StartQueryExecutionInput)
+	assert.True(t, strings.HasPrefix(*startInput.QueryString, "CREATE TABLE panther_temp."+export.Queries[0].TempTable+
+		" WITH (format='JSON', external_location='"+export.Queries[0].Location+"') AS SELECT * FROM"))
+	assert.Nil(t, startInput.ResultConfiguration.OutputLocation)
+}
+
+func TestExportAlertEventsDoesNotExist(t *testing.T) {
+	tableMock, _, athenaMock := initAthenaTest()
+	tableMock.On("GetAlert", aws.String(athenaAlertID)).Return(nil, nil)
+
+	result, err := API{}.ExportAlertEvents(&models.ExportAlertEventsInput{
+		AlertID: aws.String(athenaAlertID),
+		Format:  aws.String("csv"),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	athenaMock.AssertNotCalled(t, "StartQueryExecution", mock.Anything)
+}
+
+func TestExportPolicyAlertEvents(t *testing.T) {
+	tableMock, _, athenaMock := initAthenaTest()
+	tableMock.On("GetAlert", aws.String("alertId")).Return(
+		&table.AlertItem{AlertID: "alertId", Type: table.AlertTypePolicy, RuleID: "policyId"}, nil)
+
+	result, err := API{}.ExportAlertEvents(&models.ExportAlertEventsInput{
+		AlertID: aws.String("alertId"),
+		Format:  aws.String("csv"),
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	athenaMock.AssertNotCalled(t, "StartQueryExecution", mock.Anything)
+}
+
+func TestGetAlertEventsExport(t *testing.T) {
+	_, s3Mock, athenaMock := initAthenaTest()
+	exportID, err := (&alertEventsExport{
+		Format:  "csv",
+		Queries: []*exportQuery{{QueryExecutionID: "queryId", Location: "s3://results/alert_exports/"}},
+	}).encode()
+	require.NoError(t, err)
+	input := &models.GetAlertEventsExportInput{ExportID: &exportID}
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateRunning), nil).Once()
+	result, err := API{}.GetAlertEventsExport(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.GetAlertEventsExportOutput{Status: aws.String("RUNNING"), Files: []*string{}}, result)
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateFailed), nil).Once()
+	result, err = API{}.GetAlertEventsExport(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.GetAlertEventsExportOutput{
+		Status: aws.String("FAILED"), Error: aws.String("reason"), Files: []*string{}}, result)
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateSucceeded), nil).Once()
+	s3Mock.On("GetObjectRequest", &s3.GetObjectInput{
+		Bucket: aws.String("results"),
+		Key:    aws.String("alert_exports/alertId/20200101T000000Z/log_type/queryId.csv"),
+	}).Once()
+	result, err = API{}.GetAlertEventsExport(input)
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("SUCCEEDED"), result.Status)
+	require.Len(t, result.Files, 1)
+	assert.True(t, strings.HasPrefix(*result.Files[0],
+		"https://results.s3.us-west-2.amazonaws.com/alert_exports/alertId/20200101T000000Z/log_type/queryId.csv?"))
+	s3Mock.AssertExpectations(t)
+}
+
+func TestGetAlertEventsExportJSON(t *testing.T) {
+	_, s3Mock, athenaMock := initAthenaTest()
+	exportID, err := (&alertEventsExport{
+		Format: "json",
+		Queries: []*exportQuery{{
+			QueryExecutionID: "queryId",
+			Location:         "s3://results/alert_exports/alertId/20200101T000000Z/log_type/",
+			TempTable:        "alert_export_table",
+		}},
+	}).encode()
+	require.NoError(t, err)
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateSucceeded), nil)
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("dropQueryId")}, nil).Once()
+	s3Mock.listObjectsOutput = &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{{Key: aws.String("alert_exports/alertId/20200101T000000Z/log_type/file.gz")}},
+	}
+	s3Mock.On("ListObjectsV2Pages", &s3.ListObjectsV2Input{
+		Bucket: aws.String("results"),
+		Prefix: aws.String("alert_exports/alertId/20200101T000000Z/log_type/"),
+	}, mock.Anything).Return(nil).Once()
+	s3Mock.On("GetObjectRequest", mock.Anything).Once()
+
+	result, err := API{}.GetAlertEventsExport(&models.GetAlertEventsExportInput{ExportID: &exportID})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Contains(t, *result.Files[0], "/alert_exports/alertId/20200101T000000Z/log_type/file.gz?")
+
+	startInput := athenaMock.Calls[1].Arguments.Get(0).(*athena.StartQueryExecutionInput)
+	assert.Equal(t, "DROP TABLE IF EXISTS panther_temp.alert_export_table", *startInput.QueryString)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestGetAlertEventsExportInvalidID(t *testing.T) {
+	initAthenaTest()
+	_, err := API{}.GetAlertEventsExport(&models.GetAlertEventsExportInput{ExportID: aws.String("invalid")})
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+}
@@ -62,6 +62,17 @@ func (API) GetAlert(input *models.GetAlertInput) (result *models.GetAlertOutput,
 		return nil, nil
 	}
 
+	if alertItem.Type == table.AlertTypePolicy {
+		// Policy alerts are for a resource, there are no events to look up
+		result = &models.Alert{
+			AlertSummary: *alertItemToAlertSummary(alertItem),
+			Events:       []*string{},
+			Activity:     alertActivity(alertItem.Activity),
+		}
+		gatewayapi.ReplaceMapSliceNils(result)
+		return result, nil
+	}
+
 	var token *EventPaginationToken
 	if input.EventsExclusiveStartKey == nil {
 		token = newPaginationToken()
@@ -73,22 +84,17 @@ func (API) GetAlert(input *models.GetAlertInput) (result *models.GetAlertOutput,
 	}
 
 	var events []string
-	for _, logType := range alertItem.LogTypes {
-		// Each alert can contain events from multiple log types.
-		// Retrieve results from each log type.
-
-		// We only need to retrieve as many returns as to fit the EventsPageSize given by the user
-		eventsToReturn := *input.EventsPageSize - len(events)
-		eventsReturned, resultToken, getEventsErr := getEventsForLogType(logType, token.LogTypeToToken[logType], alertItem, eventsToReturn)
-		if getEventsErr != nil {
-			err = getEventsErr // set err so it is captured in oplog
+	if token.Athena != nil || (input.EventsExclusiveStartKey == nil && useAthena(alertItem)) {
+		var athenaToken *AthenaToken
+		events, athenaToken, err = getEventsWithAthena(token.Athena, alertItem, *input.EventsPageSize)
+		if err != nil {
 			return nil, err
 		}
-		token.LogTypeToToken[logType] = resultToken
-		events = append(events, eventsReturned...)
-		if len(events) >= *input.EventsPageSize {
-			// if we reached max result size, stop
-			break
+		token = &EventPaginationToken{Athena: athenaToken}
+	} else {
+		events, err = getEventsWithS3Select(token, alertItem, *input.EventsPageSize)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -97,20 +103,10 @@ func (API) GetAlert(input *models.GetAlertInput) (result *models.GetAlertOutput,
 		return nil, err
 	}
 	result = &models.Alert{
-		AlertSummary: models.AlertSummary{
-			AlertID:         &alertItem.AlertID,
-			RuleID:          &alertItem.RuleID,
-			DedupString:     &alertItem.DedupString,
-			CreationTime:    &alertItem.CreationTime,
-			UpdateTime:      &alertItem.UpdateTime,
-			EventsMatched:   &alertItem.EventCount,
-			RuleDisplayName: alertItem.RuleDisplayName,
-			Title:           getAlertTitle(alertItem),
-			RuleVersion:     &alertItem.RuleVersion,
-			Severity:        &alertItem.Severity,
-		},
+		AlertSummary:           *alertItemToAlertSummary(alertItem),
 		Events:                 aws.StringSlice(events),
 		EventsLastEvaluatedKey: aws.String(encodedToken),
+		Activity:               alertActivity(alertItem.Activity),
 	}
 
 	gatewayapi.ReplaceMapSliceNils(result)
@@ -129,6 +125,30 @@ func getAlertTitle(alert *table.AlertItem) *string {
 	return &alert.RuleID
 }
 
+// getEventsWithS3Select returns the events of an alert by scanning every S3 object in the partitions it spans,
+// updating the token of each log type
+func getEventsWithS3Select(token *EventPaginationToken, alertItem *table.AlertItem, pageSize int) ([]string, error) {
+	var events []string
+	for _, logType := range alertItem.LogTypes {
+		// Each alert can contain events from multiple log types.
+		// Retrieve results from each log type.
+
+		// We only need to retrieve as many returns as to fit the EventsPageSize given by the user
+		eventsToReturn := pageSize - len(events)
+		eventsReturned, resultToken, err := getEventsForLogType(logType, token.LogTypeToToken[logType], alertItem, eventsToReturn)
+		if err != nil {
+			return nil, err
+		}
+		token.LogTypeToToken[logType] = resultToken
+		events = append(events, eventsReturned...)
+		if len(events) >= pageSize {
+			// if we reached max result size, stop
+			break
+		}
+	}
+	return events, nil
+}
+
 // This method returns events from a specific log type that are associated to a given alert.
 // It will only return up to `maxResults` events
 func getEventsForLogType(
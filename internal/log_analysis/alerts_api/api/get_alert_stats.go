@@ -0,0 +1,194 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	defaultTopRules = 10
+	// The time range is limited so the response stays small
+	maxHourBuckets = 7 * 24
+	maxDayBuckets  = 366
+)
+
+// GetAlertStats counts the alerts created in a time range, for dashboards.
+//
+// Pending alerts, which have not reached the threshold of their rule, are not counted.
+func (API) GetAlertStats(input *models.GetAlertStatsInput) (result *models.GetAlertStatsOutput, err error) {
+	operation := common.OpLogManager.Start("getAlertStats")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	after, before := input.CreationTimeAfter.UTC(), input.CreationTimeBefore.UTC()
+	if !after.Before(before) {
+		err = &genericapi.InvalidInputError{Message: "creationTimeAfter must be before creationTimeBefore"}
+		return nil, err
+	}
+
+	bucketSize, maxBuckets := 24*time.Hour, maxDayBuckets
+	if aws.StringValue(input.BucketSize) == models.StatsBucketHour {
+		bucketSize, maxBuckets = time.Hour, maxHourBuckets
+	}
+	stats := newAlertStats(after.Truncate(bucketSize), before, bucketSize)
+	if len(stats.buckets) > maxBuckets {
+		err = &genericapi.InvalidInputError{Message: "the time range has too many buckets, use a larger bucket size"}
+		return nil, err
+	}
+
+	err = alertsDB.ForEachAlert(after, before, func(alerts []*table.AlertItem) {
+		for _, alert := range alerts {
+			if !alert.Pending {
+				stats.add(alert)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topRules := defaultTopRules
+	if input.TopRules != nil {
+		topRules = *input.TopRules
+	}
+	result = stats.output(topRules)
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result, nil
+}
+
+// alertCounter counts the alerts, and their events, with the same key
+type alertCounter struct {
+	alerts      int
+	events      int
+	displayName *string
+}
+
+type alertCounters map[string]*alertCounter
+
+func (counters alertCounters) add(key string, alert *table.AlertItem) *alertCounter {
+	counter, ok := counters[key]
+	if !ok {
+		counter = &alertCounter{}
+		counters[key] = counter
+	}
+	counter.alerts++
+	counter.events += alert.EventCount
+	return counter
+}
+
+// sorted returns the counts with the most alerts first (ties ordered by key), at most limit counts if limit > 0
+func (counters alertCounters) sorted(limit int) []*models.AlertCount {
+	result := make([]*models.AlertCount, 0, len(counters))
+	for key, counter := range counters {
+		result = append(result, &models.AlertCount{
+			Key:         aws.String(key),
+			AlertCount:  aws.Int(counter.alerts),
+			EventCount:  aws.Int(counter.events),
+			DisplayName: counter.displayName,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if *result[i].AlertCount != *result[j].AlertCount {
+			return *result[i].AlertCount > *result[j].AlertCount
+		}
+		return *result[i].Key < *result[j].Key
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// alertStats aggregates alert counts over a time range split in buckets
+type alertStats struct {
+	start       time.Time
+	bucketSize  time.Duration
+	totalAlerts int
+	totalEvents int
+	bySeverity  alertCounters
+	byLogType   alertCounters
+	byRule      alertCounters
+	buckets     []alertCounters // counts by severity in each bucket
+}
+
+func newAlertStats(start, end time.Time, bucketSize time.Duration) *alertStats {
+	buckets := make([]alertCounters, int(end.Sub(start)/bucketSize)+1)
+	for i := range buckets {
+		buckets[i] = alertCounters{}
+	}
+	return &alertStats{
+		start:      start,
+		bucketSize: bucketSize,
+		bySeverity: alertCounters{},
+		byLogType:  alertCounters{},
+		byRule:     alertCounters{},
+		buckets:    buckets,
+	}
+}
+
+func (stats *alertStats) add(alert *table.AlertItem) {
+	stats.totalAlerts++
+	stats.totalEvents += alert.EventCount
+	stats.bySeverity.add(alert.Severity, alert)
+	for _, logType := range alert.LogTypes {
+		stats.byLogType.add(logType, alert)
+	}
+	stats.byRule.add(alert.RuleID, alert).displayName = alert.RuleDisplayName
+
+	bucket := int(alert.CreationTime.Sub(stats.start) / stats.bucketSize)
+	if bucket >= 0 && bucket < len(stats.buckets) {
+		stats.buckets[bucket].add(alert.Severity, alert)
+	}
+}
+
+func (stats *alertStats) output(topRules int) *models.GetAlertStatsOutput {
+	overTime := make([]*models.AlertStatsBucket, len(stats.buckets))
+	for i, bucket := range stats.buckets {
+		alertCount := 0
+		for _, counter := range bucket {
+			alertCount += counter.alerts
+		}
+		overTime[i] = &models.AlertStatsBucket{
+			Time:       aws.Time(stats.start.Add(time.Duration(i) * stats.bucketSize)),
+			AlertCount: aws.Int(alertCount),
+			BySeverity: bucket.sorted(0),
+		}
+	}
+
+	return &models.GetAlertStatsOutput{
+		TotalAlerts: aws.Int(stats.totalAlerts),
+		TotalEvents: aws.Int(stats.totalEvents),
+		BySeverity:  stats.bySeverity.sorted(0),
+		ByLogType:   stats.byLogType.sorted(0),
+		ByRule:      stats.byRule.sorted(topRules),
+		OverTime:    overTime,
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var statsTime = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func alertCount(key string, alerts, events int) *models.AlertCount {
+	return &models.AlertCount{Key: aws.String(key), AlertCount: aws.Int(alerts), EventCount: aws.Int(events)}
+}
+
+func TestGetAlertStats(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	alerts := []*table.AlertItem{
+		{RuleID: "rule.a", RuleDisplayName: aws.String("Rule A"), Severity: "HIGH", EventCount: 5,
+			LogTypes: []string{"AWS.CloudTrail"}, CreationTime: statsTime.Add(time.Hour)},
+		{RuleID: "rule.a", RuleDisplayName: aws.String("Rule A"), Severity: "HIGH", EventCount: 1,
+			LogTypes: []string{"AWS.CloudTrail", "AWS.VPCFlow"}, CreationTime: statsTime.Add(25 * time.Hour)},
+		{RuleID: "rule.b", Severity: "LOW", EventCount: 2,
+			LogTypes: []string{"AWS.VPCFlow"}, CreationTime: statsTime.Add(26 * time.Hour)},
+		// Pending alerts are not counted
+		{RuleID: "rule.c", Severity: "CRITICAL", EventCount: 3, Pending: true, CreationTime: statsTime},
+	}
+	before := statsTime.Add(47 * time.Hour)
+	tableMock.On("ForEachAlert", statsTime, before, mock.Anything).Return(alerts, nil)
+
+	result, err := API{}.GetAlertStats(&models.GetAlertStatsInput{
+		CreationTimeAfter:  aws.Time(statsTime),
+		CreationTimeBefore: aws.Time(before),
+		BucketSize:         aws.String("day"),
+	})
+	require.NoError(t, err)
+
+	expectedRule := alertCount("rule.a", 2, 6)
+	expectedRule.DisplayName = aws.String("Rule A")
+	assert.Equal(t, &models.GetAlertStatsOutput{
+		TotalAlerts: aws.Int(3),
+		TotalEvents: aws.Int(8),
+		BySeverity:  []*models.AlertCount{alertCount("HIGH", 2, 6), alertCount("LOW", 1, 2)},
+		ByLogType:   []*models.AlertCount{alertCount("AWS.CloudTrail", 2, 6), alertCount("AWS.VPCFlow", 2, 3)},
+		ByRule:      []*models.AlertCount{expectedRule, alertCount("rule.b", 1, 2)},
+		OverTime: []*models.AlertStatsBucket{
+			{
+				Time:       aws.Time(statsTime),
+				AlertCount: aws.Int(1),
+				BySeverity: []*models.AlertCount{alertCount("HIGH", 1, 5)},
+			},
+			{
+				Time:       aws.Time(statsTime.Add(24 * time.Hour)),
+				AlertCount: aws.Int(2),
+				BySeverity: []*models.AlertCount{alertCount("HIGH", 1, 1), alertCount("LOW", 1, 2)},
+			},
+		},
+	}, result)
+	tableMock.AssertExpectations(t)
+}
+
+func TestGetAlertStatsTopRules(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	alerts := []*table.AlertItem{
+		{RuleID: "rule.b", Severity: "LOW", CreationTime: statsTime},
+		{RuleID: "rule.a", Severity: "LOW", CreationTime: statsTime},
+		{RuleID: "rule.c", Severity: "LOW", CreationTime: statsTime},
+		{RuleID: "rule.c", Severity: "LOW", CreationTime: statsTime},
+	}
+	tableMock.On("ForEachAlert", mock.Anything, mock.Anything, mock.Anything).Return(alerts, nil)
+
+	result, err := API{}.GetAlertStats(&models.GetAlertStatsInput{
+		CreationTimeAfter:  aws.Time(statsTime),
+		CreationTimeBefore: aws.Time(statsTime.Add(2 * time.Hour)),
+		BucketSize:         aws.String("hour"),
+		TopRules:           aws.Int(2),
+	})
+	require.NoError(t, err)
+	// Ties are ordered by rule ID
+	assert.Equal(t, []*models.AlertCount{alertCount("rule.c", 2, 0), alertCount("rule.a", 1, 0)}, result.ByRule)
+	assert.Len(t, result.OverTime, 3)
+	tableMock.AssertExpectations(t)
+}
+
+func TestGetAlertStatsInvalidRange(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	_, err := API{}.GetAlertStats(&models.GetAlertStatsInput{
+		CreationTimeAfter:  aws.Time(statsTime),
+		CreationTimeBefore: aws.Time(statsTime.Add(-time.Hour)),
+	})
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+
+	_, err = API{}.GetAlertStats(&models.GetAlertStatsInput{
+		CreationTimeAfter:  aws.Time(statsTime),
+		CreationTimeBefore: aws.Time(statsTime.Add(30 * 24 * time.Hour)),
+		BucketSize:         aws.String("hour"),
+	})
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	tableMock.AssertExpectations(t)
+}
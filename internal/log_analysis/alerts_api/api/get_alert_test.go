@@ -82,19 +82,32 @@ func (m *tableMock) GetAlert(input *string) (*table.AlertItem, error) {
 	return args.Get(0).(*table.AlertItem), args.Error(1)
 }
 
-func (m *tableMock) ListByRule(rule string, startKey *string, pageSize *int) ([]*table.AlertItem, *string, error) {
-	args := m.Called(rule, startKey, pageSize)
+func (m *tableMock) List(input *table.ListInput) ([]*table.AlertItem, *string, error) {
+	args := m.Called(input)
 	return args.Get(0).([]*table.AlertItem), args.Get(1).(*string), args.Error(2)
 }
 
-func (m *tableMock) ListAll(startKey *string, pageSize *int) ([]*table.AlertItem, *string, error) {
-	args := m.Called(startKey, pageSize)
-	return args.Get(0).([]*table.AlertItem), args.Get(1).(*string), args.Error(2)
+func (m *tableMock) UpdateStatus(alertID, status string, activity *table.AlertActivity) (*table.AlertItem, error) {
+	args := m.Called(alertID, status, activity)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) UpdateAssignee(alertID string, assigneeID *string, activity *table.AlertActivity) (*table.AlertItem, error) {
+	args := m.Called(alertID, assigneeID, activity)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) AddComment(alertID string, activity *table.AlertActivity) (*table.AlertItem, error) {
+	args := m.Called(alertID, activity)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
 }
 
 func init() {
 	env = envConfig{
 		ProcessedDataBucket: "bucket",
+		AthenaResultsBucket: "results",
+		AthenaMinHours:      24,
+		AthenaMinPartitions: 48,
 	}
 }
 
@@ -114,6 +127,31 @@ func TestGetAlertDoesNotExist(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetPolicyAlert(t *testing.T) {
+	tableMock, s3Mock := initTest()
+
+	alertItem := &table.AlertItem{
+		AlertID:      "alertId",
+		Type:         table.AlertTypePolicy,
+		RuleID:       "policyId",
+		ResourceID:   aws.String("resourceId"),
+		CreationTime: time.Date(2020, 1, 1, 1, 1, 0, 0, time.UTC),
+		UpdateTime:   time.Date(2020, 1, 1, 1, 1, 0, 0, time.UTC),
+		Severity:     "HIGH",
+		EventCount:   1,
+	}
+	tableMock.On("GetAlert", aws.String("alertId")).Return(alertItem, nil)
+
+	result, err := API{}.GetAlert(&models.GetAlertInput{AlertID: aws.String("alertId"), EventsPageSize: aws.Int(5)})
+	require.NoError(t, err)
+	require.Equal(t, "POLICY", *result.Type)
+	require.Equal(t, "policyId", *result.RuleID)
+	require.Equal(t, "resourceId", *result.ResourceID)
+	require.Empty(t, result.Events)
+	require.Nil(t, result.EventsLastEvaluatedKey)
+	s3Mock.AssertExpectations(t)
+}
+
 func TestGetAlert(t *testing.T) {
 	tableMock, s3Mock := initTest()
 
@@ -177,6 +215,7 @@ func TestGetAlert(t *testing.T) {
 	require.Equal(t, &models.GetAlertOutput{
 		AlertSummary: models.AlertSummary{
 			AlertID:       aws.String("alertId"),
+			Type:          aws.String("RULE"),
 			RuleID:        aws.String("ruleId"),
 			RuleVersion:   aws.String("ruleVersion"),
 			Severity:      aws.String("INFO"),
@@ -185,8 +224,11 @@ func TestGetAlert(t *testing.T) {
 			CreationTime:  aws.Time(time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)),
 			UpdateTime:    aws.Time(time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC)),
 			EventsMatched: aws.Int(5),
+			Status:        aws.String("OPEN"),
+			Tickets:       []*models.AlertTicket{},
 		},
-		Events: aws.StringSlice([]string{"testEvent"}),
+		Activity: []*models.AlertActivity{},
+		Events:   aws.StringSlice([]string{"testEvent"}),
 		EventsLastEvaluatedKey:
 		// nolint
 		aws.String("eyJsb2dUeXBlVG9Ub2tlbiI6eyJsb2d0eXBlIjp7InMzT2JqZWN0S2V5IjoicnVsZXMvbG9ndHlwZS95ZWFyPTIwMjAvbW9udGg9MDEvZGF5PTAxL2hvdXI9MDEvcnVsZV9pZD1ydWxlSWQvMjAyMDAxMDFUMDEwMTAwWi11dWlkNC5qc29uLmd6IiwiZXZlbnRJbmRleCI6MX19fQ=="),
@@ -227,6 +269,7 @@ func TestGetAlert(t *testing.T) {
 	require.Equal(t, &models.GetAlertOutput{
 		AlertSummary: models.AlertSummary{
 			AlertID:       aws.String("alertId"),
+			Type:          aws.String("RULE"),
 			RuleID:        aws.String("ruleId"),
 			RuleVersion:   aws.String("ruleVersion"),
 			Severity:      aws.String("INFO"),
@@ -235,8 +278,11 @@ func TestGetAlert(t *testing.T) {
 			CreationTime:  aws.Time(time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)),
 			UpdateTime:    aws.Time(time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC)),
 			EventsMatched: aws.Int(5),
+			Status:        aws.String("OPEN"),
+			Tickets:       []*models.AlertTicket{},
 		},
-		Events: aws.StringSlice([]string{}),
+		Activity: []*models.AlertActivity{},
+		Events:   aws.StringSlice([]string{}),
 		EventsLastEvaluatedKey:
 		// nolint
 		aws.String("eyJsb2dUeXBlVG9Ub2tlbiI6eyJsb2d0eXBlIjp7InMzT2JqZWN0S2V5IjoicnVsZXMvbG9ndHlwZS95ZWFyPTIwMjAvbW9udGg9MDEvZGF5PTAxL2hvdXI9MDEvcnVsZV9pZD1ydWxlSWQvMjAyMDAxMDFUMDEwMTAwWi11dWlkNC5qc29uLmd6IiwiZXZlbnRJbmRleCI6MH19fQ=="),
@@ -299,6 +345,7 @@ func TestGetAlertFilterOutS3KeysOutsideTheTimePeriod(t *testing.T) {
 	require.Equal(t, &models.GetAlertOutput{
 		AlertSummary: models.AlertSummary{
 			AlertID:       aws.String("alertId"),
+			Type:          aws.String("RULE"),
 			RuleID:        aws.String("ruleId"),
 			RuleVersion:   aws.String("ruleVersion"),
 			Title:         aws.String("ruleId"),
@@ -307,8 +354,11 @@ func TestGetAlertFilterOutS3KeysOutsideTheTimePeriod(t *testing.T) {
 			EventsMatched: aws.Int(5),
 			Severity:      aws.String("INFO"),
 			DedupString:   aws.String("dedupString"),
+			Status:        aws.String("OPEN"),
+			Tickets:       []*models.AlertTicket{},
 		},
-		Events: aws.StringSlice([]string{"testEvent"}),
+		Activity: []*models.AlertActivity{},
+		Events:   aws.StringSlice([]string{"testEvent"}),
 		EventsLastEvaluatedKey:
 		// nolint
 		aws.String("eyJsb2dUeXBlVG9Ub2tlbiI6eyJsb2d0eXBlIjp7InMzT2JqZWN0S2V5IjoicnVsZXMvbG9ndHlwZS95ZWFyPTIwMjAvbW9udGg9MDEvZGF5PTAxL2hvdXI9MDEvcnVsZV9pZD1ydWxlSWQvMjAyMDAxMDFUMDEwNTAwWi11dWlkNC5qc29uLmd6IiwiZXZlbnRJbmRleCI6MX19fQ=="),
@@ -338,3 +388,24 @@ func initTest() (*tableMock, *s3Mock) {
 
 	return tableMock, s3Mock
 }
+
+func (m *tableMock) AddSuppression(suppression *table.SuppressionItem) error {
+	args := m.Called(suppression)
+	return args.Error(0)
+}
+
+func (m *tableMock) ListSuppressions(now time.Time) ([]*table.SuppressionItem, error) {
+	args := m.Called(now)
+	return args.Get(0).([]*table.SuppressionItem), args.Error(1)
+}
+
+func (m *tableMock) DeleteSuppression(suppressionID string) error {
+	args := m.Called(suppressionID)
+	return args.Error(0)
+}
+
+func (m *tableMock) ForEachAlert(creationTimeAfter, creationTimeBefore time.Time, handler func([]*table.AlertItem)) error {
+	args := m.Called(creationTimeAfter, creationTimeBefore, handler)
+	handler(args.Get(0).([]*table.AlertItem))
+	return args.Error(1)
+}
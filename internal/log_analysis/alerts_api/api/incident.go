@@ -0,0 +1,80 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+// Incident actions sent to outputs like PagerDuty and Opsgenie when an alert is triaged
+var incidentActions = map[string]string{
+	models.AlertStatusTriaged:       alertmodels.AcknowledgeAction,
+	models.AlertStatusClosed:        alertmodels.ResolveAction,
+	models.AlertStatusFalsePositive: alertmodels.ResolveAction,
+}
+
+// queueIncidentAction sends the incident action for the new status of an alert to alert delivery.
+//
+// Statuses without an action, like reopening an alert, send nothing.
+func queueIncidentAction(item *table.AlertItem) error {
+	action, ok := incidentActions[item.Status]
+	if !ok || item.Suppressed || item.Pending {
+		// Suppressed and pending alerts were never delivered, so there is no incident to update
+		return nil
+	}
+
+	alertType := alertmodels.RuleType
+	if item.Type == table.AlertTypePolicy {
+		alertType = alertmodels.PolicyType
+	}
+	alert := &alertmodels.Alert{
+		AlertID:         aws.String(item.AlertID),
+		Action:          aws.String(action),
+		CreatedAt:       aws.Time(item.CreationTime),
+		PolicyID:        aws.String(item.RuleID),
+		PolicyName:      item.RuleDisplayName,
+		PolicyVersionID: aws.String(item.RuleVersion),
+		Severity:        aws.String(item.Severity),
+		Type:            aws.String(alertType),
+		Title:           item.Title,
+		ResourceID:      item.ResourceID,
+	}
+	body, err := jsoniter.MarshalToString(alert)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal incident action")
+	}
+	_, err = sqsClient.SendMessage(&sqs.SendMessageInput{
+		QueueUrl:    aws.String(env.AlertingQueueURL),
+		MessageBody: aws.String(body),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to queue incident action")
+	}
+
+	zap.L().Info("queued incident action", zap.String("alertId", item.AlertID), zap.String("action", action))
+	return nil
+}
@@ -0,0 +1,105 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestQueueIncidentActionResolve(t *testing.T) {
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	env.AlertingQueueURL = "queueUrl"
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+
+	item := triagedAlertItem()
+	item.Status = models.AlertStatusClosed
+	item.Type = table.AlertTypePolicy
+	item.ResourceID = aws.String("resourceId")
+	require.NoError(t, queueIncidentAction(item))
+
+	input := sqsMock.Calls[0].Arguments.Get(0).(*sqs.SendMessageInput)
+	assert.Equal(t, "queueUrl", *input.QueueUrl)
+	var alert alertmodels.Alert
+	require.NoError(t, jsoniter.UnmarshalFromString(*input.MessageBody, &alert))
+	assert.True(t, timeInTest.Equal(*alert.CreatedAt))
+	alert.CreatedAt = nil
+	assert.Equal(t, alertmodels.Alert{
+		AlertID:         aws.String(triageAlertID),
+		Action:          aws.String("resolve"),
+		PolicyID:        aws.String("ruleId"),
+		PolicyVersionID: aws.String("ruleVersion"),
+		Severity:        aws.String("INFO"),
+		Type:            aws.String("POLICY"),
+		ResourceID:      aws.String("resourceId"),
+	}, alert)
+	sqsMock.AssertExpectations(t)
+}
+
+func TestQueueIncidentActionNoAction(t *testing.T) {
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+
+	// Reopening an alert does not trigger its incidents again
+	item := triagedAlertItem()
+	item.Status = models.AlertStatusOpen
+	require.NoError(t, queueIncidentAction(item))
+
+	// Suppressed alerts were never delivered
+	item.Status = models.AlertStatusClosed
+	item.Suppressed = true
+	require.NoError(t, queueIncidentAction(item))
+	sqsMock.AssertExpectations(t)
+}
+
+func TestUpdateAlertStatusQueueError(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+
+	closed := triagedAlertItem()
+	closed.Status = models.AlertStatusFalsePositive
+	tableMock.On("UpdateStatus", triageAlertID, "FALSE_POSITIVE", mock.Anything).Return(closed, nil)
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, errors.New("queue unavailable"))
+
+	// The status change is saved even if the incident can't be resolved
+	result, err := API{}.UpdateAlertStatus(&models.UpdateAlertStatusInput{
+		AlertID: aws.String(triageAlertID),
+		Status:  aws.String("FALSE_POSITIVE"),
+		UserID:  aws.String(triageUserID),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("FALSE_POSITIVE"), result.Status)
+	tableMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+}
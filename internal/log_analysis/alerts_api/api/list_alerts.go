@@ -19,6 +19,8 @@ package api
  */
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+
 	"github.com/panther-labs/panther/api/lambda/alerts/models"
 	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
@@ -35,11 +37,7 @@ func (API) ListAlerts(input *models.ListAlertsInput) (result *models.ListAlertsO
 
 	result = &models.ListAlertsOutput{}
 	var alertItems []*table.AlertItem
-	if input.RuleID != nil { // list per specific ruleId
-		alertItems, result.LastEvaluatedKey, err = alertsDB.ListByRule(*input.RuleID, input.ExclusiveStartKey, input.PageSize)
-	} else { // list all alerts time desc order
-		alertItems, result.LastEvaluatedKey, err = alertsDB.ListAll(input.ExclusiveStartKey, input.PageSize)
-	}
+	alertItems, result.LastEvaluatedKey, err = alertsDB.List(listAlertsInputToListInput(input))
 	if err != nil {
 		return nil, err
 	}
@@ -50,24 +48,77 @@ func (API) ListAlerts(input *models.ListAlertsInput) (result *models.ListAlertsO
 	return result, nil
 }
 
+// listAlertsInputToListInput converts the API request to a table query
+func listAlertsInputToListInput(input *models.ListAlertsInput) *table.ListInput {
+	return &table.ListInput{
+		RuleID:             input.RuleID,
+		Type:               input.Type,
+		Statuses:           aws.StringValueSlice(input.Status),
+		AssigneeID:         input.AssigneeID,
+		Severities:         aws.StringValueSlice(input.Severity),
+		LogTypes:           aws.StringValueSlice(input.LogTypes),
+		RuleTags:           aws.StringValueSlice(input.Tags),
+		TitleContains:      input.TitleContains,
+		CreationTimeAfter:  input.CreationTimeAfter,
+		CreationTimeBefore: input.CreationTimeBefore,
+		UpdateTimeAfter:    input.UpdateTimeAfter,
+		UpdateTimeBefore:   input.UpdateTimeBefore,
+		EventCountMin:      input.EventCountMin,
+		EventCountMax:      input.EventCountMax,
+		SortBy:             aws.StringValue(input.SortBy),
+		SortDescending:     aws.StringValue(input.SortDir) != "ascending", // newest first by default
+		PageSize:           input.PageSize,
+		ExclusiveStartKey:  input.ExclusiveStartKey,
+	}
+}
+
 // alertItemsToAlertSummary converts a DDB Alert Item to an Alert Summary that will be returned by the API
 func alertItemsToAlertSummary(items []*table.AlertItem) []*models.AlertSummary {
 	result := make([]*models.AlertSummary, len(items))
 
 	for i, item := range items {
-		result[i] = &models.AlertSummary{
-			AlertID:         &item.AlertID,
-			RuleID:          &item.RuleID,
-			DedupString:     &item.DedupString,
-			CreationTime:    &item.CreationTime,
-			Severity:        &item.Severity,
-			UpdateTime:      &item.UpdateTime,
-			EventsMatched:   &item.EventCount,
-			RuleDisplayName: item.RuleDisplayName,
-			Title:           getAlertTitle(item),
-			RuleVersion:     &item.RuleVersion,
-		}
+		result[i] = alertItemToAlertSummary(item)
 	}
 
 	return result
 }
+
+// alertItemToAlertSummary converts a single DDB Alert Item to an Alert Summary
+func alertItemToAlertSummary(item *table.AlertItem) *models.AlertSummary {
+	status := item.Status
+	if status == "" {
+		status = models.AlertStatusOpen
+	}
+	alertType := item.Type
+	if alertType == "" {
+		alertType = models.AlertTypeRule
+	}
+
+	summary := &models.AlertSummary{
+		AlertID:         &item.AlertID,
+		Type:            &alertType,
+		RuleID:          &item.RuleID,
+		DedupString:     &item.DedupString,
+		CreationTime:    &item.CreationTime,
+		Severity:        &item.Severity,
+		UpdateTime:      &item.UpdateTime,
+		EventsMatched:   &item.EventCount,
+		RuleDisplayName: item.RuleDisplayName,
+		Title:           getAlertTitle(item),
+		RuleVersion:     &item.RuleVersion,
+		Status:          &status,
+		AssigneeID:      item.AssigneeID,
+		LastUpdatedBy:   item.LastUpdatedBy,
+		LastUpdatedTime: item.LastUpdatedTime,
+		SuppressionID:   item.SuppressionID,
+		ResourceID:      item.ResourceID,
+		Tickets:         alertTickets(item.Tickets),
+	}
+	if item.Suppressed {
+		summary.Suppressed = aws.Bool(true)
+	}
+	if item.Pending {
+		summary.Pending = aws.Bool(true)
+	}
+	return summary
+}
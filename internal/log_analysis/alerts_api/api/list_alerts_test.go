@@ -55,12 +55,15 @@ var (
 			RuleVersion:     aws.String("ruleVersion"),
 			RuleDisplayName: aws.String("ruleDisplayName"),
 			AlertID:         aws.String("alertId"),
+			Type:            aws.String("RULE"),
 			UpdateTime:      aws.Time(timeInTest),
 			CreationTime:    aws.Time(timeInTest),
 			Severity:        aws.String("INFO"),
 			DedupString:     aws.String("dedupString"),
 			EventsMatched:   aws.Int(100),
 			Title:           aws.String("title"),
+			Status:          aws.String("OPEN"),
+			Tickets:         []*models.AlertTicket{},
 		},
 	}
 )
@@ -75,7 +78,8 @@ func TestListAlertsForRule(t *testing.T) {
 		ExclusiveStartKey: aws.String("startKey"),
 	}
 
-	tableMock.On("ListByRule", "ruleId", aws.String("startKey"), aws.Int(10)).
+	tableMock.On("List", listInput(&table.ListInput{
+		RuleID: aws.String("ruleId"), PageSize: aws.Int(10), ExclusiveStartKey: aws.String("startKey")})).
 		Return(alertItems, aws.String("lastKey"), nil)
 	result, err := API{}.ListAlerts(input)
 	require.NoError(t, err)
@@ -95,7 +99,7 @@ func TestListAllAlerts(t *testing.T) {
 		ExclusiveStartKey: aws.String("startKey"),
 	}
 
-	tableMock.On("ListAll", aws.String("startKey"), aws.Int(10)).
+	tableMock.On("List", listInput(&table.ListInput{PageSize: aws.Int(10), ExclusiveStartKey: aws.String("startKey")})).
 		Return(alertItems, aws.String("lastKey"), nil)
 	result, err := API{}.ListAlerts(input)
 	require.NoError(t, err)
@@ -143,17 +147,21 @@ func TestListAllAlertsWithoutTitle(t *testing.T) {
 			RuleID:        aws.String("ruleId"),
 			RuleVersion:   aws.String("ruleVersion"),
 			AlertID:       aws.String("alertId"),
+			Type:          aws.String("RULE"),
 			UpdateTime:    aws.Time(timeInTest),
 			CreationTime:  aws.Time(timeInTest),
 			Severity:      aws.String("INFO"),
 			DedupString:   aws.String("dedupString"),
 			EventsMatched: aws.Int(100),
 			Title:         aws.String("ruleId"),
+			Status:        aws.String("OPEN"),
+			Tickets:       []*models.AlertTicket{},
 		},
 		{
 			RuleID:          aws.String("ruleId"),
 			RuleVersion:     aws.String("ruleVersion"),
 			AlertID:         aws.String("alertId"),
+			Type:            aws.String("RULE"),
 			UpdateTime:      aws.Time(timeInTest),
 			CreationTime:    aws.Time(timeInTest),
 			Severity:        aws.String("INFO"),
@@ -162,7 +170,9 @@ func TestListAllAlertsWithoutTitle(t *testing.T) {
 			RuleDisplayName: aws.String("ruleDisplayName"),
 			// Since there is no dynamically generated title,
 			// we return the display name
-			Title: aws.String("ruleDisplayName"),
+			Title:   aws.String("ruleDisplayName"),
+			Status:  aws.String("OPEN"),
+			Tickets: []*models.AlertTicket{},
 		},
 	}
 
@@ -171,7 +181,7 @@ func TestListAllAlertsWithoutTitle(t *testing.T) {
 		ExclusiveStartKey: aws.String("startKey"),
 	}
 
-	tableMock.On("ListAll", aws.String("startKey"), aws.Int(10)).
+	tableMock.On("List", listInput(&table.ListInput{PageSize: aws.Int(10), ExclusiveStartKey: aws.String("startKey")})).
 		Return(alertItems, aws.String("lastKey"), nil)
 	result, err := API{}.ListAlerts(input)
 	require.NoError(t, err)
@@ -181,3 +191,50 @@ func TestListAllAlertsWithoutTitle(t *testing.T) {
 		LastEvaluatedKey: aws.String("lastKey"),
 	}, result)
 }
+
+func TestListAlertsFilteredAndSorted(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListAlertsInput{
+		PageSize:          aws.Int(10),
+		Severity:          aws.StringSlice([]string{"HIGH", "CRITICAL"}),
+		LogTypes:          aws.StringSlice([]string{"AWS.CloudTrail"}),
+		Tags:              aws.StringSlice([]string{"PCI"}),
+		TitleContains:     aws.String("root"),
+		CreationTimeAfter: aws.Time(timeInTest),
+		UpdateTimeBefore:  aws.Time(timeInTest.Add(time.Hour)),
+		EventCountMin:     aws.Int(10),
+		EventCountMax:     aws.Int(100),
+		SortBy:            aws.String("severity"),
+		SortDir:           aws.String("ascending"),
+	}
+
+	expectedInput := &table.ListInput{
+		Statuses:          []string{},
+		Severities:        []string{"HIGH", "CRITICAL"},
+		LogTypes:          []string{"AWS.CloudTrail"},
+		RuleTags:          []string{"PCI"},
+		TitleContains:     aws.String("root"),
+		CreationTimeAfter: aws.Time(timeInTest),
+		UpdateTimeBefore:  aws.Time(timeInTest.Add(time.Hour)),
+		EventCountMin:     aws.Int(10),
+		EventCountMax:     aws.Int(100),
+		SortBy:            table.SortBySeverity,
+		SortDescending:    false,
+		PageSize:          aws.Int(10),
+	}
+	tableMock.On("List", expectedInput).Return(alertItems, (*string)(nil), nil)
+
+	result, err := API{}.ListAlerts(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ListAlertsOutput{Alerts: expectedAlertSummary}, result)
+	tableMock.AssertExpectations(t)
+}
+
+// listInput fills in the empty filters of a list input which has no severity, status, log type or tag filters
+func listInput(input *table.ListInput) *table.ListInput {
+	input.Statuses, input.Severities, input.LogTypes, input.RuleTags = []string{}, []string{}, []string{}, []string{}
+	input.SortDescending = true
+	return input
+}
@@ -0,0 +1,118 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/uuid"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// Suppressions are time-boxed, they can't mute alerts for longer than this
+const maxSuppressionDuration = 90 * 24 * time.Hour
+
+// CreateAlertSuppression mutes new alerts matching the input until it expires.
+func (API) CreateAlertSuppression(input *models.CreateAlertSuppressionInput) (result *models.AlertSuppression, err error) {
+	operation := common.OpLogManager.Start("createAlertSuppression")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	now := time.Now().UTC()
+	if !input.ExpiresAt.After(now) || input.ExpiresAt.Sub(now) > maxSuppressionDuration {
+		err = &genericapi.InvalidInputError{Message: "expiresAt must be in the future, at most 90 days from now"}
+		return nil, err
+	}
+	if input.Value != nil && input.Field == nil {
+		err = &genericapi.InvalidInputError{Message: "value requires a field to match"}
+		return nil, err
+	}
+
+	suppression := &table.SuppressionItem{
+		ID:          uuid.New().String(),
+		RuleID:      input.RuleID,
+		DedupString: input.DedupString,
+		Field:       input.Field,
+		Value:       input.Value,
+		ExpiresAt:   input.ExpiresAt.Unix(),
+		Reason:      *input.Reason,
+		CreatedBy:   *input.UserID,
+		CreatedAt:   now,
+	}
+	if err = alertsDB.AddSuppression(suppression); err != nil {
+		return nil, err
+	}
+	return suppressionItemToAlertSuppression(suppression), nil
+}
+
+// ListAlertSuppressions returns the suppressions which have not expired.
+func (API) ListAlertSuppressions(input *models.ListAlertSuppressionsInput) (result models.ListAlertSuppressionsOutput, err error) {
+	operation := common.OpLogManager.Start("listAlertSuppressions")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	suppressions, err := alertsDB.ListSuppressions(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result = make(models.ListAlertSuppressionsOutput, len(suppressions))
+	for i, suppression := range suppressions {
+		result[i] = suppressionItemToAlertSuppression(suppression)
+	}
+	return result, nil
+}
+
+// DeleteAlertSuppression removes a suppression, new alerts it matched are delivered again.
+func (API) DeleteAlertSuppression(input *models.DeleteAlertSuppressionInput) (err error) {
+	operation := common.OpLogManager.Start("deleteAlertSuppression")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	return alertsDB.DeleteSuppression(*input.SuppressionID)
+}
+
+// suppressionItemToAlertSuppression converts a DDB suppression to the API format
+func suppressionItemToAlertSuppression(item *table.SuppressionItem) *models.AlertSuppression {
+	result := &models.AlertSuppression{
+		SuppressionID: aws.String(item.ID),
+		RuleID:        item.RuleID,
+		DedupString:   item.DedupString,
+		Field:         item.Field,
+		Value:         item.Value,
+		ExpiresAt:     aws.Time(time.Unix(item.ExpiresAt, 0).UTC()),
+		Reason:        aws.String(item.Reason),
+		CreatedBy:     aws.String(item.CreatedBy),
+		CreatedAt:     aws.Time(item.CreatedAt),
+	}
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result
+}
@@ -0,0 +1,147 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const suppressionUserID = "6a4b3a36-79d4-4d33-a7e5-9a3b9e4dd3a8"
+
+func TestCreateAlertSuppression(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	input := &models.CreateAlertSuppressionInput{
+		RuleID:    aws.String("ruleId"),
+		Field:     aws.String("severity"),
+		Value:     aws.String("LOW"),
+		ExpiresAt: aws.Time(expiresAt),
+		Reason:    aws.String("noisy during migration"),
+		UserID:    aws.String(suppressionUserID),
+	}
+
+	var stored *table.SuppressionItem
+	tableMock.On("AddSuppression", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*table.SuppressionItem)
+	})
+
+	result, err := API{}.CreateAlertSuppression(input)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.NotEmpty(t, stored.ID)
+	assert.Equal(t, expiresAt.Unix(), stored.ExpiresAt)
+	assert.Equal(t, suppressionUserID, stored.CreatedBy)
+
+	assert.Equal(t, &models.AlertSuppression{
+		SuppressionID: aws.String(stored.ID),
+		RuleID:        aws.String("ruleId"),
+		Field:         aws.String("severity"),
+		Value:         aws.String("LOW"),
+		ExpiresAt:     aws.Time(expiresAt),
+		Reason:        aws.String("noisy during migration"),
+		CreatedBy:     aws.String(suppressionUserID),
+		CreatedAt:     aws.Time(stored.CreatedAt),
+	}, result)
+	tableMock.AssertExpectations(t)
+}
+
+func TestCreateAlertSuppressionInvalidExpiration(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	for _, expiresAt := range []time.Time{time.Now().Add(-time.Minute), time.Now().Add(100 * 24 * time.Hour)} {
+		_, err := API{}.CreateAlertSuppression(&models.CreateAlertSuppressionInput{
+			DedupString: aws.String("dedup"),
+			ExpiresAt:   aws.Time(expiresAt),
+			Reason:      aws.String("reason"),
+			UserID:      aws.String(suppressionUserID),
+		})
+		assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	}
+	tableMock.AssertExpectations(t)
+}
+
+func TestCreateAlertSuppressionValueWithoutField(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	_, err := API{}.CreateAlertSuppression(&models.CreateAlertSuppressionInput{
+		DedupString: aws.String("dedup"),
+		Value:       aws.String("LOW"),
+		ExpiresAt:   aws.Time(time.Now().Add(time.Hour)),
+		Reason:      aws.String("reason"),
+		UserID:      aws.String(suppressionUserID),
+	})
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	tableMock.AssertExpectations(t)
+}
+
+func TestListAlertSuppressions(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	item := &table.SuppressionItem{
+		ID:          "suppressionId",
+		DedupString: aws.String("dedup"),
+		ExpiresAt:   timeInTest.Unix(),
+		Reason:      "reason",
+		CreatedBy:   suppressionUserID,
+		CreatedAt:   timeInTest,
+	}
+	tableMock.On("ListSuppressions", mock.Anything).Return([]*table.SuppressionItem{item}, nil)
+
+	result, err := API{}.ListAlertSuppressions(&models.ListAlertSuppressionsInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.ListAlertSuppressionsOutput{
+		{
+			SuppressionID: aws.String("suppressionId"),
+			DedupString:   aws.String("dedup"),
+			ExpiresAt:     aws.Time(time.Unix(timeInTest.Unix(), 0).UTC()),
+			Reason:        aws.String("reason"),
+			CreatedBy:     aws.String(suppressionUserID),
+			CreatedAt:     aws.Time(timeInTest),
+		},
+	}, result)
+	tableMock.AssertExpectations(t)
+}
+
+func TestDeleteAlertSuppression(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("DeleteSuppression", "suppressionId").Return(errors.New("error")).Once()
+	assert.Error(t, API{}.DeleteAlertSuppression(&models.DeleteAlertSuppressionInput{SuppressionID: aws.String("suppressionId")}))
+
+	tableMock.On("DeleteSuppression", "suppressionId").Return(nil).Once()
+	assert.NoError(t, API{}.DeleteAlertSuppression(&models.DeleteAlertSuppressionInput{SuppressionID: aws.String("suppressionId")}))
+	tableMock.AssertExpectations(t)
+}
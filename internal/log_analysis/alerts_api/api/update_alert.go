@@ -0,0 +1,139 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// UpdateAlertStatus sets the triage status of an alert.
+func (API) UpdateAlertStatus(input *models.UpdateAlertStatusInput) (result *models.UpdateAlertOutput, err error) {
+	operation := common.OpLogManager.Start("updateAlertStatus")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	activity := newActivity(models.AlertActivityStatus, *input.UserID)
+	activity.Status = *input.Status
+	alertItem, err := alertsDB.UpdateStatus(*input.AlertID, *input.Status, activity)
+	if err != nil {
+		return nil, err
+	}
+	if alertItem != nil {
+		// The status is already saved, so a failure to update the incident is logged rather than returned
+		if queueErr := queueIncidentAction(alertItem); queueErr != nil {
+			zap.L().Error("failed to update the alert incident", zap.String("alertId", alertItem.AlertID), zap.Error(queueErr))
+		}
+	}
+	return updateAlertOutput(alertItem), nil
+}
+
+// UpdateAlertAssignee assigns an alert to a user, or unassigns it.
+func (API) UpdateAlertAssignee(input *models.UpdateAlertAssigneeInput) (result *models.UpdateAlertOutput, err error) {
+	operation := common.OpLogManager.Start("updateAlertAssignee")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	activity := newActivity(models.AlertActivityAssignee, *input.UserID)
+	activity.AssigneeID = input.AssigneeID
+	alertItem, err := alertsDB.UpdateAssignee(*input.AlertID, input.AssigneeID, activity)
+	if err != nil {
+		return nil, err
+	}
+	return updateAlertOutput(alertItem), nil
+}
+
+// AddAlertComment appends a comment to the alert activity.
+func (API) AddAlertComment(input *models.AddAlertCommentInput) (result *models.UpdateAlertOutput, err error) {
+	operation := common.OpLogManager.Start("addAlertComment")
+	defer func() {
+		operation.Stop()
+		operation.Log(err)
+	}()
+
+	activity := newActivity(models.AlertActivityComment, *input.UserID)
+	activity.Comment = *input.Comment
+	alertItem, err := alertsDB.AddComment(*input.AlertID, activity)
+	if err != nil {
+		return nil, err
+	}
+	return updateAlertOutput(alertItem), nil
+}
+
+func newActivity(activityType, userID string) *table.AlertActivity {
+	return &table.AlertActivity{Type: activityType, UserID: userID, Time: time.Now().UTC()}
+}
+
+// updateAlertOutput converts the updated alert to the API response, nil if the alert does not exist
+func updateAlertOutput(item *table.AlertItem) *models.UpdateAlertOutput {
+	if item == nil {
+		return nil
+	}
+	result := &models.UpdateAlertOutput{
+		AlertSummary: *alertItemToAlertSummary(item),
+		Activity:     alertActivity(item.Activity),
+	}
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result
+}
+
+// alertActivity converts the DDB audit trail of an alert to the API format
+func alertActivity(items []*table.AlertActivity) []*models.AlertActivity {
+	result := make([]*models.AlertActivity, len(items))
+	for i, item := range items {
+		result[i] = &models.AlertActivity{
+			Type:       aws.String(item.Type),
+			UserID:     aws.String(item.UserID),
+			Time:       aws.Time(item.Time),
+			AssigneeID: item.AssigneeID,
+		}
+		if item.Status != "" {
+			result[i].Status = aws.String(item.Status)
+		}
+		if item.Comment != "" {
+			result[i].Comment = aws.String(item.Comment)
+		}
+	}
+	return result
+}
+
+func alertTickets(items []*table.AlertTicket) []*models.AlertTicket {
+	result := make([]*models.AlertTicket, len(items))
+	for i, item := range items {
+		result[i] = &models.AlertTicket{
+			OutputID:  aws.String(item.OutputID),
+			System:    aws.String(item.System),
+			Key:       aws.String(item.Key),
+			URL:       aws.String(item.URL),
+			CreatedAt: aws.Time(item.CreatedAt),
+		}
+	}
+	return result
+}
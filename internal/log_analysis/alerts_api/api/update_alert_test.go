@@ -0,0 +1,170 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	triageAlertID = "6c3a6b0f8b56f8f6b3ad4bd1d1b0cd30"
+	triageUserID  = "6a4b3a36-79d4-4d33-a7e5-9a3b9e4dd3a8"
+)
+
+func triagedAlertItem(activity ...*table.AlertActivity) *table.AlertItem {
+	return &table.AlertItem{
+		AlertID:         triageAlertID,
+		RuleID:          "ruleId",
+		RuleVersion:     "ruleVersion",
+		CreationTime:    timeInTest,
+		UpdateTime:      timeInTest,
+		Severity:        "INFO",
+		EventCount:      1,
+		Status:          models.AlertStatusTriaged,
+		AssigneeID:      aws.String(triageUserID),
+		LastUpdatedBy:   aws.String(triageUserID),
+		LastUpdatedTime: aws.Time(timeInTest),
+		Activity:        activity,
+	}
+}
+
+func TestUpdateAlertStatus(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+
+	activity := &table.AlertActivity{Type: "STATUS", UserID: triageUserID, Time: timeInTest, Status: "TRIAGED"}
+	tableMock.On("UpdateStatus", triageAlertID, "TRIAGED", mock.Anything).Return(triagedAlertItem(activity), nil)
+
+	result, err := API{}.UpdateAlertStatus(&models.UpdateAlertStatusInput{
+		AlertID: aws.String(triageAlertID),
+		Status:  aws.String("TRIAGED"),
+		UserID:  aws.String(triageUserID),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("TRIAGED"), result.Status)
+	assert.Equal(t, aws.String(triageUserID), result.LastUpdatedBy)
+	assert.Equal(t, []*models.AlertActivity{{
+		Type:   aws.String("STATUS"),
+		UserID: aws.String(triageUserID),
+		Time:   aws.Time(timeInTest),
+		Status: aws.String("TRIAGED"),
+	}}, result.Activity)
+
+	// The change is recorded with the acting user
+	recorded := tableMock.Calls[0].Arguments.Get(2).(*table.AlertActivity)
+	assert.Equal(t, "STATUS", recorded.Type)
+	assert.Equal(t, triageUserID, recorded.UserID)
+	assert.Equal(t, "TRIAGED", recorded.Status)
+	assert.WithinDuration(t, time.Now(), recorded.Time, time.Minute)
+	tableMock.AssertExpectations(t)
+	// Triaging the alert acknowledges its incidents
+	sqsMock.AssertExpectations(t)
+}
+
+func TestUpdateAlertStatusDoesNotExist(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("UpdateStatus", triageAlertID, "CLOSED", mock.Anything).Return((*table.AlertItem)(nil), nil)
+
+	result, err := API{}.UpdateAlertStatus(&models.UpdateAlertStatusInput{
+		AlertID: aws.String(triageAlertID),
+		Status:  aws.String("CLOSED"),
+		UserID:  aws.String(triageUserID),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestUpdateAlertAssignee(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("UpdateAssignee", triageAlertID, (*string)(nil), mock.Anything).Return(triagedAlertItem(), nil)
+
+	result, err := API{}.UpdateAlertAssignee(&models.UpdateAlertAssigneeInput{
+		AlertID: aws.String(triageAlertID),
+		UserID:  aws.String(triageUserID),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.AlertActivity{}, result.Activity)
+
+	recorded := tableMock.Calls[0].Arguments.Get(2).(*table.AlertActivity)
+	assert.Equal(t, "ASSIGNEE", recorded.Type)
+	assert.Nil(t, recorded.AssigneeID)
+	tableMock.AssertExpectations(t)
+}
+
+func TestAddAlertComment(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("AddComment", triageAlertID, mock.Anything).Return(
+		(*table.AlertItem)(nil), errors.New("throttled"))
+
+	result, err := API{}.AddAlertComment(&models.AddAlertCommentInput{
+		AlertID: aws.String(triageAlertID),
+		Comment: aws.String("known scanner"),
+		UserID:  aws.String(triageUserID),
+	})
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	recorded := tableMock.Calls[0].Arguments.Get(1).(*table.AlertActivity)
+	assert.Equal(t, "COMMENT", recorded.Type)
+	assert.Equal(t, "known scanner", recorded.Comment)
+	tableMock.AssertExpectations(t)
+}
+
+func TestListAlertsFiltered(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListAlertsInput{
+		PageSize:   aws.Int(10),
+		Status:     aws.StringSlice([]string{"OPEN", "TRIAGED"}),
+		AssigneeID: aws.String(triageUserID),
+	}
+	expectedInput := listInput(&table.ListInput{PageSize: aws.Int(10), AssigneeID: aws.String(triageUserID)})
+	expectedInput.Statuses = []string{"OPEN", "TRIAGED"}
+	tableMock.On("List", expectedInput).
+		Return([]*table.AlertItem{triagedAlertItem()}, (*string)(nil), nil)
+
+	result, err := API{}.ListAlerts(input)
+	require.NoError(t, err)
+	require.Len(t, result.Alerts, 1)
+	assert.Equal(t, aws.String("TRIAGED"), result.Alerts[0].Status)
+	assert.Equal(t, aws.String(triageUserID), result.Alerts[0].AssigneeID)
+	tableMock.AssertExpectations(t)
+}
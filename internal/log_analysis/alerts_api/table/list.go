@@ -19,93 +19,120 @@ package table
  */
 
 import (
+	"sort"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
-	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
-func (table *AlertsTable) ListByRule(ruleID string, exclusiveStartKey *string, pageSize *int) (
-	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+const (
+	// maxListQueries limits how many queries are made to fill a page when most alerts are filtered out.
+	// The page is returned early (with a last evaluated key) once the limit is reached.
+	maxListQueries = 10
 
-	return table.list(RuleIDKey, ruleID, exclusiveStartKey, pageSize)
-}
+	// maxSortedAlerts is the most alerts which can be sorted by something other than creation time
+	maxSortedAlerts = 10000
 
-func (table *AlertsTable) ListAll(exclusiveStartKey *string, pageSize *int) (
-	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+	// maxSortWindow is the longest creation time range which is read to sort by something other than creation time
+	maxSortWindow = 7 * 24 * time.Hour
+)
 
-	return table.list(TimePartitionKey, TimePartitionValue, exclusiveStartKey, pageSize)
-}
+// severityRank orders the alert severities from lowest to highest
+var severityRank = map[string]int{"INFO": 0, "LOW": 1, "MEDIUM": 2, "HIGH": 3, "CRITICAL": 4}
 
-// list returns a page of alerts ordered by creationTime, last evaluated key, any error
-func (table *AlertsTable) list(ddbKey, ddbValue string, exclusiveStartKey *string, pageSize *int) (
-	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+// List returns a page of alerts matching the input, the last evaluated key, any error
+//
+// Alerts ordered by creation time are paged with the DynamoDB index. For any other order, every matching alert
+// created within maxSortWindow of the creation time upper bound (or now) is read and sorted, and the last evaluated
+// key is the position of the last alert returned in that order.
+func (table *AlertsTable) List(input *ListInput) (summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+	if input.CreationTimeAfter != nil && input.CreationTimeBefore != nil &&
+		input.CreationTimeAfter.After(*input.CreationTimeBefore) {
 
-	// pick index
-	var index string
-	if ddbKey == RuleIDKey {
-		index = table.RuleIDCreationTimeIndexName
-	} else if ddbKey == TimePartitionKey {
-		index = table.TimePartitionCreationTimeIndexName
-	} else {
-		return nil, nil, errors.New("unknown key" + ddbKey)
+		return nil, nil, nil
 	}
 
-	// queries require and = condition on primary key
-	keyCondition := expression.Key(ddbKey).Equal(expression.Value(&ddbValue))
-
-	queryExpression, err := expression.NewBuilder().
-		WithKeyCondition(keyCondition).
-		Build()
+	if input.SortBy == "" || input.SortBy == SortByCreationTime {
+		queryInput, err := table.buildListQuery(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return table.listByCreationTime(queryInput, input.ExclusiveStartKey, input.PageSize)
+	}
 
+	input = limitSortWindow(input, time.Now().UTC())
+	queryInput, err := table.buildListQuery(input)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to build expression")
+		return nil, nil, err
 	}
+	return table.listSorted(queryInput, input)
+}
 
-	var queryResultsLimit *int64
-	if pageSize != nil {
-		queryResultsLimit = aws.Int64(int64(*pageSize))
+// limitSortWindow returns a copy of the input whose creation time range is at most maxSortWindow long.
+func limitSortWindow(input *ListInput, now time.Time) *ListInput {
+	end := now
+	if input.CreationTimeBefore != nil {
+		end = *input.CreationTimeBefore
 	}
+	start := end.Add(-maxSortWindow)
+	if input.CreationTimeAfter != nil && input.CreationTimeAfter.After(start) {
+		return input
+	}
+
+	limited := *input
+	limited.CreationTimeAfter = &start
+	return &limited
+}
+
+// listByCreationTime returns a page of alerts in index order.
+//
+// Items are filtered after DynamoDB reads them, so it can take several queries to fill a page.
+func (table *AlertsTable) listByCreationTime(queryInput *dynamodb.QueryInput, exclusiveStartKey *string, pageSize *int) (
+	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
 
-	var queryExclusiveStartKey map[string]*dynamodb.AttributeValue
 	if exclusiveStartKey != nil {
-		queryExclusiveStartKey = make(map[string]*dynamodb.AttributeValue)
+		queryExclusiveStartKey := make(map[string]*dynamodb.AttributeValue)
 		err = jsoniter.UnmarshalFromString(*exclusiveStartKey, &queryExclusiveStartKey)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to Unmarshal ExclusiveStartKey")
 		}
+		queryInput.ExclusiveStartKey = queryExclusiveStartKey
 	}
 
-	var queryInput = &dynamodb.QueryInput{
-		TableName:                 &table.AlertsTableName,
-		ScanIndexForward:          aws.Bool(false),
-		ExpressionAttributeNames:  queryExpression.Names(),
-		ExpressionAttributeValues: queryExpression.Values(),
-		KeyConditionExpression:    queryExpression.KeyCondition(),
-		ExclusiveStartKey:         queryExclusiveStartKey,
-		IndexName:                 aws.String(index),
-		Limit:                     queryResultsLimit,
-	}
+	for queries := 0; queries < maxListQueries; queries++ {
+		if pageSize != nil {
+			// The limit applies before filtering, so the page never has more than pageSize items
+			queryInput.Limit = aws.Int64(int64(*pageSize - len(summaries)))
+		}
 
-	queryOutput, err := table.Client.Query(queryInput)
-	if err != nil {
-		// this deserves detailed logging for debugging
-		zap.L().Error("Query()", zap.Error(err), zap.Any("input", queryInput), zap.Any("startKey", queryExclusiveStartKey))
-		return nil, nil, errors.Wrapf(err, "QueryInput() failed for %s,%s", ddbKey, ddbValue)
-	}
+		queryOutput, err := table.Client.Query(queryInput)
+		if err != nil {
+			// this deserves detailed logging for debugging
+			zap.L().Error("Query()", zap.Error(err), zap.Any("input", queryInput))
+			return nil, nil, errors.Wrapf(err, "QueryInput() failed for %s", *queryInput.IndexName)
+		}
 
-	err = dynamodbattribute.UnmarshalListOfMaps(queryOutput.Items, &summaries)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "UnmarshalListOfMaps() failed")
+		var items []*AlertItem
+		if err = dynamodbattribute.UnmarshalListOfMaps(queryOutput.Items, &items); err != nil {
+			return nil, nil, errors.Wrap(err, "UnmarshalListOfMaps() failed")
+		}
+		summaries = append(summaries, items...)
+		queryInput.ExclusiveStartKey = queryOutput.LastEvaluatedKey
+
+		if len(queryOutput.LastEvaluatedKey) == 0 || pageSize == nil || len(summaries) >= *pageSize {
+			break
+		}
 	}
 
 	// If DDB returned a LastEvaluatedKey (the "primary key of the item where the operation stopped"),
 	// it means there are more alerts to be returned. Return populated `lastEvaluatedKey` JSON blob in the response.
-	if len(queryOutput.LastEvaluatedKey) > 0 {
-		lastEvaluatedKeySerialized, err := jsoniter.MarshalToString(queryOutput.LastEvaluatedKey)
+	if len(queryInput.ExclusiveStartKey) > 0 {
+		lastEvaluatedKeySerialized, err := jsoniter.MarshalToString(queryInput.ExclusiveStartKey)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to Marshal LastEvaluatedKey)")
 		}
@@ -114,3 +141,122 @@ func (table *AlertsTable) list(ddbKey, ddbValue string, exclusiveStartKey *strin
 
 	return summaries, lastEvaluatedKey, nil
 }
+
+// sortCursor is the position of the last alert returned in a sorted list
+type sortCursor struct {
+	AlertID      string    `json:"id"`
+	CreationTime time.Time `json:"creationTime"`
+	UpdateTime   time.Time `json:"updateTime"`
+	Severity     string    `json:"severity"`
+	EventCount   int       `json:"eventCount"`
+}
+
+// listSorted reads every alert matching the query, sorts them and returns the page after the exclusive start key.
+func (table *AlertsTable) listSorted(queryInput *dynamodb.QueryInput, input *ListInput) (
+	summaries []*AlertItem, lastEvaluatedKey *string, err error) {
+
+	var unmarshalErr error
+	err = table.Client.QueryPages(queryInput, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*AlertItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false
+		}
+		summaries = append(summaries, items...)
+		return len(summaries) <= maxSortedAlerts
+	})
+	if err != nil {
+		zap.L().Error("QueryPages()", zap.Error(err), zap.Any("input", queryInput))
+		return nil, nil, errors.Wrapf(err, "QueryPages() failed for %s", *queryInput.IndexName)
+	}
+	if unmarshalErr != nil {
+		return nil, nil, errors.Wrap(unmarshalErr, "UnmarshalListOfMaps() failed")
+	}
+	if len(summaries) > maxSortedAlerts {
+		return nil, nil, errors.Errorf(
+			"more than %d alerts match, narrow down the filters to sort by %s", maxSortedAlerts, input.SortBy)
+	}
+
+	less := alertLess(input.SortBy, input.SortDescending)
+	sort.Slice(summaries, func(i, j int) bool { return less(summaries[i], summaries[j]) })
+
+	if input.ExclusiveStartKey != nil {
+		var cursor sortCursor
+		if err = jsoniter.UnmarshalFromString(*input.ExclusiveStartKey, &cursor); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to Unmarshal ExclusiveStartKey")
+		}
+		cursorItem := &AlertItem{
+			AlertID:      cursor.AlertID,
+			CreationTime: cursor.CreationTime,
+			UpdateTime:   cursor.UpdateTime,
+			Severity:     cursor.Severity,
+			EventCount:   cursor.EventCount,
+		}
+		start := sort.Search(len(summaries), func(i int) bool { return less(cursorItem, summaries[i]) })
+		summaries = summaries[start:]
+	}
+
+	if input.PageSize != nil && len(summaries) > *input.PageSize {
+		summaries = summaries[:*input.PageSize]
+		last := summaries[len(summaries)-1]
+		cursor, err := jsoniter.MarshalToString(&sortCursor{
+			AlertID:      last.AlertID,
+			CreationTime: last.CreationTime,
+			UpdateTime:   last.UpdateTime,
+			Severity:     last.Severity,
+			EventCount:   last.EventCount,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to Marshal LastEvaluatedKey")
+		}
+		lastEvaluatedKey = &cursor
+	}
+
+	return summaries, lastEvaluatedKey, nil
+}
+
+// alertLess returns the ordering function for sorting alerts.
+//
+// Ties are broken by creation time (in the same direction) and then alert ID, so the order is stable between pages.
+func alertLess(sortBy string, descending bool) func(a, b *AlertItem) bool {
+	ordered := func(comparison int) bool {
+		if descending {
+			return comparison > 0
+		}
+		return comparison < 0
+	}
+
+	return func(a, b *AlertItem) bool {
+		if comparison := compareAlerts(sortBy, a, b); comparison != 0 {
+			return ordered(comparison)
+		}
+		if comparison := compareTimes(a.CreationTime, b.CreationTime); comparison != 0 {
+			return ordered(comparison)
+		}
+		return a.AlertID < b.AlertID
+	}
+}
+
+// compareAlerts returns a negative number if a sorts before b in ascending order, positive if after, else 0
+func compareAlerts(sortBy string, a, b *AlertItem) int {
+	switch sortBy {
+	case SortByUpdateTime:
+		return compareTimes(a.UpdateTime, b.UpdateTime)
+	case SortBySeverity:
+		return severityRank[a.Severity] - severityRank[b.Severity]
+	case SortByEventCount:
+		return a.EventCount - b.EventCount
+	default:
+		return 0
+	}
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,270 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var listTime = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func (m *mockDynamoDB) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
+func (m *mockDynamoDB) QueryPages(input *dynamodb.QueryInput, fn func(*dynamodb.QueryOutput, bool) bool) error {
+	args := m.Called(input, fn)
+	fn(args.Get(0).(*dynamodb.QueryOutput), true)
+	return args.Error(1)
+}
+
+func newListTable(client *mockDynamoDB) *AlertsTable {
+	return &AlertsTable{
+		AlertsTableName:                    "alertsTableName",
+		RuleIDCreationTimeIndexName:        "ruleIdCreationTimeIndexName",
+		TimePartitionCreationTimeIndexName: "timePartitionCreationTimeIndexName",
+		Client:                             client,
+	}
+}
+
+func marshalAlerts(t *testing.T, alerts ...*AlertItem) []map[string]*dynamodb.AttributeValue {
+	items := make([]map[string]*dynamodb.AttributeValue, len(alerts))
+	for i, alert := range alerts {
+		item, err := dynamodbattribute.MarshalMap(alert)
+		require.NoError(t, err)
+		items[i] = item
+	}
+	return items
+}
+
+func TestBuildFilter(t *testing.T) {
+	_, ok := buildFilter(&ListInput{})
+	assert.False(t, ok)
+
+	condition, ok := buildFilter(&ListInput{Statuses: []string{"OPEN", "TRIAGED"}, AssigneeID: aws.String("userId")})
+	require.True(t, ok)
+	expr, err := expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "((#0 IN (:0, :1)) OR (attribute_not_exists (#0))) AND (#1 = :2)", *expr.Filter())
+
+	condition, ok = buildFilter(&ListInput{Statuses: []string{"CLOSED"}})
+	require.True(t, ok)
+	expr, err = expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "#0 IN (:0)", *expr.Filter())
+
+	condition, ok = buildFilter(&ListInput{
+		Severities:    []string{"HIGH"},
+		RuleTags:      []string{"PCI", "SOC2"},
+		EventCountMin: aws.Int(5),
+	})
+	require.True(t, ok)
+	expr, err = expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "(#0 IN (:0)) AND ((contains (#1, :1)) OR (contains (#1, :2))) AND (#2 >= :3)", *expr.Filter())
+	assert.Equal(t, map[string]*string{"#0": aws.String("severity"), "#1": aws.String("ruleTags"),
+		"#2": aws.String("eventCount")}, expr.Names())
+
+	condition, ok = buildFilter(&ListInput{Type: aws.String(AlertTypeRule)})
+	require.True(t, ok)
+	expr, err = expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "(#0 = :0) OR (attribute_not_exists (#0))", *expr.Filter())
+
+	condition, ok = buildFilter(&ListInput{Type: aws.String(AlertTypePolicy)})
+	require.True(t, ok)
+	expr, err = expression.NewBuilder().WithFilter(condition).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "#0 = :0", *expr.Filter())
+}
+
+func TestBuildListQuery(t *testing.T) {
+	table := newListTable(&mockDynamoDB{})
+
+	query, err := table.buildListQuery(&ListInput{SortDescending: true})
+	require.NoError(t, err)
+	assert.Equal(t, "timePartitionCreationTimeIndexName", *query.IndexName)
+	assert.Equal(t, "#0 = :0", *query.KeyConditionExpression)
+	assert.Nil(t, query.FilterExpression)
+	assert.False(t, *query.ScanIndexForward)
+
+	query, err = table.buildListQuery(&ListInput{
+		RuleID:             aws.String("ruleId"),
+		CreationTimeAfter:  aws.Time(listTime),
+		CreationTimeBefore: aws.Time(listTime.Add(time.Hour)),
+		TitleContains:      aws.String("root"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ruleIdCreationTimeIndexName", *query.IndexName)
+	assert.Equal(t, "(#2 = :3) AND (#3 BETWEEN :4 AND :5)", *query.KeyConditionExpression)
+	assert.Equal(t, "(contains (#0, :0)) OR ((attribute_not_exists (#0)) AND ((contains (#1, :1)) OR (contains (#2, :2))))",
+		*query.FilterExpression)
+	assert.True(t, *query.ScanIndexForward)
+}
+
+func TestListFiltered(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := newListTable(mockDdbClient)
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil)
+
+	_, _, err := table.List(&ListInput{Statuses: []string{"CLOSED"}, PageSize: aws.Int(10)})
+	require.NoError(t, err)
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	require.NotNil(t, input.FilterExpression)
+	var values []string
+	for _, value := range input.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.S))
+	}
+	assert.ElementsMatch(t, []string{"defaultPartition", "CLOSED"}, values)
+}
+
+func TestListFillsPage(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := newListTable(mockDdbClient)
+
+	lastKey := map[string]*dynamodb.AttributeValue{"id": {S: aws.String("alert-1")}}
+	// The first query matches one of the 2 alerts it read, so another query is made for the rest of the page
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items:            marshalAlerts(t, &AlertItem{AlertID: "alert-1"}),
+		LastEvaluatedKey: lastKey,
+	}, nil).Once()
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: marshalAlerts(t, &AlertItem{AlertID: "alert-3"}),
+	}, nil).Once()
+
+	alerts, lastEvaluatedKey, err := table.List(&ListInput{Severities: []string{"HIGH"}, PageSize: aws.Int(3)})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "alert-3", alerts[1].AlertID)
+	assert.Nil(t, lastEvaluatedKey)
+
+	mockDdbClient.AssertNumberOfCalls(t, "Query", 2)
+	// The second query only reads as many alerts as are missing from the page
+	input := mockDdbClient.Calls[1].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, int64(2), *input.Limit)
+}
+
+func TestListEmptyCreationTimeRange(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := newListTable(mockDdbClient)
+
+	alerts, lastEvaluatedKey, err := table.List(&ListInput{
+		CreationTimeAfter:  aws.Time(listTime.Add(time.Hour)),
+		CreationTimeBefore: aws.Time(listTime),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+	assert.Nil(t, lastEvaluatedKey)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListSorted(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := newListTable(mockDdbClient)
+
+	alerts := []*AlertItem{
+		{AlertID: "low", Severity: "LOW", CreationTime: listTime},
+		{AlertID: "critical", Severity: "CRITICAL", CreationTime: listTime},
+		{AlertID: "high-old", Severity: "HIGH", CreationTime: listTime},
+		{AlertID: "high-new", Severity: "HIGH", CreationTime: listTime.Add(time.Minute)},
+		{AlertID: "info", Severity: "INFO", CreationTime: listTime},
+	}
+	mockDdbClient.On("QueryPages", mock.Anything, mock.Anything).Return(
+		&dynamodb.QueryOutput{Items: marshalAlerts(t, alerts...)}, nil)
+
+	var ids []string
+	var startKey *string
+	for page := 0; page < 3; page++ {
+		result, lastEvaluatedKey, err := table.List(&ListInput{
+			SortBy:            SortBySeverity,
+			SortDescending:    true,
+			PageSize:          aws.Int(2),
+			ExclusiveStartKey: startKey,
+		})
+		require.NoError(t, err)
+		for _, alert := range result {
+			ids = append(ids, alert.AlertID)
+		}
+		startKey = lastEvaluatedKey
+		if startKey == nil {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"critical", "high-new", "high-old", "low", "info"}, ids)
+	assert.Nil(t, startKey)
+}
+
+func TestListSortedTooManyAlerts(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := newListTable(mockDdbClient)
+
+	alerts := make([]*AlertItem, maxSortedAlerts+1)
+	for i := range alerts {
+		alerts[i] = &AlertItem{AlertID: strconv.Itoa(i)}
+	}
+	mockDdbClient.On("QueryPages", mock.Anything, mock.Anything).Return(
+		&dynamodb.QueryOutput{Items: marshalAlerts(t, alerts...)}, nil)
+
+	_, _, err := table.List(&ListInput{SortBy: SortByEventCount})
+	require.Error(t, err)
+}
+
+func TestLimitSortWindow(t *testing.T) {
+	now := listTime.Add(30 * 24 * time.Hour)
+
+	// No range is limited to the most recent alerts
+	limited := limitSortWindow(&ListInput{SortBy: SortBySeverity}, now)
+	assert.Equal(t, now.Add(-maxSortWindow), *limited.CreationTimeAfter)
+	assert.Nil(t, limited.CreationTimeBefore)
+
+	// A long range is limited to the end of the range
+	input := &ListInput{CreationTimeAfter: aws.Time(listTime), CreationTimeBefore: aws.Time(listTime.Add(10 * 24 * time.Hour))}
+	limited = limitSortWindow(input, now)
+	assert.Equal(t, listTime.Add(10*24*time.Hour-maxSortWindow), *limited.CreationTimeAfter)
+	assert.Equal(t, listTime, *input.CreationTimeAfter, "input should not be modified")
+
+	// A short range is kept
+	input = &ListInput{CreationTimeAfter: aws.Time(now.Add(-time.Hour))}
+	assert.Equal(t, input, limitSortWindow(input, now))
+}
+
+func TestAlertLess(t *testing.T) {
+	a := &AlertItem{AlertID: "a", EventCount: 1, UpdateTime: listTime, CreationTime: listTime}
+	b := &AlertItem{AlertID: "b", EventCount: 2, UpdateTime: listTime, CreationTime: listTime}
+
+	assert.True(t, alertLess(SortByEventCount, false)(a, b))
+	assert.True(t, alertLess(SortByEventCount, true)(b, a))
+	// Ties are broken by alert ID, in either direction
+	assert.True(t, alertLess(SortByUpdateTime, false)(a, b))
+	assert.True(t, alertLess(SortByUpdateTime, true)(a, b))
+	assert.False(t, alertLess(SortByUpdateTime, true)(a, a))
+}
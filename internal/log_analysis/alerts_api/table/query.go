@@ -0,0 +1,160 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+// buildListQuery picks the index for the list input and filters everything it can't query by server-side.
+//
+// Both indices are sorted by creation time: the rule index is used if the alerts are for a single rule,
+// otherwise the time partition index has every alert. A creation time range is part of the key condition.
+func (table *AlertsTable) buildListQuery(input *ListInput) (*dynamodb.QueryInput, error) {
+	var index string
+	var keyCondition expression.KeyConditionBuilder
+	if input.RuleID != nil {
+		index = table.RuleIDCreationTimeIndexName
+		keyCondition = expression.Key(RuleIDKey).Equal(expression.Value(*input.RuleID))
+	} else {
+		index = table.TimePartitionCreationTimeIndexName
+		keyCondition = expression.Key(TimePartitionKey).Equal(expression.Value(TimePartitionValue))
+	}
+
+	creationTime := expression.Key(CreationTimeKey)
+	switch {
+	case input.CreationTimeAfter != nil && input.CreationTimeBefore != nil:
+		keyCondition = keyCondition.And(creationTime.Between(
+			expression.Value(*input.CreationTimeAfter), expression.Value(*input.CreationTimeBefore)))
+	case input.CreationTimeAfter != nil:
+		keyCondition = keyCondition.And(creationTime.GreaterThanEqual(expression.Value(*input.CreationTimeAfter)))
+	case input.CreationTimeBefore != nil:
+		keyCondition = keyCondition.And(creationTime.LessThanEqual(expression.Value(*input.CreationTimeBefore)))
+	}
+
+	builder := expression.NewBuilder().WithKeyCondition(keyCondition)
+	if filter, ok := buildFilter(input); ok {
+		builder = builder.WithFilter(filter)
+	}
+	queryExpression, err := builder.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build expression")
+	}
+
+	return &dynamodb.QueryInput{
+		TableName:                 &table.AlertsTableName,
+		ScanIndexForward:          aws.Bool(!input.SortDescending),
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+		FilterExpression:          queryExpression.Filter(),
+		IndexName:                 aws.String(index),
+	}, nil
+}
+
+// buildFilter returns the filter condition for the list query, false if nothing needs to be filtered
+func buildFilter(input *ListInput) (expression.ConditionBuilder, bool) {
+	var conditions []expression.ConditionBuilder
+
+	if input.Type != nil {
+		typeCondition := expression.Name(TypeKey).Equal(expression.Value(*input.Type))
+		if *input.Type == AlertTypeRule {
+			typeCondition = typeCondition.Or(expression.Name(TypeKey).AttributeNotExists())
+		}
+		conditions = append(conditions, typeCondition)
+	}
+	if len(input.Statuses) > 0 {
+		statusCondition := isAnyOf(StatusKey, input.Statuses)
+		for _, status := range input.Statuses {
+			if status == StatusOpen {
+				statusCondition = statusCondition.Or(expression.Name(StatusKey).AttributeNotExists())
+				break
+			}
+		}
+		conditions = append(conditions, statusCondition)
+	}
+	if input.AssigneeID != nil {
+		conditions = append(conditions, expression.Name(AssigneeIDKey).Equal(expression.Value(*input.AssigneeID)))
+	}
+	if len(input.Severities) > 0 {
+		conditions = append(conditions, isAnyOf(SeverityKey, input.Severities))
+	}
+	if len(input.LogTypes) > 0 {
+		conditions = append(conditions, containsAnyOf(LogTypesKey, input.LogTypes))
+	}
+	if len(input.RuleTags) > 0 {
+		conditions = append(conditions, containsAnyOf(RuleTagsKey, input.RuleTags))
+	}
+	if input.TitleContains != nil {
+		// Older alerts have no title, their title is the rule display name or the rule ID
+		substring := *input.TitleContains
+		conditions = append(conditions, expression.Name(TitleKey).Contains(substring).Or(
+			expression.Name(TitleKey).AttributeNotExists().And(
+				expression.Name(RuleDisplayNameKey).Contains(substring).Or(expression.Name(RuleIDKey).Contains(substring)))))
+	}
+	if input.UpdateTimeAfter != nil {
+		conditions = append(conditions, expression.Name(UpdateTimeKey).GreaterThanEqual(expression.Value(*input.UpdateTimeAfter)))
+	}
+	if input.UpdateTimeBefore != nil {
+		conditions = append(conditions, expression.Name(UpdateTimeKey).LessThanEqual(expression.Value(*input.UpdateTimeBefore)))
+	}
+	if input.EventCountMin != nil {
+		conditions = append(conditions, expression.Name(EventCountKey).GreaterThanEqual(expression.Value(*input.EventCountMin)))
+	}
+	if input.EventCountMax != nil {
+		conditions = append(conditions, expression.Name(EventCountKey).LessThanEqual(expression.Value(*input.EventCountMax)))
+	}
+
+	if len(conditions) == 0 {
+		return expression.ConditionBuilder{}, false
+	}
+	return join(expression.And, conditions), true
+}
+
+// isAnyOf matches items where the attribute is one of the values
+func isAnyOf(name string, values []string) expression.ConditionBuilder {
+	operands := make([]expression.OperandBuilder, len(values))
+	for i, value := range values {
+		operands[i] = expression.Value(value)
+	}
+	return expression.Name(name).In(operands[0], operands[1:]...)
+}
+
+// containsAnyOf matches items where the set attribute contains at least one of the values
+func containsAnyOf(name string, values []string) expression.ConditionBuilder {
+	conditions := make([]expression.ConditionBuilder, len(values))
+	for i, value := range values {
+		conditions[i] = expression.Name(name).Contains(value)
+	}
+	return join(expression.Or, conditions)
+}
+
+// join combines one or more conditions with expression.And or expression.Or
+func join(
+	operator func(left, right expression.ConditionBuilder, other ...expression.ConditionBuilder) expression.ConditionBuilder,
+	conditions []expression.ConditionBuilder) expression.ConditionBuilder {
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return operator(conditions[0], conditions[1], conditions[2:]...)
+}
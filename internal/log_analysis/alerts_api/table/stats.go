@@ -0,0 +1,83 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ForEachAlert calls the handler with every alert created in the given time range, page by page.
+//
+// Only the attributes needed for alert statistics are read, the other fields of the items are empty.
+func (table *AlertsTable) ForEachAlert(creationTimeAfter, creationTimeBefore time.Time, handler func([]*AlertItem)) error {
+	keyCondition := expression.Key(TimePartitionKey).Equal(expression.Value(TimePartitionValue)).
+		And(expression.Key(CreationTimeKey).Between(
+			expression.Value(creationTimeAfter), expression.Value(creationTimeBefore)))
+	projection := expression.NamesList(
+		expression.Name(AlertIDKey),
+		expression.Name(RuleIDKey),
+		expression.Name(RuleDisplayNameKey),
+		expression.Name(CreationTimeKey),
+		expression.Name(SeverityKey),
+		expression.Name(EventCountKey),
+		expression.Name(LogTypesKey),
+		expression.Name(PendingKey),
+	)
+	queryExpression, err := expression.NewBuilder().
+		WithKeyCondition(keyCondition).
+		WithProjection(projection).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build expression")
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 &table.AlertsTableName,
+		IndexName:                 aws.String(table.TimePartitionCreationTimeIndexName),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+		ProjectionExpression:      queryExpression.Projection(),
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+	}
+
+	var unmarshalErr error
+	err = table.Client.QueryPages(queryInput, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*AlertItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false
+		}
+		handler(items)
+		return true
+	})
+	if err != nil {
+		zap.L().Error("QueryPages()", zap.Error(err), zap.Any("input", queryInput))
+		return errors.Wrapf(err, "QueryPages() failed for %s", *queryInput.IndexName)
+	}
+	if unmarshalErr != nil {
+		return errors.Wrap(unmarshalErr, "UnmarshalListOfMaps() failed")
+	}
+	return nil
+}
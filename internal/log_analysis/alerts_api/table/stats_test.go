@@ -0,0 +1,50 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachAlert(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := newListTable(mockDdbClient)
+
+	alert := &AlertItem{AlertID: "alertId", RuleID: "ruleId", Severity: "HIGH", EventCount: 3, CreationTime: listTime}
+	mockDdbClient.On("QueryPages", mock.Anything, mock.Anything).Return(
+		&dynamodb.QueryOutput{Items: marshalAlerts(t, alert)}, nil)
+
+	var result []*AlertItem
+	err := table.ForEachAlert(listTime, listTime.AddDate(0, 0, 7), func(alerts []*AlertItem) {
+		result = append(result, alerts...)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*AlertItem{alert}, result)
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "timePartitionCreationTimeIndexName", *input.IndexName)
+	assert.Equal(t, "(#0 = :0) AND (#1 BETWEEN :1 AND :2)", *input.KeyConditionExpression)
+	assert.NotNil(t, input.ProjectionExpression)
+	mockDdbClient.AssertExpectations(t)
+}
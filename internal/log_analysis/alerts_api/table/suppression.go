@@ -0,0 +1,95 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+// AddSuppression stores a new suppression
+func (table *AlertsTable) AddSuppression(suppression *SuppressionItem) error {
+	item, err := dynamodbattribute.MarshalMap(suppression)
+	if err != nil {
+		return errors.Wrap(err, "MarshalMap() failed")
+	}
+
+	_, err = table.Client.PutItem(&dynamodb.PutItemInput{
+		Item:      item,
+		TableName: aws.String(table.SuppressionsTableName),
+	})
+	if err != nil {
+		return errors.Wrap(err, "PutItem() failed for: "+suppression.ID)
+	}
+	return nil
+}
+
+// ListSuppressions returns the suppressions which have not expired at the given time
+func (table *AlertsTable) ListSuppressions(now time.Time) ([]*SuppressionItem, error) {
+	// Expired items are deleted with a delay by the TTL, so they have to be filtered out
+	filter := expression.Name(SuppressionExpiresAtKey).GreaterThan(expression.Value(now.Unix()))
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build filter expression")
+	}
+
+	input := &dynamodb.ScanInput{
+		TableName:                 aws.String(table.SuppressionsTableName),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var suppressions []*SuppressionItem
+	var unmarshalErr error
+	err = table.Client.ScanPages(input, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		var items []*SuppressionItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false
+		}
+		suppressions = append(suppressions, items...)
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ScanPages() failed for: "+table.SuppressionsTableName)
+	}
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "UnmarshalListOfMaps() failed")
+	}
+	return suppressions, nil
+}
+
+// DeleteSuppression removes a suppression, new alerts it matched are delivered again
+func (table *AlertsTable) DeleteSuppression(suppressionID string) error {
+	_, err := table.Client.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			SuppressionIDKey: {S: aws.String(suppressionID)},
+		},
+		TableName: aws.String(table.SuppressionsTableName),
+	})
+	if err != nil {
+		return errors.Wrap(err, "DeleteItem() failed for: "+suppressionID)
+	}
+	return nil
+}
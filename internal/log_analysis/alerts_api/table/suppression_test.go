@@ -0,0 +1,93 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func (m *mockDynamoDB) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.PutItemOutput), args.Error(1)
+}
+
+func (m *mockDynamoDB) ScanPages(input *dynamodb.ScanInput, fn func(*dynamodb.ScanOutput, bool) bool) error {
+	args := m.Called(input, fn)
+	fn(args.Get(0).(*dynamodb.ScanOutput), true)
+	return args.Error(1)
+}
+
+func (m *mockDynamoDB) DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.DeleteItemOutput), args.Error(1)
+}
+
+func TestAddSuppression(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{SuppressionsTableName: "suppressionsTableName", Client: mockDdbClient}
+	mockDdbClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	suppression := &SuppressionItem{ID: "suppressionId", RuleID: aws.String("ruleId"), ExpiresAt: 1588291200, Reason: "reason"}
+	require.NoError(t, table.AddSuppression(suppression))
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, "suppressionsTableName", *input.TableName)
+	assert.Equal(t, "1588291200", *input.Item["expiresAt"].N)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListSuppressions(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{SuppressionsTableName: "suppressionsTableName", Client: mockDdbClient}
+
+	expected := &SuppressionItem{ID: "suppressionId", DedupString: aws.String("dedup"), ExpiresAt: 1588291200, Reason: "reason"}
+	item, err := dynamodbattribute.MarshalMap(expected)
+	require.NoError(t, err)
+	mockDdbClient.On("ScanPages", mock.Anything, mock.Anything).Return(
+		&dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{item}}, nil)
+
+	result, err := table.ListSuppressions(time.Unix(1588204800, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []*SuppressionItem{expected}, result)
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.ScanInput)
+	assert.Equal(t, "#0 > :0", *input.FilterExpression)
+	assert.Equal(t, "1588204800", *input.ExpressionAttributeValues[":0"].N)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestDeleteSuppressionError(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{SuppressionsTableName: "suppressionsTableName", Client: mockDdbClient}
+	mockDdbClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, errors.New("error"))
+
+	assert.Error(t, table.DeleteSuppression("suppressionId"))
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.DeleteItemInput)
+	assert.Equal(t, "suppressionId", *input.Key["id"].S)
+	mockDdbClient.AssertExpectations(t)
+}
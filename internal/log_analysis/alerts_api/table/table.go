@@ -31,13 +31,51 @@ const (
 	AlertIDKey         = "id"
 	TimePartitionKey   = "timePartition"
 	TimePartitionValue = "defaultPartition"
+
+	CreationTimeKey    = "creationTime"
+	UpdateTimeKey      = "updateTime"
+	SeverityKey        = "severity"
+	EventCountKey      = "eventCount"
+	LogTypesKey        = "logTypes"
+	RuleTagsKey        = "ruleTags"
+	TitleKey           = "title"
+	RuleDisplayNameKey = "ruleDisplayName"
+	StatusKey          = "status"
+	AssigneeIDKey      = "assigneeId"
+	LastUpdatedByKey   = "lastUpdatedBy"
+	LastUpdatedTimeKey = "lastUpdatedTime"
+	ActivityKey        = "activity"
+	PendingKey         = "pending"
+	TypeKey            = "type"
+	TicketsKey         = "tickets"
+
+	SuppressionIDKey        = "id"
+	SuppressionExpiresAtKey = "expiresAt"
+
+	TicketIDKey = "ticketId"
+
+	// StatusOpen is the status of alerts which have never been triaged
+	StatusOpen = "OPEN"
+
+	// AlertTypeRule is the type of log analysis alerts, alerts without a type are rule alerts
+	AlertTypeRule = "RULE"
+	// AlertTypePolicy is the type of cloud security alerts, they are for a single resource and have no events
+	AlertTypePolicy = "POLICY"
 )
 
 // API defines the interface for the alerts table which can be used for mocking.
 type API interface {
 	GetAlert(*string) (*AlertItem, error)
-	ListByRule(string, *string, *int) ([]*AlertItem, *string, error)
-	ListAll(*string, *int) ([]*AlertItem, *string, error)
+	List(*ListInput) ([]*AlertItem, *string, error)
+	UpdateStatus(string, string, *AlertActivity) (*AlertItem, error)
+	UpdateAssignee(string, *string, *AlertActivity) (*AlertItem, error)
+	AddComment(string, *AlertActivity) (*AlertItem, error)
+	AddSuppression(*SuppressionItem) error
+	ListSuppressions(time.Time) ([]*SuppressionItem, error)
+	DeleteSuppression(string) error
+	ForEachAlert(time.Time, time.Time, func([]*AlertItem)) error
+	AddTicket(string, *AlertTicket) error
+	GetTicket(string) (*TicketItem, error)
 }
 
 // AlertsTable encapsulates a connection to the Dynamo alerts table.
@@ -45,6 +83,8 @@ type AlertsTable struct {
 	AlertsTableName                    string
 	RuleIDCreationTimeIndexName        string
 	TimePartitionCreationTimeIndexName string
+	SuppressionsTableName              string
+	TicketsTableName                   string
 	Client                             dynamodbiface.DynamoDBAPI
 }
 
@@ -57,6 +97,7 @@ type DynamoItem = map[string]*dynamodb.AttributeValue
 // AlertItem is a DDB representation of an Alert
 type AlertItem struct {
 	AlertID         string    `json:"id"`
+	Type            string    `json:"type,omitempty"` // alerts stored before policy alerts have no type, they are RULE alerts
 	RuleID          string    `json:"ruleId"`
 	RuleVersion     string    `json:"ruleVersion"`
 	RuleDisplayName *string   `json:"ruleDisplayName"`
@@ -67,4 +108,103 @@ type AlertItem struct {
 	Severity        string    `json:"severity"`
 	EventCount      int       `json:"eventCount"`
 	LogTypes        []string  `json:"logTypes"`
+	RuleTags        []string  `json:"ruleTags,omitempty"`
+
+	// Triage state, set by the alerts-api (the alert forwarder never overwrites these)
+	Status          string           `json:"status,omitempty"`
+	AssigneeID      *string          `json:"assigneeId,omitempty"`
+	LastUpdatedBy   *string          `json:"lastUpdatedBy,omitempty"`
+	LastUpdatedTime *time.Time       `json:"lastUpdatedTime,omitempty"`
+	Activity        []*AlertActivity `json:"activity,omitempty"`
+
+	// Suppressed alerts were muted by a suppression and never delivered
+	Suppressed    bool    `json:"suppressed,omitempty"`
+	SuppressionID *string `json:"suppressionId,omitempty"`
+	// Pending alerts have not reached the threshold of their rule yet and were not delivered
+	Pending bool `json:"pending,omitempty"`
+	// ResourceID is the resource which failed the policy of a policy alert
+	ResourceID *string `json:"resourceId,omitempty"`
+	// Tickets are the issues created for the alert by ticketing outputs
+	Tickets []*AlertTicket `json:"tickets,omitempty"`
+}
+
+// AlertActivity is the audit trail entry for a single triage change
+type AlertActivity struct {
+	Type       string    `json:"type"`
+	UserID     string    `json:"userId"`
+	Time       time.Time `json:"time"`
+	Status     string    `json:"status,omitempty"`
+	AssigneeID *string   `json:"assigneeId,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+}
+
+// AlertTicket is an issue created for an alert by a Jira or GitHub output
+type AlertTicket struct {
+	OutputID  string    `json:"outputId"`
+	System    string    `json:"system"` // jira or github
+	Key       string    `json:"key"`    // the Jira issue key (PANTHER-12) or the GitHub issue (org/repo#12)
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TicketItem maps a ticket back to its alert, so changes to the ticket can be synced to the alert.
+type TicketItem struct {
+	TicketID string `json:"ticketId"`
+	AlertID  string `json:"alertId"`
+	OutputID string `json:"outputId"`
+}
+
+// TicketID is the key of a ticket in the tickets table
+func TicketID(system, key string) string {
+	return system + ":" + key
+}
+
+// SuppressionItem mutes new alerts until it expires.
+//
+// Every criteria which is set must match the alert: the rule, the dedup string and an alert field value.
+// The alert forwarder reads the suppressions when it creates an alert.
+type SuppressionItem struct {
+	ID          string    `json:"id"`
+	RuleID      *string   `json:"ruleId,omitempty"`
+	DedupString *string   `json:"dedup,omitempty"`
+	Field       *string   `json:"field,omitempty"`
+	Value       *string   `json:"value,omitempty"`
+	ExpiresAt   int64     `json:"expiresAt"` // Unix time, DynamoDB deletes the item some time after it expires
+	Reason      string    `json:"reason"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Sort orders for listing alerts
+const (
+	SortByCreationTime = "creationTime"
+	SortByUpdateTime   = "updateTime"
+	SortBySeverity     = "severity"
+	SortByEventCount   = "eventsMatched"
+)
+
+// ListInput describes which alerts to list and in which order.
+//
+// Slices match any of their values, all other filters must match.
+type ListInput struct {
+	RuleID             *string
+	Type               *string  // RULE includes alerts which have no type
+	Statuses           []string // OPEN includes alerts which have no status
+	AssigneeID         *string
+	Severities         []string
+	LogTypes           []string
+	RuleTags           []string
+	TitleContains      *string
+	CreationTimeAfter  *time.Time
+	CreationTimeBefore *time.Time
+	UpdateTimeAfter    *time.Time
+	UpdateTimeBefore   *time.Time
+	EventCountMin      *int
+	EventCountMax      *int
+
+	SortBy         string // defaults to SortByCreationTime
+	SortDescending bool
+
+	PageSize          *int
+	ExclusiveStartKey *string
 }
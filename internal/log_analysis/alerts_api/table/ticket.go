@@ -0,0 +1,100 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+// AddTicket records an issue created for an alert, and maps the ticket back to the alert.
+//
+// Nothing is stored if the alert does not exist (e.g. the ticket is for a digest).
+func (table *AlertsTable) AddTicket(alertID string, ticket *AlertTicket) error {
+	update := expression.Set(expression.Name(TicketsKey), expression.ListAppend(
+		// An empty slice would be marshaled as NULL, which can't be appended to
+		expression.IfNotExists(expression.Name(TicketsKey), expression.Value(&dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}})),
+		expression.Value([]*AlertTicket{ticket}),
+	))
+	condition := expression.AttributeExists(expression.Name(AlertIDKey))
+	updateExpression, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = table.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		ConditionExpression:       updateExpression.Condition(),
+		ExpressionAttributeNames:  updateExpression.Names(),
+		ExpressionAttributeValues: updateExpression.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			AlertIDKey: {S: aws.String(alertID)},
+		},
+		TableName:        aws.String(table.AlertsTableName),
+		UpdateExpression: updateExpression.Update(),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil
+		}
+		return errors.Wrap(err, "UpdateItem() failed for: "+alertID)
+	}
+
+	item, err := dynamodbattribute.MarshalMap(&TicketItem{
+		TicketID: TicketID(ticket.System, ticket.Key),
+		AlertID:  alertID,
+		OutputID: ticket.OutputID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "MarshalMap() failed")
+	}
+	_, err = table.Client.PutItem(&dynamodb.PutItemInput{
+		Item:      item,
+		TableName: aws.String(table.TicketsTableName),
+	})
+	if err != nil {
+		return errors.Wrap(err, "PutItem() failed for: "+ticket.Key)
+	}
+	return nil
+}
+
+// GetTicket looks up the alert of a ticket, returns nil if the ticket was not created by Panther.
+func (table *AlertsTable) GetTicket(ticketID string) (*TicketItem, error) {
+	response, err := table.Client.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			TicketIDKey: {S: aws.String(ticketID)},
+		},
+		TableName: aws.String(table.TicketsTableName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetItem() failed for: "+ticketID)
+	}
+	if response.Item == nil {
+		return nil, nil
+	}
+
+	ticket := &TicketItem{}
+	if err = dynamodbattribute.UnmarshalMap(response.Item, ticket); err != nil {
+		return nil, errors.Wrap(err, "UnmarshalMap() failed for: "+ticketID)
+	}
+	return ticket, nil
+}
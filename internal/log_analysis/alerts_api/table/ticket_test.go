@@ -0,0 +1,96 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testTicket = &AlertTicket{
+	OutputID:  "outputId",
+	System:    "jira",
+	Key:       "PANTHER-12",
+	URL:       "https://panther.atlassian.net/browse/PANTHER-12",
+	CreatedAt: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestAddTicket(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", TicketsTableName: "ticketsTableName", Client: mockDdbClient}
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+	mockDdbClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	require.NoError(t, table.AddTicket("alertId", testTicket))
+
+	update := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "alertsTableName", *update.TableName)
+	assert.Equal(t, "SET #1 = list_append(if_not_exists(#1, :0), :1)\n", *update.UpdateExpression)
+	assert.Equal(t, "attribute_exists (#0)", *update.ConditionExpression)
+	assert.Equal(t, "tickets", *update.ExpressionAttributeNames["#1"])
+
+	put := mockDdbClient.Calls[1].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, "ticketsTableName", *put.TableName)
+	assert.Equal(t, "jira:PANTHER-12", *put.Item["ticketId"].S)
+	assert.Equal(t, "alertId", *put.Item["alertId"].S)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestAddTicketAlertDoesNotExist(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", TicketsTableName: "ticketsTableName", Client: mockDdbClient}
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "does not exist", nil))
+
+	require.NoError(t, table.AddTicket("alertId", testTicket))
+	mockDdbClient.AssertExpectations(t)
+	mockDdbClient.AssertNotCalled(t, "PutItem", mock.Anything)
+}
+
+func TestGetTicket(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{TicketsTableName: "ticketsTableName", Client: mockDdbClient}
+	expected := &TicketItem{TicketID: "github:org/repo#12", AlertID: "alertId", OutputID: "outputId"}
+	item, err := dynamodbattribute.MarshalMap(expected)
+	require.NoError(t, err)
+	mockDdbClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+
+	result, err := table.GetTicket("github:org/repo#12")
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.GetItemInput)
+	assert.Equal(t, "ticketsTableName", *input.TableName)
+}
+
+func TestGetTicketDoesNotExist(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{TicketsTableName: "ticketsTableName", Client: mockDdbClient}
+	mockDdbClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+	result, err := table.GetTicket("jira:PANTHER-12")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,152 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+// Only the most recent activity is kept, so that an alert with a long triage history stays well within
+// the DynamoDB item size limit (comments are at most 4000 characters).
+const maxAlertActivity = 50
+
+// UpdateStatus sets the triage status of an alert and records the change in its activity.
+//
+// Returns nil if the alert does not exist.
+func (table *AlertsTable) UpdateStatus(alertID, status string, activity *AlertActivity) (*AlertItem, error) {
+	update := expression.Set(expression.Name(StatusKey), expression.Value(status))
+	return table.update(alertID, update, activity)
+}
+
+// UpdateAssignee assigns an alert to a user, or unassigns it if assigneeID is nil.
+//
+// Returns nil if the alert does not exist.
+func (table *AlertsTable) UpdateAssignee(alertID string, assigneeID *string, activity *AlertActivity) (*AlertItem, error) {
+	var update expression.UpdateBuilder
+	if assigneeID == nil {
+		update = expression.Remove(expression.Name(AssigneeIDKey))
+	} else {
+		update = expression.Set(expression.Name(AssigneeIDKey), expression.Value(*assigneeID))
+	}
+	return table.update(alertID, update, activity)
+}
+
+// AddComment appends a comment to the alert activity.
+//
+// Returns nil if the alert does not exist.
+func (table *AlertsTable) AddComment(alertID string, activity *AlertActivity) (*AlertItem, error) {
+	return table.update(alertID, expression.UpdateBuilder{}, activity)
+}
+
+// update applies a triage change to an existing alert, appending the activity to its audit trail.
+func (table *AlertsTable) update(alertID string, update expression.UpdateBuilder, activity *AlertActivity) (*AlertItem, error) {
+	update = update.
+		Set(expression.Name(LastUpdatedByKey), expression.Value(activity.UserID)).
+		Set(expression.Name(LastUpdatedTimeKey), expression.Value(activity.Time)).
+		Set(expression.Name(ActivityKey), expression.ListAppend(
+			// An empty slice would be marshaled as NULL, which can't be appended to
+			expression.IfNotExists(expression.Name(ActivityKey), expression.Value(&dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}})),
+			expression.Value([]*AlertActivity{activity}),
+		))
+	condition := expression.AttributeExists(expression.Name(AlertIDKey))
+
+	updateExpression, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(condition).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build update expression")
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		ConditionExpression:       updateExpression.Condition(),
+		ExpressionAttributeNames:  updateExpression.Names(),
+		ExpressionAttributeValues: updateExpression.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			AlertIDKey: {S: aws.String(alertID)},
+		},
+		ReturnValues:     aws.String(dynamodb.ReturnValueAllNew),
+		TableName:        aws.String(table.AlertsTableName),
+		UpdateExpression: updateExpression.Update(),
+	}
+
+	response, err := table.Client.UpdateItem(input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "UpdateItem() failed for: "+alertID)
+	}
+
+	alertItem := &AlertItem{}
+	if err = dynamodbattribute.UnmarshalMap(response.Attributes, alertItem); err != nil {
+		return nil, errors.Wrap(err, "UnmarshalMap() failed for: "+alertID)
+	}
+
+	if excess := len(alertItem.Activity) - maxAlertActivity; excess > 0 {
+		if err = table.trimActivity(alertID, excess); err != nil {
+			return nil, err
+		}
+		alertItem.Activity = alertItem.Activity[excess:]
+	}
+	return alertItem, nil
+}
+
+// trimActivity removes the oldest activity entries of an alert.
+func (table *AlertsTable) trimActivity(alertID string, count int) error {
+	var update expression.UpdateBuilder
+	for i := 0; i < count; i++ {
+		update = update.Remove(expression.Name(ActivityKey + "[" + strconv.Itoa(i) + "]"))
+	}
+	// Another request may have trimmed the list in the meantime
+	condition := expression.Name(ActivityKey).Size().GreaterThanEqual(expression.Value(maxAlertActivity + count))
+
+	updateExpression, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(condition).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = table.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		ConditionExpression:       updateExpression.Condition(),
+		ExpressionAttributeNames:  updateExpression.Names(),
+		ExpressionAttributeValues: updateExpression.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			AlertIDKey: {S: aws.String(alertID)},
+		},
+		TableName:        aws.String(table.AlertsTableName),
+		UpdateExpression: updateExpression.Update(),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil
+		}
+		return errors.Wrap(err, "UpdateItem() failed to trim activity for: "+alertID)
+	}
+	return nil
+}
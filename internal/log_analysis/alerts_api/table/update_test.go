@@ -0,0 +1,139 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func (m *mockDynamoDB) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	activity := &AlertActivity{Type: "STATUS", UserID: "userId", Time: time.Now().UTC(), Status: "CLOSED"}
+	expectedAlert := &AlertItem{
+		AlertID:         "alertId",
+		RuleID:          "ruleId",
+		Status:          "CLOSED",
+		LastUpdatedBy:   aws.String("userId"),
+		LastUpdatedTime: aws.Time(activity.Time),
+		Activity:        []*AlertActivity{activity},
+	}
+	item, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item}, nil)
+
+	result, err := table.UpdateStatus("alertId", "CLOSED", activity)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAlert, result)
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "attribute_exists (#0)", *input.ConditionExpression)
+	assert.Equal(t, aws.String("id"), input.ExpressionAttributeNames["#0"])
+	assert.Contains(t, *input.UpdateExpression, "list_append(if_not_exists(")
+	assert.Equal(t, dynamodb.ReturnValueAllNew, *input.ReturnValues)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestUpdateAssigneeRemove(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	_, err := table.UpdateAssignee("alertId", nil, &AlertActivity{Type: "ASSIGNEE", UserID: "userId"})
+	require.NoError(t, err)
+
+	input := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Contains(t, *input.UpdateExpression, "REMOVE ")
+}
+
+func TestAddCommentDoesNotExist(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "not found", nil))
+
+	result, err := table.AddComment("alertId", &AlertActivity{Type: "COMMENT", UserID: "userId", Comment: "hi"})
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestAddCommentError(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeProvisionedThroughputExceededException, "slow down", nil))
+
+	_, err := table.AddComment("alertId", &AlertActivity{Type: "COMMENT", UserID: "userId", Comment: "hi"})
+	require.Error(t, err)
+}
+
+func TestUpdateTrimsActivity(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	activity := make([]*AlertActivity, maxAlertActivity+2)
+	for i := range activity {
+		activity[i] = &AlertActivity{Type: "COMMENT", UserID: "userId", Comment: strconv.Itoa(i)}
+	}
+	item, err := dynamodbattribute.MarshalMap(&AlertItem{AlertID: "alertId", Activity: activity})
+	require.NoError(t, err)
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item}, nil).Once()
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	result, err := table.AddComment("alertId", activity[len(activity)-1])
+	require.NoError(t, err)
+	assert.Equal(t, activity[2:], result.Activity)
+
+	input := mockDdbClient.Calls[1].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "REMOVE #0[0], #0[1]\n", *input.UpdateExpression)
+	assert.Equal(t, "size (#0) >= :0", *input.ConditionExpression)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestUpdateTrimActivityAlreadyTrimmed(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	item, err := dynamodbattribute.MarshalMap(&AlertItem{AlertID: "alertId", Activity: make([]*AlertActivity, maxAlertActivity+1)})
+	require.NoError(t, err)
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item}, nil).Once()
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+
+	result, err := table.AddComment("alertId", &AlertActivity{Type: "COMMENT", UserID: "userId"})
+	require.NoError(t, err)
+	assert.Len(t, result.Activity, maxAlertActivity)
+	mockDdbClient.AssertExpectations(t)
+}
@@ -18,7 +18,7 @@ import hashlib
 import os
 from dataclasses import dataclass
 from datetime import datetime
-from typing import Optional
+from typing import List, Optional
 
 import boto3
 
@@ -38,6 +38,7 @@ _ALERT_COUNT_ATTR_NAME = 'alertCount'
 _ALERT_EVENT_COUNT = 'eventCount'
 _ALERT_LOG_TYPES = 'logTypes'
 _ALERT_TITLE = 'title'
+_ALERT_SAMPLE_EVENTS = 'sampleEvents'
 
 
 # pylint: disable=too-many-instance-attributes
@@ -52,6 +53,7 @@ class MatchingGroupInfo:
     num_matches: int
     title: Optional[str]
     processing_time: datetime
+    sample_events: List[str]  # serialized events attached to the notification of a new alert
 
 
 def _generate_dedup_key(rule_id: str, dedup: str) -> str:
@@ -83,10 +85,10 @@ def _update_get_conditional(group_info: MatchingGroupInfo) -> AlertInfo:
     2. This rule with the same dedup string has fired before, but after the dedup period has expired
     """
     condition_expression = '(#1 < :1) OR (attribute_not_exists(#2))'
-    update_expression = 'ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10'
+    update_expression = 'ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10, #11=:11'
 
     if group_info.title:
-        update_expression += ', #11=:11'
+        update_expression += ', #12=:12'
     expresion_attribute_names = {
         '#1': _ALERT_CREATION_TIME_ATTR_NAME,
         '#2': _PARTITION_KEY_NAME,
@@ -98,10 +100,11 @@ def _update_get_conditional(group_info: MatchingGroupInfo) -> AlertInfo:
         '#8': _ALERT_EVENT_COUNT,
         '#9': _ALERT_LOG_TYPES,
         '#10': _RULE_VERSION_ATTR_NAME,
+        '#11': _ALERT_SAMPLE_EVENTS,
     }
 
     if group_info.title:
-        expresion_attribute_names['#11'] = _ALERT_TITLE
+        expresion_attribute_names['#12'] = _ALERT_TITLE
 
     expression_attribute_values = {
         ':1':
@@ -133,10 +136,16 @@ def _update_get_conditional(group_info: MatchingGroupInfo) -> AlertInfo:
         ':10': {
             'S': group_info.rule_version
         },
+        # Only a new alert sets the sample events, so they are the first events of the alert
+        ':11': {
+            'L': [{
+                'S': event
+            } for event in group_info.sample_events]
+        },
     }
 
     if group_info.title:
-        expression_attribute_values[':11'] = {'S': group_info.title}
+        expression_attribute_values[':12'] = {'S': group_info.title}
 
     response = _DDB_CLIENT.update_item(
         TableName=_DDB_TABLE_NAME,
@@ -38,6 +38,9 @@ _S3_KEY_DATE_FORMAT = '%Y%m%dT%H%M%SZ'
 _DATE_FORMAT = '%Y-%m-%d %H:%M:%S.%f000'
 _S3_BUCKET = os.environ['S3_BUCKET']
 _SNS_TOPIC_ARN = os.environ['NOTIFICATIONS_TOPIC']
+# Sample events are stored with the alert and sent in its notification, large events are left out
+_MAX_SAMPLE_EVENTS = 10
+_MAX_SAMPLE_EVENT_BYTES = 10000
 
 # AWS Clients
 _S3_CLIENT = boto3.client('s3')
@@ -127,7 +130,8 @@ def _write_to_s3(time: datetime, key: OutputGroupingKey, events: List[EventMatch
         dedup_period_mins=events[0].dedup_period_mins,
         num_matches=len(events),
         title=events[0].title,
-        processing_time=time
+        processing_time=time,
+        sample_events=_sample_events(events)
     )
     alert_info = update_get_alert_info(group_info)
     data_stream = BytesIO()
@@ -167,6 +171,18 @@ def _write_to_s3(time: datetime, key: OutputGroupingKey, events: List[EventMatch
     )
 
 
+def _sample_events(events: List[EventMatch]) -> List[str]:
+    """Serializes the first events of a batch, skipping any too large to store with the alert"""
+    result: List[str] = []
+    for event in events:
+        if len(result) >= _MAX_SAMPLE_EVENTS:
+            break
+        serialized = json.dumps(event.event)
+        if len(serialized) <= _MAX_SAMPLE_EVENT_BYTES:
+            result.append(serialized)
+    return result
+
+
 def _s3_put_object_notification(bucket: str, key: str, byte_size: int) -> Dict[str, list]:
     """The notification that will be sent to the SNS topic when we create a new object in S3.
 
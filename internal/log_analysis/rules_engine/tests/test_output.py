@@ -29,7 +29,7 @@ from ..src import EventMatch
 
 with mock.patch.dict(os.environ, {'ALERTS_DEDUP_TABLE': 'table_name', 'S3_BUCKET': 's3_bucket', 'NOTIFICATIONS_TOPIC': 'sns_topic'}), \
      mock.patch.object(boto3, 'client', side_effect=mock_to_return) as mock_boto:
-    from ..src.output import MatchedEventsBuffer
+    from ..src.output import MatchedEventsBuffer, _sample_events
 
 
 class TestMatchedEventsBuffer(TestCase):
@@ -68,7 +68,8 @@ class TestMatchedEventsBuffer(TestCase):
                 '#7': 'alertUpdateTime',
                 '#8': 'eventCount',
                 '#9': 'logTypes',
-                '#10': 'ruleVersion'
+                '#10': 'ruleVersion',
+                '#11': 'sampleEvents'
             },
             ExpressionAttributeValues={
                 ':1': {
@@ -97,6 +98,11 @@ class TestMatchedEventsBuffer(TestCase):
                 },
                 ':10': {
                     'S': 'rule_version'
+                },
+                ':11': {
+                    'L': [{
+                        'S': '{"data_key": "data_value"}'
+                    }]
                 }
             },
             Key={
@@ -107,7 +113,7 @@ class TestMatchedEventsBuffer(TestCase):
             },
             ReturnValues='ALL_NEW',
             TableName='table_name',
-            UpdateExpression='ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10'
+            UpdateExpression='ADD #3 :3\nSET #4=:4, #5=:5, #6=:6, #7=:7, #8=:8, #9=:9, #10=:10, #11=:11'
         )
 
         S3_MOCK.put_object.assert_called_once_with(Body=mock.ANY, Bucket='s3_bucket', ContentType='gzip', Key=mock.ANY)
@@ -335,3 +341,26 @@ class TestMatchedEventsBuffer(TestCase):
         # Assert that the buffer has been cleared
         self.assertEqual(len(buffer.data), 0)
         self.assertEqual(buffer.bytes_in_memory, 0)
+
+    def test_sample_events(self) -> None:
+        small_match = EventMatch(
+            rule_id='rule_id',
+            rule_version='rule_version',
+            log_type='log_type',
+            dedup='dedup',
+            dedup_period_mins=100,
+            event={'data_key': 'data_value'}
+        )
+        large_match = EventMatch(
+            rule_id='rule_id',
+            rule_version='rule_version',
+            log_type='log_type',
+            dedup='dedup',
+            dedup_period_mins=100,
+            event={'data_key': 'x' * 10000}
+        )
+
+        # Events too large to store with the alert are left out
+        self.assertEqual(_sample_events([large_match, small_match]), ['{"data_key": "data_value"}'])
+        # At most 10 events are sampled
+        self.assertEqual(len(_sample_events([small_match] * 20)), 10)
@@ -109,6 +109,35 @@ func (m *DynamoDBMock) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, er
 	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
 }
 
+func (m *DynamoDBMock) ScanPages(input *dynamodb.ScanInput, f func(*dynamodb.ScanOutput, bool) bool) error {
+	args := m.Called(input, f)
+	f(args.Get(0).(*dynamodb.ScanOutput), true)
+	return args.Error(1)
+}
+
+func (m *DynamoDBMock) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
+func (m *DynamoDBMock) QueryPages(input *dynamodb.QueryInput, f func(*dynamodb.QueryOutput, bool) bool) error {
+	args := m.Called(input, f)
+	return args.Error(0)
+}
+
+func (m *DynamoDBMock) BatchGetItemPages(
+	input *dynamodb.BatchGetItemInput, f func(*dynamodb.BatchGetItemOutput, bool) bool) error {
+
+	args := m.Called(input, f)
+	f(args.Get(0).(*dynamodb.BatchGetItemOutput), true)
+	return args.Error(1)
+}
+
+func (m *DynamoDBMock) BatchWriteItem(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.BatchWriteItemOutput), args.Error(1)
+}
+
 type SqsMock struct {
 	sqsiface.SQSAPI
 	mock.Mock
@@ -0,0 +1,128 @@
+// Package webhooksig signs and verifies Panther custom webhook requests.
+//
+// Receivers can import this package to check that a request was sent by Panther and is not a replay:
+//
+//	body, err := webhooksig.VerifyRequest(request, secret, webhooksig.DefaultTolerance)
+package webhooksig
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the versioned HMAC-SHA256 signature, e.g. "v1=5257a869..."
+	SignatureHeader = "X-Panther-Signature"
+
+	// TimestampHeader holds the time of signing in seconds since epoch
+	TimestampHeader = "X-Panther-Timestamp"
+
+	// DefaultTolerance is the maximum accepted age of a signed request
+	DefaultTolerance = 5 * time.Minute
+
+	signatureVersion = "v1"
+)
+
+var (
+	ErrMissingSignature  = errors.New("webhooksig: missing signature or timestamp header")
+	ErrInvalidTimestamp  = errors.New("webhooksig: invalid timestamp header")
+	ErrTimestampExpired  = errors.New("webhooksig: timestamp outside of the tolerance window")
+	ErrSignatureMismatch = errors.New("webhooksig: signature does not match")
+)
+
+// Sign computes the signature header value for a request body sent at the given time.
+//
+// The signed payload is "<timestamp>.<body>", so the timestamp cannot be changed without
+// invalidating the signature.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	return signatureVersion + "=" + hex.EncodeToString(computeMAC(secret, timestamp.Unix(), body))
+}
+
+// SignRequest adds the timestamp and signature headers to a request with the given body.
+func SignRequest(request *http.Request, secret []byte, timestamp time.Time, body []byte) {
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	request.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+}
+
+// Verify checks the signature headers against the body.
+//
+// Requests signed more than tolerance before (or after) now are rejected to prevent replays.
+func Verify(header http.Header, secret []byte, body []byte, tolerance time.Duration, now time.Time) error {
+	timestampValue, signatureValue := header.Get(TimestampHeader), header.Get(SignatureHeader)
+	if timestampValue == "" || signatureValue == "" {
+		return ErrMissingSignature
+	}
+
+	timestamp, err := strconv.ParseInt(timestampValue, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+
+	expected := computeMAC(secret, timestamp, body)
+	// More than one signature can be sent while a secret is being rotated
+	for _, signature := range strings.Split(signatureValue, ",") {
+		parts := strings.SplitN(strings.TrimSpace(signature), "=", 2)
+		if len(parts) != 2 || parts[0] != signatureVersion {
+			continue
+		}
+		actual, err := hex.DecodeString(parts[1])
+		if err == nil && hmac.Equal(expected, actual) {
+			return nil
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+// VerifyRequest reads and verifies the body of an incoming request.
+//
+// The request body is replaced so handlers can read it again.
+func VerifyRequest(request *http.Request, secret []byte, tolerance time.Duration) ([]byte, error) {
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err = Verify(request.Header, secret, body, tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func computeMAC(secret []byte, timestamp int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
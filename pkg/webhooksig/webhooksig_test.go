@@ -0,0 +1,102 @@
+package webhooksig
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSecret = []byte("secret")
+	testBody   = []byte(`{"alertId":"123"}`)
+	testTime   = time.Unix(1564832413, 0)
+)
+
+func signedHeader(secret []byte, timestamp time.Time, body []byte) http.Header {
+	header := http.Header{}
+	header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	return header
+}
+
+func TestSign(t *testing.T) {
+	// Sign is deterministic for the same inputs
+	assert.Equal(t, Sign(testSecret, testTime, testBody), Sign(testSecret, testTime, testBody))
+	assert.Regexp(t, "^v1=[0-9a-f]{64}$", Sign(testSecret, testTime, testBody))
+	assert.NotEqual(t, Sign(testSecret, testTime, testBody), Sign(testSecret, testTime.Add(time.Second), testBody))
+}
+
+func TestVerify(t *testing.T) {
+	header := signedHeader(testSecret, testTime, testBody)
+	assert.NoError(t, Verify(header, testSecret, testBody, DefaultTolerance, testTime.Add(time.Minute)))
+}
+
+func TestVerifyMissingHeaders(t *testing.T) {
+	assert.Equal(t, ErrMissingSignature, Verify(http.Header{}, testSecret, testBody, DefaultTolerance, testTime))
+}
+
+func TestVerifyInvalidTimestamp(t *testing.T) {
+	header := signedHeader(testSecret, testTime, testBody)
+	header.Set(TimestampHeader, "yesterday")
+	assert.Equal(t, ErrInvalidTimestamp, Verify(header, testSecret, testBody, DefaultTolerance, testTime))
+}
+
+func TestVerifyReplay(t *testing.T) {
+	header := signedHeader(testSecret, testTime, testBody)
+	assert.Equal(t, ErrTimestampExpired, Verify(header, testSecret, testBody, DefaultTolerance, testTime.Add(time.Hour)))
+}
+
+func TestVerifyTamperedBody(t *testing.T) {
+	header := signedHeader(testSecret, testTime, testBody)
+	assert.Equal(t, ErrSignatureMismatch, Verify(header, testSecret, []byte(`{}`), DefaultTolerance, testTime))
+}
+
+func TestVerifyWrongSecret(t *testing.T) {
+	header := signedHeader(testSecret, testTime, testBody)
+	assert.Equal(t, ErrSignatureMismatch, Verify(header, []byte("other"), testBody, DefaultTolerance, testTime))
+}
+
+func TestVerifyMultipleSignatures(t *testing.T) {
+	header := signedHeader(testSecret, testTime, testBody)
+	header.Set(SignatureHeader, Sign([]byte("old"), testTime, testBody)+","+header.Get(SignatureHeader))
+	assert.NoError(t, Verify(header, testSecret, testBody, DefaultTolerance, testTime))
+}
+
+func TestVerifyRequest(t *testing.T) {
+	request, err := http.NewRequest("POST", "https://example.com", bytes.NewReader(testBody))
+	require.NoError(t, err)
+	SignRequest(request, testSecret, time.Now(), testBody)
+
+	body, err := VerifyRequest(request, testSecret, DefaultTolerance)
+	require.NoError(t, err)
+	assert.Equal(t, testBody, body)
+
+	// The body can still be read by the handler
+	remaining, err := ioutil.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, testBody, remaining)
+}
@@ -28,12 +28,18 @@ import (
 const Filepath = "deployments/panther_config.yml"
 
 type PantherConfig struct {
+	Alerts     Alerts     `yaml:"Alerts"`
 	Infra      Infra      `yaml:"Infra"`
 	Monitoring Monitoring `yaml:"Monitoring"`
 	Setup      Setup      `yaml:"Setup"`
 	Web        Web        `yaml:"Web"`
 }
 
+type Alerts struct {
+	SampleEvents      int                 `yaml:"SampleEvents"`
+	SampleEventFields map[string][]string `yaml:"SampleEventFields"`
+}
+
 type Infra struct {
 	BaseLayerVersionArns         string   `yaml:"BaseLayerVersionArns"`
 	LogProcessorLambdaMemorySize int      `yaml:"LogProcessorLambdaMemorySize"`
@@ -32,6 +32,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/sts"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/magefile/mage/sh"
 
 	"github.com/panther-labs/panther/api/lambda/users/models"
@@ -117,6 +118,7 @@ func Deploy() {
 	migrate(accountID)
 	outputs := bootstrap(settings)
 	deployMainStacks(settings, accountID, outputs)
+	postDeployMigrate(outputs)
 
 	logger.Infof("deploy: finished successfully in %s", time.Since(start).Round(time.Second))
 	logger.Infof("***** Panther URL = https://%s", outputs["LoadBalancerUrl"])
@@ -432,6 +434,7 @@ func deployCloudSecurityStack(settings *config.PantherConfig, outputs map[string
 		"AnalysisApiId":              outputs["AnalysisApiId"],
 		"CloudWatchLogRetentionDays": strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
 		"ComplianceApiId":            outputs["ComplianceApiId"],
+		"ComplianceReportsBucket":    outputs["ComplianceReportsBucket"],
 		"CustomResourceVersion":      customResourceVersion(),
 		"Debug":                      strconv.FormatBool(settings.Monitoring.Debug),
 		"LayerVersionArns":           settings.Infra.BaseLayerVersionArns,
@@ -488,8 +491,18 @@ func deployLogAnalysisStack(settings *config.PantherConfig, outputs map[string]s
 		return err
 	}
 
+	// The alert forwarder reads the sample event field allowlists as a JSON object
+	sampleEventFields := ""
+	if len(settings.Alerts.SampleEventFields) > 0 {
+		if sampleEventFields, err = jsoniter.MarshalToString(settings.Alerts.SampleEventFields); err != nil {
+			return fmt.Errorf("invalid Alerts.SampleEventFields: %v", err)
+		}
+	}
+
 	_, err = deployTemplate(logAnalysisTemplate, outputs["SourceBucket"], logAnalysisStack, map[string]string{
 		"AlarmTopicArn":                outputs["AlarmTopicArn"],
+		"AlertSampleEventFields":       sampleEventFields,
+		"AlertSampleEvents":            strconv.Itoa(settings.Alerts.SampleEvents),
 		"AnalysisApiId":                outputs["AnalysisApiId"],
 		"AthenaResultsBucket":          outputs["AthenaResultsBucket"],
 		"CloudWatchLogRetentionDays":   strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
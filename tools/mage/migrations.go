@@ -20,12 +20,21 @@ package mage
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client"
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
 const (
@@ -33,6 +42,12 @@ const (
 	alarmsStack            = "panther-cw-alarms"
 	metricFilterStack      = "panther-cw-metric-filters"
 	realTimeEventsStackSet = "panther-real-time-events"
+
+	resourceRelationshipsTable = "panther-resource-relationships"
+	resourcesTable             = "panther-resources"
+
+	applicationLoadBalancerType = "AWS.ELBV2.ApplicationLoadBalancer"
+	maxDeleteResources          = 1000 // the most resources the resources-api deletes in one request
 )
 
 // Migrations which run before the main deploy.
@@ -128,6 +143,108 @@ func migrate(accountID string) {
 	}
 }
 
+// Migrations which run after the main deploy.
+//
+// These can be removed a few releases after they have been added.
+func postDeployMigrate(outputs map[string]string) {
+	apiClient := client.NewHTTPClientWithConfig(nil, client.DefaultTransportConfig().
+		WithBasePath("/v1").WithHost(outputs["ResourcesApiEndpoint"]))
+	httpClient := gatewayapi.GatewayClient(awsSession)
+
+	// In v1.5.0, resource relationships are indexed when resources are added. Resources already in the
+	// table are only indexed when they next change, so index all of them once while the table is empty.
+	indexResourceRelationships(apiClient, httpClient)
+
+	// In v1.5.0, network load balancers became their own resource type. Those polled before were stored as
+	// application load balancers and are no longer updated under that type, so they are deleted (along with
+	// their compliance status) and added back with the new type by the next scan.
+	deleteNetworkLoadBalancersOfApplicationType(apiClient, httpClient)
+}
+
+func indexResourceRelationships(apiClient *client.PantherResources, httpClient *http.Client) {
+	response, err := dynamodb.New(awsSession).Scan(&dynamodb.ScanInput{
+		Limit:     aws.Int64(1),
+		TableName: aws.String(resourceRelationshipsTable),
+	})
+	if err != nil {
+		logger.Warnf("failed to scan %s: %v", resourceRelationshipsTable, err)
+		return
+	}
+	if len(response.Items) > 0 {
+		return
+	}
+
+	logger.Infof("migration: indexing resource relationships")
+	params := &operations.ReindexRelationshipsParams{HTTPClient: httpClient}
+	total := int64(0)
+	for {
+		result, err := apiClient.Operations.ReindexRelationships(params)
+		if err != nil {
+			logger.Warnf("failed to index resource relationships: %v", err)
+			return
+		}
+		total += *result.Payload.ResourceCount
+		if result.Payload.LastEvaluatedKey == "" {
+			break
+		}
+		params.ExclusiveStartKey = aws.String(string(result.Payload.LastEvaluatedKey))
+	}
+	logger.Infof("migration: indexed relationships of %d resources", total)
+}
+
+func deleteNetworkLoadBalancersOfApplicationType(apiClient *client.PantherResources, httpClient *http.Client) {
+	filter := expression.Name("type").Equal(expression.Value(applicationLoadBalancerType)).
+		And(expression.Name("attributes.Type").Equal(expression.Value(elbv2.LoadBalancerTypeEnumNetwork))).
+		And(expression.Name("deleted").Equal(expression.Value(false)))
+	expr, err := expression.NewBuilder().
+		WithFilter(filter).
+		WithProjection(expression.NamesList(expression.Name("id"))).
+		Build()
+	if err != nil {
+		logger.Warnf("failed to build %s filter: %v", resourcesTable, err)
+		return
+	}
+
+	var entries []*models.DeleteEntry
+	err = dynamodb.New(awsSession).ScanPages(&dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(resourcesTable),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		for _, item := range page.Items {
+			entries = append(entries, &models.DeleteEntry{ID: models.ResourceID(aws.StringValue(item["id"].S))})
+		}
+		return true
+	})
+	if err != nil {
+		logger.Warnf("failed to scan %s: %v", resourcesTable, err)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	logger.Infof("migration: deleting %d network load balancers stored as %s", len(entries), applicationLoadBalancerType)
+	for len(entries) > 0 {
+		batch := entries
+		if len(batch) > maxDeleteResources {
+			batch = batch[:maxDeleteResources]
+		}
+		entries = entries[len(batch):]
+
+		_, err = apiClient.Operations.DeleteResources(&operations.DeleteResourcesParams{
+			Body:       &models.DeleteResources{Resources: batch},
+			HTTPClient: httpClient,
+		})
+		if err != nil {
+			logger.Warnf("failed to delete network load balancers stored as %s: %v", applicationLoadBalancerType, err)
+			return
+		}
+	}
+}
+
 // Delete a CloudFormation stack set and wait for it to finish.
 //
 // Only deletes stack instances from the current region.
@@ -57,7 +57,10 @@ export const DEFAULT_DEDUP_FUNCTION =
 
 export const RESOURCE_TYPES = [
   'AWS.ACM.Certificate',
+  'AWS.APIGateway.RestAPI',
+  'AWS.APIGatewayV2.API',
   'AWS.CloudFormation.Stack',
+  'AWS.CloudFront.Distribution',
   'AWS.CloudTrail',
   'AWS.CloudTrail.Meta',
   'AWS.CloudWatch.LogGroup',
@@ -70,8 +73,14 @@ export const RESOURCE_TYPES = [
   'AWS.EC2.SecurityGroup',
   'AWS.EC2.Volume',
   'AWS.EC2.VPC',
+  'AWS.ECR.Repository',
   'AWS.ECS.Cluster',
+  'AWS.ECS.Service',
+  'AWS.ECS.TaskDefinition',
+  'AWS.EKS.Cluster',
+  'AWS.ELB.LoadBalancer',
   'AWS.ELBV2.ApplicationLoadBalancer',
+  'AWS.ELBV2.NetworkLoadBalancer',
   'AWS.GuardDuty.Detector',
   'AWS.IAM.Group',
   'AWS.IAM.Policy',
@@ -83,7 +92,12 @@ export const RESOURCE_TYPES = [
   'AWS.PasswordPolicy',
   'AWS.RDS.Instance',
   'AWS.Redshift.Cluster',
+  'AWS.Route53.HostedZone',
   'AWS.S3.Bucket',
+  'AWS.SecretsManager.Secret',
+  'AWS.SNS.Topic',
+  'AWS.SQS.Queue',
+  'AWS.StepFunctions.StateMachine',
   'AWS.WAF.Regional.WebACL',
   'AWS.WAF.WebACL',
 ] as const;